@@ -17,6 +17,7 @@ func init() {
 	AccountCmd.Flags().String("keystore-file", "", "name of keystore file to import")
 	AccountCmd.Flags().String("password", "", "password used to encrypt the keystore. Used with --generate or --unlock")
 	AccountCmd.Flags().String("scheme", crypto.Sr25519Type, "keyring scheme (sr25519, ed25519, secp256k1)")
+	AccountCmd.Flags().String("suri", "", "secret URI to derive the key from. Used with --generate. eg. --suri=//Alice")
 }
 
 // AccountCmd is the command to manage the gossamer keystore
@@ -30,6 +31,8 @@ func init() {
 	gossamer account generate --keystore-path=path/to/location --scheme=ed25519
 To generate a new secp256k1 account:
 	gossamer account generate --keystore-path=path/to/location --scheme secp256k1
+To generate an account derived from a secret URI:
+	gossamer account generate --keystore-path=path/to/location --suri="//Alice"
 To import a keystore file:
 	gossamer account import --keystore-path=path/to/location --keystore-file=keystore.json
 To import a raw key:
@@ -90,9 +93,19 @@ func generateKeyPair(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to get password: %s", err)
 	}
 
+	suri, err := cmd.Flags().GetString("suri")
+	if err != nil {
+		return fmt.Errorf("failed to get suri: %s", err)
+	}
+
 	logger.Info("Generating keypair")
 
-	file, err := keystore.GenerateKeypair(scheme, nil, keystorePath, []byte(password))
+	var file string
+	if suri != "" {
+		file, err = keystore.ImportSecretURI(suri, scheme, keystorePath, []byte(password))
+	} else {
+		file, err = keystore.GenerateKeypair(scheme, nil, keystorePath, []byte(password))
+	}
 	if err != nil {
 		logger.Errorf("failed to generate keypair: %s", err)
 		return err
@@ -5,6 +5,7 @@
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -69,6 +70,24 @@ func TestAccountGenerateSr25519(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestAccountGenerateSecretURI test "gossamer account generate --suri=//Alice"
+func TestAccountGenerateSecretURI(t *testing.T) {
+	testDir := t.TempDir()
+	directory := fmt.Sprintf("--keystore-path=%s", testDir)
+
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(AccountCmd)
+
+	rootCmd.SetArgs([]string{"account", "generate", directory, "--suri=//Alice"})
+
+	err = rootCmd.Execute()
+	require.NoError(t, err)
+
+	const alicePublicKey = "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"
+	require.FileExists(t, filepath.Join(testDir, "keystore", alicePublicKey+".key"))
+}
+
 // TestAccountGenerateSecp256k1 test "gossamer account generate --scheme=secp256k1"
 func TestAccountGenerateSecp256k1(t *testing.T) {
 	testDir := t.TempDir()
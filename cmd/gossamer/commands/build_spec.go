@@ -5,6 +5,8 @@
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/ChainSafe/gossamer/dot"
 	"github.com/spf13/cobra"
@@ -14,6 +16,11 @@ func init() {
 	BuildSpecCmd.Flags().Bool("raw", false, "print raw genesis json")
 	BuildSpecCmd.Flags().
 		String("output-path", "", "path to output the recently created chain-spec JSON file")
+	BuildSpecCmd.Flags().
+		String("runtime", "", "path to a runtime wasm file to build the chain-spec from, through its genesis builder API")
+	BuildSpecCmd.Flags().
+		String("preset", "", "name of the runtime genesis config preset, the runtime default genesis config if not set")
+	BuildSpecCmd.Flags().Bool("list-presets", false, "print the names of the runtime genesis config presets")
 }
 
 // BuildSpecCmd is the command to generate genesis JSON
@@ -25,7 +32,11 @@ func init() {
 To generate raw chain-spec file from default:
 	gossamer build-spec --raw --output chain-spec.json
 To generate raw chain-spec file from specific chain-spec file:
-	gossamer build-spec --raw --chain chain-spec.json --output-path chain-spec-raw.json`,
+	gossamer build-spec --raw --chain chain-spec.json --output-path chain-spec-raw.json
+To generate raw chain-spec file from a runtime genesis config preset:
+	gossamer build-spec --raw --runtime runtime.wasm --preset development --output-path chain-spec-raw.json
+To list the genesis config presets of a runtime:
+	gossamer build-spec --runtime runtime.wasm --list-presets`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return execBuildSpec(cmd)
 	},
@@ -49,8 +60,47 @@ func execBuildSpec(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to get base-path value: %s", err)
 	}
 
-	if chainSpec == "" && basePath == "" {
-		return fmt.Errorf("one of chain or base-path must be specified")
+	runtimePath, err := cmd.Flags().GetString("runtime")
+	if err != nil {
+		return fmt.Errorf("failed to get runtime value: %s", err)
+	}
+
+	preset, err := cmd.Flags().GetString("preset")
+	if err != nil {
+		return fmt.Errorf("failed to get preset value: %s", err)
+	}
+
+	listPresets, err := cmd.Flags().GetBool("list-presets")
+	if err != nil {
+		return fmt.Errorf("failed to get list-presets value: %s", err)
+	}
+
+	if chainSpec == "" && basePath == "" && runtimePath == "" {
+		return fmt.Errorf("one of chain, base-path or runtime must be specified")
+	}
+
+	if (preset != "" || listPresets) && runtimePath == "" {
+		return fmt.Errorf("runtime must be specified to use a genesis config preset")
+	}
+
+	var code []byte
+	if runtimePath != "" {
+		code, err = os.ReadFile(filepath.Clean(runtimePath))
+		if err != nil {
+			return fmt.Errorf("failed to read runtime file: %w", err)
+		}
+	}
+
+	if listPresets {
+		names, err := dot.RuntimeGenesisPresetNames(code)
+		if err != nil {
+			return fmt.Errorf("failed to get genesis config preset names: %w", err)
+		}
+
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return nil
 	}
 
 	outputPath, err := cmd.Flags().GetString("output-path")
@@ -60,7 +110,12 @@ func execBuildSpec(cmd *cobra.Command) error {
 
 	var bs *dot.BuildSpec
 
-	if chainSpec != "" {
+	if runtimePath != "" {
+		bs, err = dot.BuildFromRuntime(code, preset)
+		if err != nil {
+			return fmt.Errorf("error building spec from runtime: %w", err)
+		}
+	} else if chainSpec != "" {
 		bs, err = dot.BuildFromGenesis(chainSpec, 0)
 		if err != nil {
 			return err
@@ -0,0 +1,375 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"crypto/ed25519"
+	crand "crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ss58"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	KeyCmd.Flags().String("scheme", crypto.Sr25519Type, "cryptography scheme of the key (sr25519, ed25519, secp256k1)")
+	KeyCmd.Flags().String("network", "substrate",
+		"network of the SS58 addresses, either polkadot, kusama, substrate or an address type number")
+	KeyCmd.Flags().Bool("public", false, "inspect a hex encoded public key or SS58 address instead of a secret URI")
+	KeyCmd.Flags().Int("words", 12, "number of words of the generated mnemonic (12, 15, 18, 21 or 24)")
+	KeyCmd.Flags().String("file", "", "file of the node key. Used with generate-node-key or inspect-node-key")
+	KeyCmd.Flags().String("suri", "", "secret URI of the key to insert. eg. --suri=//Alice")
+	KeyCmd.Flags().String("key-type", "", "key type of the key to insert. eg. --key-type=babe")
+	KeyCmd.Flags().String("keystore-path", "",
+		"keystore directory to insert the key into, defaults to [base-path]/chains/[chain-spec id]/keystore")
+}
+
+// KeyCmd is the command to generate, inspect and insert keys
+var KeyCmd = &cobra.Command{
+	Use:   "key",
+	Short: "Generate, inspect and insert keys",
+	Long: `The key command is used to generate, inspect and insert session and node keys.
+Examples:
+
+To inspect the keys derived from a secret URI:
+	gossamer key inspect "//Alice" --scheme=ed25519 --network=polkadot
+To inspect a public key or SS58 address:
+	gossamer key inspect --public 5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY
+To generate a new mnemonic:
+	gossamer key generate --scheme=sr25519 --words=24
+To generate a new node key, written to stdout if no file is given:
+	gossamer key generate-node-key --file=path/to/node-key
+To inspect the peer ID of a node key file:
+	gossamer key inspect-node-key --file=path/to/node-key
+To insert a key into the keystore of a node:
+	gossamer key insert --chain=westend --base-path=path/to/node --key-type=babe --suri="//Alice"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			logger.Errorf("key command cannot be empty")
+			return cmd.Help()
+		}
+
+		switch args[0] {
+		case "inspect":
+			return inspectKey(cmd, args[1:])
+		case "generate":
+			return generateKey(cmd)
+		case "generate-node-key":
+			return generateNodeKey(cmd)
+		case "inspect-node-key":
+			return inspectNodeKey(cmd)
+		case "insert":
+			return insertKey(cmd)
+		default:
+			logger.Errorf("invalid key command: %s", args[0])
+			return fmt.Errorf("invalid key command: %s", args[0])
+		}
+	},
+}
+
+// getScheme returns the cryptography scheme flag
+func getScheme(cmd *cobra.Command) (crypto.KeyType, error) {
+	scheme, err := cmd.Flags().GetString("scheme")
+	if err != nil {
+		return "", fmt.Errorf("failed to get scheme: %s", err)
+	}
+	if !(scheme == crypto.Ed25519Type || scheme == crypto.Sr25519Type || scheme == crypto.Secp256k1Type) {
+		return "", fmt.Errorf("invalid scheme: %s", scheme)
+	}
+	return scheme, nil
+}
+
+// getNetworkPrefix returns the network flag and the SS58 address type of the network
+func getNetworkPrefix(cmd *cobra.Command) (network string, prefix uint16, err error) {
+	network, err = cmd.Flags().GetString("network")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get network: %s", err)
+	}
+
+	switch network {
+	case "polkadot":
+		return network, ss58.PolkadotPrefix, nil
+	case "kusama":
+		return network, ss58.KusamaPrefix, nil
+	case "substrate":
+		return network, ss58.GenericPrefix, nil
+	}
+
+	number, err := strconv.ParseUint(network, 10, 16)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid network: %s", network)
+	}
+	return network, uint16(number), nil
+}
+
+// printKeyInfo prints the public key, account id and SS58 addresses of the given public key
+func printKeyInfo(w io.Writer, header string, pub crypto.PublicKey, network string, prefix uint16) error {
+	accountID := crypto.PublicKeyToAccountID(pub)
+	publicAddress, err := ss58.Encode(pub.Encode(), prefix)
+	if err != nil {
+		return fmt.Errorf("encoding public key address: %w", err)
+	}
+	accountAddress, err := ss58.Encode(accountID, prefix)
+	if err != nil {
+		return fmt.Errorf("encoding account address: %w", err)
+	}
+
+	_, err = fmt.Fprintf(w, `%s
+  Network ID:        %s
+  Public key (hex):  %s
+  Account ID:        %s
+  Public key (SS58): %s
+  SS58 Address:      %s
+`, header, network, pub.Hex(), common.BytesToHex(accountID), publicAddress, accountAddress)
+	return err
+}
+
+// inspectKey prints the keys derived from the given secret URI, or the given public key
+func inspectKey(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("inspect expects a secret URI or public key, got %d arguments", len(args))
+	}
+
+	scheme, err := getScheme(cmd)
+	if err != nil {
+		return err
+	}
+
+	network, prefix, err := getNetworkPrefix(cmd)
+	if err != nil {
+		return err
+	}
+
+	public, err := cmd.Flags().GetBool("public")
+	if err != nil {
+		return fmt.Errorf("failed to get public: %s", err)
+	}
+
+	if !public {
+		kp, err := keystore.DecodeKeyPairFromSecretURI(args[0], scheme)
+		if err != nil {
+			return fmt.Errorf("failed to derive key from secret URI: %w", err)
+		}
+
+		header := fmt.Sprintf("Secret Key URI `%s` is account:", args[0])
+		return printKeyInfo(cmd.OutOrStdout(), header, kp.Public(), network, prefix)
+	}
+
+	var pubBytes []byte
+	if strings.HasPrefix(args[0], "0x") {
+		pubBytes, err = common.HexToBytes(args[0])
+	} else {
+		pubBytes, _, err = ss58.Decode(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decode public key: %w", err)
+	}
+
+	pub, err := keystore.DecodePublicKey(pubBytes, scheme)
+	if err != nil {
+		return fmt.Errorf("failed to decode public key: %w", err)
+	}
+
+	header := fmt.Sprintf("Public Key URI `%s` is account:", args[0])
+	return printKeyInfo(cmd.OutOrStdout(), header, pub, network, prefix)
+}
+
+// generateKey generates a new mnemonic and prints the keys derived from it
+func generateKey(cmd *cobra.Command) error {
+	scheme, err := getScheme(cmd)
+	if err != nil {
+		return err
+	}
+
+	network, prefix, err := getNetworkPrefix(cmd)
+	if err != nil {
+		return err
+	}
+
+	words, err := cmd.Flags().GetInt("words")
+	if err != nil {
+		return fmt.Errorf("failed to get words: %s", err)
+	}
+
+	mnemonic, err := crypto.NewBIP39MnemonicWithWordCount(words)
+	if err != nil {
+		return fmt.Errorf("failed to generate mnemonic: %w", err)
+	}
+
+	kp, err := keystore.DecodeKeyPairFromSecretURI(mnemonic, scheme)
+	if err != nil {
+		return fmt.Errorf("failed to derive key from mnemonic: %w", err)
+	}
+
+	header := fmt.Sprintf("Secret phrase:       %s", mnemonic)
+	return printKeyInfo(cmd.OutOrStdout(), header, kp.Public(), network, prefix)
+}
+
+// nodeKeyPeerID returns the peer ID of the given ed25519 node key seed
+func nodeKeyPeerID(seed []byte) (peer.ID, error) {
+	key, err := libp2pcrypto.UnmarshalEd25519PrivateKey(ed25519.NewKeyFromSeed(seed))
+	if err != nil {
+		return "", fmt.Errorf("decoding ed25519 key: %w", err)
+	}
+
+	return peer.IDFromPrivateKey(key)
+}
+
+// generateNodeKey generates a new hex encoded node key, which can be used with --node-key, and writes it
+// to the given file or to stdout. The peer ID of the key is printed to stderr.
+func generateNodeKey(cmd *cobra.Command) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("failed to get file: %s", err)
+	}
+
+	seed := make([]byte, ed25519.SeedSize)
+	_, err = crand.Read(seed)
+	if err != nil {
+		return fmt.Errorf("failed to generate node key: %w", err)
+	}
+
+	peerID, err := nodeKeyPeerID(seed)
+	if err != nil {
+		return err
+	}
+
+	encoded := hex.EncodeToString(seed)
+	if file == "" {
+		fmt.Fprintln(cmd.OutOrStdout(), encoded)
+	} else {
+		err = os.WriteFile(utils.ExpandDir(file), []byte(encoded), 0600)
+		if err != nil {
+			return fmt.Errorf("failed to write node key: %w", err)
+		}
+	}
+
+	fmt.Fprintln(cmd.ErrOrStderr(), peerID)
+	return nil
+}
+
+// inspectNodeKey prints the peer ID of the given node key file, which holds either the hex encoded
+// 32 bytes seed of the key, or the hex encoded 64 bytes key of the node.key file of a gossamer node
+func inspectNodeKey(cmd *cobra.Command) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("failed to get file: %s", err)
+	}
+	if file == "" {
+		return fmt.Errorf("file cannot be empty")
+	}
+
+	data, err := os.ReadFile(utils.ExpandDir(file))
+	if err != nil {
+		return fmt.Errorf("failed to read node key: %w", err)
+	}
+
+	key, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+	if err != nil {
+		return fmt.Errorf("failed to decode node key: %w", err)
+	}
+	if len(key) != ed25519.SeedSize && len(key) != ed25519.PrivateKeySize {
+		return fmt.Errorf("invalid node key length: %d", len(key))
+	}
+
+	peerID, err := nodeKeyPeerID(key[:ed25519.SeedSize])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), peerID)
+	return nil
+}
+
+// insertKey writes the given secret URI to the keystore directory of a node
+func insertKey(cmd *cobra.Command) error {
+	suri, err := cmd.Flags().GetString("suri")
+	if err != nil {
+		return fmt.Errorf("failed to get suri: %s", err)
+	}
+	if suri == "" {
+		return fmt.Errorf("suri cannot be empty")
+	}
+
+	keyType, err := cmd.Flags().GetString("key-type")
+	if err != nil {
+		return fmt.Errorf("failed to get key-type: %s", err)
+	}
+	if len(keyType) != 4 {
+		return fmt.Errorf("invalid key-type: %q is not 4 characters long", keyType)
+	}
+
+	// the scheme defaults to the scheme of the key type
+	scheme := keystore.DetermineKeyType(keyType)
+	if cmd.Flags().Changed("scheme") || scheme == crypto.UnknownType {
+		scheme, err = getScheme(cmd)
+		if err != nil {
+			return err
+		}
+	}
+
+	keystorePath, err := getKeystorePath(cmd)
+	if err != nil {
+		return err
+	}
+
+	kp, err := keystore.DecodeKeyPairFromSecretURI(suri, scheme)
+	if err != nil {
+		return fmt.Errorf("failed to derive key from secret URI: %w", err)
+	}
+
+	fs, err := keystore.NewFilesystemKeystore(keystorePath)
+	if err != nil {
+		return fmt.Errorf("failed to open keystore: %w", err)
+	}
+
+	err = fs.Write(keystore.Name(keyType), suri, kp.Public())
+	if err != nil {
+		return fmt.Errorf("failed to insert key: %w", err)
+	}
+
+	logger.Infof("inserted %s key %s into %s", keyType, kp.Public().Hex(), keystorePath)
+	return nil
+}
+
+// getKeystorePath returns the keystore-path flag, or the keystore directory of the chain
+// given by the chain and base-path flags
+func getKeystorePath(cmd *cobra.Command) (string, error) {
+	keystorePath, err := cmd.Flags().GetString("keystore-path")
+	if err != nil {
+		return "", fmt.Errorf("failed to get keystore-path: %s", err)
+	}
+	if keystorePath != "" {
+		return utils.ExpandDir(keystorePath), nil
+	}
+
+	chainSpec, err := cmd.Flags().GetString("chain")
+	if err != nil {
+		return "", fmt.Errorf("failed to get chain: %s", err)
+	}
+	if err := parseChainSpec(chainSpec); err != nil {
+		return "", fmt.Errorf("failed to parse chain-spec: %s", err)
+	}
+
+	base, err := cmd.Flags().GetString("base-path")
+	if err != nil {
+		return "", fmt.Errorf("failed to get base-path: %s", err)
+	}
+	if base == "" {
+		base = config.BasePath
+	}
+
+	return chainKeystoreDir(base, config.ChainSpec)
+}
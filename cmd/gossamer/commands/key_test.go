@@ -0,0 +1,184 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// executeKeyCommand executes "gossamer key" with the given arguments and returns its output
+func executeKeyCommand(t *testing.T, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(KeyCmd)
+
+	// reset the flags of the package level command set by the previous executions
+	KeyCmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		require.NoError(t, flag.Value.Set(flag.DefValue))
+		flag.Changed = false
+	})
+
+	var outBuffer, errBuffer bytes.Buffer
+	rootCmd.SetOut(&outBuffer)
+	rootCmd.SetErr(&errBuffer)
+	rootCmd.SetArgs(append([]string{KeyCmd.Name()}, args...))
+	err = rootCmd.Execute()
+	return outBuffer.String(), errBuffer.String(), err
+}
+
+// TestKeyInspect test "gossamer key inspect //Alice"
+func TestKeyInspect(t *testing.T) {
+	testCases := map[string]struct {
+		args     []string
+		expected string
+		errMsg   string
+	}{
+		"sr25519": {
+			args: []string{"inspect", "//Alice"},
+			expected: "Secret Key URI `//Alice` is account:\n" +
+				"  Network ID:        substrate\n" +
+				"  Public key (hex):  0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d\n" +
+				"  Account ID:        0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d\n" +
+				"  Public key (SS58): 5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY\n" +
+				"  SS58 Address:      5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY\n",
+		},
+		"secp256k1_polkadot": {
+			args: []string{"inspect", "//Alice", "--scheme=secp256k1", "--network=polkadot"},
+			expected: "Secret Key URI `//Alice` is account:\n" +
+				"  Network ID:        polkadot\n" +
+				"  Public key (hex):  0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1\n" +
+				"  Account ID:        0x01e552298e47454041ea31273b4b630c64c104e4514aa3643490b8aaca9cf8ed\n" +
+				"  Public key (SS58): 1CoWvCoktJHtTXSDybnqa1Evg2weWuhRusAiry1dU1fNR2Fy\n" +
+				"  SS58 Address:      13VAtLwNPFNMpqRJ6yzU4cwe3w4eyS9pDaLVW5DFzdvFwWa\n",
+		},
+		"public_address_kusama": {
+			args: []string{"inspect", "--public", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", "--network=2"},
+			expected: "Public Key URI `5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY` is account:\n" +
+				"  Network ID:        2\n" +
+				"  Public key (hex):  0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d\n" +
+				"  Account ID:        0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d\n" +
+				"  Public key (SS58): HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F\n" +
+				"  SS58 Address:      HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F\n",
+		},
+		"invalid_network": {
+			args:   []string{"inspect", "//Alice", "--network=unknown"},
+			errMsg: "invalid network: unknown",
+		},
+		"missing_uri": {
+			args:   []string{"inspect"},
+			errMsg: "inspect expects a secret URI or public key, got 0 arguments",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			stdout, _, err := executeKeyCommand(t, testCase.args...)
+			if testCase.errMsg != "" {
+				require.EqualError(t, err, testCase.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, stdout)
+		})
+	}
+}
+
+// TestKeyGenerate test "gossamer key generate --scheme=ed25519 --words=24"
+func TestKeyGenerate(t *testing.T) {
+	stdout, _, err := executeKeyCommand(t, "generate", "--scheme=ed25519", "--words=24")
+	require.NoError(t, err)
+
+	phrase, _, found := strings.Cut(strings.TrimPrefix(stdout, "Secret phrase:"), "\n")
+	require.True(t, found)
+	require.Len(t, strings.Fields(phrase), 24)
+
+	// the generated phrase derives the printed keys
+	inspected, _, err := executeKeyCommand(t, "inspect", strings.TrimSpace(phrase), "--scheme=ed25519")
+	require.NoError(t, err)
+	_, expected, _ := strings.Cut(stdout, "\n")
+	_, actual, _ := strings.Cut(inspected, "\n")
+	assert.Equal(t, expected, actual)
+
+	_, _, err = executeKeyCommand(t, "generate", "--words=13")
+	require.EqualError(t, err, "failed to generate mnemonic: invalid mnemonic word count: 13")
+}
+
+// TestKeyGenerateNodeKey test "gossamer key generate-node-key --file=node-key"
+// and "gossamer key inspect-node-key --file=node-key"
+func TestKeyGenerateNodeKey(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "node-key")
+
+	_, stderr, err := executeKeyCommand(t, "generate-node-key", "--file", file)
+	require.NoError(t, err)
+
+	key, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Len(t, key, 64)
+
+	stdout, _, err := executeKeyCommand(t, "inspect-node-key", "--file", file)
+	require.NoError(t, err)
+	assert.Equal(t, stderr, stdout)
+	assert.True(t, strings.HasPrefix(stdout, "12D3KooW"))
+}
+
+// TestKeyInspectNodeKey test "gossamer key inspect-node-key" with a gossamer node.key file
+func TestKeyInspectNodeKey(t *testing.T) {
+	// node.key file of a gossamer node holding the seed 0x01..01 followed by its public key
+	const nodeKey = "0101010101010101010101010101010101010101010101010101010101010101" +
+		"8a88e3dd7409f195fd52db2d3cba5d72ca6709bf1d94121bf3748801b40f6f5c"
+	file := filepath.Join(t.TempDir(), "node.key")
+	err := os.WriteFile(file, []byte(nodeKey), 0600)
+	require.NoError(t, err)
+
+	stdout, _, err := executeKeyCommand(t, "inspect-node-key", "--file", file)
+	require.NoError(t, err)
+
+	err = os.WriteFile(file, []byte(nodeKey[:64]+"\n"), 0600)
+	require.NoError(t, err)
+	seedStdout, _, err := executeKeyCommand(t, "inspect-node-key", "--file", file)
+	require.NoError(t, err)
+	assert.Equal(t, stdout, seedStdout)
+
+	err = os.WriteFile(file, []byte("0102"), 0600)
+	require.NoError(t, err)
+	_, _, err = executeKeyCommand(t, "inspect-node-key", "--file", file)
+	require.EqualError(t, err, "invalid node key length: 2")
+}
+
+// TestKeyInsert test "gossamer key insert --key-type=gran --suri=//Alice"
+func TestKeyInsert(t *testing.T) {
+	basepath := t.TempDir()
+
+	_, _, err := executeKeyCommand(t, "insert", "--key-type=gran", "--suri=//Alice",
+		"--chain", testChainSpec, "--base-path", basepath)
+	require.NoError(t, err)
+
+	fp := filepath.Join(basepath, "chains", "westend_dev", "keystore",
+		"6772616e88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee")
+	data, err := os.ReadFile(fp)
+	require.NoError(t, err)
+	assert.Equal(t, `"//Alice"`, string(data))
+
+	keystorePath := t.TempDir()
+	_, _, err = executeKeyCommand(t, "insert", "--key-type=beef", "--suri=//Alice",
+		"--keystore-path", keystorePath)
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(keystorePath,
+		"62656566020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1"))
+	require.NoError(t, err)
+
+	_, _, err = executeKeyCommand(t, "insert", "--key-type=babes", "--suri=//Alice",
+		"--keystore-path", keystorePath)
+	require.EqualError(t, err, `invalid key-type: "babes" is not 4 characters long`)
+}
@@ -31,7 +31,6 @@
 	// Base Config
 	name          string
 	id            string
-	pruning       string
 	telemetryURLs string
 
 	// Core Config
@@ -47,6 +46,9 @@
 	// RPC Config
 	// RPC modules to enable
 	rpcModules string
+
+	// statePruning deprecated alias of the pruning flag
+	statePruning string
 )
 
 // Flag values for persistent flags
@@ -134,6 +136,8 @@ func NewRootCommand() (*cobra.Command, error) {
 					return fmt.Errorf("failed to parse config: %s", err)
 				}
 
+				parseStatePruning(cmd)
+
 				if err := config.ValidateBasic(); err != nil {
 					return fmt.Errorf("error in config file: %v", err)
 				}
@@ -238,10 +242,23 @@ func addBaseConfigFlags(cmd *cobra.Command) error {
 		"retain-blocks"); err != nil {
 		return fmt.Errorf("failed to add --retain-blocks flag: %s", err)
 	}
-	cmd.Flags().StringVar(&pruning,
-		"state-pruning",
+	if err := addStringFlagBindViper(cmd,
+		"pruning",
 		string(config.BaseConfig.Pruning),
-		"State trie online pruning")
+		`State trie online pruning mode.
+	Either 'archive' to keep the state of all blocks, 'full' to keep the state
+	of the last --retain-blocks finalised blocks, or the number of finalised
+	blocks to keep the state of, e.g. --pruning=256`,
+		"pruning"); err != nil {
+		return fmt.Errorf("failed to add --pruning flag: %s", err)
+	}
+	cmd.PersistentFlags().StringVar(&statePruning,
+		"state-pruning",
+		"",
+		"State trie online pruning mode, deprecated alias of --pruning")
+	if err := cmd.PersistentFlags().MarkDeprecated("state-pruning", "use --pruning instead"); err != nil {
+		return fmt.Errorf("failed to deprecate --state-pruning flag: %s", err)
+	}
 	if err := addBoolFlagBindViper(cmd,
 		"prometheus-external",
 		config.BaseConfig.PrometheusExternal,
@@ -249,6 +266,13 @@ func addBaseConfigFlags(cmd *cobra.Command) error {
 		"prometheus-external"); err != nil {
 		return fmt.Errorf("failed to add --prometheus-external flag: %s", err)
 	}
+	if err := addStringFlagBindViper(cmd,
+		"log-format",
+		config.BaseConfig.LogFormat,
+		"Log output format, either 'console' or 'json'",
+		"log-format"); err != nil {
+		return fmt.Errorf("failed to add --log-format flag: %s", err)
+	}
 	cmd.Flags().StringVar(&telemetryURLs,
 		"telemetry-url",
 		"",
@@ -266,7 +290,7 @@ func addAccountFlags(cmd *cobra.Command) error {
 	cmd.PersistentFlags().StringVar(&key,
 		"key",
 		"",
-		"Keyring to use for the node")
+		"Keyring to use for the node, either a test key name or a secret URI. eg. --key=alice or --key=//Alice")
 
 	if err := addStringFlagBindViper(cmd,
 		"unlock",
@@ -276,6 +300,14 @@ func addAccountFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --unlock flag: %s", err)
 	}
 
+	if err := addStringFlagBindViper(cmd,
+		"keystore-path",
+		config.Account.KeystorePath,
+		"Directory of the Substrate compatible keystore, defaults to [base-path]/chains/[chain-spec id]/keystore.",
+		"account.keystore-path"); err != nil {
+		return fmt.Errorf("failed to add --keystore-path flag: %s", err)
+	}
+
 	// Default Account flags
 	cmd.PersistentFlags().BoolVar(&alice,
 		"alice",
@@ -403,6 +435,16 @@ func addNetworkFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --listen-addr flag: %s", err)
 	}
 
+	if err := addStringFlagBindViper(cmd,
+		"sync",
+		string(config.Network.SyncMode),
+		"Syncing mode, either full, fast or warp. The fast mode downloads the state "+
+			"of a recent finalised block instead of executing all the blocks from genesis, "+
+			"and the warp mode proves this block with the GRANDPA authority set changes",
+		"network.sync"); err != nil {
+		return fmt.Errorf("failed to add --sync flag: %s", err)
+	}
+
 	return nil
 }
 
@@ -529,6 +571,22 @@ func addCoreFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --grandpa-interval flag: %s", err)
 	}
 
+	if err := addStringFlagBindViper(cmd,
+		"grandpa-voter",
+		string(config.Core.GrandpaVoter),
+		"GRANDPA voter, either legacy or finality-grandpa",
+		"core.grandpa-voter"); err != nil {
+		return fmt.Errorf("failed to add --grandpa-voter flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"offchain-worker",
+		string(config.Core.OffchainWorker),
+		"When to run the runtime offchain workers, either always, never or when-validator",
+		"core.offchain-worker"); err != nil {
+		return fmt.Errorf("failed to add --offchain-worker flag: %s", err)
+	}
+
 	return nil
 }
 
@@ -540,6 +598,22 @@ func addStateFlags(cmd *cobra.Command) error {
 		"state.rewind"); err != nil {
 		return fmt.Errorf("failed to add --rewind flag: %s", err)
 	}
+	if err := addStringFlagBindViper(cmd,
+		"state-backend", config.State.Backend,
+		`Storage state backend.
+	Either 'inmemory' to load the state tries in memory, or 'triedb' to
+	read the state trie nodes from the database as they are needed`,
+		"state.backend"); err != nil {
+		return fmt.Errorf("failed to add --state-backend flag: %s", err)
+	}
+	if err := addUintFlagBindViper(cmd,
+		"justifications-window", config.State.JustificationsWindow,
+		`Number of the latest finalised blocks whose justifications are kept.
+	The justifications of the last blocks of the authority sets are always
+	kept, and 0 keeps all the justifications`,
+		"state.justifications-window"); err != nil {
+		return fmt.Errorf("failed to add --justifications-window flag: %s", err)
+	}
 
 	return nil
 }
@@ -608,6 +682,10 @@ func execRoot(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to validate config: %s", err)
 	}
 
+	if err := loadFilesystemKeystore(ks, config.BasePath, config.ChainSpec, config.Account.KeystorePath); err != nil {
+		return fmt.Errorf("failed to load filesystem keystore: %s", err)
+	}
+
 	// Write the config to the base path
 	if err := cfg.WriteConfigFile(config.BasePath, config); err != nil {
 		return fmt.Errorf("failed to ensure root: %s", err)
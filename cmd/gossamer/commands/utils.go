@@ -23,6 +23,7 @@
 	"github.com/ChainSafe/gossamer/chain/westend"
 	westenddev "github.com/ChainSafe/gossamer/chain/westend-dev"
 	westendlocal "github.com/ChainSafe/gossamer/chain/westend-local"
+	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	gssmros "github.com/ChainSafe/gossamer/lib/os"
 
 	"github.com/ChainSafe/gossamer/lib/genesis"
@@ -163,6 +164,42 @@ func loadBuiltInTestKeys(accountKey string, ks keystore.GlobalKeystore) (err err
 	return nil
 }
 
+// chainKeystoreDir returns the Substrate compatible keystore directory of the given chain-spec,
+// which is named after the id of the chain-spec
+func chainKeystoreDir(basepath, chainSpec string) (string, error) {
+	spec, err := genesis.NewGenesisFromJSONRaw(chainSpec)
+	if err != nil {
+		return "", fmt.Errorf("loading chain spec: %w", err)
+	}
+
+	return utils.ChainKeystoreDir(basepath, spec.ID)
+}
+
+// loadFilesystemKeystore opens the Substrate compatible keystore at the given path, defaulting
+// to the keystore directory of the chain, loads its keys and persists the keys inserted at runtime
+func loadFilesystemKeystore(ks *keystore.GlobalKeystore, basepath, chainSpec, path string) error {
+	if path == "" {
+		var err error
+		path, err = chainKeystoreDir(basepath, chainSpec)
+		if err != nil {
+			return fmt.Errorf("getting keystore directory: %w", err)
+		}
+	}
+
+	fs, err := keystore.NewFilesystemKeystore(utils.ExpandDir(path))
+	if err != nil {
+		return fmt.Errorf("opening keystore: %w", err)
+	}
+
+	err = fs.Load(ks)
+	if err != nil {
+		return fmt.Errorf("loading keystore: %w", err)
+	}
+
+	ks.Filesystem = fs
+	return nil
+}
+
 // KeypairInserter inserts a keypair.
 type KeypairInserter interface {
 	Insert(kp keystore.KeyPair) error
@@ -272,6 +309,7 @@ func parseChainSpec(chain string) error {
 
 	config.Network.Bootnodes = spec.Bootnodes
 	config.Network.ProtocolID = spec.ProtocolID
+	config.Network.ForkID = spec.ForkID
 	parseIdentity()
 
 	return nil
@@ -384,6 +422,15 @@ func copyChainSpec(source, destination string) error {
 	return nil
 }
 
+// parseStatePruning sets the pruning mode from the deprecated --state-pruning flag,
+// unless the --pruning flag is set.
+func parseStatePruning(cmd *cobra.Command) {
+	if !cmd.Flags().Changed("state-pruning") || cmd.Flags().Changed("pruning") {
+		return
+	}
+	config.BaseConfig.Pruning = pruner.Mode(statePruning)
+}
+
 // parseRole parses the role from the command line flags
 func parseRole() error {
 	var selectedRole common.NetworkRole
@@ -14,6 +14,7 @@
 	"github.com/spf13/viper"
 
 	"github.com/ChainSafe/gossamer/chain/westend"
+	"github.com/ChainSafe/gossamer/dot/state/pruner"
 )
 
 func TestAddStringFlagBindViper(t *testing.T) {
@@ -268,3 +269,23 @@ func TestBindToViper(t *testing.T) {
 		})
 	}
 }
+
+func TestParseStatePruning(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.PersistentFlags().StringVar(&statePruning, "state-pruning", "", "usage")
+	cmd.PersistentFlags().String("pruning", "", "usage")
+
+	config.BaseConfig.Pruning = pruner.Archive
+	t.Cleanup(func() { config.BaseConfig.Pruning = pruner.Archive })
+
+	err := cmd.ParseFlags([]string{"--state-pruning=full"})
+	require.NoError(t, err)
+	parseStatePruning(cmd)
+	require.Equal(t, pruner.Full, config.BaseConfig.Pruning)
+
+	err = cmd.ParseFlags([]string{"--state-pruning=full", "--pruning=archive"})
+	require.NoError(t, err)
+	config.BaseConfig.Pruning = pruner.Archive
+	parseStatePruning(cmd)
+	require.Equal(t, pruner.Archive, config.BaseConfig.Pruning)
+}
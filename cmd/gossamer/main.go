@@ -21,6 +21,7 @@ func main() {
 	rootCmd.AddCommand(
 		commands.InitCmd,
 		commands.AccountCmd,
+		commands.KeyCmd,
 		commands.ImportRuntimeCmd,
 		commands.BuildSpecCmd,
 		commands.PruneStateCmd,
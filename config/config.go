@@ -23,12 +23,16 @@
 	defaultChainSpecFile = "chain-spec-raw.json"
 	// DefaultLogLevel is the default log level
 	DefaultLogLevel = "info"
+	// DefaultLogFormat is the default log format
+	DefaultLogFormat = "console"
 	// DefaultPrometheusPort is the default prometheus port
 	DefaultPrometheusPort = uint32(9876)
 	// DefaultRetainBlocks is the default number of blocks to retain
 	DefaultRetainBlocks = uint32(512)
 	// DefaultPruning is the default pruning strategy
 	DefaultPruning = pruner.Archive
+	// DefaultStorageBackend is the default storage state backend
+	DefaultStorageBackend = "inmemory"
 
 	// defaultAccount is the default account key
 	defaultAccount = "alice"
@@ -46,6 +50,12 @@
 	DefaultMinPeers = 0
 	// DefaultMaxPeers is the default maximum number of peers
 	DefaultMaxPeers = 50
+	// DefaultSyncMode is the default syncing mode
+	DefaultSyncMode = FullSyncMode
+	// DefaultGrandpaVoter is the default GRANDPA voter
+	DefaultGrandpaVoter = LegacyGrandpaVoter
+	// DefaultOffchainWorker is the default offchain worker mode
+	DefaultOffchainWorker = WhenValidatorOffchainWorker
 
 	// DefaultRPCPort is the default RPC port
 	DefaultRPCPort = uint32(8545)
@@ -75,6 +85,11 @@
 	"childstate",
 	"syncstate",
 	"payment",
+	"chainHead",
+	"chainSpec",
+	"transaction",
+	"archive",
+	"mmr",
 }
 
 // Config defines the configuration for the gossamer node
@@ -130,6 +145,7 @@ type BaseConfig struct {
 	BasePath           string                      `mapstructure:"base-path,omitempty"`
 	ChainSpec          string                      `mapstructure:"chain-spec,omitempty"`
 	LogLevel           string                      `mapstructure:"log-level,omitempty"`
+	LogFormat          string                      `mapstructure:"log-format,omitempty"`
 	PrometheusPort     uint32                      `mapstructure:"prometheus-port,omitempty"`
 	RetainBlocks       uint32                      `mapstructure:"retain-blocks,omitempty"`
 	Pruning            pruner.Mode                 `mapstructure:"pruning,omitempty"`
@@ -160,8 +176,9 @@ type LogConfig struct {
 
 // AccountConfig is to marshal/unmarshal account config vars
 type AccountConfig struct {
-	Key    string `mapstructure:"key,omitempty"`
-	Unlock string `mapstructure:"unlock,omitempty"`
+	Key          string `mapstructure:"key,omitempty"`
+	Unlock       string `mapstructure:"unlock,omitempty"`
+	KeystorePath string `mapstructure:"keystore-path,omitempty"`
 }
 
 // NetworkConfig is to marshal/unmarshal toml network config vars
@@ -169,6 +186,7 @@ type NetworkConfig struct {
 	Port              uint16        `mapstructure:"port"`
 	Bootnodes         []string      `mapstructure:"bootnodes"`
 	ProtocolID        string        `mapstructure:"protocol"`
+	ForkID            string        `mapstructure:"fork-id"`
 	NoBootstrap       bool          `mapstructure:"no-bootstrap"`
 	NoMDNS            bool          `mapstructure:"no-mdns"`
 	MinPeers          int           `mapstructure:"min-peers"`
@@ -179,8 +197,23 @@ type NetworkConfig struct {
 	PublicDNS         string        `mapstructure:"public-dns"`
 	NodeKey           string        `mapstructure:"node-key"`
 	ListenAddress     string        `mapstructure:"listen-addr"`
+	SyncMode          SyncMode      `mapstructure:"sync"`
 }
 
+// SyncMode is the blockchain syncing mode.
+type SyncMode string
+
+const (
+	// FullSyncMode downloads and executes all the blocks.
+	FullSyncMode SyncMode = "full"
+	// FastSyncMode downloads the state of a recent finalised block
+	// and then executes the blocks from that block.
+	FastSyncMode SyncMode = "fast"
+	// WarpSyncMode proves the latest finalised block with the GRANDPA
+	// authority set changes, and then downloads its state.
+	WarpSyncMode SyncMode = "warp"
+)
+
 // CoreConfig is to marshal/unmarshal toml core config vars
 type CoreConfig struct {
 	Role             common.NetworkRole `mapstructure:"role,omitempty"`
@@ -188,11 +221,39 @@ type CoreConfig struct {
 	GrandpaAuthority bool               `mapstructure:"grandpa-authority"`
 	WasmInterpreter  string             `mapstructure:"wasm-interpreter,omitempty"`
 	GrandpaInterval  time.Duration      `mapstructure:"grandpa-interval,omitempty"`
+	GrandpaVoter     GrandpaVoter       `mapstructure:"grandpa-voter,omitempty"`
+	OffchainWorker   OffchainWorker     `mapstructure:"offchain-worker,omitempty"`
 }
 
+// GrandpaVoter is the implementation of the GRANDPA voter run by authorities.
+type GrandpaVoter string
+
+const (
+	// LegacyGrandpaVoter runs the voting rounds of the lib/grandpa service.
+	LegacyGrandpaVoter GrandpaVoter = "legacy"
+	// FinalityGrandpaVoter runs the pkg/finality-grandpa voter, which
+	// supports catch up, past rounds and equivocation reporting.
+	FinalityGrandpaVoter GrandpaVoter = "finality-grandpa"
+)
+
+// OffchainWorker is the mode deciding when to run the runtime offchain workers.
+type OffchainWorker string
+
+const (
+	// AlwaysOffchainWorker runs the offchain workers on every node.
+	AlwaysOffchainWorker OffchainWorker = "always"
+	// NeverOffchainWorker never runs the offchain workers.
+	NeverOffchainWorker OffchainWorker = "never"
+	// WhenValidatorOffchainWorker runs the offchain workers only
+	// when the node runs as an authority.
+	WhenValidatorOffchainWorker OffchainWorker = "when-validator"
+)
+
 // StateConfig contains the configuration for the state.
 type StateConfig struct {
-	Rewind uint `mapstructure:"rewind,omitempty"`
+	Rewind               uint   `mapstructure:"rewind,omitempty"`
+	Backend              string `mapstructure:"backend,omitempty"`
+	JustificationsWindow uint   `mapstructure:"justifications-window,omitempty"`
 }
 
 // RPCConfig is to marshal/unmarshal toml RPC config vars
@@ -239,6 +300,9 @@ func (b *BaseConfig) ValidateBasic() error {
 			uint32Max,
 		)
 	}
+	if !b.Pruning.IsValid() {
+		return fmt.Errorf("pruning mode %q is not valid", b.Pruning)
+	}
 
 	return nil
 }
@@ -268,6 +332,11 @@ func (n *NetworkConfig) ValidateBasic() error {
 	if n.DiscoveryInterval == 0 {
 		return fmt.Errorf("discovery-interval cannot be empty")
 	}
+	switch n.SyncMode {
+	case "", FullSyncMode, FastSyncMode, WarpSyncMode:
+	default:
+		return fmt.Errorf("sync mode %q is not valid", n.SyncMode)
+	}
 
 	return nil
 }
@@ -280,6 +349,16 @@ func (c *CoreConfig) ValidateBasic() error {
 	if c.WasmInterpreter != wazero.Name {
 		return fmt.Errorf("wasm-interpreter is invalid")
 	}
+	switch c.GrandpaVoter {
+	case "", LegacyGrandpaVoter, FinalityGrandpaVoter:
+	default:
+		return fmt.Errorf("grandpa-voter %q is not valid", c.GrandpaVoter)
+	}
+	switch c.OffchainWorker {
+	case "", AlwaysOffchainWorker, NeverOffchainWorker, WhenValidatorOffchainWorker:
+	default:
+		return fmt.Errorf("offchain-worker %q is not valid", c.OffchainWorker)
+	}
 
 	return nil
 }
@@ -334,6 +413,7 @@ func DefaultConfig() *Config {
 			BasePath:           xdg.DataHome + "gossamer",
 			ChainSpec:          "",
 			LogLevel:           DefaultLogLevel,
+			LogFormat:          DefaultLogFormat,
 			PrometheusPort:     DefaultPrometheusPort,
 			RetainBlocks:       DefaultRetainBlocks,
 			Pruning:            DefaultPruning,
@@ -363,6 +443,8 @@ func DefaultConfig() *Config {
 			GrandpaAuthority: true,
 			WasmInterpreter:  DefaultWasmInterpreter,
 			GrandpaInterval:  DefaultDiscoveryInterval,
+			GrandpaVoter:     DefaultGrandpaVoter,
+			OffchainWorker:   DefaultOffchainWorker,
 		},
 		Network: &NetworkConfig{
 			Port:              DefaultNetworkPort,
@@ -378,9 +460,12 @@ func DefaultConfig() *Config {
 			PublicDNS:         "",
 			NodeKey:           "",
 			ListenAddress:     "",
+			SyncMode:          DefaultSyncMode,
 		},
 		State: &StateConfig{
-			Rewind: 0,
+			Rewind:               0,
+			Backend:              DefaultStorageBackend,
+			JustificationsWindow: 0,
 		},
 		RPC: &RPCConfig{
 			RPCExternal:       false,
@@ -415,6 +500,7 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			BasePath:           xdg.DataHome + "gossamer",
 			ChainSpec:          "",
 			LogLevel:           DefaultLogLevel,
+			LogFormat:          DefaultLogFormat,
 			PrometheusPort:     uint32(9876),
 			RetainBlocks:       DefaultRetainBlocks,
 			Pruning:            DefaultPruning,
@@ -444,11 +530,14 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			GrandpaAuthority: true,
 			WasmInterpreter:  DefaultWasmInterpreter,
 			GrandpaInterval:  DefaultDiscoveryInterval,
+			GrandpaVoter:     DefaultGrandpaVoter,
+			OffchainWorker:   DefaultOffchainWorker,
 		},
 		Network: &NetworkConfig{
 			Port:              DefaultNetworkPort,
 			Bootnodes:         nodeSpec.Bootnodes,
 			ProtocolID:        nodeSpec.ProtocolID,
+			ForkID:            nodeSpec.ForkID,
 			NoBootstrap:       false,
 			NoMDNS:            false,
 			MinPeers:          DefaultMinPeers,
@@ -459,9 +548,12 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			PublicDNS:         "",
 			NodeKey:           "",
 			ListenAddress:     "",
+			SyncMode:          DefaultSyncMode,
 		},
 		State: &StateConfig{
-			Rewind: 0,
+			Rewind:               0,
+			Backend:              DefaultStorageBackend,
+			JustificationsWindow: 0,
 		},
 		RPC: &RPCConfig{
 			RPCExternal:       false,
@@ -496,6 +588,7 @@ func Copy(c *Config) Config {
 			BasePath:           c.BaseConfig.BasePath,
 			ChainSpec:          c.BaseConfig.ChainSpec,
 			LogLevel:           c.BaseConfig.LogLevel,
+			LogFormat:          c.BaseConfig.LogFormat,
 			PrometheusPort:     c.PrometheusPort,
 			RetainBlocks:       c.RetainBlocks,
 			Pruning:            c.Pruning,
@@ -516,8 +609,9 @@ func Copy(c *Config) Config {
 			Wasmer:  c.Log.Wasmer,
 		},
 		Account: &AccountConfig{
-			Key:    c.Account.Key,
-			Unlock: c.Account.Unlock,
+			Key:          c.Account.Key,
+			Unlock:       c.Account.Unlock,
+			KeystorePath: c.Account.KeystorePath,
 		},
 		Core: &CoreConfig{
 			Role:             c.Core.Role,
@@ -525,11 +619,14 @@ func Copy(c *Config) Config {
 			GrandpaAuthority: c.Core.GrandpaAuthority,
 			WasmInterpreter:  c.Core.WasmInterpreter,
 			GrandpaInterval:  c.Core.GrandpaInterval,
+			GrandpaVoter:     c.Core.GrandpaVoter,
+			OffchainWorker:   c.Core.OffchainWorker,
 		},
 		Network: &NetworkConfig{
 			Port:              c.Network.Port,
 			Bootnodes:         c.Network.Bootnodes,
 			ProtocolID:        c.Network.ProtocolID,
+			ForkID:            c.Network.ForkID,
 			NoBootstrap:       c.Network.NoBootstrap,
 			NoMDNS:            c.Network.NoMDNS,
 			MinPeers:          c.Network.MinPeers,
@@ -540,9 +637,12 @@ func Copy(c *Config) Config {
 			PublicDNS:         c.Network.PublicDNS,
 			NodeKey:           c.Network.NodeKey,
 			ListenAddress:     c.Network.ListenAddress,
+			SyncMode:          c.Network.SyncMode,
 		},
 		State: &StateConfig{
-			Rewind: c.State.Rewind,
+			Rewind:               c.State.Rewind,
+			Backend:              c.State.Backend,
+			JustificationsWindow: c.State.JustificationsWindow,
 		},
 		RPC: &RPCConfig{
 			UnsafeRPC:         c.RPC.UnsafeRPC,
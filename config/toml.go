@@ -82,6 +82,11 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Defaults to "info"
 log-level = "{{ .BaseConfig.LogLevel }}"
 
+# Log output format
+# One of: console, json
+# Defaults to "console"
+log-format = "{{ .BaseConfig.LogFormat }}"
+
 # Listen address for the prometheus server
 # Defaults to "localhost:9876"
 prometheus-port = {{ .BaseConfig.PrometheusPort }}
@@ -90,7 +95,8 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Defaults to 512
 retain-blocks = {{ .BaseConfig.RetainBlocks }}
 
-# State trie online pruning mode
+# State trie online pruning mode, either "archive", "full"
+# or the number of finalised blocks to retain the state of
 # Defaults to "archive"
 pruning = "{{ .BaseConfig.Pruning }}"
 
@@ -167,6 +173,9 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Unlock an account. eg. --unlock=0 to unlock account 0
 unlock = "{{ .Account.Unlock }}"
 
+# Directory of the Substrate compatible keystore, defaults to [base-path]/chains/[chain-spec id]/keystore
+keystore-path = "{{ .Account.KeystorePath }}"
+
 #######################################################
 ###          Network Configuration Options          ###
 #######################################################
@@ -182,6 +191,9 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Protocol ID to use
 protocol-id = "{{ .Network.ProtocolID }}"
 
+# Fork ID of the chain, which is part of the protocol names when not empty
+fork-id = "{{ .Network.ForkID }}"
+
 # Disables network bootstrapping (mDNS still enabled)
 # Defaults to false
 no-bootstrap = {{ .Network.NoBootstrap }}
@@ -217,6 +229,14 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Multiaddress to listen on
 listen-addr = "{{ .Network.ListenAddress }}"
 
+# Syncing mode, either "full", "fast" or "warp"
+# The fast mode downloads the state of a recent finalised block
+# instead of executing all the blocks from genesis
+# The warp mode downloads the state of the latest finalised block,
+# proven with the GRANDPA authority set changes from genesis
+# Defaults to "full"
+sync = "{{ .Network.SyncMode }}"
+
 #######################################################
 ###             Core Configuration Options          ###
 #######################################################
@@ -242,6 +262,14 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Grandpa interval
 grandpa-interval = "{{ .Core.GrandpaInterval }}"
 
+# GRANDPA voter, either "legacy" or "finality-grandpa"
+# Defaults to "legacy"
+grandpa-voter = "{{ .Core.GrandpaVoter }}"
+
+# When to run the runtime offchain workers, either "always", "never" or "when-validator"
+# Defaults to "when-validator"
+offchain-worker = "{{ .Core.OffchainWorker }}"
+
 #######################################################
 ###            State Configuration Options          ###
 #######################################################
@@ -250,6 +278,17 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Defaults to 0
 rewind = {{ .State.Rewind }}
 
+# Storage state backend, either "inmemory" to load the state tries
+# in memory or "triedb" to read the state trie nodes from the database
+# as they are needed, using less memory
+# Defaults to "inmemory"
+backend = "{{ .State.Backend }}"
+
+# Number of the latest finalised blocks whose justifications are kept.
+# The justifications of the last blocks of the authority sets are always kept.
+# Defaults to 0 which keeps all the justifications
+justifications-window = {{ .State.JustificationsWindow }}
+
 #######################################################
 ###              RPC Configuration Options          ###
 #######################################################
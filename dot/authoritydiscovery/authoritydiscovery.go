@@ -0,0 +1,429 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package authoritydiscovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "authority-discovery"))
+
+const (
+	// DefaultPublishInterval is the default interval between two publications of our addresses
+	DefaultPublishInterval = time.Hour
+	// DefaultResolveInterval is the default interval between two resolutions of the authorities addresses
+	DefaultResolveInterval = 10 * time.Minute
+
+	dhtQueryTimeout = time.Minute
+)
+
+var (
+	// ErrInvalidKeystoreType is returned when the keystore is not the sr25519 authority discovery keystore
+	ErrInvalidKeystoreType = errors.New("invalid keystore type")
+	// ErrNilNetwork is returned when the network service is nil
+	ErrNilNetwork = errors.New("network is nil")
+)
+
+// Config is the configuration of the authority discovery service
+type Config struct {
+	LogLvl          log.Level
+	Network         Network
+	BlockState      BlockState
+	StorageState    StorageState
+	Keystore        keystore.Keystore
+	PublishInterval time.Duration
+	ResolveInterval time.Duration
+	// PublicIP and PublicDNS are the configured public ip and dns name of the node, whose
+	// addresses are published even if they are not globally reachable.
+	PublicIP  string
+	PublicDNS string
+}
+
+// Service publishes the addresses of the node in the DHT, signed with the authority discovery
+// keys of the node which belong to the current authority set, and resolves the addresses of the
+// other authorities of the set from the DHT, so that authorities can connect to each other directly.
+type Service struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	network         Network
+	blockState      BlockState
+	storageState    StorageState
+	keystore        keystore.Keystore
+	publishInterval time.Duration
+	resolveInterval time.Duration
+	publicHosts     []string
+
+	mu                   sync.RWMutex
+	addrInfoByAuthority  map[types.AuthorityID]peer.AddrInfo
+	creationTimeByRecord map[types.AuthorityID]uint64
+	// reservedPeers are the peers of the authorities added as reserved peers of the network
+	reservedPeers map[peer.ID]struct{}
+}
+
+// NewService returns a new authority discovery service
+func NewService(cfg *Config) (*Service, error) {
+	if cfg.Network == nil {
+		return nil, ErrNilNetwork
+	}
+
+	if cfg.Keystore.Name() != keystore.AudiName || cfg.Keystore.Type() != crypto.Sr25519Type {
+		return nil, ErrInvalidKeystoreType
+	}
+
+	logger.Patch(log.SetLevel(cfg.LogLvl))
+
+	publishInterval := cfg.PublishInterval
+	if publishInterval == 0 {
+		publishInterval = DefaultPublishInterval
+	}
+
+	resolveInterval := cfg.ResolveInterval
+	if resolveInterval == 0 {
+		resolveInterval = DefaultResolveInterval
+	}
+
+	var publicHosts []string
+	for _, publicHost := range []string{cfg.PublicIP, cfg.PublicDNS} {
+		publicHost = strings.TrimSpace(publicHost)
+		if publicHost != "" {
+			publicHosts = append(publicHosts, publicHost)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		ctx:                  ctx,
+		cancel:               cancel,
+		network:              cfg.Network,
+		blockState:           cfg.BlockState,
+		storageState:         cfg.StorageState,
+		keystore:             cfg.Keystore,
+		publishInterval:      publishInterval,
+		resolveInterval:      resolveInterval,
+		publicHosts:          publicHosts,
+		addrInfoByAuthority:  make(map[types.AuthorityID]peer.AddrInfo),
+		creationTimeByRecord: make(map[types.AuthorityID]uint64),
+		reservedPeers:        make(map[peer.ID]struct{}),
+	}, nil
+}
+
+// Start starts publishing our addresses and resolving the addresses of the authorities
+func (s *Service) Start() error {
+	s.wg.Add(2)
+	go s.runPeriodically(s.publishInterval, s.publish)
+	go s.runPeriodically(s.resolveInterval, s.resolve)
+	return nil
+}
+
+// Stop stops the service
+func (s *Service) Stop() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+// runPeriodically calls run immediately and then at each interval, until the service is stopped.
+func (s *Service) runPeriodically(interval time.Duration, run func() error) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := run()
+		if err != nil && !errors.Is(err, context.Canceled) {
+			logger.Warn(err.Error())
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetAddressesByAuthorityID returns the addresses of the peer of the given authority,
+// or nil if they are not known.
+func (s *Service) GetAddressesByAuthorityID(authorityID types.AuthorityID) []multiaddr.Multiaddr {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.addrInfoByAuthority[authorityID].Addrs
+}
+
+// GetAuthorityIDsByPeerID returns the ids of the authorities whose addresses are the addresses
+// of the given peer.
+func (s *Service) GetAuthorityIDsByPeerID(peerID peer.ID) (authorityIDs []types.AuthorityID) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for authorityID, addrInfo := range s.addrInfoByAuthority {
+		if addrInfo.ID == peerID {
+			authorityIDs = append(authorityIDs, authorityID)
+		}
+	}
+	return authorityIDs
+}
+
+// authorities returns the authority discovery ids of the current authority set,
+// from the state of the best block.
+func (s *Service) authorities() ([]types.AuthorityID, error) {
+	bestBlockHash := s.blockState.BestBlockHash()
+	stateRoot, err := s.blockState.GetBlockStateRoot(bestBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting state root: %w", err)
+	}
+
+	trieState, err := s.storageState.TrieState(&stateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	rt, err := s.blockState.GetRuntime(bestBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime: %w", err)
+	}
+
+	rt.SetContextStorage(trieState)
+	ret, err := rt.Exec(runtime.AuthorityDiscoveryAPIAuthorities, []byte{})
+	if err != nil {
+		return nil, fmt.Errorf("getting authorities: %w", err)
+	}
+
+	var authorities []types.AuthorityID
+	err = scale.Unmarshal(ret, &authorities)
+	if err != nil {
+		return nil, fmt.Errorf("decoding authorities: %w", err)
+	}
+	return authorities, nil
+}
+
+// publish puts a record with our addresses in the DHT for each of our keys in the current
+// authority set.
+func (s *Service) publish() error {
+	authorities, err := s.authorities()
+	if err != nil {
+		return fmt.Errorf("publishing addresses: %w", err)
+	}
+
+	var keypairs []keystore.KeyPair
+	for _, authorityID := range authorities {
+		publicKey, err := sr25519.NewPublicKey(authorityID[:])
+		if err != nil {
+			return fmt.Errorf("decoding authority id: %w", err)
+		}
+
+		keypair := s.keystore.GetKeypair(publicKey)
+		if keypair != nil {
+			keypairs = append(keypairs, keypair)
+		}
+	}
+
+	if len(keypairs) == 0 {
+		return nil
+	}
+
+	addresses := s.publishedAddresses()
+	if len(addresses) == 0 {
+		return fmt.Errorf("publishing addresses: %w", errNoAddresses)
+	}
+
+	record := authorityRecord{
+		addresses:    make([][]byte, len(addresses)),
+		creationTime: uint64(time.Now().UnixNano()),
+	}
+	for i, address := range addresses {
+		record.addresses[i] = address.Bytes()
+	}
+	encodedRecord := record.encode()
+
+	signature, publicKey, err := s.network.SignWithHostKey(encodedRecord)
+	if err != nil {
+		return fmt.Errorf("signing record with host key: %w", err)
+	}
+
+	for _, keypair := range keypairs {
+		authSignature, err := keypair.Sign(encodedRecord)
+		if err != nil {
+			return fmt.Errorf("signing record with authority key: %w", err)
+		}
+
+		signed := signedAuthorityRecord{
+			record:        encodedRecord,
+			authSignature: authSignature,
+			peerSignature: &peerSignature{
+				signature: signature,
+				publicKey: publicKey,
+			},
+		}
+
+		var authorityID types.AuthorityID
+		copy(authorityID[:], keypair.Public().Encode())
+
+		ctx, cancel := context.WithTimeout(s.ctx, dhtQueryTimeout)
+		err = s.network.PutValue(ctx, dhtKey(authorityID), signed.encode())
+		cancel()
+		if err != nil {
+			return fmt.Errorf("putting record of authority 0x%x: %w", authorityID, err)
+		}
+		logger.Debugf("published addresses of authority 0x%x", authorityID)
+	}
+
+	return nil
+}
+
+// publishedAddresses returns the addresses of the node to publish, which are its globally reachable
+// addresses and the addresses of its configured public ip or dns name, so that the other authorities
+// do not try to reach the node on its loopback or private network addresses.
+func (s *Service) publishedAddresses() (addresses []multiaddr.Multiaddr) {
+	for _, address := range s.network.NetworkState().Multiaddrs {
+		if manet.IsPublicAddr(address) || s.isPublicHostAddress(address) {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+// isPublicHostAddress returns whether the ip or dns name of the address is the configured public ip
+// or dns name of the node.
+func (s *Service) isPublicHostAddress(address multiaddr.Multiaddr) bool {
+	host, _ := multiaddr.SplitFirst(address)
+	if host == nil {
+		return false
+	}
+
+	for _, publicHost := range s.publicHosts {
+		if host.Value() == publicHost {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve gets the records of the other authorities of the current authority set from the DHT,
+// and adds their peers as reserved peers of the network, so the node stays connected to them.
+// The peers of the authorities which left the authority set are removed from the reserved peers.
+func (s *Service) resolve() error {
+	authorities, err := s.authorities()
+	if err != nil {
+		return fmt.Errorf("resolving addresses: %w", err)
+	}
+
+	current := make(map[types.AuthorityID]struct{}, len(authorities))
+	for _, authorityID := range authorities {
+		current[authorityID] = struct{}{}
+
+		publicKey, err := sr25519.NewPublicKey(authorityID[:])
+		if err != nil {
+			return fmt.Errorf("decoding authority id: %w", err)
+		}
+
+		if s.keystore.GetKeypair(publicKey) != nil {
+			continue
+		}
+
+		err = s.resolveAuthority(authorityID)
+		if errors.Is(err, context.Canceled) {
+			return err
+		} else if err != nil {
+			logger.Debugf("failed to resolve addresses of authority 0x%x: %s", authorityID, err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for authorityID := range s.addrInfoByAuthority {
+		if _, ok := current[authorityID]; !ok {
+			delete(s.addrInfoByAuthority, authorityID)
+			delete(s.creationTimeByRecord, authorityID)
+		}
+	}
+
+	return s.removeUnusedReservedPeers()
+}
+
+// removeUnusedReservedPeers removes the reserved peers added for authorities
+// which are not the peer of any known authority anymore.
+// It must be called with the lock held.
+func (s *Service) removeUnusedReservedPeers() error {
+	used := make(map[peer.ID]struct{}, len(s.addrInfoByAuthority))
+	for _, addrInfo := range s.addrInfoByAuthority {
+		used[addrInfo.ID] = struct{}{}
+	}
+
+	for peerID := range s.reservedPeers {
+		if _, ok := used[peerID]; ok {
+			continue
+		}
+
+		err := s.network.RemoveReservedPeers(peerID.String())
+		if err != nil {
+			return fmt.Errorf("removing reserved peer %s: %w", peerID, err)
+		}
+		delete(s.reservedPeers, peerID)
+	}
+	return nil
+}
+
+func (s *Service) resolveAuthority(authorityID types.AuthorityID) error {
+	ctx, cancel := context.WithTimeout(s.ctx, dhtQueryTimeout)
+	value, err := s.network.GetValue(ctx, dhtKey(authorityID))
+	cancel()
+	if err != nil {
+		return fmt.Errorf("getting record: %w", err)
+	}
+
+	signed, err := decodeSignedAuthorityRecord(value)
+	if err != nil {
+		return fmt.Errorf("decoding signed record: %w", err)
+	}
+
+	addrInfo, creationTime, err := signed.verify(authorityID)
+	if err != nil {
+		return fmt.Errorf("verifying record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if creationTime < s.creationTimeByRecord[authorityID] {
+		return nil
+	}
+	s.addrInfoByAuthority[authorityID] = addrInfo
+	s.creationTimeByRecord[authorityID] = creationTime
+
+	p2pAddrs, err := peer.AddrInfoToP2pAddrs(&addrInfo)
+	if err != nil {
+		return fmt.Errorf("getting p2p addresses: %w", err)
+	}
+
+	addrs := make([]string, len(p2pAddrs))
+	for i, p2pAddr := range p2pAddrs {
+		addrs[i] = p2pAddr.String()
+	}
+
+	err = s.network.AddReservedPeers(addrs...)
+	if err != nil {
+		return fmt.Errorf("adding reserved peer: %w", err)
+	}
+	s.reservedPeers[addrInfo.ID] = struct{}{}
+	logger.Debugf("resolved addresses %s of authority 0x%x", addrInfo, authorityID)
+	return nil
+}
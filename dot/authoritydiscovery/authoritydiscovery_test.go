@@ -0,0 +1,240 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package authoritydiscovery
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestAuthority(t *testing.T) (keystore.Keystore, types.AuthorityID) {
+	t.Helper()
+
+	keypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	ks := keystore.NewBasicKeystore(keystore.AudiName, crypto.Sr25519Type)
+	err = ks.Insert(keypair)
+	require.NoError(t, err)
+
+	var authorityID types.AuthorityID
+	copy(authorityID[:], keypair.Public().Encode())
+	return ks, authorityID
+}
+
+func newTestStates(ctrl *gomock.Controller, t *testing.T, authorities ...types.AuthorityID) (
+	BlockState, StorageState) {
+	t.Helper()
+
+	encodedAuthorities, err := scale.Marshal(authorities)
+	require.NoError(t, err)
+
+	bestBlockHash := common.Hash{1}
+	stateRoot := common.Hash{2}
+	trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+
+	rt := NewMockInstance(ctrl)
+	rt.EXPECT().SetContextStorage(trieState)
+	rt.EXPECT().Exec(runtime.AuthorityDiscoveryAPIAuthorities, []byte{}).Return(encodedAuthorities, nil)
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().BestBlockHash().Return(bestBlockHash)
+	blockState.EXPECT().GetBlockStateRoot(bestBlockHash).Return(stateRoot, nil)
+	blockState.EXPECT().GetRuntime(bestBlockHash).Return(rt, nil)
+	storageState := NewMockStorageState(ctrl)
+	storageState.EXPECT().TrieState(&stateRoot).Return(trieState, nil)
+	return blockState, storageState
+}
+
+func TestNewService(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	_, err := NewService(&Config{Keystore: keystore.NewBasicKeystore(keystore.AudiName, crypto.Sr25519Type)})
+	assert.ErrorIs(t, err, ErrNilNetwork)
+
+	_, err = NewService(&Config{
+		Network:  NewMockNetwork(ctrl),
+		Keystore: keystore.NewBasicKeystore(keystore.GranName, crypto.Ed25519Type),
+	})
+	assert.ErrorIs(t, err, ErrInvalidKeystoreType)
+
+	service, err := NewService(&Config{
+		Network:  NewMockNetwork(ctrl),
+		Keystore: keystore.NewBasicKeystore(keystore.AudiName, crypto.Sr25519Type),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPublishInterval, service.publishInterval)
+	assert.Equal(t, DefaultResolveInterval, service.resolveInterval)
+}
+
+func TestService_publishAndResolve(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	publisherKeystore, publisherID := newTestAuthority(t)
+	resolverKeystore, resolverID := newTestAuthority(t)
+	_, unknownID := newTestAuthority(t)
+
+	peerKey, peerID := newTestPeer(t)
+	address := newTestAddress(t, 30333, peerID)
+
+	// the publisher puts a record with its addresses in the DHT
+	var published []byte
+	publisherNetwork := NewMockNetwork(ctrl)
+	publisherNetwork.EXPECT().NetworkState().Return(common.NetworkState{
+		PeerID:     peerID.String(),
+		Multiaddrs: []multiaddr.Multiaddr{address},
+	})
+	publisherNetwork.EXPECT().SignWithHostKey(gomock.Any()).DoAndReturn(func(msg []byte) ([]byte, []byte, error) {
+		signature, err := peerKey.Sign(msg)
+		require.NoError(t, err)
+		publicKey, err := libp2pcrypto.MarshalPublicKey(peerKey.GetPublic())
+		require.NoError(t, err)
+		return signature, publicKey, nil
+	})
+	publisherNetwork.EXPECT().PutValue(gomock.Any(), dhtKey(publisherID), gomock.Any()).
+		DoAndReturn(func(_ interface{}, _ string, value []byte) error {
+			published = value
+			return nil
+		})
+
+	publisherBlockState, publisherStorageState := newTestStates(ctrl, t, publisherID, resolverID)
+	publisher, err := NewService(&Config{
+		Network:      publisherNetwork,
+		BlockState:   publisherBlockState,
+		StorageState: publisherStorageState,
+		Keystore:     publisherKeystore,
+	})
+	require.NoError(t, err)
+
+	err = publisher.publish()
+	require.NoError(t, err)
+
+	// the resolver gets the record of the publisher from the DHT, and does not resolve itself
+	resolverNetwork := NewMockNetwork(ctrl)
+	resolverNetwork.EXPECT().GetValue(gomock.Any(), dhtKey(publisherID)).Return(published, nil)
+	resolverNetwork.EXPECT().GetValue(gomock.Any(), dhtKey(unknownID)).Return(nil, errNoAddresses)
+	resolverNetwork.EXPECT().AddReservedPeers("/ip4/1.2.3.4/tcp/30333/p2p/" + peerID.String())
+
+	resolverBlockState, resolverStorageState := newTestStates(ctrl, t, publisherID, resolverID, unknownID)
+	resolver, err := NewService(&Config{
+		Network:      resolverNetwork,
+		BlockState:   resolverBlockState,
+		StorageState: resolverStorageState,
+		Keystore:     resolverKeystore,
+	})
+	require.NoError(t, err)
+
+	err = resolver.resolve()
+	require.NoError(t, err)
+
+	addresses := resolver.GetAddressesByAuthorityID(publisherID)
+	require.Len(t, addresses, 1)
+	assert.Equal(t, "/ip4/1.2.3.4/tcp/30333", addresses[0].String())
+	assert.Nil(t, resolver.GetAddressesByAuthorityID(unknownID))
+	assert.Equal(t, []types.AuthorityID{publisherID}, resolver.GetAuthorityIDsByPeerID(peerID))
+
+	// the addresses of the authorities which left the authority set are forgotten,
+	// and their peers are not reserved peers anymore
+	resolverNetwork.EXPECT().RemoveReservedPeers(peerID.String())
+	resolver.blockState, resolver.storageState = newTestStates(ctrl, t, resolverID)
+	err = resolver.resolve()
+	require.NoError(t, err)
+	assert.Nil(t, resolver.GetAddressesByAuthorityID(publisherID))
+	assert.Empty(t, resolver.reservedPeers)
+}
+
+func TestService_publish_notAuthority(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	_, authorityID := newTestAuthority(t)
+
+	blockState, storageState := newTestStates(ctrl, t, authorityID)
+	service, err := NewService(&Config{
+		Network:      NewMockNetwork(ctrl),
+		BlockState:   blockState,
+		StorageState: storageState,
+		Keystore:     keystore.NewBasicKeystore(keystore.AudiName, crypto.Sr25519Type),
+	})
+	require.NoError(t, err)
+
+	err = service.publish()
+	assert.NoError(t, err)
+}
+
+func TestService_publishedAddresses(t *testing.T) {
+	t.Parallel()
+
+	_, peerID := newTestPeer(t)
+	newAddress := func(address string) multiaddr.Multiaddr {
+		return multiaddr.StringCast(address + "/p2p/" + peerID.String())
+	}
+	addresses := []multiaddr.Multiaddr{
+		newAddress("/ip4/127.0.0.1/tcp/30333"),
+		newAddress("/ip4/192.168.1.2/tcp/30333"),
+		newAddress("/ip4/10.0.0.2/tcp/30333"),
+		newAddress("/ip4/1.2.3.4/tcp/30333"),
+		newAddress("/dns/localhost/tcp/30333"),
+		newAddress("/dns/node.example.com/tcp/30333"),
+	}
+
+	testCases := map[string]struct {
+		publicIP  string
+		publicDNS string
+		expected  []multiaddr.Multiaddr
+	}{
+		"globally_reachable": {
+			expected: []multiaddr.Multiaddr{addresses[3], addresses[5]},
+		},
+		"public_ip": {
+			publicIP: "192.168.1.2",
+			expected: []multiaddr.Multiaddr{addresses[1], addresses[3], addresses[5]},
+		},
+		"public_dns": {
+			publicDNS: "localhost",
+			expected:  []multiaddr.Multiaddr{addresses[3], addresses[4], addresses[5]},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			network := NewMockNetwork(ctrl)
+			network.EXPECT().NetworkState().Return(common.NetworkState{
+				PeerID:     peerID.String(),
+				Multiaddrs: addresses,
+			})
+
+			service, err := NewService(&Config{
+				Network:   network,
+				Keystore:  keystore.NewBasicKeystore(keystore.AudiName, crypto.Sr25519Type),
+				PublicIP:  testCase.publicIP,
+				PublicDNS: testCase.publicDNS,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expected, service.publishedAddresses())
+		})
+	}
+}
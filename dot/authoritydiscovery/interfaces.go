@@ -0,0 +1,34 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package authoritydiscovery
+
+import (
+	"context"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+)
+
+// Network is the interface for the network service
+type Network interface {
+	PutValue(ctx context.Context, key string, value []byte) error
+	GetValue(ctx context.Context, key string) ([]byte, error)
+	NetworkState() common.NetworkState
+	SignWithHostKey(msg []byte) (signature, publicKey []byte, err error)
+	AddReservedPeers(addrs ...string) error
+	RemoveReservedPeers(addrs ...string) error
+}
+
+// BlockState is the interface for the block state
+type BlockState interface {
+	BestBlockHash() common.Hash
+	GetBlockStateRoot(bhash common.Hash) (hash common.Hash, err error)
+	GetRuntime(blockHash common.Hash) (instance runtime.Instance, err error)
+}
+
+// StorageState is the interface for the storage state
+type StorageState interface {
+	TrieState(root *common.Hash) (*rtstorage.TrieState, error)
+}
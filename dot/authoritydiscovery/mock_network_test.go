@@ -0,0 +1,136 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ChainSafe/gossamer/dot/authoritydiscovery (interfaces: Network)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock_network_test.go -package authoritydiscovery . Network
+//
+
+// Package authoritydiscovery is a generated GoMock package.
+package authoritydiscovery
+
+import (
+	context "context"
+	reflect "reflect"
+
+	common "github.com/ChainSafe/gossamer/lib/common"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNetwork is a mock of Network interface.
+type MockNetwork struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetworkMockRecorder
+}
+
+// MockNetworkMockRecorder is the mock recorder for MockNetwork.
+type MockNetworkMockRecorder struct {
+	mock *MockNetwork
+}
+
+// NewMockNetwork creates a new mock instance.
+func NewMockNetwork(ctrl *gomock.Controller) *MockNetwork {
+	mock := &MockNetwork{ctrl: ctrl}
+	mock.recorder = &MockNetworkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNetwork) EXPECT() *MockNetworkMockRecorder {
+	return m.recorder
+}
+
+// AddReservedPeers mocks base method.
+func (m *MockNetwork) AddReservedPeers(arg0 ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddReservedPeers", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReservedPeers indicates an expected call of AddReservedPeers.
+func (mr *MockNetworkMockRecorder) AddReservedPeers(arg0 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReservedPeers", reflect.TypeOf((*MockNetwork)(nil).AddReservedPeers), arg0...)
+}
+
+// GetValue mocks base method.
+func (m *MockNetwork) GetValue(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValue", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValue indicates an expected call of GetValue.
+func (mr *MockNetworkMockRecorder) GetValue(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValue", reflect.TypeOf((*MockNetwork)(nil).GetValue), arg0, arg1)
+}
+
+// NetworkState mocks base method.
+func (m *MockNetwork) NetworkState() common.NetworkState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkState")
+	ret0, _ := ret[0].(common.NetworkState)
+	return ret0
+}
+
+// NetworkState indicates an expected call of NetworkState.
+func (mr *MockNetworkMockRecorder) NetworkState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkState", reflect.TypeOf((*MockNetwork)(nil).NetworkState))
+}
+
+// PutValue mocks base method.
+func (m *MockNetwork) PutValue(arg0 context.Context, arg1 string, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutValue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutValue indicates an expected call of PutValue.
+func (mr *MockNetworkMockRecorder) PutValue(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutValue", reflect.TypeOf((*MockNetwork)(nil).PutValue), arg0, arg1, arg2)
+}
+
+// RemoveReservedPeers mocks base method.
+func (m *MockNetwork) RemoveReservedPeers(arg0 ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveReservedPeers", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReservedPeers indicates an expected call of RemoveReservedPeers.
+func (mr *MockNetworkMockRecorder) RemoveReservedPeers(arg0 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReservedPeers", reflect.TypeOf((*MockNetwork)(nil).RemoveReservedPeers), arg0...)
+}
+
+// SignWithHostKey mocks base method.
+func (m *MockNetwork) SignWithHostKey(arg0 []byte) ([]byte, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignWithHostKey", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SignWithHostKey indicates an expected call of SignWithHostKey.
+func (mr *MockNetworkMockRecorder) SignWithHostKey(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignWithHostKey", reflect.TypeOf((*MockNetwork)(nil).SignWithHostKey), arg0)
+}
@@ -0,0 +1,9 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package authoritydiscovery
+
+//go:generate mockgen -destination=mock_network_test.go -package $GOPACKAGE . Network
+//go:generate mockgen -destination=mock_block_state_test.go -package $GOPACKAGE . BlockState
+//go:generate mockgen -destination=mock_storage_state_test.go -package $GOPACKAGE . StorageState
+//go:generate mockgen -destination=mock_runtime_instance_test.go -package $GOPACKAGE github.com/ChainSafe/gossamer/lib/runtime Instance
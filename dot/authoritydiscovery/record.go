@@ -0,0 +1,318 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package authoritydiscovery
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The records published in the DHT are encoded with the protobuf schema of the
+// authority discovery protocol:
+//
+//	message AuthorityRecord {
+//		repeated bytes addresses = 1;
+//		optional TimestampInfo creation_time = 2;
+//	}
+//
+//	message TimestampInfo {
+//		// SCALE encoded u128 of the nanoseconds since the UNIX epoch
+//		bytes timestamp = 1;
+//	}
+//
+//	message PeerSignature {
+//		bytes signature = 1;
+//		bytes public_key = 2;
+//	}
+//
+//	message SignedAuthorityRecord {
+//		bytes record = 1;
+//		bytes auth_signature = 2;
+//		PeerSignature peer_signature = 3;
+//	}
+
+var (
+	errInvalidAuthoritySignature = errors.New("invalid authority signature")
+	errInvalidPeerSignature      = errors.New("invalid peer signature")
+	errNoAddresses               = errors.New("no addresses")
+	errDifferentPeerIDs          = errors.New("addresses of different peer ids")
+	errInvalidTimestamp          = errors.New("invalid timestamp")
+	errNoValidRecord             = errors.New("no valid record")
+)
+
+// authorityRecord holds the addresses of an authority, and the creation time of the record
+// in nanoseconds since the UNIX epoch.
+type authorityRecord struct {
+	addresses    [][]byte
+	creationTime uint64
+}
+
+type peerSignature struct {
+	signature []byte
+	publicKey []byte
+}
+
+// signedAuthorityRecord is the encoded authority record, with the signature of the
+// authority and the signature of the peer whose addresses are in the record.
+type signedAuthorityRecord struct {
+	record        []byte
+	authSignature []byte
+	peerSignature *peerSignature
+}
+
+// dhtKey returns the DHT key of the record of the given authority, which is the SHA-256 hash of its id.
+func dhtKey(authorityID types.AuthorityID) string {
+	hash := sha256.Sum256(authorityID[:])
+	return string(hash[:])
+}
+
+func (r authorityRecord) encode() []byte {
+	var encoded []byte
+	for _, address := range r.addresses {
+		encoded = protowire.AppendTag(encoded, 1, protowire.BytesType)
+		encoded = protowire.AppendBytes(encoded, address)
+	}
+
+	timestamp := make([]byte, 16)
+	binary.LittleEndian.PutUint64(timestamp, r.creationTime)
+	var timestampInfo []byte
+	timestampInfo = protowire.AppendTag(timestampInfo, 1, protowire.BytesType)
+	timestampInfo = protowire.AppendBytes(timestampInfo, timestamp)
+
+	encoded = protowire.AppendTag(encoded, 2, protowire.BytesType)
+	return protowire.AppendBytes(encoded, timestampInfo)
+}
+
+func decodeAuthorityRecord(data []byte) (record authorityRecord, err error) {
+	err = decodeFields(data, func(number protowire.Number, value []byte) error {
+		switch number {
+		case 1:
+			record.addresses = append(record.addresses, value)
+		case 2:
+			return decodeFields(value, func(number protowire.Number, value []byte) error {
+				if number != 1 {
+					return nil
+				}
+				// the nanoseconds since the UNIX epoch fit in the lowest 8 bytes of the u128
+				if len(value) != 16 || binary.LittleEndian.Uint64(value[8:]) != 0 {
+					return fmt.Errorf("%w: 0x%x", errInvalidTimestamp, value)
+				}
+				record.creationTime = binary.LittleEndian.Uint64(value)
+				return nil
+			})
+		}
+		return nil
+	})
+	return record, err
+}
+
+func (r signedAuthorityRecord) encode() []byte {
+	var encoded []byte
+	encoded = protowire.AppendTag(encoded, 1, protowire.BytesType)
+	encoded = protowire.AppendBytes(encoded, r.record)
+	encoded = protowire.AppendTag(encoded, 2, protowire.BytesType)
+	encoded = protowire.AppendBytes(encoded, r.authSignature)
+
+	if r.peerSignature != nil {
+		var encodedPeerSignature []byte
+		encodedPeerSignature = protowire.AppendTag(encodedPeerSignature, 1, protowire.BytesType)
+		encodedPeerSignature = protowire.AppendBytes(encodedPeerSignature, r.peerSignature.signature)
+		encodedPeerSignature = protowire.AppendTag(encodedPeerSignature, 2, protowire.BytesType)
+		encodedPeerSignature = protowire.AppendBytes(encodedPeerSignature, r.peerSignature.publicKey)
+
+		encoded = protowire.AppendTag(encoded, 3, protowire.BytesType)
+		encoded = protowire.AppendBytes(encoded, encodedPeerSignature)
+	}
+	return encoded
+}
+
+func decodeSignedAuthorityRecord(data []byte) (signed signedAuthorityRecord, err error) {
+	err = decodeFields(data, func(number protowire.Number, value []byte) error {
+		switch number {
+		case 1:
+			signed.record = value
+		case 2:
+			signed.authSignature = value
+		case 3:
+			signed.peerSignature = &peerSignature{}
+			return decodeFields(value, func(number protowire.Number, value []byte) error {
+				switch number {
+				case 1:
+					signed.peerSignature.signature = value
+				case 2:
+					signed.peerSignature.publicKey = value
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	return signed, err
+}
+
+// decodeFields calls handleField with each length-delimited field of the protobuf encoded message,
+// and skips the fields of other wire types.
+func decodeFields(data []byte, handleField func(number protowire.Number, value []byte) error) error {
+	for len(data) > 0 {
+		number, wireType, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return fmt.Errorf("decoding field tag: %w", protowire.ParseError(n))
+		}
+		data = data[n:]
+
+		if wireType != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(number, wireType, data)
+			if n < 0 {
+				return fmt.Errorf("decoding field %d: %w", number, protowire.ParseError(n))
+			}
+			data = data[n:]
+			continue
+		}
+
+		value, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			return fmt.Errorf("decoding field %d: %w", number, protowire.ParseError(n))
+		}
+		data = data[n:]
+
+		err := handleField(number, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verify checks the signatures of the record published by the given authority, and returns the
+// addresses of the peer in the record with the creation time of the record. The addresses without
+// the peer id are ignored.
+func (r signedAuthorityRecord) verify(authorityID types.AuthorityID) (
+	addrInfo peer.AddrInfo, creationTime uint64, err error) {
+	publicKey, err := sr25519.NewPublicKey(authorityID[:])
+	if err != nil {
+		return addrInfo, 0, fmt.Errorf("decoding authority id: %w", err)
+	}
+
+	ok, err := publicKey.Verify(r.record, r.authSignature)
+	if err != nil {
+		return addrInfo, 0, fmt.Errorf("verifying authority signature: %w", err)
+	} else if !ok {
+		return addrInfo, 0, errInvalidAuthoritySignature
+	}
+
+	record, err := decodeAuthorityRecord(r.record)
+	if err != nil {
+		return addrInfo, 0, fmt.Errorf("decoding authority record: %w", err)
+	}
+
+	for _, encodedAddress := range record.addresses {
+		address, err := multiaddr.NewMultiaddrBytes(encodedAddress)
+		if err != nil {
+			logger.Debugf("ignoring invalid address of authority 0x%x: %s", authorityID, err)
+			continue
+		}
+
+		info, err := peer.AddrInfoFromP2pAddr(address)
+		if err != nil {
+			logger.Debugf("ignoring address %s of authority 0x%x: %s", address, authorityID, err)
+			continue
+		}
+
+		if addrInfo.ID == "" {
+			addrInfo.ID = info.ID
+		} else if addrInfo.ID != info.ID {
+			return addrInfo, 0, fmt.Errorf("%w: %s and %s", errDifferentPeerIDs, addrInfo.ID, info.ID)
+		}
+		addrInfo.Addrs = append(addrInfo.Addrs, info.Addrs...)
+	}
+
+	if addrInfo.ID == "" {
+		return addrInfo, 0, errNoAddresses
+	}
+
+	if r.peerSignature != nil {
+		err = r.peerSignature.verify(r.record, addrInfo.ID)
+		if err != nil {
+			return addrInfo, 0, err
+		}
+	}
+
+	return addrInfo, record.creationTime, nil
+}
+
+// RecordValidator validates the authority discovery records of the DHT, and selects the record with
+// the newest creation time among the records of an authority. The signatures of a record are checked
+// when it is resolved, since its key is the hash of the authority id.
+type RecordValidator struct{}
+
+// Validate checks the value is a signed authority record.
+func (RecordValidator) Validate(_ string, value []byte) error {
+	_, err := decodeCreationTime(value)
+	return err
+}
+
+// Select returns the index of the record with the newest creation time, ignoring the invalid records.
+func (RecordValidator) Select(_ string, values [][]byte) (int, error) {
+	selected := -1
+	var newestCreationTime uint64
+	for i, value := range values {
+		creationTime, err := decodeCreationTime(value)
+		if err != nil {
+			continue
+		}
+
+		if selected == -1 || creationTime > newestCreationTime {
+			selected = i
+			newestCreationTime = creationTime
+		}
+	}
+
+	if selected == -1 {
+		return 0, errNoValidRecord
+	}
+	return selected, nil
+}
+
+// decodeCreationTime returns the creation time of the encoded signed authority record.
+func decodeCreationTime(value []byte) (creationTime uint64, err error) {
+	signed, err := decodeSignedAuthorityRecord(value)
+	if err != nil {
+		return 0, fmt.Errorf("decoding signed authority record: %w", err)
+	}
+
+	record, err := decodeAuthorityRecord(signed.record)
+	if err != nil {
+		return 0, fmt.Errorf("decoding authority record: %w", err)
+	}
+	return record.creationTime, nil
+}
+
+// verify checks the signature of the record by the peer with the given id.
+func (s peerSignature) verify(record []byte, peerID peer.ID) error {
+	publicKey, err := libp2pcrypto.UnmarshalPublicKey(s.publicKey)
+	if err != nil {
+		return fmt.Errorf("decoding peer public key: %w", err)
+	}
+
+	if !peerID.MatchesPublicKey(publicKey) {
+		return fmt.Errorf("%w: public key does not match peer id %s", errInvalidPeerSignature, peerID)
+	}
+
+	ok, err := publicKey.Verify(record, s.signature)
+	if err != nil {
+		return fmt.Errorf("verifying peer signature: %w", err)
+	} else if !ok {
+		return errInvalidPeerSignature
+	}
+	return nil
+}
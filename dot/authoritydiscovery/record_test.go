@@ -0,0 +1,203 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package authoritydiscovery
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPeer(t *testing.T) (libp2pcrypto.PrivKey, peer.ID) {
+	t.Helper()
+	privateKey, _, err := libp2pcrypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	peerID, err := peer.IDFromPrivateKey(privateKey)
+	require.NoError(t, err)
+	return privateKey, peerID
+}
+
+func newTestAddress(t *testing.T, port int, peerID peer.ID) multiaddr.Multiaddr {
+	t.Helper()
+	address, err := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/1.2.3.4/tcp/%d/p2p/%s", port, peerID))
+	require.NoError(t, err)
+	return address
+}
+
+func newTestSignedRecord(t *testing.T, keypair *sr25519.Keypair, peerKey libp2pcrypto.PrivKey,
+	addresses ...multiaddr.Multiaddr) signedAuthorityRecord {
+	t.Helper()
+
+	record := authorityRecord{creationTime: 1}
+	for _, address := range addresses {
+		record.addresses = append(record.addresses, address.Bytes())
+	}
+	encodedRecord := record.encode()
+
+	authSignature, err := keypair.Sign(encodedRecord)
+	require.NoError(t, err)
+
+	signed := signedAuthorityRecord{
+		record:        encodedRecord,
+		authSignature: authSignature,
+	}
+
+	if peerKey != nil {
+		signature, err := peerKey.Sign(encodedRecord)
+		require.NoError(t, err)
+		publicKey, err := libp2pcrypto.MarshalPublicKey(peerKey.GetPublic())
+		require.NoError(t, err)
+		signed.peerSignature = &peerSignature{signature: signature, publicKey: publicKey}
+	}
+	return signed
+}
+
+func Test_signedAuthorityRecord_encodeDecode(t *testing.T) {
+	t.Parallel()
+
+	record := authorityRecord{
+		addresses:    [][]byte{{1, 2}, {3}},
+		creationTime: 1700000000000000000,
+	}
+	decodedRecord, err := decodeAuthorityRecord(record.encode())
+	require.NoError(t, err)
+	assert.Equal(t, record, decodedRecord)
+
+	signed := signedAuthorityRecord{
+		record:        record.encode(),
+		authSignature: []byte{4, 5},
+		peerSignature: &peerSignature{
+			signature: []byte{6},
+			publicKey: []byte{7, 8},
+		},
+	}
+	decodedSigned, err := decodeSignedAuthorityRecord(signed.encode())
+	require.NoError(t, err)
+	assert.Equal(t, signed, decodedSigned)
+
+	_, err = decodeSignedAuthorityRecord([]byte{0x0a, 0x05, 0x01})
+	assert.ErrorContains(t, err, "decoding field 1: unexpected EOF")
+}
+
+func Test_signedAuthorityRecord_verify(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	var authorityID types.AuthorityID
+	copy(authorityID[:], keypair.Public().Encode())
+
+	otherKeypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	peerKey, peerID := newTestPeer(t)
+	otherPeerKey, otherPeerID := newTestPeer(t)
+	address := newTestAddress(t, 30333, peerID)
+	addressWithoutPeerID, err := multiaddr.NewMultiaddr("/ip4/1.2.3.4/tcp/30334")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		signed    signedAuthorityRecord
+		expPeerID peer.ID
+		expErr    error
+	}{
+		"valid_record": {
+			signed:    newTestSignedRecord(t, keypair, peerKey, address, addressWithoutPeerID),
+			expPeerID: peerID,
+		},
+		"valid_record_without_peer_signature": {
+			signed:    newTestSignedRecord(t, keypair, nil, address),
+			expPeerID: peerID,
+		},
+		"signed_by_other_authority": {
+			signed: newTestSignedRecord(t, otherKeypair, peerKey, address),
+			expErr: errInvalidAuthoritySignature,
+		},
+		"signed_by_other_peer": {
+			signed: newTestSignedRecord(t, keypair, otherPeerKey, address),
+			expErr: errInvalidPeerSignature,
+		},
+		"no_addresses": {
+			signed: newTestSignedRecord(t, keypair, peerKey, addressWithoutPeerID),
+			expErr: errNoAddresses,
+		},
+		"different_peer_ids": {
+			signed: newTestSignedRecord(t, keypair, peerKey, address, newTestAddress(t, 30334, otherPeerID)),
+			expErr: errDifferentPeerIDs,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addrInfo, creationTime, err := tt.signed.verify(authorityID)
+			assert.ErrorIs(t, err, tt.expErr)
+			if tt.expErr != nil {
+				return
+			}
+			assert.Equal(t, uint64(1), creationTime)
+			assert.Equal(t, tt.expPeerID, addrInfo.ID)
+			require.Len(t, addrInfo.Addrs, 1)
+			assert.Equal(t, "/ip4/1.2.3.4/tcp/30333", addrInfo.Addrs[0].String())
+		})
+	}
+}
+
+func TestRecordValidator(t *testing.T) {
+	t.Parallel()
+
+	newRecord := func(creationTime uint64) []byte {
+		signed := signedAuthorityRecord{
+			record: authorityRecord{creationTime: creationTime}.encode(),
+		}
+		return signed.encode()
+	}
+	invalidRecord := signedAuthorityRecord{record: []byte{0x12, 0x02, 0x0a, 0x00}}.encode()
+
+	validator := RecordValidator{}
+	err := validator.Validate("key", newRecord(1))
+	assert.NoError(t, err)
+	err = validator.Validate("key", invalidRecord)
+	assert.ErrorIs(t, err, errInvalidTimestamp)
+
+	testCases := map[string]struct {
+		values     [][]byte
+		selected   int
+		errWrapped error
+	}{
+		"newest_record": {
+			values:   [][]byte{newRecord(2), newRecord(3), newRecord(1)},
+			selected: 1,
+		},
+		"invalid_records_ignored": {
+			values:   [][]byte{invalidRecord, newRecord(1)},
+			selected: 1,
+		},
+		"no_valid_record": {
+			values:     [][]byte{invalidRecord},
+			errWrapped: errNoValidRecord,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			selected, err := validator.Select("key", testCase.values)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.selected, selected)
+		})
+	}
+}
@@ -33,7 +33,8 @@ func (b *BuildSpec) ToJSON() ([]byte, error) {
 		ProtocolID: b.genesis.ProtocolID,
 		Properties: b.genesis.Properties,
 		Genesis: genesis.Fields{
-			Runtime: b.genesis.GenesisFields().Runtime,
+			Runtime:        b.genesis.GenesisFields().Runtime,
+			RuntimeGenesis: b.genesis.GenesisFields().RuntimeGenesis,
 		},
 	}
 	return json.MarshalIndent(tmpGen, "", "    ")
@@ -49,7 +50,8 @@ func (b *BuildSpec) ToJSONRaw() ([]byte, error) {
 		ProtocolID: b.genesis.ProtocolID,
 		Properties: b.genesis.Properties,
 		Genesis: genesis.Fields{
-			Raw: b.genesis.GenesisFields().Raw,
+			Raw:             b.genesis.GenesisFields().Raw,
+			ChildrenDefault: b.genesis.GenesisFields().ChildrenDefault,
 		},
 	}
 	return json.MarshalIndent(tmpGen, "", "    ")
@@ -61,6 +63,56 @@ func BuildFromGenesis(path string, authCount int) (*BuildSpec, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	if gen.Genesis.RuntimeGenesis != nil {
+		err = buildRawGenesis(gen)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bs := &BuildSpec{
+		genesis: gen,
+	}
+	return bs, nil
+}
+
+// BuildFromRuntime builds a BuildSpec from the given runtime code, with the genesis config of the
+// runtime preset with the given name, or the default genesis config of the runtime if preset is empty.
+func BuildFromRuntime(code []byte, preset string) (*BuildSpec, error) {
+	patch := []byte("{}")
+	if preset != "" {
+		instance, _, err := newGenesisBuilderInstance(code)
+		if err != nil {
+			return nil, err
+		}
+
+		patch, err = instance.GenesisBuilderGetPreset(&preset)
+		instance.Stop()
+		if err != nil {
+			return nil, fmt.Errorf("getting genesis config preset %s: %w", preset, err)
+		} else if patch == nil {
+			return nil, fmt.Errorf("%w: %s", ErrGenesisPresetNotFound, preset)
+		}
+	}
+
+	gen := &genesis.Genesis{
+		Name:      "Custom",
+		ID:        "custom",
+		ChainType: "Live",
+		Genesis: genesis.Fields{
+			RuntimeGenesis: &genesis.RuntimeGenesis{
+				Code:  common.BytesToHex(code),
+				Patch: patch,
+			},
+		},
+	}
+
+	err := buildRawGenesis(gen)
+	if err != nil {
+		return nil, err
+	}
+
 	bs := &BuildSpec{
 		genesis: gen,
 	}
@@ -16,4 +16,5 @@
 	ErrEmptyRuntimeCode = errors.New("new :code is empty")
 
 	errInvalidTransactionQueueVersion = errors.New("invalid transaction queue version")
+	errInvalidChildStorageKey         = errors.New("invalid child storage key")
 )
@@ -5,13 +5,11 @@
 
 import (
 	"bytes"
-	"path/filepath"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
-	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
@@ -103,11 +101,6 @@ func createTestService(t *testing.T, genesisFilePath string,
 	rtCfg.CodeHash, err = cfgStorageState.LoadCodeHash(nil)
 	require.NoError(t, err)
 
-	nodeStorage := runtime.NodeStorage{}
-	nodeStorage.BaseDB = stateSrvc.Base
-
-	rtCfg.NodeStorage = nodeStorage
-
 	cfgRuntime, err := wazero_runtime.NewRuntimeFromGenesis(rtCfg)
 	require.NoError(t, err)
 
@@ -224,17 +217,6 @@ func NewTestService(t *testing.T, cfg *Config) *Service {
 		rtCfg.CodeHash, err = cfg.StorageState.(*state.InmemoryStorageState).LoadCodeHash(nil)
 		require.NoError(t, err)
 
-		nodeStorage := runtime.NodeStorage{}
-
-		if stateSrvc != nil {
-			nodeStorage.BaseDB = stateSrvc.Base
-		} else {
-			nodeStorage.BaseDB, err = database.LoadDatabase(filepath.Join(testDatadirPath, "offline_storage"), false)
-			require.NoError(t, err)
-		}
-
-		rtCfg.NodeStorage = nodeStorage
-
 		cfg.Runtime, err = wazero_runtime.NewRuntimeFromGenesis(rtCfg)
 		require.NoError(t, err)
 	}
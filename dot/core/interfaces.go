@@ -17,6 +17,7 @@
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/ChainSafe/gossamer/pkg/trie/triedb"
 )
 
 type BlockImportDigestHandler interface {
@@ -37,11 +38,13 @@ type BlockState interface {
 	GetRuntime(blockHash common.Hash) (instance runtime.Instance, err error)
 	StoreRuntime(blockHash common.Hash, runtime runtime.Instance)
 	LowestCommonAncestor(a, b common.Hash) (common.Hash, error)
+	SetOffchainIndexChanges(hash common.Hash, changes map[string][]byte) error
 }
 
 // StorageState interface for storage state methods
 type StorageState interface {
 	TrieState(root *common.Hash) (*rtstorage.TrieState, error)
+	TrieStateWithRecorder(root common.Hash, recorder *triedb.Recorder) (*rtstorage.TrieState, error)
 	StoreTrie(*rtstorage.TrieState, *types.Header) error
 	GetStateRootFromBlock(bhash *common.Hash) (*common.Hash, error)
 	GenerateTrieProof(stateRoot common.Hash, keys [][]byte) ([][]byte, error)
@@ -71,6 +74,8 @@ type TransactionState interface {
 	AddToPool(vt *transaction.ValidTransaction) common.Hash
 	RemoveExtrinsic(ext types.Extrinsic)
 	RemoveExtrinsicFromPool(ext types.Extrinsic)
+	PruneExpired(blockNumber uint)
+	NotifyStatus(ext types.Extrinsic, notification transaction.StatusNotification)
 	PendingInPool() []*transaction.ValidTransaction
 	Exists(ext types.Extrinsic) bool
 }
@@ -78,6 +83,8 @@ type TransactionState interface {
 // Network is the interface for the network service
 type Network interface {
 	GossipMessage(network.NotificationsMessage)
+	PropagateMessage(network.NotificationsMessage) []peer.ID
+	Peers() []common.PeerInfo
 	IsSynced() bool
 	ReportPeer(change peerset.ReputationChange, p peer.ID)
 }
@@ -0,0 +1,111 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/ChainSafe/gossamer/pkg/trie/triedb"
+)
+
+// GetExecutionProofAt executes the runtime method with the given data on the state of the
+// given block, and returns the storage proof of the state accessed during the execution.
+// The proof holds every trie node read during the execution, including the nodes proving
+// the absence of the keys not found and the nodes crossed to find the next keys.
+func (s *Service) GetExecutionProofAt(block common.Hash, method string, data []byte) (
+	proof [][]byte, err error) {
+	stateRoot, err := s.blockState.GetBlockStateRoot(block)
+	if err != nil {
+		return nil, fmt.Errorf("getting state root: %w", err)
+	}
+
+	recorder := triedb.NewRecorder()
+	trieState, err := s.storageState.TrieStateWithRecorder(stateRoot, recorder)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	rt, err := s.blockState.GetRuntime(block)
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime: %w", err)
+	}
+
+	// the light client needs the runtime code to execute the call
+	trieState.Get(common.CodeKey)
+
+	rt.SetContextStorage(trieState)
+	_, err = rt.Exec(method, data)
+	if err != nil {
+		return nil, fmt.Errorf("executing %s: %w", method, err)
+	}
+
+	records := recorder.Drain()
+	nodes := make([][]byte, len(records))
+	for i, record := range records {
+		nodes[i] = record.Data
+	}
+	return appendProofNodes(nil, nodes), nil
+}
+
+// GetChildReadProofAt returns the storage proof of the given keys in the child trie
+// at the given prefixed child storage key, on the state of the given block.
+func (s *Service) GetChildReadProofAt(block common.Hash, prefixedChildKey []byte, keys [][]byte) (
+	proof [][]byte, err error) {
+	if !bytes.HasPrefix(prefixedChildKey, inmemory.ChildStorageKeyPrefix) {
+		return nil, fmt.Errorf("%w: 0x%x", errInvalidChildStorageKey, prefixedChildKey)
+	}
+	keyToChild := prefixedChildKey[len(inmemory.ChildStorageKeyPrefix):]
+
+	stateRoot, err := s.blockState.GetBlockStateRoot(block)
+	if err != nil {
+		return nil, fmt.Errorf("getting state root: %w", err)
+	}
+
+	trieState, err := s.storageState.TrieState(&stateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	childRoot, err := trieState.GetChildRoot(keyToChild)
+	if err != nil {
+		return nil, fmt.Errorf("getting child root: %w", err)
+	}
+
+	proof, err = s.storageState.GenerateTrieProof(stateRoot, [][]byte{prefixedChildKey})
+	if err != nil {
+		return nil, fmt.Errorf("generating trie proof: %w", err)
+	}
+
+	childProof, err := s.storageState.GenerateTrieProof(childRoot, keys)
+	if err != nil {
+		return nil, fmt.Errorf("generating child trie proof: %w", err)
+	}
+
+	return appendProofNodes(proof, childProof), nil
+}
+
+func childStorageKey(keyToChild []byte) []byte {
+	return append(append([]byte{}, inmemory.ChildStorageKeyPrefix...), keyToChild...)
+}
+
+// appendProofNodes appends the encoded proof nodes not already in the proof.
+func appendProofNodes(proof, nodes [][]byte) [][]byte {
+	seen := make(map[string]struct{}, len(proof))
+	for _, node := range proof {
+		seen[string(node)] = struct{}{}
+	}
+
+	for _, node := range nodes {
+		_, ok := seen[string(node)]
+		if ok {
+			continue
+		}
+		seen[string(node)] = struct{}{}
+		proof = append(proof, node)
+	}
+	return proof
+}
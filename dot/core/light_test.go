@@ -0,0 +1,134 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/ChainSafe/gossamer/pkg/trie/triedb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Service_GetExecutionProofAt(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	db, err := database.NewPebble("", true)
+	require.NoError(t, err)
+	storageDB := database.NewTable(db, "storage")
+
+	// values are long enough for the leaves not to be inlined in their parent
+	trieDB := triedb.NewEmptyTrieDB(storageDB)
+	for _, key := range []string{":code", "a", "c", "q", "s"} {
+		err = trieDB.Put([]byte(key), bytes.Repeat([]byte(key), 40))
+		require.NoError(t, err)
+	}
+	err = trieDB.Commit()
+	require.NoError(t, err)
+	stateRoot := trieDB.MustHash()
+
+	blockHash := common.Hash{1}
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().GetBlockStateRoot(blockHash).Return(stateRoot, nil)
+	storageState := NewMockStorageState(ctrl)
+	storageState.EXPECT().TrieStateWithRecorder(stateRoot, gomock.Any()).
+		DoAndReturn(func(root common.Hash, recorder *triedb.Recorder) (*rtstorage.TrieState, error) {
+			return rtstorage.NewTrieState(triedb.NewTrieDB(root, storageDB, triedb.WithRecorder(recorder))), nil
+		})
+
+	var contextStorage runtime.Storage
+	instance := NewMockInstance(ctrl)
+	instance.EXPECT().SetContextStorage(gomock.Any()).Do(func(storage runtime.Storage) {
+		contextStorage = storage
+	})
+	instance.EXPECT().Exec("Core_version", []byte{2}).DoAndReturn(func(string, []byte) ([]byte, error) {
+		assert.Nil(t, contextStorage.Get([]byte("r")))
+		assert.Equal(t, []byte("c"), contextStorage.NextKey([]byte("a")))
+		return nil, nil
+	})
+	blockState.EXPECT().GetRuntime(blockHash).Return(instance, nil)
+
+	s := &Service{
+		blockState:   blockState,
+		storageState: storageState,
+	}
+
+	proof, err := s.GetExecutionProofAt(blockHash, "Core_version", []byte{2})
+	require.NoError(t, err)
+
+	proofDB := database.NewTable(db, "proof")
+	for _, node := range proof {
+		hash, err := common.Blake2bHash(node)
+		require.NoError(t, err)
+		err = proofDB.Put(hash[:], node)
+		require.NoError(t, err)
+	}
+
+	// the proof holds the nodes read to find the code, the absent key and the next key,
+	// but not the leaves of the keys q and s
+	proofTrie := triedb.NewTrieDB(stateRoot, proofDB)
+	assert.Equal(t, bytes.Repeat([]byte(":code"), 40), proofTrie.Get([]byte(":code")))
+	assert.Nil(t, proofTrie.Get([]byte("r")))
+	assert.Equal(t, []byte("c"), proofTrie.NextKey([]byte("a")))
+	assert.Nil(t, proofTrie.Get([]byte("q")))
+	assert.Nil(t, proofTrie.Get([]byte("s")))
+}
+
+func Test_Service_GetChildReadProofAt(t *testing.T) {
+	t.Parallel()
+
+	trieState := rtstorage.NewTrieState(inmemory.NewEmptyTrie())
+	err := trieState.SetChildStorage([]byte("child"), []byte("x"), []byte{3})
+	require.NoError(t, err)
+	childRoot, err := trieState.GetChildRoot([]byte("child"))
+	require.NoError(t, err)
+
+	blockHash := common.Hash{1}
+	stateRoot := common.Hash{2}
+	prefixedChildKey := childStorageKey([]byte("child"))
+	keys := [][]byte{[]byte("x")}
+
+	t.Run("invalid_child_storage_key", func(t *testing.T) {
+		t.Parallel()
+
+		s := &Service{}
+		proof, err := s.GetChildReadProofAt(blockHash, []byte("child"), keys)
+
+		assert.ErrorIs(t, err, errInvalidChildStorageKey)
+		assert.EqualError(t, err, "invalid child storage key: 0x6368696c64")
+		assert.Nil(t, proof)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		blockState := NewMockBlockState(ctrl)
+		blockState.EXPECT().GetBlockStateRoot(blockHash).Return(stateRoot, nil)
+		storageState := NewMockStorageState(ctrl)
+		storageState.EXPECT().TrieState(&stateRoot).Return(trieState, nil)
+		storageState.EXPECT().GenerateTrieProof(stateRoot, [][]byte{prefixedChildKey}).
+			Return([][]byte{{1}, {2}}, nil)
+		storageState.EXPECT().GenerateTrieProof(childRoot, keys).
+			Return([][]byte{{2}, {3}}, nil)
+
+		s := &Service{
+			blockState:   blockState,
+			storageState: storageState,
+		}
+
+		proof, err := s.GetChildReadProofAt(blockHash, prefixedChildKey, keys)
+
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{{1}, {2}, {3}}, proof)
+	})
+}
@@ -20,6 +20,7 @@
 	runtime "github.com/ChainSafe/gossamer/lib/runtime"
 	storage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	transaction "github.com/ChainSafe/gossamer/lib/transaction"
+	triedb "github.com/ChainSafe/gossamer/pkg/trie/triedb"
 	peer "github.com/libp2p/go-libp2p/core/peer"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -208,6 +209,20 @@ func (mr *MockBlockStateMockRecorder) RangeInMemory(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RangeInMemory", reflect.TypeOf((*MockBlockState)(nil).RangeInMemory), arg0, arg1)
 }
 
+// SetOffchainIndexChanges mocks base method.
+func (m *MockBlockState) SetOffchainIndexChanges(arg0 common.Hash, arg1 map[string][]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOffchainIndexChanges", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOffchainIndexChanges indicates an expected call of SetOffchainIndexChanges.
+func (mr *MockBlockStateMockRecorder) SetOffchainIndexChanges(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOffchainIndexChanges", reflect.TypeOf((*MockBlockState)(nil).SetOffchainIndexChanges), arg0, arg1)
+}
+
 // StoreRuntime mocks base method.
 func (m *MockBlockState) StoreRuntime(arg0 common.Hash, arg1 runtime.Instance) {
 	m.ctrl.T.Helper()
@@ -314,6 +329,21 @@ func (mr *MockStorageStateMockRecorder) TrieState(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrieState", reflect.TypeOf((*MockStorageState)(nil).TrieState), arg0)
 }
 
+// TrieStateWithRecorder mocks base method.
+func (m *MockStorageState) TrieStateWithRecorder(arg0 common.Hash, arg1 *triedb.Recorder) (*storage.TrieState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrieStateWithRecorder", arg0, arg1)
+	ret0, _ := ret[0].(*storage.TrieState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrieStateWithRecorder indicates an expected call of TrieStateWithRecorder.
+func (mr *MockStorageStateMockRecorder) TrieStateWithRecorder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrieStateWithRecorder", reflect.TypeOf((*MockStorageState)(nil).TrieStateWithRecorder), arg0, arg1)
+}
+
 // Unlock mocks base method.
 func (m *MockStorageState) Unlock() {
 	m.ctrl.T.Helper()
@@ -377,6 +407,18 @@ func (mr *MockTransactionStateMockRecorder) Exists(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockTransactionState)(nil).Exists), arg0)
 }
 
+// NotifyStatus mocks base method.
+func (m *MockTransactionState) NotifyStatus(arg0 types.Extrinsic, arg1 transaction.StatusNotification) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyStatus", arg0, arg1)
+}
+
+// NotifyStatus indicates an expected call of NotifyStatus.
+func (mr *MockTransactionStateMockRecorder) NotifyStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyStatus", reflect.TypeOf((*MockTransactionState)(nil).NotifyStatus), arg0, arg1)
+}
+
 // PendingInPool mocks base method.
 func (m *MockTransactionState) PendingInPool() []*transaction.ValidTransaction {
 	m.ctrl.T.Helper()
@@ -391,6 +433,18 @@ func (mr *MockTransactionStateMockRecorder) PendingInPool() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingInPool", reflect.TypeOf((*MockTransactionState)(nil).PendingInPool))
 }
 
+// PruneExpired mocks base method.
+func (m *MockTransactionState) PruneExpired(arg0 uint) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PruneExpired", arg0)
+}
+
+// PruneExpired indicates an expected call of PruneExpired.
+func (mr *MockTransactionStateMockRecorder) PruneExpired(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneExpired", reflect.TypeOf((*MockTransactionState)(nil).PruneExpired), arg0)
+}
+
 // Push mocks base method.
 func (m *MockTransactionState) Push(arg0 *transaction.ValidTransaction) (common.Hash, error) {
 	m.ctrl.T.Helper()
@@ -479,6 +533,34 @@ func (mr *MockNetworkMockRecorder) IsSynced() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSynced", reflect.TypeOf((*MockNetwork)(nil).IsSynced))
 }
 
+// Peers mocks base method.
+func (m *MockNetwork) Peers() []common.PeerInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Peers")
+	ret0, _ := ret[0].([]common.PeerInfo)
+	return ret0
+}
+
+// Peers indicates an expected call of Peers.
+func (mr *MockNetworkMockRecorder) Peers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peers", reflect.TypeOf((*MockNetwork)(nil).Peers))
+}
+
+// PropagateMessage mocks base method.
+func (m *MockNetwork) PropagateMessage(arg0 network.NotificationsMessage) []peer.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PropagateMessage", arg0)
+	ret0, _ := ret[0].([]peer.ID)
+	return ret0
+}
+
+// PropagateMessage indicates an expected call of PropagateMessage.
+func (mr *MockNetworkMockRecorder) PropagateMessage(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropagateMessage", reflect.TypeOf((*MockNetwork)(nil).PropagateMessage), arg0)
+}
+
 // ReportPeer mocks base method.
 func (m *MockNetwork) ReportPeer(arg0 peerset.ReputationChange, arg1 peer.ID) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,139 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+)
+
+// maxConcurrentOffchainWorkers is the maximum number of offchain workers
+// running at the same time. Offchain workers of new best blocks are skipped
+// while this limit is reached.
+const maxConcurrentOffchainWorkers = 4
+
+type newInstanceFunc func(code []byte, cfg wazero_runtime.Config) (runtime.Instance, error)
+
+func newWazeroInstance(code []byte, cfg wazero_runtime.Config) (runtime.Instance, error) {
+	return wazero_runtime.NewInstance(code, cfg)
+}
+
+// offchainWorkers runs the runtime offchain workers of the imported best blocks.
+// Each offchain worker runs in its own runtime instance, so it does not block
+// nor modify the state of the runtime instance used to import blocks.
+type offchainWorkers struct {
+	blockState       BlockState
+	storageState     StorageState
+	transactionState TransactionState
+	newInstance      newInstanceFunc
+
+	semaphore chan struct{}
+	wg        sync.WaitGroup
+}
+
+func newOffchainWorkers(blockState BlockState, storageState StorageState,
+	transactionState TransactionState) *offchainWorkers {
+	return &offchainWorkers{
+		blockState:       blockState,
+		storageState:     storageState,
+		transactionState: transactionState,
+		newInstance:      newWazeroInstance,
+		semaphore:        make(chan struct{}, maxConcurrentOffchainWorkers),
+	}
+}
+
+// run runs the offchain worker for the given block header in the background.
+func (o *offchainWorkers) run(header *types.Header) {
+	select {
+	case o.semaphore <- struct{}{}:
+	default:
+		logger.Debugf("skipping offchain worker for block #%d (%s): too many offchain workers running",
+			header.Number, header.Hash())
+		return
+	}
+
+	o.wg.Add(1)
+	go func() {
+		defer func() {
+			<-o.semaphore
+			o.wg.Done()
+		}()
+
+		err := o.runWorker(header)
+		if err != nil {
+			logger.Warnf("running offchain worker for block #%d (%s): %s",
+				header.Number, header.Hash(), err)
+		}
+	}()
+}
+
+// wait waits for all the running offchain workers to return.
+func (o *offchainWorkers) wait() {
+	o.wg.Wait()
+}
+
+func (o *offchainWorkers) runWorker(header *types.Header) error {
+	hash := header.Hash()
+	rt, err := o.blockState.GetRuntime(hash)
+	if err != nil {
+		return fmt.Errorf("getting runtime: %w", err)
+	}
+
+	version, err := rt.Version()
+	if err != nil {
+		return fmt.Errorf("getting runtime version: %w", err)
+	}
+
+	_, err = version.OffchainWorkerAPIVersion()
+	if errors.Is(err, runtime.ErrAPINotFound) {
+		logger.Tracef("runtime of block %s does not implement offchain workers", hash)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting offchain worker API version: %w", err)
+	}
+
+	o.storageState.Lock()
+	ts, err := o.storageState.TrieState(&header.StateRoot)
+	o.storageState.Unlock()
+	if err != nil {
+		return fmt.Errorf("getting trie state: %w", err)
+	}
+
+	code := ts.LoadCode()
+	if len(code) == 0 {
+		return fmt.Errorf("%w: for block hash %s", ErrEmptyRuntimeCode, hash)
+	}
+
+	cfg := wazero_runtime.Config{
+		Storage:     ts,
+		Keystore:    rt.Keystore(),
+		NodeStorage: rt.NodeStorage(),
+		Network:     rt.NetworkService(),
+		Transaction: o.transactionState,
+	}
+
+	if rt.Validator() {
+		cfg.Role = common.AuthorityRole
+	}
+
+	instance, err := o.newInstance(code, cfg)
+	if err != nil {
+		return fmt.Errorf("creating runtime instance: %w", err)
+	}
+	defer instance.Stop()
+
+	logger.Debugf("running offchain worker for block #%d (%s)", header.Number, hash)
+	err = instance.OffchainWorker(header)
+	if err != nil {
+		return fmt.Errorf("running offchain worker: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_offchainWorkers_runWorker(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	header := types.NewHeader(common.Hash{1}, common.Hash{2}, common.Hash{}, 1, types.NewDigest())
+
+	offchainWorkerAPIName, err := common.Blake2b8([]byte("OffchainWorkerApi"))
+	require.NoError(t, err)
+	offchainWorkerVersion := runtime.Version{
+		APIItems: []runtime.APIItem{{Name: offchainWorkerAPIName, Ver: 2}},
+	}
+
+	newTrieState := func(code []byte) *rtstorage.TrieState {
+		trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+		if code != nil {
+			require.NoError(t, trieState.Put(common.CodeKey, code))
+		}
+		return trieState
+	}
+
+	testCases := map[string]struct {
+		runtimeVersion    runtime.Version
+		trieState         *rtstorage.TrieState
+		trieStateErr      error
+		validator         bool
+		expectedRole      common.NetworkRole
+		newInstanceErr    error
+		offchainWorker    bool
+		offchainWorkerErr error
+		errWrapped        error
+		errMessage        string
+	}{
+		"offchain_worker_api_not_implemented": {
+			runtimeVersion: runtime.Version{},
+		},
+		"trie_state_error": {
+			runtimeVersion: offchainWorkerVersion,
+			trieStateErr:   errTest,
+			errWrapped:     errTest,
+			errMessage:     "getting trie state: test error",
+		},
+		"empty_runtime_code": {
+			runtimeVersion: offchainWorkerVersion,
+			trieState:      newTrieState(nil),
+			errWrapped:     ErrEmptyRuntimeCode,
+			errMessage:     "new :code is empty: for block hash " + header.Hash().String(),
+		},
+		"new_instance_error": {
+			runtimeVersion: offchainWorkerVersion,
+			trieState:      newTrieState([]byte{1}),
+			newInstanceErr: errTest,
+			errWrapped:     errTest,
+			errMessage:     "creating runtime instance: test error",
+		},
+		"offchain_worker_error": {
+			runtimeVersion:    offchainWorkerVersion,
+			trieState:         newTrieState([]byte{1}),
+			offchainWorker:    true,
+			offchainWorkerErr: errTest,
+			errWrapped:        errTest,
+			errMessage:        "running offchain worker: test error",
+		},
+		"validator_offchain_worker": {
+			runtimeVersion: offchainWorkerVersion,
+			trieState:      newTrieState([]byte{1}),
+			validator:      true,
+			expectedRole:   common.AuthorityRole,
+			offchainWorker: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockRuntime := NewMockInstance(ctrl)
+			blockRuntime.EXPECT().Version().Return(testCase.runtimeVersion, nil)
+
+			blockState := NewMockBlockState(ctrl)
+			blockState.EXPECT().GetRuntime(header.Hash()).Return(blockRuntime, nil)
+
+			storageState := NewMockStorageState(ctrl)
+			expectTrieState := testCase.trieState != nil || testCase.trieStateErr != nil
+			if expectTrieState {
+				storageState.EXPECT().Lock()
+				storageState.EXPECT().TrieState(&header.StateRoot).
+					Return(testCase.trieState, testCase.trieStateErr)
+				storageState.EXPECT().Unlock()
+			}
+
+			expectNewInstance := testCase.newInstanceErr != nil || testCase.offchainWorker
+			if expectNewInstance {
+				blockRuntime.EXPECT().Keystore().Return(nil)
+				blockRuntime.EXPECT().NodeStorage().Return(runtime.NodeStorage{})
+				blockRuntime.EXPECT().NetworkService().Return(nil)
+				blockRuntime.EXPECT().Validator().Return(testCase.validator)
+			}
+
+			workerInstance := NewMockInstance(ctrl)
+			if testCase.offchainWorker {
+				workerInstance.EXPECT().OffchainWorker(header).Return(testCase.offchainWorkerErr)
+				workerInstance.EXPECT().Stop()
+			}
+
+			newInstanceCalls := 0
+			workers := newOffchainWorkers(blockState, storageState, nil)
+			workers.newInstance = func(code []byte, cfg wazero_runtime.Config) (runtime.Instance, error) {
+				newInstanceCalls++
+				assert.Equal(t, []byte{1}, code)
+				assert.Equal(t, testCase.expectedRole, cfg.Role)
+				if testCase.newInstanceErr != nil {
+					return nil, testCase.newInstanceErr
+				}
+				return workerInstance, nil
+			}
+
+			err := workers.runWorker(header)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+
+			expectedNewInstanceCalls := 0
+			if expectNewInstance {
+				expectedNewInstanceCalls = 1
+			}
+			assert.Equal(t, expectedNewInstanceCalls, newInstanceCalls)
+		})
+	}
+}
+
+func Test_offchainWorkers_run_skipsWhenFull(t *testing.T) {
+	t.Parallel()
+
+	workers := newOffchainWorkers(nil, nil, nil)
+	for i := 0; i < maxConcurrentOffchainWorkers; i++ {
+		workers.semaphore <- struct{}{}
+	}
+
+	// no worker goroutine is spawned, otherwise it would panic
+	// using the nil block state.
+	workers.run(types.NewEmptyHeader())
+	workers.wait()
+	assert.Len(t, workers.semaphore, maxConcurrentOffchainWorkers)
+}
@@ -57,6 +57,9 @@ type Service struct {
 	// Keystore
 	keys          *keystore.GlobalKeystore
 	onBlockImport BlockImportDigestHandler
+
+	// offchainWorkers is nil if offchain workers are disabled
+	offchainWorkers *offchainWorkers
 }
 
 // Config holds the configuration for the core Service.
@@ -75,6 +78,10 @@ type Config struct {
 	CodeSubstitutes      map[common.Hash]string
 	CodeSubstitutedState CodeSubstitutedState
 	OnBlockImport        BlockImportDigestHandler
+
+	// OffchainWorkers enables running the runtime offchain workers
+	// for each imported best block.
+	OffchainWorkers bool
 }
 
 // NewService returns a new core service that connects the runtime, BABE
@@ -101,12 +108,16 @@ func NewService(cfg *Config) (*Service, error) {
 		epochState:           cfg.EpochState,
 	}
 
+	if cfg.OffchainWorkers {
+		srv.offchainWorkers = newOffchainWorkers(cfg.BlockState, cfg.StorageState, cfg.TransactionState)
+	}
+
 	return srv, nil
 }
 
 // Start starts the core service
 func (s *Service) Start() error {
-	go s.handleBlocksAsync()
+	go s.handleBlocksAsync(s.blockState.BestBlockHash())
 	return nil
 }
 
@@ -117,6 +128,10 @@ func (s *Service) Stop() error {
 
 	s.cancel()
 	close(s.blockAddCh)
+
+	if s.offchainWorkers != nil {
+		s.offchainWorkers.wait()
+	}
 	return nil
 }
 
@@ -269,6 +284,12 @@ func (s *Service) handleBlock(block *types.Block, state *rtstorage.TrieState) er
 		}
 	}
 
+	// the offchain index changes are written to the offchain storage once the block is finalised
+	err = s.blockState.SetOffchainIndexChanges(block.Header.Hash(), state.OffchainIndexChanges())
+	if err != nil {
+		return fmt.Errorf("setting offchain index changes: %w", err)
+	}
+
 	err = s.onBlockImport.HandleDigests(&block.Header)
 	if err != nil {
 		return fmt.Errorf("on block import handle: %w", err)
@@ -361,7 +382,9 @@ func (s *Service) handleCodeSubstitution(hash common.Hash,
 
 // handleBlocksAsync handles a block asynchronously; the handling performed by this function
 // does not need to be completed before the next block can be imported.
-func (s *Service) handleBlocksAsync() {
+// The given best block hash is the best block hash before handling the first block,
+// to detect the best chain re-orgs.
+func (s *Service) handleBlocksAsync(previousBestBlockHash common.Hash) {
 	for {
 		select {
 		case block, ok := <-s.blockAddCh:
@@ -374,15 +397,20 @@ func (s *Service) handleBlocksAsync() {
 			}
 
 			bestBlockHash := s.blockState.BestBlockHash()
-			if err := s.handleChainReorg(bestBlockHash, block.Header.Hash()); err != nil {
+			if err := s.handleChainReorg(previousBestBlockHash, bestBlockHash); err != nil {
 				// TODO remove once gossamer is in stable state
 				panic(fmt.Errorf("failed to re-add transactions to chain upon re-org: %s", err))
 			}
+			previousBestBlockHash = bestBlockHash
 
 			if err := s.maintainTransactionPool(block, bestBlockHash); err != nil {
 				// TODO remove once gossamer is in stable state
 				panic(fmt.Errorf("failed to maintain txn pool after re-org: %s", err))
 			}
+
+			if s.offchainWorkers != nil && block.Header.Hash() == bestBlockHash {
+				s.offchainWorkers.run(&block.Header)
+			}
 		case <-s.ctx.Done():
 			return
 		}
@@ -390,21 +418,25 @@ func (s *Service) handleBlocksAsync() {
 }
 
 // handleChainReorg checks if there is a chain re-org (ie. new chain head is on a different chain than the
-// previous chain head). If there is a re-org, it moves the transactions that were included on the previous
-// chain back into the transaction pool.
-func (s *Service) handleChainReorg(best, curr common.Hash) error {
-	ancestor, err := s.blockState.LowestCommonAncestor(best, curr)
+// previous chain head). If there is a re-org, it notifies the transactions that were included on the previous
+// chain as retracted, and moves them back into the transaction pool.
+func (s *Service) handleChainReorg(previousBest, best common.Hash) error {
+	if previousBest == best {
+		return nil
+	}
+
+	ancestor, err := s.blockState.LowestCommonAncestor(previousBest, best)
 	if err != nil {
 		return err
 	}
 
 	// if the highest common ancestor of the previous chain head and current chain head is the previous chain head,
 	// then the current chain head is the descendant of the previous and thus are on the same chain
-	if ancestor == best || ancestor == curr {
+	if ancestor == previousBest || ancestor == best {
 		return nil
 	}
 
-	subchain, err := s.blockState.RangeInMemory(ancestor, best)
+	subchain, err := s.blockState.RangeInMemory(ancestor, previousBest)
 	if err != nil {
 		return err
 	}
@@ -433,6 +465,7 @@ func (s *Service) handleChainReorg(best, curr common.Hash) error {
 		if err != nil || body == nil {
 			continue
 		}
+		retractedHash := hash
 
 		for _, ext := range *body {
 			logger.Tracef("validating transaction on re-org chain for extrinsic %s", ext)
@@ -447,6 +480,11 @@ func (s *Service) handleChainReorg(best, curr common.Hash) error {
 				continue
 			}
 
+			s.transactionState.NotifyStatus(ext, transaction.StatusNotification{
+				Status:    transaction.Retracted,
+				BlockHash: &retractedHash,
+			})
+
 			externalExt, err := s.buildExternalTransaction(rt, ext)
 			if err != nil {
 				return fmt.Errorf("building external transaction: %s", err)
@@ -456,6 +494,7 @@ func (s *Service) handleChainReorg(best, curr common.Hash) error {
 			if err != nil {
 				logger.Debugf("failed to validate transaction for extrinsic %s: %s skipping in chain reorg", ext, err)
 				s.transactionState.RemoveExtrinsic(ext)
+				s.transactionState.NotifyStatus(ext, transaction.StatusNotification{Status: transaction.Invalid})
 				continue
 			}
 			vtx := transaction.NewValidTransaction(ext, transactionValidity)
@@ -467,14 +506,15 @@ func (s *Service) handleChainReorg(best, curr common.Hash) error {
 }
 
 // maintainTransactionPool removes any transactions that were included in
-// the new block, revalidates the transactions in the pool, and moves
-// them to the queue if valid.
+// the new block or whose longevity expired, revalidates the transactions in
+// the pool, and moves them to the queue if valid and ready.
 // See https://github.com/paritytech/substrate/blob/74804b5649eccfb83c90aec87bdca58e5d5c8789/client/transaction-pool/src/lib.rs#L545
 func (s *Service) maintainTransactionPool(block *types.Block, bestBlockHash common.Hash) error {
 	// remove extrinsics included in a block
 	for _, ext := range block.Body {
 		s.transactionState.RemoveExtrinsic(ext)
 	}
+	s.transactionState.PruneExpired(block.Header.Number)
 
 	stateRoot, err := s.storageState.GetStateRootFromBlock(&bestBlockHash)
 	if err != nil {
@@ -507,28 +547,31 @@ func (s *Service) maintainTransactionPool(block *types.Block, bestBlockHash comm
 		if err != nil {
 			logger.Debugf("failed to validate transaction for extrinsic %s: %s", tx.Extrinsic, err)
 			s.transactionState.RemoveExtrinsic(tx.Extrinsic)
+			s.transactionState.NotifyStatus(tx.Extrinsic,
+				transaction.StatusNotification{Status: transaction.Invalid})
 			continue
 		}
 
 		tx = transaction.NewValidTransaction(tx.Extrinsic, txnValidity)
 
-		// Err is only thrown if tx is already in pool, in which case it still gets removed
-		h, _ := s.transactionState.Push(tx)
-
-		s.transactionState.RemoveExtrinsicFromPool(tx.Extrinsic)
-		logger.Tracef("moved transaction %s to queue", h)
+		// the transaction is kept in the pool if it is a future transaction,
+		// and is dropped if it is already in the queue or cannot replace the
+		// transactions providing the same tags.
+		h, err := s.transactionState.Push(tx)
+		if err != nil {
+			logger.Debugf("failed to push transaction %s: %s", h, err)
+			s.transactionState.RemoveExtrinsicFromPool(tx.Extrinsic)
+			continue
+		}
+		logger.Tracef("pushed revalidated transaction %s", h)
 	}
 	return nil
 }
 
-// InsertKey inserts keypair into the account keystore
-func (s *Service) InsertKey(kp KeyPair, keystoreType string) error {
-	ks, err := s.keys.GetKeystore([]byte(keystoreType))
-	if err != nil {
-		return err
-	}
-
-	return ks.Insert(kp)
+// InsertKey inserts keypair into the account keystore, and writes its secret URI to the
+// filesystem keystore of the node if there is one
+func (s *Service) InsertKey(kp KeyPair, keystoreType, suri string) error {
+	return s.keys.Insert([]byte(keystoreType), kp, suri)
 }
 
 // HasKey returns true if given hex encoded public key string is found in keystore, false otherwise, error if there
@@ -553,6 +596,17 @@ func (s *Service) DecodeSessionKeys(encodedSessionKeys []byte) ([]byte, error) {
 	return rt.DecodeSessionKeys(encodedSessionKeys)
 }
 
+// GenerateSessionKeys executes the runtime GenerateSessionKeys at the best block, storing the
+// new session keys in the keystore, and returns the scale encoded public keys
+func (s *Service) GenerateSessionKeys() ([]byte, error) {
+	rt, err := prepareRuntime(nil, s.storageState, s.blockState)
+	if err != nil {
+		return nil, fmt.Errorf("setting up runtime: %w", err)
+	}
+
+	return rt.GenerateSessionKeys()
+}
+
 // GetRuntimeVersion gets the current RuntimeVersion
 func (s *Service) GetRuntimeVersion(bhash *common.Hash) (
 	version runtime.Version, err error) {
@@ -603,13 +657,33 @@ func (s *Service) HandleSubmittedExtrinsic(ext types.Extrinsic) error {
 		return err
 	}
 
-	// add transaction to pool
+	// the transaction is pushed to the queue and notified as ready if the tags it
+	// requires are provided, otherwise it is kept in the pool and notified as future.
 	vtx := transaction.NewValidTransaction(ext, transactionValidity)
-	s.transactionState.AddToPool(vtx)
+	_, err = s.transactionState.Push(vtx)
+	if err != nil {
+		return fmt.Errorf("pushing transaction: %w", err)
+	}
+
+	if !transactionValidity.Propagate {
+		return nil
+	}
 
 	// broadcast transaction
 	msg := &network.TransactionMessage{Extrinsics: []types.Extrinsic{ext}}
-	s.net.GossipMessage(msg)
+	sentTo := s.net.PropagateMessage(msg)
+	if len(sentTo) == 0 {
+		return nil
+	}
+
+	peerIDs := make([]string, len(sentTo))
+	for i, peerID := range sentTo {
+		peerIDs[i] = peerID.String()
+	}
+	s.transactionState.NotifyStatus(ext, transaction.StatusNotification{
+		Status:             transaction.Broadcast,
+		PeersBroadcastedTo: peerIDs,
+	})
 	return nil
 }
 
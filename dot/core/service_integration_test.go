@@ -144,7 +144,7 @@ func TestService_InsertKey(t *testing.T) {
 		t.Run(c.description, func(t *testing.T) {
 			t.Parallel()
 
-			err := s.InsertKey(kr.Alice(), c.keystoreType)
+			err := s.InsertKey(kr.Alice(), c.keystoreType, "//Alice")
 
 			if c.err == nil {
 				require.NoError(t, err)
@@ -466,6 +466,7 @@ func TestService_HandleSubmittedExtrinsic(t *testing.T) {
 
 	net := NewMockNetwork(ctrl)
 	net.EXPECT().GossipMessage(gomock.AssignableToTypeOf(new(network.TransactionMessage)))
+	net.EXPECT().Peers()
 	cfg.Network = net
 	s := NewTestService(t, cfg)
 
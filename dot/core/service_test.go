@@ -9,6 +9,8 @@
 	"encoding/hex"
 	"errors"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/network"
@@ -28,6 +30,7 @@
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/libp2p/go-libp2p/core/peer"
 
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 
@@ -448,6 +451,7 @@ func Test_Service_handleBlock(t *testing.T) {
 		mockStorageState.EXPECT().StoreTrie(trieState, &block.Header).Return(nil)
 		mockBlockState := NewMockBlockState(ctrl)
 		mockBlockState.EXPECT().AddBlock(&block).Return(blocktree.ErrBlockExists)
+		mockBlockState.EXPECT().SetOffchainIndexChanges(block.Header.Hash(), map[string][]byte(nil)).Return(nil)
 		mockBlockState.EXPECT().GetRuntime(block.Header.ParentHash).Return(nil, errTestDummyError)
 
 		onBlockImportHandlerMock := NewMockBlockImportDigestHandler(ctrl)
@@ -478,6 +482,7 @@ func Test_Service_handleBlock(t *testing.T) {
 		mockStorageState.EXPECT().StoreTrie(trieState, &block.Header).Return(nil)
 		mockBlockState := NewMockBlockState(ctrl)
 		mockBlockState.EXPECT().AddBlock(&block).Return(blocktree.ErrBlockExists)
+		mockBlockState.EXPECT().SetOffchainIndexChanges(block.Header.Hash(), map[string][]byte(nil)).Return(nil)
 		mockBlockState.EXPECT().GetRuntime(block.Header.ParentHash).Return(runtimeMock, nil)
 		mockBlockState.EXPECT().HandleRuntimeChanges(trieState, runtimeMock, block.Header.Hash()).
 			Return(errTestDummyError)
@@ -510,6 +515,7 @@ func Test_Service_handleBlock(t *testing.T) {
 		mockStorageState.EXPECT().StoreTrie(trieState, &block.Header).Return(nil)
 		mockBlockState := NewMockBlockState(ctrl)
 		mockBlockState.EXPECT().AddBlock(&block).Return(blocktree.ErrBlockExists)
+		mockBlockState.EXPECT().SetOffchainIndexChanges(block.Header.Hash(), map[string][]byte(nil)).Return(nil)
 		mockBlockState.EXPECT().GetRuntime(block.Header.ParentHash).Return(runtimeMock, nil)
 		mockBlockState.EXPECT().HandleRuntimeChanges(trieState, runtimeMock, block.Header.Hash()).Return(nil)
 		mockGrandpaState := NewMockGrandpaState(ctrl)
@@ -574,6 +580,7 @@ func Test_Service_HandleBlockProduced(t *testing.T) {
 		mockStorageState.EXPECT().StoreTrie(trieState, &block.Header).Return(nil)
 		mockBlockState := NewMockBlockState(ctrl)
 		mockBlockState.EXPECT().AddBlock(&block).Return(blocktree.ErrBlockExists)
+		mockBlockState.EXPECT().SetOffchainIndexChanges(block.Header.Hash(), map[string][]byte(nil)).Return(nil)
 		mockBlockState.EXPECT().GetRuntime(block.Header.ParentHash).Return(runtimeMock, nil)
 		mockBlockState.EXPECT().HandleRuntimeChanges(trieState, runtimeMock, block.Header.Hash()).Return(nil)
 		mockNetwork := NewMockNetwork(ctrl)
@@ -641,6 +648,9 @@ func Test_Service_maintainTransactionPool(t *testing.T) {
 
 		mockTxnState := NewMockTransactionState(ctrl)
 		mockTxnState.EXPECT().RemoveExtrinsic(types.Extrinsic{21}).Times(2)
+		mockTxnState.EXPECT().NotifyStatus(types.Extrinsic{21},
+			transaction.StatusNotification{Status: transaction.Invalid})
+		mockTxnState.EXPECT().PruneExpired(uint(21))
 		mockTxnState.EXPECT().PendingInPool().Return([]*transaction.ValidTransaction{vt})
 		mockBlockState := NewMockBlockState(ctrl)
 		runtimeBlockHashCall := mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{1})
@@ -705,9 +715,9 @@ func Test_Service_maintainTransactionPool(t *testing.T) {
 		runtimeMock.EXPECT().SetContextStorage(&rtstorage.TrieState{})
 		mockTxnState := NewMockTransactionState(ctrl)
 		mockTxnState.EXPECT().RemoveExtrinsic(types.Extrinsic{21})
+		mockTxnState.EXPECT().PruneExpired(uint(21))
 		mockTxnState.EXPECT().PendingInPool().Return([]*transaction.ValidTransaction{vt})
 		mockTxnState.EXPECT().Push(tx).Return(common.Hash{}, nil)
-		mockTxnState.EXPECT().RemoveExtrinsicFromPool(types.Extrinsic{21})
 
 		mockBlockStateOk := NewMockBlockState(ctrl)
 		runtimeBlockHashCall := mockBlockStateOk.EXPECT().BestBlockHash().Return(common.Hash{1})
@@ -740,7 +750,7 @@ func Test_Service_handleBlocksAsync(t *testing.T) {
 			blockAddCh: blockAddChan,
 			ctx:        ctx,
 		}
-		service.handleBlocksAsync()
+		service.handleBlocksAsync(common.Hash{})
 	})
 
 	t.Run("channel_not_ok", func(t *testing.T) {
@@ -751,7 +761,7 @@ func Test_Service_handleBlocksAsync(t *testing.T) {
 			blockAddCh: blockAddChan,
 			ctx:        context.Background(),
 		}
-		service.handleBlocksAsync()
+		service.handleBlocksAsync(common.Hash{})
 	})
 
 	t.Run("nil_block", func(t *testing.T) {
@@ -765,7 +775,7 @@ func Test_Service_handleBlocksAsync(t *testing.T) {
 			blockAddCh: blockAddChan,
 			ctx:        context.Background(),
 		}
-		service.handleBlocksAsync()
+		service.handleBlocksAsync(common.Hash{})
 	})
 
 	t.Run("handleChainReorg_error", func(t *testing.T) {
@@ -777,8 +787,8 @@ func Test_Service_handleBlocksAsync(t *testing.T) {
 
 		ctrl := gomock.NewController(t)
 		mockBlockState := NewMockBlockState(ctrl)
-		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{})
-		mockBlockState.EXPECT().LowestCommonAncestor(common.Hash{}, block.Header.Hash()).
+		mockBlockState.EXPECT().BestBlockHash().Return(block.Header.Hash())
+		mockBlockState.EXPECT().LowestCommonAncestor(common.Hash{1}, block.Header.Hash()).
 			Return(common.Hash{}, errTestDummyError)
 
 		blockAddChan := make(chan *types.Block)
@@ -793,7 +803,7 @@ func Test_Service_handleBlocksAsync(t *testing.T) {
 		}
 
 		assert.PanicsWithError(t, "failed to re-add transactions to chain upon re-org: test dummy error",
-			service.handleBlocksAsync)
+			func() { service.handleBlocksAsync(common.Hash{1}) })
 	})
 }
 
@@ -842,6 +852,12 @@ func TestService_handleChainReorg(t *testing.T) {
 		execTest(t, service, testPrevHash, testCurrentHash, errDummyErr)
 	})
 
+	t.Run("same_best_block", func(t *testing.T) {
+		t.Parallel()
+		service := &Service{}
+		execTest(t, service, testPrevHash, testPrevHash, nil)
+	})
+
 	t.Run("ancestor_eq_priv", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
@@ -929,7 +945,12 @@ func TestService_handleChainReorg(t *testing.T) {
 		mockBlockState.EXPECT().GetBlockBody(testAncestorHash).Return(body, nil)
 		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{})
 		mockTxnState := NewMockTransactionState(ctrl)
+		mockTxnState.EXPECT().NotifyStatus(ext, transaction.StatusNotification{
+			Status:    transaction.Retracted,
+			BlockHash: &testAncestorHash,
+		})
 		mockTxnState.EXPECT().RemoveExtrinsic(ext)
+		mockTxnState.EXPECT().NotifyStatus(ext, transaction.StatusNotification{Status: transaction.Invalid})
 
 		service := &Service{
 			blockState:       mockBlockState,
@@ -968,6 +989,10 @@ func TestService_handleChainReorg(t *testing.T) {
 		mockBlockState.EXPECT().GetBlockBody(testAncestorHash).Return(body, nil)
 		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{})
 		mockTxnStateOk := NewMockTransactionState(ctrl)
+		mockTxnStateOk.EXPECT().NotifyStatus(ext, transaction.StatusNotification{
+			Status:    transaction.Retracted,
+			BlockHash: &testAncestorHash,
+		})
 		mockTxnStateOk.EXPECT().AddToPool(vtx).Return(common.Hash{})
 
 		service := &Service{
@@ -984,16 +1009,25 @@ func TestServiceInsertKey(t *testing.T) {
 		Babe: keystore.NewBasicKeystore(keystore.BabeName, crypto.Sr25519Type),
 	}
 
+	fsKeystore, err := keystore.NewFilesystemKeystore(t.TempDir())
+	require.NoError(t, err)
+	fsKeyStore := keystore.GlobalKeystore{
+		Babe:       keystore.NewBasicKeystore(keystore.BabeName, crypto.Sr25519Type),
+		Filesystem: fsKeystore,
+	}
+
 	keyring, _ := keystore.NewSr25519Keyring()
 	aliceKeypair := keyring.Alice().(*sr25519.Keypair)
 	type args struct {
 		kp           KeyPair
 		keystoreType string
+		suri         string
 	}
 	tests := []struct {
 		name      string
 		service   *Service
 		args      args
+		expFile   string
 		expErr    error
 		expErrMsg string
 	}{
@@ -1007,6 +1041,18 @@ type args struct {
 				keystoreType: string(keystore.BabeName),
 			},
 		},
+		{
+			name: "filesystem_keystore",
+			service: &Service{
+				keys: &fsKeyStore,
+			},
+			args: args{
+				kp:           aliceKeypair,
+				keystoreType: string(keystore.BabeName),
+				suri:         "//Alice",
+			},
+			expFile: "62616265d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+		},
 		{
 			name: "err_case",
 			service: &Service{
@@ -1025,11 +1071,17 @@ type args struct {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			service := tt.service
-			err := service.InsertKey(tt.args.kp, tt.args.keystoreType)
+			err := service.InsertKey(tt.args.kp, tt.args.keystoreType, tt.args.suri)
 			assert.ErrorIs(t, err, tt.expErr)
 			if tt.expErr != nil {
 				assert.EqualError(t, err, tt.expErrMsg)
 			}
+
+			if tt.expFile != "" {
+				data, err := os.ReadFile(filepath.Join(fsKeystore.Path(), tt.expFile))
+				require.NoError(t, err)
+				assert.Equal(t, `"//Alice"`, string(data))
+			}
 		})
 	}
 }
@@ -1326,17 +1378,15 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 		execTest(t, service, types.Extrinsic{}, errDummyErr)
 	})
 
-	t.Run("happy_path", func(t *testing.T) {
-		t.Parallel()
-		ctrl := gomock.NewController(t)
-
+	newValidatingService := func(ctrl *gomock.Controller, validity *transaction.Validity,
+		txnState TransactionState, net Network) *Service {
 		runtimeMock := NewMockInstance(ctrl)
 		mockBlockState := NewMockBlockState(ctrl)
 		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{})
 		mockBlockState.EXPECT().GetRuntime(common.Hash{}).Return(runtimeMock, nil).MaxTimes(2)
 		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{})
 
-		runtimeMock.EXPECT().ValidateTransaction(externalExt).Return(&transaction.Validity{Propagate: true}, nil)
+		runtimeMock.EXPECT().ValidateTransaction(externalExt).Return(validity, nil)
 		runtimeMock.EXPECT().Version().Return(runtime.Version{
 			SpecName:         []byte("polkadot"),
 			ImplName:         []byte("parity-polkadot"),
@@ -1356,17 +1406,75 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 		mockStorageState.EXPECT().TrieState(&common.Hash{}).Return(&rtstorage.TrieState{}, nil)
 		mockStorageState.EXPECT().GetStateRootFromBlock(&common.Hash{}).Return(&common.Hash{}, nil)
 
-		mockTxnState := NewMockTransactionState(ctrl)
-		mockTxnState.EXPECT().Exists(types.Extrinsic{}).MaxTimes(2)
-		mockTxnState.EXPECT().AddToPool(transaction.NewValidTransaction(ext, &transaction.Validity{Propagate: true}))
-		mockNetState := NewMockNetwork(ctrl)
-		mockNetState.EXPECT().GossipMessage(&network.TransactionMessage{Extrinsics: []types.Extrinsic{ext}})
-		service := &Service{
+		return &Service{
 			storageState:     mockStorageState,
-			transactionState: mockTxnState,
+			transactionState: txnState,
 			blockState:       mockBlockState,
-			net:              mockNetState,
+			net:              net,
 		}
+	}
+
+	t.Run("push_err", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		validity := &transaction.Validity{Propagate: true}
+		mockTxnState := NewMockTransactionState(ctrl)
+		mockTxnState.EXPECT().Exists(types.Extrinsic{})
+		mockTxnState.EXPECT().Push(transaction.NewValidTransaction(ext, validity)).
+			Return(common.Hash{}, transaction.ErrTooLowPriority)
+
+		service := newValidatingService(ctrl, validity, mockTxnState, NewMockNetwork(ctrl))
+		err := service.HandleSubmittedExtrinsic(types.Extrinsic{})
+		assert.ErrorIs(t, err, transaction.ErrTooLowPriority)
+		assert.EqualError(t, err, "pushing transaction: priority is too low to replace transactions providing the same tags")
+	})
+
+	t.Run("not_propagated", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		validity := &transaction.Validity{Propagate: false}
+		mockTxnState := NewMockTransactionState(ctrl)
+		mockTxnState.EXPECT().Exists(types.Extrinsic{})
+		mockTxnState.EXPECT().Push(transaction.NewValidTransaction(ext, validity))
+
+		service := newValidatingService(ctrl, validity, mockTxnState, NewMockNetwork(ctrl))
+		execTest(t, service, types.Extrinsic{}, nil)
+	})
+
+	t.Run("not_sent_to_any_peer", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		validity := &transaction.Validity{Propagate: true}
+		mockTxnState := NewMockTransactionState(ctrl)
+		mockTxnState.EXPECT().Exists(types.Extrinsic{})
+		mockTxnState.EXPECT().Push(transaction.NewValidTransaction(ext, validity))
+		mockNetState := NewMockNetwork(ctrl)
+		mockNetState.EXPECT().PropagateMessage(&network.TransactionMessage{Extrinsics: []types.Extrinsic{ext}})
+
+		service := newValidatingService(ctrl, validity, mockTxnState, mockNetState)
+		execTest(t, service, types.Extrinsic{}, nil)
+	})
+
+	t.Run("happy_path", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		validity := &transaction.Validity{Propagate: true}
+		mockTxnState := NewMockTransactionState(ctrl)
+		mockTxnState.EXPECT().Exists(types.Extrinsic{})
+		mockTxnState.EXPECT().Push(transaction.NewValidTransaction(ext, validity))
+		mockNetState := NewMockNetwork(ctrl)
+		mockNetState.EXPECT().PropagateMessage(&network.TransactionMessage{Extrinsics: []types.Extrinsic{ext}}).
+			Return([]peer.ID{"peer1"})
+		mockTxnState.EXPECT().NotifyStatus(ext, transaction.StatusNotification{
+			Status:             transaction.Broadcast,
+			PeersBroadcastedTo: []string{peer.ID("peer1").String()},
+		})
+
+		service := newValidatingService(ctrl, validity, mockTxnState, mockNetState)
 		execTest(t, service, types.Extrinsic{}, nil)
 	})
 }
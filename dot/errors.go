@@ -14,3 +14,6 @@
 var ErrInvalidKeystoreType = errors.New("invalid keystore type")
 
 var ErrWasmInterpreterName = errors.New("unknown wasm interpreter name")
+
+// ErrGenesisPresetNotFound is returned when the runtime has no genesis config preset with the given name
+var ErrGenesisPresetNotFound = errors.New("genesis config preset not found")
@@ -0,0 +1,175 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// newGenesisBuilderInstance returns a runtime instance of the given code, with an empty storage
+// the GenesisBuilder API writes the genesis storage to.
+func newGenesisBuilderInstance(code []byte) (*wazero_runtime.Instance, *rtstorage.TrieState, error) {
+	storage := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+	rtCfg := wazero_runtime.Config{
+		LogLvl:  log.Critical,
+		Storage: storage,
+	}
+
+	instance, err := wazero_runtime.NewInstance(code, rtCfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating runtime instance: %w", err)
+	}
+	return instance, storage, nil
+}
+
+// buildRawGenesis converts a non-raw genesis to a raw genesis, building the storage
+// of a runtime genesis through the GenesisBuilder API of its runtime.
+func buildRawGenesis(gen *genesis.Genesis) error {
+	if gen.Genesis.RuntimeGenesis == nil {
+		return gen.ToRaw()
+	}
+
+	top, childrenDefault, err := buildRuntimeGenesisStorage(gen.Genesis.RuntimeGenesis)
+	if err != nil {
+		return fmt.Errorf("building runtime genesis storage: %w", err)
+	}
+
+	gen.Genesis.Raw = map[string]map[string]string{"top": top}
+	gen.Genesis.ChildrenDefault = childrenDefault
+	return nil
+}
+
+// buildRuntimeGenesisStorage returns the hex encoded key-values of the genesis storage built
+// by the runtime from the genesis config, or from its default genesis config with the patch applied.
+// The key-values of each child trie are keyed by the hex encoded child storage key of the child trie,
+// without the :child_storage:default: prefix, and the child trie roots are not part of the top key-values.
+func buildRuntimeGenesisStorage(runtimeGenesis *genesis.RuntimeGenesis) (
+	top map[string]string, childrenDefault map[string]map[string]string, err error) {
+	code, err := common.HexToBytes(runtimeGenesis.Code)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding runtime code: %w", err)
+	}
+
+	instance, storage, err := newGenesisBuilderInstance(code)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer instance.Stop()
+
+	config := []byte(runtimeGenesis.Config)
+	if config == nil {
+		config, err = instance.GenesisBuilderGetPreset(nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting default genesis config: %w", err)
+		}
+
+		if runtimeGenesis.Patch != nil {
+			config, err = mergeGenesisConfigPatch(config, runtimeGenesis.Patch)
+			if err != nil {
+				return nil, nil, fmt.Errorf("applying genesis config patch: %w", err)
+			}
+		}
+	}
+
+	err = instance.GenesisBuilderBuildState(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	top = make(map[string]string)
+	childrenDefault = make(map[string]map[string]string)
+	for key, value := range storage.TrieEntries() {
+		if !bytes.HasPrefix([]byte(key), inmemory_trie.ChildStorageKeyPrefix) {
+			top[common.BytesToHex([]byte(key))] = common.BytesToHex(value)
+			continue
+		}
+
+		keyToChild := []byte(key)[len(inmemory_trie.ChildStorageKeyPrefix):]
+		child, err := storage.Trie().GetChild(keyToChild)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting child trie at key 0x%x: %w", keyToChild, err)
+		}
+
+		childEntries := make(map[string]string)
+		for childKey, childValue := range child.Entries() {
+			childEntries[common.BytesToHex([]byte(childKey))] = common.BytesToHex(childValue)
+		}
+		childrenDefault[common.BytesToHex(keyToChild)] = childEntries
+	}
+	top[common.BytesToHex(common.CodeKey)] = common.BytesToHex(code)
+	return top, childrenDefault, nil
+}
+
+// mergeGenesisConfigPatch applies the JSON patch to the JSON genesis config, merging objects
+// recursively, removing the keys with a null value and replacing any other value.
+func mergeGenesisConfigPatch(config, patch []byte) ([]byte, error) {
+	configValue, err := decodeJSONValue(config)
+	if err != nil {
+		return nil, fmt.Errorf("decoding genesis config: %w", err)
+	}
+
+	patchValue, err := decodeJSONValue(patch)
+	if err != nil {
+		return nil, fmt.Errorf("decoding patch: %w", err)
+	}
+
+	return json.Marshal(mergeJSONValues(configValue, patchValue))
+}
+
+// decodeJSONValue decodes the JSON value keeping its numbers as json.Number, since balances
+// and other u128 values of a genesis config do not fit in a float64 without losing precision.
+func decodeJSONValue(data []byte) (value interface{}, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err = decoder.Decode(&value)
+	if err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value at offset %d", decoder.InputOffset())
+	}
+	return value, nil
+}
+
+func mergeJSONValues(value, patch interface{}) interface{} {
+	valueObject, ok := value.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	for key, patchValue := range patchObject {
+		if patchValue == nil {
+			delete(valueObject, key)
+			continue
+		}
+		valueObject[key] = mergeJSONValues(valueObject[key], patchValue)
+	}
+	return valueObject
+}
+
+// RuntimeGenesisPresetNames returns the names of the genesis config presets of the given runtime code.
+func RuntimeGenesisPresetNames(code []byte) ([]string, error) {
+	instance, _, err := newGenesisBuilderInstance(code)
+	if err != nil {
+		return nil, err
+	}
+	defer instance.Stop()
+
+	return instance.GenesisBuilderPresetNames()
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_mergeGenesisConfigPatch(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config    string
+		patch     string
+		expected  string
+		expErrMsg string
+	}{
+		"empty_patch": {
+			config:   `{"balances":{"balances":[]},"sudo":{"key":null}}`,
+			patch:    `{}`,
+			expected: `{"balances":{"balances":[]},"sudo":{"key":null}}`,
+		},
+		"nested_objects_merged": {
+			config:   `{"babe":{"authorities":[],"epochConfig":{"c":[1,4]}},"sudo":{"key":null}}`,
+			patch:    `{"babe":{"authorities":["a"]},"sudo":{"key":"b"}}`,
+			expected: `{"babe":{"authorities":["a"],"epochConfig":{"c":[1,4]}},"sudo":{"key":"b"}}`,
+		},
+		"arrays_replaced": {
+			config:   `{"balances":{"balances":[["a",1],["b",2]]}}`,
+			patch:    `{"balances":{"balances":[["c",3]]}}`,
+			expected: `{"balances":{"balances":[["c",3]]}}`,
+		},
+		"null_removes_key": {
+			config:   `{"sudo":{"key":"a"},"system":{}}`,
+			patch:    `{"sudo":null}`,
+			expected: `{"system":{}}`,
+		},
+		"large_numbers_kept": {
+			config:   `{"balances":{"balances":[["a",1000000000000000000000]]},"staking":{"minimumBond":1}}`,
+			patch:    `{"balances":{"balances":[["b",340282366920938463463374607431768211455]]}}`,
+			expected: `{"balances":{"balances":[["b",340282366920938463463374607431768211455]]},"staking":{"minimumBond":1}}`,
+		},
+		"new_keys_added": {
+			config:   `{"system":{}}`,
+			patch:    `{"sudo":{"key":"a"}}`,
+			expected: `{"sudo":{"key":"a"},"system":{}}`,
+		},
+		"invalid_config": {
+			config:    `{`,
+			patch:     `{}`,
+			expErrMsg: "decoding genesis config: unexpected EOF",
+		},
+		"invalid_patch": {
+			config:    `{}`,
+			patch:     `[`,
+			expErrMsg: "decoding patch: unexpected EOF",
+		},
+		"trailing_data": {
+			config:    `{} {}`,
+			patch:     `{}`,
+			expErrMsg: "decoding genesis config: unexpected data after JSON value at offset 3",
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			merged, err := mergeGenesisConfigPatch([]byte(tt.config), []byte(tt.patch))
+			if tt.expErrMsg != "" {
+				assert.EqualError(t, err, tt.expErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(merged))
+		})
+	}
+}
+
+func Test_buildRawGenesis(t *testing.T) {
+	t.Parallel()
+
+	gen := &genesis.Genesis{
+		Genesis: genesis.Fields{
+			RuntimeGenesis: &genesis.RuntimeGenesis{Code: "0xzz"},
+		},
+	}
+
+	err := buildRawGenesis(gen)
+	assert.ErrorContains(t, err, "building runtime genesis storage: decoding runtime code")
+	assert.Nil(t, gen.Genesis.Raw)
+}
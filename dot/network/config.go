@@ -11,6 +11,7 @@
 	"time"
 
 	"github.com/adrg/xdg"
+	record "github.com/libp2p/go-libp2p-record"
 	"github.com/libp2p/go-libp2p/core/crypto"
 
 	"github.com/ChainSafe/gossamer/internal/log"
@@ -79,8 +80,10 @@ type Config struct {
 	RandSeed int64
 	// Bootnodes the peer addresses used for bootstrapping
 	Bootnodes []string
-	// ProtocolID the protocol ID for network messages
+	// ProtocolID the protocol ID for network messages, used by the legacy protocol names
 	ProtocolID string
+	// ForkID the optional fork ID of the chain, part of the protocol names when not empty
+	ForkID string
 	// NoBootstrap disables bootstrapping
 	NoBootstrap bool
 	// NoMDNS disables MDNS discovery
@@ -99,6 +102,10 @@ type Config struct {
 	// NodeKey is the private hex encoded Ed25519 key to build the p2p identity
 	NodeKey string
 
+	// DHTRecordValidator validates and selects the DHT records which are not namespaced,
+	// such as the authority discovery records. The records are accepted if it is nil.
+	DHTRecordValidator record.Validator
+
 	// privateKey the private key for the network p2p identity
 	privateKey crypto.PrivKey
 
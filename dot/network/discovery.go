@@ -12,6 +12,7 @@
 	badger "github.com/ipfs/go-ds-badger2"
 	kaddht "github.com/libp2p/go-libp2p-kad-dht"
 	"github.com/libp2p/go-libp2p-kad-dht/dual"
+	record "github.com/libp2p/go-libp2p-record"
 	libp2phost "github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
@@ -33,6 +34,41 @@
 	findPeersTimeout            = time.Minute
 )
 
+// dhtValidator validates the namespaced DHT records, such as the public key records, with the
+// validator of their namespace, and the other records, such as the authority discovery records
+// whose keys are hashes, with the fallback validator. Without a fallback validator, it accepts
+// the other records and leaves their validation to the subsystems reading them.
+type dhtValidator struct {
+	namespaced record.NamespacedValidator
+	fallback   record.Validator
+}
+
+// Validate validates the record with the given key and value.
+func (v dhtValidator) Validate(key string, value []byte) error {
+	validator := v.validatorByKey(key)
+	if validator == nil {
+		return nil
+	}
+	return validator.Validate(key, value)
+}
+
+// Select returns the index of the best record among the given records with the same key.
+func (v dhtValidator) Select(key string, values [][]byte) (int, error) {
+	validator := v.validatorByKey(key)
+	if validator == nil {
+		return 0, nil
+	}
+	return validator.Select(key, values)
+}
+
+func (v dhtValidator) validatorByKey(key string) record.Validator {
+	validator := v.namespaced.ValidatorByKey(key)
+	if validator == nil {
+		return v.fallback
+	}
+	return validator
+}
+
 // discovery handles discovery of new peers via the kademlia DHT
 type discovery struct {
 	ctx       context.Context
@@ -44,11 +80,12 @@ type discovery struct {
 	pid       protocol.ID
 	maxPeers  int
 	handler   PeerSetHandler
+	validator dhtValidator
 }
 
 func newDiscovery(ctx context.Context, h libp2phost.Host,
 	bootnodes []peer.AddrInfo, ds *badger.Datastore,
-	pid protocol.ID, max int, handler PeerSetHandler) *discovery {
+	pid protocol.ID, max int, handler PeerSetHandler, recordValidator record.Validator) *discovery {
 	return &discovery{
 		ctx:       ctx,
 		h:         h,
@@ -57,6 +94,10 @@ func newDiscovery(ctx context.Context, h libp2phost.Host,
 		pid:       pid,
 		maxPeers:  max,
 		handler:   handler,
+		validator: dhtValidator{
+			namespaced: record.NamespacedValidator{"pk": record.PublicKeyValidator{}},
+			fallback:   recordValidator,
+		},
 	}
 }
 
@@ -112,6 +153,7 @@ func (d *discovery) start() error {
 		dual.DHTOption(kaddht.BootstrapPeers(d.bootnodes...)),
 		dual.DHTOption(kaddht.V1ProtocolOverride(d.pid + "/kad")),
 		dual.DHTOption(kaddht.Mode(kaddht.ModeAutoServer)),
+		dual.DHTOption(kaddht.Validator(d.validator)),
 		dual.DHTOption(kaddht.AddressFilter(func(as []multiaddr.Multiaddr) []multiaddr.Multiaddr {
 			var addrs []multiaddr.Multiaddr
 			for _, addr := range as {
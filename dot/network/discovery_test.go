@@ -0,0 +1,46 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"errors"
+	"testing"
+
+	record "github.com/libp2p/go-libp2p-record"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRecordValidator struct{}
+
+func (testRecordValidator) Validate(_ string, value []byte) error {
+	if len(value) == 0 {
+		return errors.New("empty record")
+	}
+	return nil
+}
+
+func (testRecordValidator) Select(_ string, values [][]byte) (int, error) {
+	return len(values) - 1, nil
+}
+
+func Test_dhtValidator(t *testing.T) {
+	t.Parallel()
+
+	namespaced := record.NamespacedValidator{"pk": record.PublicKeyValidator{}}
+	values := [][]byte{{1}, {2}}
+
+	validator := dhtValidator{namespaced: namespaced}
+	assert.NoError(t, validator.Validate("hash", nil))
+	selected, err := validator.Select("hash", values)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, selected)
+	assert.Error(t, validator.Validate("/pk/key", []byte{1}))
+
+	validator = dhtValidator{namespaced: namespaced, fallback: testRecordValidator{}}
+	assert.EqualError(t, validator.Validate("hash", nil), "empty record")
+	selected, err = validator.Select("hash", values)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, selected)
+	assert.Error(t, validator.Validate("/pk/key", []byte{1}))
+}
@@ -17,9 +17,14 @@
 	errHandshakeTimeout          = errors.New("handshake timeout reached")
 	errInboundHanshakeExists     = errors.New("an inbound handshake already exists for given peer")
 	errInvalidRole               = errors.New("invalid role")
+	errNoLightRequestHandler     = errors.New("no light request handler")
+	errNoWarpSyncProvider        = errors.New("no warp sync provider")
+	errInvalidBlockHash          = errors.New("invalid block hash")
+	errInvalidBlockNumber        = errors.New("invalid block number")
 	ErrFailedToReadEntireMessage = errors.New("failed to read entire message")
 	ErrNilStream                 = errors.New("nil stream")
 	ErrInvalidLEB128EncodedData  = errors.New("invalid LEB128 encoded data")
 	ErrGreaterThanMaxSize        = errors.New("greater than maximum size")
 	ErrStreamReset               = errors.New("stream reset")
+	ErrDHTNotStarted             = errors.New("DHT not started")
 )
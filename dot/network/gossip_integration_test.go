@@ -84,7 +84,7 @@ func TestGossip(t *testing.T) {
 		Digest: types.NewDigest(),
 	}
 
-	_, err = nodeA.host.send(addrInfoB.ID, "/gossamer/test/0/block-announces/1", announceMessage)
+	_, err = nodeA.host.send(addrInfoB.ID, announceMessage, "/gossamer/test/0/block-announces/1")
 	require.NoError(t, err)
 
 	time.Sleep(TestMessageTimeout)
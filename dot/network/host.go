@@ -9,6 +9,7 @@
 	"log"
 	"net"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -83,6 +84,8 @@ type host struct {
 	bwc             *metrics.BandwidthCounter
 	closeSync       sync.Once
 	externalAddr    ma.Multiaddr
+
+	negotiatedProtocols *negotiatedProtocols
 }
 
 func newHost(ctx context.Context, cfg *Config) (*host, error) {
@@ -249,7 +252,7 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 	}
 
 	bwc := metrics.NewBandwidthCounter()
-	discovery := newDiscovery(ctx, h, bns, ds, pid, cfg.MaxPeers, cm.peerSetHandler)
+	discovery := newDiscovery(ctx, h, bns, ds, pid, cfg.MaxPeers, cm.peerSetHandler, cfg.DHTRecordValidator)
 
 	host := &host{
 		ctx:             ctx,
@@ -263,6 +266,8 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 		messageCache:    msgCache,
 		bwc:             bwc,
 		externalAddr:    externalAddr,
+
+		negotiatedProtocols: newNegotiatedProtocols(),
 	}
 
 	cm.host = host
@@ -330,15 +335,19 @@ func (h *host) bootstrap() {
 }
 
 // send creates a new outbound stream with the given peer and writes the message. It also returns
-// the newly created stream.
-func (h *host) send(p peer.ID, pid protocol.ID, msg messages.P2PMessage) (network.Stream, error) {
+// the newly created stream. The stream is opened with the first of the given protocol names
+// supported by the peer, the first name being the primary name of the protocol.
+func (h *host) send(p peer.ID, msg messages.P2PMessage, pids ...protocol.ID) (network.Stream, error) {
 	// open outbound stream with host protocol id
-	stream, err := h.p2pHost.NewStream(h.ctx, p, pid)
+	stream, err := h.p2pHost.NewStream(h.ctx, p, pids...)
 	if err != nil {
-		logger.Tracef("failed to open new stream with peer %s using protocol %s: %s", p, pid, err)
+		logger.Tracef("failed to open new stream with peer %s using protocols %v: %s", p, pids, err)
 		return nil, err
 	}
 
+	pid := stream.Protocol()
+	h.negotiatedProtocols.set(p, pids[0], pid)
+
 	logger.Tracef(
 		"Opened stream with host %s, peer %s and protocol %s",
 		h.id(), p, pid)
@@ -418,10 +427,10 @@ func (h *host) removeReservedPeers(ids ...string) error {
 	return nil
 }
 
-// supportsProtocol checks if the protocol is supported by peerID
+// supportsProtocol checks if any of the protocol names is supported by peerID
 // returns an error if could not get peer protocols
-func (h *host) supportsProtocol(peerID peer.ID, protocol protocol.ID) (bool, error) {
-	peerProtocols, err := h.p2pHost.Peerstore().SupportsProtocols(peerID, protocol)
+func (h *host) supportsProtocol(peerID peer.ID, protocols ...protocol.ID) (bool, error) {
+	peerProtocols, err := h.p2pHost.Peerstore().SupportsProtocols(peerID, protocols...)
 	if err != nil {
 		return false, err
 	}
@@ -463,16 +472,17 @@ func (h *host) closePeer(peer peer.ID) error {
 	return h.p2pHost.Network().ClosePeer(peer)
 }
 
-func (h *host) closeProtocolStream(pID protocol.ID, p peer.ID) {
+// closeProtocolStream closes the streams with the peer using any of the protocol names.
+func (h *host) closeProtocolStream(p peer.ID, pIDs ...protocol.ID) {
 	connToPeer := h.p2pHost.Network().ConnsToPeer(p)
 	for _, c := range connToPeer {
 		for _, st := range c.GetStreams() {
-			if st.Protocol() != pID {
+			if !slices.Contains(pIDs, st.Protocol()) {
 				continue
 			}
 			err := st.Close()
 			if err != nil {
-				logger.Tracef("Failed to close stream for protocol %s: %s", pID, err)
+				logger.Tracef("Failed to close stream for protocol %s: %s", st.Protocol(), err)
 			}
 		}
 	}
@@ -219,7 +219,7 @@ func TestSend(t *testing.T) {
 	require.NoError(t, err)
 
 	testBlockReqMessage := newTestBlockRequestMessage(t)
-	_, err = nodeA.host.send(addrInfoB.ID, nodeB.host.protocolID, testBlockReqMessage)
+	_, err = nodeA.host.send(addrInfoB.ID, testBlockReqMessage, nodeB.host.protocolID)
 	require.NoError(t, err)
 
 	time.Sleep(TestMessageTimeout)
@@ -271,7 +271,7 @@ func TestExistingStream(t *testing.T) {
 	testBlockReqMessage := newTestBlockRequestMessage(t)
 
 	// node A opens the stream to send the first message
-	stream, err := nodeA.host.send(addrInfoB.ID, nodeB.host.protocolID, testBlockReqMessage)
+	stream, err := nodeA.host.send(addrInfoB.ID, testBlockReqMessage, nodeB.host.protocolID)
 	require.NoError(t, err)
 
 	time.Sleep(TestMessageTimeout)
@@ -283,7 +283,7 @@ func TestExistingStream(t *testing.T) {
 	require.NotNil(t, handlerB.messages[nodeA.host.id()], "node B timeout waiting for message from node A")
 
 	// node B opens the stream to send the first message
-	stream, err = nodeB.host.send(addrInfoA.ID, nodeB.host.protocolID, testBlockReqMessage)
+	stream, err = nodeB.host.send(addrInfoA.ID, testBlockReqMessage, nodeB.host.protocolID)
 	require.NoError(t, err)
 
 	time.Sleep(TestMessageTimeout)
@@ -343,7 +343,7 @@ func TestStreamCloseMetadataCleanup(t *testing.T) {
 	}
 
 	// node A opens the stream to send the first message
-	_, err = nodeA.host.send(nodeB.host.id(), nodeB.host.protocolID+blockAnnounceID, testHandshake)
+	_, err = nodeA.host.send(nodeB.host.id(), testHandshake, nodeB.host.protocolID+blockAnnounceID)
 	require.NoError(t, err)
 
 	info := nodeA.notificationsProtocols[blockAnnounceMsgType]
@@ -543,7 +543,7 @@ func TestStreamCloseEOF(t *testing.T) {
 
 	testBlockReqMessage := newTestBlockRequestMessage(t)
 
-	stream, err := nodeA.host.send(addrInfoB.ID, nodeB.host.protocolID, testBlockReqMessage)
+	stream, err := nodeA.host.send(addrInfoB.ID, testBlockReqMessage, nodeB.host.protocolID)
 	require.NoError(t, err)
 	require.False(t, handler.exit)
 
@@ -4,6 +4,8 @@
 package network
 
 import (
+	"slices"
+
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 )
 
@@ -61,7 +63,7 @@ func (s *Service) resetInboundStream(stream libp2pnetwork.Stream) {
 	defer s.notificationsMu.Unlock()
 
 	for _, prtl := range s.notificationsProtocols {
-		if prtl.protocolID != protocolID {
+		if !slices.Contains(prtl.protocolIDs(), protocolID) {
 			continue
 		}
 
@@ -4,6 +4,7 @@
 package network
 
 import (
+	"encoding/binary"
 	"fmt"
 
 	"github.com/ChainSafe/gossamer/dot/network/messages"
@@ -50,15 +51,15 @@ func (s *Service) handleLightMsg(stream libp2pnetwork.Stream, msg messages.P2PMe
 	resp := NewLightResponse()
 	switch {
 	case lr.RemoteCallRequest != nil:
-		resp.RemoteCallResponse, err = remoteCallResp(lr.RemoteCallRequest)
+		resp.RemoteCallResponse, err = s.remoteCallResp(lr.RemoteCallRequest)
 	case lr.RemoteHeaderRequest != nil:
-		resp.RemoteHeaderResponse, err = remoteHeaderResp(lr.RemoteHeaderRequest)
+		resp.RemoteHeaderResponse, err = s.remoteHeaderResp(lr.RemoteHeaderRequest)
 	case lr.RemoteChangesRequest != nil:
 		resp.RemoteChangesResponse, err = remoteChangeResp(lr.RemoteChangesRequest)
 	case lr.RemoteReadRequest != nil:
-		resp.RemoteReadResponse, err = remoteReadResp(lr.RemoteReadRequest)
+		resp.RemoteReadResponse, err = s.remoteReadResp(lr.RemoteReadRequest)
 	case lr.RemoteReadChildRequest != nil:
-		resp.RemoteReadResponse, err = remoteReadChildResp(lr.RemoteReadChildRequest)
+		resp.RemoteReadResponse, err = s.remoteReadChildResp(lr.RemoteReadChildRequest)
 	default:
 		logger.Warn("ignoring LightRequest without request data")
 		return nil
@@ -68,7 +69,6 @@ func (s *Service) handleLightMsg(stream libp2pnetwork.Stream, msg messages.P2PMe
 		return err
 	}
 
-	// TODO(arijit): Remove once we implement the internal APIs. Added to increase code coverage. (#1856)
 	logger.Debugf("LightResponse message: %s", resp)
 
 	err = s.host.writeToStream(stream, resp)
@@ -113,10 +113,32 @@ func NewLightRequest() *LightRequest {
 	}
 }
 
+// newLightRequestFromBytes decodes a LightRequest from the given bytes.
+// Since all the sub-requests are encoded in a LightRequest, the empty
+// sub-requests are set to nil so only the requested ones are handled.
 func newLightRequestFromBytes(in []byte) (msg *LightRequest, err error) {
 	msg = NewLightRequest()
 	err = msg.Decode(in)
-	return msg, err
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.RemoteCallRequest.isEmpty() {
+		msg.RemoteCallRequest = nil
+	}
+	if msg.RemoteReadRequest.isEmpty() {
+		msg.RemoteReadRequest = nil
+	}
+	if msg.RemoteHeaderRequest.isEmpty() {
+		msg.RemoteHeaderRequest = nil
+	}
+	if msg.RemoteReadChildRequest.isEmpty() {
+		msg.RemoteReadChildRequest = nil
+	}
+	if msg.RemoteChangesRequest.isEmpty() {
+		msg.RemoteChangesRequest = nil
+	}
+	return msg, nil
 }
 
 func newRequest() *request {
@@ -129,16 +151,26 @@ func newRequest() *request {
 	}
 }
 
-// Encode encodes a LightRequest message using SCALE and appends the type byte to the start
+// Encode encodes a LightRequest message using SCALE and appends the type byte to the start.
+// Nil sub-requests are encoded as empty sub-requests.
 func (l *LightRequest) Encode() ([]byte, error) {
-	req := request{
-		RemoteCallRequest:      *l.RemoteCallRequest,
-		RemoteReadRequest:      *l.RemoteReadRequest,
-		RemoteHeaderRequest:    *l.RemoteHeaderRequest,
-		RemoteReadChildRequest: *l.RemoteReadChildRequest,
-		RemoteChangesRequest:   *l.RemoteChangesRequest,
+	req := newRequest()
+	if l.RemoteCallRequest != nil {
+		req.RemoteCallRequest = *l.RemoteCallRequest
 	}
-	return scale.Marshal(req)
+	if l.RemoteReadRequest != nil {
+		req.RemoteReadRequest = *l.RemoteReadRequest
+	}
+	if l.RemoteHeaderRequest != nil {
+		req.RemoteHeaderRequest = *l.RemoteHeaderRequest
+	}
+	if l.RemoteReadChildRequest != nil {
+		req.RemoteReadChildRequest = *l.RemoteReadChildRequest
+	}
+	if l.RemoteChangesRequest != nil {
+		req.RemoteChangesRequest = *l.RemoteChangesRequest
+	}
+	return scale.Marshal(*req)
 }
 
 // Decode the message into a LightRequest, it assumes the type byte has been removed
@@ -253,6 +285,10 @@ func newRemoteCallRequest() *RemoteCallRequest {
 	}
 }
 
+func (rc *RemoteCallRequest) isEmpty() bool {
+	return len(rc.Block) == 0 && rc.Method == "" && len(rc.Data) == 0
+}
+
 // RemoteReadRequest ...
 type RemoteReadRequest struct {
 	Block []byte
@@ -265,6 +301,10 @@ func newRemoteReadRequest() *RemoteReadRequest {
 	}
 }
 
+func (rr *RemoteReadRequest) isEmpty() bool {
+	return len(rr.Block) == 0 && len(rr.Keys) == 0
+}
+
 // RemoteReadChildRequest ...
 type RemoteReadChildRequest struct {
 	Block      []byte
@@ -279,8 +319,13 @@ func newRemoteReadChildRequest() *RemoteReadChildRequest {
 	}
 }
 
+func (rr *RemoteReadChildRequest) isEmpty() bool {
+	return len(rr.Block) == 0 && len(rr.StorageKey) == 0 && len(rr.Keys) == 0
+}
+
 // RemoteHeaderRequest ...
 type RemoteHeaderRequest struct {
+	// Block is the SCALE encoded number of the requested block
 	Block []byte
 }
 
@@ -290,6 +335,10 @@ func newRemoteHeaderRequest() *RemoteHeaderRequest {
 	}
 }
 
+func (rh *RemoteHeaderRequest) isEmpty() bool {
+	return len(rh.Block) == 0
+}
+
 // RemoteChangesRequest ...
 type RemoteChangesRequest struct {
 	FirstBlock *common.Hash
@@ -310,6 +359,11 @@ func newRemoteChangesRequest() RemoteChangesRequest {
 	}
 }
 
+func (rc *RemoteChangesRequest) isEmpty() bool {
+	return rc.FirstBlock == nil && rc.LastBlock == nil &&
+		len(rc.Min) == 0 && len(rc.Max) == 0 && rc.StorageKey == nil
+}
+
 // RemoteCallResponse ...
 type RemoteCallResponse struct {
 	Proof []byte
@@ -447,18 +501,106 @@ func (rh *RemoteHeaderResponse) String() string {
 	return fmt.Sprintf("Header =%+v Proof =%s", rh.Header, string(rh.proof))
 }
 
-func remoteCallResp(_ *RemoteCallRequest) (*RemoteCallResponse, error) {
-	return &RemoteCallResponse{}, nil
+// remoteCallResp executes the requested runtime call at the requested block and
+// responds with the proof of the storage read during the execution.
+func (s *Service) remoteCallResp(req *RemoteCallRequest) (*RemoteCallResponse, error) {
+	if s.lightRequestHandler == nil {
+		return nil, errNoLightRequestHandler
+	}
+
+	blockHash, err := blockHashFromBytes(req.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := s.lightRequestHandler.GetExecutionProofAt(blockHash, req.Method, req.Data)
+	if err != nil {
+		return nil, fmt.Errorf("getting execution proof of %s at block %s: %w", req.Method, blockHash, err)
+	}
+
+	encodedProof, err := scale.Marshal(proof)
+	if err != nil {
+		return nil, fmt.Errorf("encoding execution proof: %w", err)
+	}
+
+	return &RemoteCallResponse{Proof: encodedProof}, nil
 }
+
+// remoteChangeResp responds with an empty response since changes tries
+// are no longer supported.
 func remoteChangeResp(_ *RemoteChangesRequest) (*RemoteChangesResponse, error) {
 	return &RemoteChangesResponse{}, nil
 }
-func remoteHeaderResp(_ *RemoteHeaderRequest) (*RemoteHeaderResponse, error) {
-	return &RemoteHeaderResponse{}, nil
+
+// remoteHeaderResp responds with the header of the requested block number.
+func (s *Service) remoteHeaderResp(req *RemoteHeaderRequest) (*RemoteHeaderResponse, error) {
+	if len(req.Block) != 4 {
+		return nil, fmt.Errorf("%w: 0x%x", errInvalidBlockNumber, req.Block)
+	}
+	blockNumber := binary.LittleEndian.Uint32(req.Block)
+
+	header, err := s.blockState.GetHeaderByNumber(uint(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("getting header for block number %d: %w", blockNumber, err)
+	}
+
+	return &RemoteHeaderResponse{
+		Header: []*types.Header{header},
+	}, nil
+}
+
+// remoteReadChildResp responds with the proof of the requested keys
+// in the requested child trie.
+func (s *Service) remoteReadChildResp(req *RemoteReadChildRequest) (*RemoteReadResponse, error) {
+	if s.lightRequestHandler == nil {
+		return nil, errNoLightRequestHandler
+	}
+
+	blockHash, err := blockHashFromBytes(req.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := s.lightRequestHandler.GetChildReadProofAt(blockHash, req.StorageKey, req.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("getting child read proof at block %s: %w", blockHash, err)
+	}
+
+	encodedProof, err := scale.Marshal(proof)
+	if err != nil {
+		return nil, fmt.Errorf("encoding child read proof: %w", err)
+	}
+
+	return &RemoteReadResponse{Proof: encodedProof}, nil
 }
-func remoteReadChildResp(_ *RemoteReadChildRequest) (*RemoteReadResponse, error) {
-	return &RemoteReadResponse{}, nil
+
+// remoteReadResp responds with the proof of the requested keys.
+func (s *Service) remoteReadResp(req *RemoteReadRequest) (*RemoteReadResponse, error) {
+	if s.lightRequestHandler == nil {
+		return nil, errNoLightRequestHandler
+	}
+
+	blockHash, err := blockHashFromBytes(req.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	_, proof, err := s.lightRequestHandler.GetReadProofAt(blockHash, req.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("getting read proof at block %s: %w", blockHash, err)
+	}
+
+	encodedProof, err := scale.Marshal(proof)
+	if err != nil {
+		return nil, fmt.Errorf("encoding read proof: %w", err)
+	}
+
+	return &RemoteReadResponse{Proof: encodedProof}, nil
 }
-func remoteReadResp(_ *RemoteReadRequest) (*RemoteReadResponse, error) {
-	return &RemoteReadResponse{}, nil
+
+func blockHashFromBytes(block []byte) (blockHash common.Hash, err error) {
+	if len(block) != common.HashLength {
+		return blockHash, fmt.Errorf("%w: 0x%x", errInvalidBlockHash, block)
+	}
+	return common.NewHash(block), nil
 }
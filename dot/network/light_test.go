@@ -0,0 +1,187 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_newLightRequestFromBytes(t *testing.T) {
+	t.Parallel()
+
+	request := &LightRequest{
+		RemoteHeaderRequest: &RemoteHeaderRequest{Block: []byte{1, 0, 0, 0}},
+	}
+	encoded, err := request.Encode()
+	require.NoError(t, err)
+
+	decoded, err := newLightRequestFromBytes(encoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, request, decoded)
+}
+
+func Test_Service_remoteCallResp(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	blockHash := common.Hash{1}
+
+	testCases := map[string]struct {
+		handlerBuilder func(ctrl *gomock.Controller) LightRequestHandler
+		request        *RemoteCallRequest
+		response       *RemoteCallResponse
+		errWrapped     error
+		errMessage     string
+	}{
+		"no_handler": {
+			handlerBuilder: func(_ *gomock.Controller) LightRequestHandler { return nil },
+			request:        &RemoteCallRequest{Block: blockHash[:]},
+			errWrapped:     errNoLightRequestHandler,
+			errMessage:     "no light request handler",
+		},
+		"invalid_block_hash": {
+			handlerBuilder: func(ctrl *gomock.Controller) LightRequestHandler {
+				return NewMockLightRequestHandler(ctrl)
+			},
+			request:    &RemoteCallRequest{Block: []byte{1}},
+			errWrapped: errInvalidBlockHash,
+			errMessage: "invalid block hash: 0x01",
+		},
+		"execution_proof_error": {
+			handlerBuilder: func(ctrl *gomock.Controller) LightRequestHandler {
+				handler := NewMockLightRequestHandler(ctrl)
+				handler.EXPECT().GetExecutionProofAt(blockHash, "Core_version", []byte{2}).
+					Return(nil, errTest)
+				return handler
+			},
+			request:    &RemoteCallRequest{Block: blockHash[:], Method: "Core_version", Data: []byte{2}},
+			errWrapped: errTest,
+			errMessage: "getting execution proof of Core_version at block " +
+				"0x0100000000000000000000000000000000000000000000000000000000000000: test error",
+		},
+		"success": {
+			handlerBuilder: func(ctrl *gomock.Controller) LightRequestHandler {
+				handler := NewMockLightRequestHandler(ctrl)
+				handler.EXPECT().GetExecutionProofAt(blockHash, "Core_version", []byte{2}).
+					Return([][]byte{{3}, {4}}, nil)
+				return handler
+			},
+			request: &RemoteCallRequest{Block: blockHash[:], Method: "Core_version", Data: []byte{2}},
+			response: &RemoteCallResponse{
+				Proof: []byte{8, 4, 3, 4, 4},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			s := &Service{
+				lightRequestHandler: testCase.handlerBuilder(ctrl),
+			}
+
+			response, err := s.remoteCallResp(testCase.request)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.response, response)
+		})
+	}
+}
+
+func Test_Service_remoteReadResp(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	blockHash := common.Hash{1}
+	keys := [][]byte{{1}, {2}}
+	handler := NewMockLightRequestHandler(ctrl)
+	handler.EXPECT().GetReadProofAt(blockHash, keys).
+		Return(blockHash, [][]byte{{3}}, nil)
+	s := &Service{lightRequestHandler: handler}
+
+	response, err := s.remoteReadResp(&RemoteReadRequest{Block: blockHash[:], Keys: keys})
+	require.NoError(t, err)
+
+	expectedProof, err := scale.Marshal([][]byte{{3}})
+	require.NoError(t, err)
+	assert.Equal(t, &RemoteReadResponse{Proof: expectedProof}, response)
+}
+
+func Test_Service_remoteHeaderResp(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		blockStateBuilder func(ctrl *gomock.Controller) BlockState
+		request           *RemoteHeaderRequest
+		response          *RemoteHeaderResponse
+		errWrapped        error
+		errMessage        string
+	}{
+		"invalid_block_number": {
+			blockStateBuilder: func(_ *gomock.Controller) BlockState { return nil },
+			request:           &RemoteHeaderRequest{Block: []byte{1}},
+			errWrapped:        errInvalidBlockNumber,
+			errMessage:        "invalid block number: 0x01",
+		},
+		"get_header_error": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeaderByNumber(uint(1)).Return(nil, errTest)
+				return blockState
+			},
+			request:    &RemoteHeaderRequest{Block: []byte{1, 0, 0, 0}},
+			errWrapped: errTest,
+			errMessage: "getting header for block number 1: test error",
+		},
+		"success": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeaderByNumber(uint(1)).
+					Return(&types.Header{Number: 1}, nil)
+				return blockState
+			},
+			request: &RemoteHeaderRequest{Block: []byte{1, 0, 0, 0}},
+			response: &RemoteHeaderResponse{
+				Header: []*types.Header{{Number: 1}},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			s := &Service{
+				blockState: testCase.blockStateBuilder(ctrl),
+			}
+
+			response, err := s.remoteHeaderResp(testCase.request)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.response, response)
+		})
+	}
+}
@@ -17,6 +17,7 @@
 	blockAnnounceMsgType MessageType = iota + 3
 	transactionMsgType
 	ConsensusMsgType
+	BeefyMsgType
 )
 
 // NotificationsMessage must be implemented by all messages sent over a notifications protocol
@@ -26,7 +27,10 @@ type NotificationsMessage interface {
 	Hash() (common.Hash, error)
 }
 
-var _ NotificationsMessage = &ConsensusMessage{}
+var (
+	_ NotificationsMessage = &ConsensusMessage{}
+	_ NotificationsMessage = &BeefyMessage{}
+)
 
 // ConsensusMessage is mostly opaque to us
 type ConsensusMessage struct {
@@ -63,3 +67,38 @@ func (cm *ConsensusMessage) Hash() (common.Hash, error) {
 	}
 	return common.Blake2bHash(encMsg)
 }
+
+// BeefyMessage is an opaque BEEFY gossip message
+type BeefyMessage struct {
+	Data []byte
+}
+
+// Type returns BeefyMsgType
+func (*BeefyMessage) Type() MessageType {
+	return BeefyMsgType
+}
+
+// String is the string
+func (bm *BeefyMessage) String() string {
+	return fmt.Sprintf("BeefyMessage Data=%x", bm.Data)
+}
+
+// Encode returns the raw data of the message
+func (bm *BeefyMessage) Encode() ([]byte, error) {
+	return bm.Data, nil
+}
+
+// Decode the message into a BeefyMessage
+func (bm *BeefyMessage) Decode(in []byte) error {
+	bm.Data = in
+	return nil
+}
+
+// Hash returns the Hash of BeefyMessage
+func (bm *BeefyMessage) Hash() (common.Hash, error) {
+	encMsg, err := bm.Encode()
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("cannot encode message: %w", err)
+	}
+	return common.Blake2bHash(encMsg)
+}
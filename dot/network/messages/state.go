@@ -5,6 +5,7 @@
 
 import (
 	"fmt"
+	"strings"
 
 	pb "github.com/ChainSafe/gossamer/dot/network/proto"
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -12,7 +13,15 @@
 	"google.golang.org/protobuf/proto"
 )
 
-var _ P2PMessage = (*StateRequest)(nil)
+var (
+	_ P2PMessage = (*StateRequest)(nil)
+	_ P2PMessage = (*StateResponse)(nil)
+)
+
+// MaxStateResponseEntriesSize is the maximum size of the key-value entries
+// in a state response, so the encoded response stays below the maximum
+// response size.
+const MaxStateResponseEntriesSize = 1024 * 1024 * 2 // 2mb
 
 // StateRequest defines the parameters to request the state keys
 // and values from another peer
@@ -23,9 +32,14 @@ type StateRequest struct {
 }
 
 func (s *StateRequest) String() string {
-	return fmt.Sprintf("StateRequest Block=%s Start=[0x%x, 0x%x] NoProof=%v",
+	start := make([]string, len(s.Start))
+	for i, key := range s.Start {
+		start[i] = fmt.Sprintf("0x%x", key)
+	}
+
+	return fmt.Sprintf("StateRequest Block=%s Start=[%s] NoProof=%v",
 		s.Block.String(),
-		s.Start[0], s.Start[1],
+		strings.Join(start, ", "),
 		s.NoProof,
 	)
 }
@@ -54,17 +68,59 @@ func (s *StateRequest) Decode(in []byte) error {
 	return nil
 }
 
+// StateResponse holds the state keys and values requested with a StateRequest
 type StateResponse struct {
 	Entries []KeyValueStateEntry
 	Proof   []byte
 }
 
+// KeyValueStateEntry holds the key-value entries of a trie. The state root is
+// empty for the top trie, and is the root of the child trie for child tries.
+// Complete is true if the entries are the last entries of the trie.
 type KeyValueStateEntry struct {
 	StateRoot    common.Hash
 	StateEntries trie.Entries
 	Complete     bool
 }
 
+func (s *StateResponse) String() string {
+	if s == nil {
+		return "StateResponse=nil"
+	}
+
+	return fmt.Sprintf("StateResponse Entries=%d ProofSize=%d", len(s.Entries), len(s.Proof))
+}
+
+func (s *StateResponse) Encode() ([]byte, error) {
+	message := &pb.StateResponse{
+		Entries: make([]*pb.KeyValueStateEntry, len(s.Entries)),
+		Proof:   s.Proof,
+	}
+
+	for idx, entry := range s.Entries {
+		pbEntry := &pb.KeyValueStateEntry{
+			Entries:  make([]*pb.StateEntry, len(entry.StateEntries)),
+			Complete: entry.Complete,
+		}
+
+		// the state root of the top trie is left empty
+		if !entry.StateRoot.IsEmpty() {
+			pbEntry.StateRoot = entry.StateRoot.ToBytes()
+		}
+
+		for stateEntryIdx, stateEntry := range entry.StateEntries {
+			pbEntry.Entries[stateEntryIdx] = &pb.StateEntry{
+				Key:   stateEntry.Key,
+				Value: stateEntry.Value,
+			}
+		}
+
+		message.Entries[idx] = pbEntry
+	}
+
+	return proto.Marshal(message)
+}
+
 func (s *StateResponse) Decode(in []byte) error {
 	decodedResponse := &pb.StateResponse{}
 	err := proto.Unmarshal(in, decodedResponse)
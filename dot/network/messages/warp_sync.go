@@ -0,0 +1,80 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package messages
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+var (
+	_ P2PMessage = (*WarpSyncRequest)(nil)
+	_ P2PMessage = (*WarpSyncProof)(nil)
+)
+
+// MaxWarpSyncProofSize is the maximum size of an encoded warp sync proof.
+const MaxWarpSyncProofSize = 8 * 1024 * 1024 // 8mb
+
+// WarpSyncRequest requests a warp sync proof of the authority set
+// changes following the given finalised block
+type WarpSyncRequest struct {
+	Begin common.Hash
+}
+
+func (w *WarpSyncRequest) String() string {
+	return fmt.Sprintf("WarpSyncRequest Begin=%s", w.Begin)
+}
+
+func (w *WarpSyncRequest) Encode() ([]byte, error) {
+	return scale.Marshal(w.Begin)
+}
+
+func (w *WarpSyncRequest) Decode(in []byte) error {
+	return scale.Unmarshal(in, &w.Begin)
+}
+
+// WarpSyncProof holds the justifications of the blocks enacting the authority set
+// changes following the requested block. IsFinished is true if the last fragment
+// is for the latest finalised block known by the peer.
+type WarpSyncProof struct {
+	Fragments  []WarpSyncFragment
+	IsFinished bool
+}
+
+// WarpSyncFragment holds the header of the last block of an authority set,
+// and the justification of this block signed by the authority set.
+type WarpSyncFragment struct {
+	Header        types.Header
+	Justification WarpSyncJustification
+}
+
+// WarpSyncJustification is a GRANDPA justification with the ancestry of the
+// blocks precommitted, so it can be verified without the block tree.
+type WarpSyncJustification struct {
+	Round           uint64
+	Commit          WarpSyncCommit
+	VotesAncestries []types.Header
+}
+
+// WarpSyncCommit holds the precommits for a finalised block
+type WarpSyncCommit struct {
+	Hash       common.Hash
+	Number     uint32
+	Precommits []types.GrandpaSignedVote
+}
+
+func (w *WarpSyncProof) String() string {
+	return fmt.Sprintf("WarpSyncProof Fragments=%d IsFinished=%v", len(w.Fragments), w.IsFinished)
+}
+
+func (w *WarpSyncProof) Encode() ([]byte, error) {
+	return scale.Marshal(*w)
+}
+
+func (w *WarpSyncProof) Decode(in []byte) error {
+	return scale.Unmarshal(in, w)
+}
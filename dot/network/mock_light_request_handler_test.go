@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ChainSafe/gossamer/dot/network (interfaces: LightRequestHandler)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock_light_request_handler_test.go -package network . LightRequestHandler
+//
+
+// Package network is a generated GoMock package.
+package network
+
+import (
+	reflect "reflect"
+
+	common "github.com/ChainSafe/gossamer/lib/common"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLightRequestHandler is a mock of LightRequestHandler interface.
+type MockLightRequestHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockLightRequestHandlerMockRecorder
+}
+
+// MockLightRequestHandlerMockRecorder is the mock recorder for MockLightRequestHandler.
+type MockLightRequestHandlerMockRecorder struct {
+	mock *MockLightRequestHandler
+}
+
+// NewMockLightRequestHandler creates a new mock instance.
+func NewMockLightRequestHandler(ctrl *gomock.Controller) *MockLightRequestHandler {
+	mock := &MockLightRequestHandler{ctrl: ctrl}
+	mock.recorder = &MockLightRequestHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLightRequestHandler) EXPECT() *MockLightRequestHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetChildReadProofAt mocks base method.
+func (m *MockLightRequestHandler) GetChildReadProofAt(arg0 common.Hash, arg1 []byte, arg2 [][]byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChildReadProofAt", arg0, arg1, arg2)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChildReadProofAt indicates an expected call of GetChildReadProofAt.
+func (mr *MockLightRequestHandlerMockRecorder) GetChildReadProofAt(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildReadProofAt", reflect.TypeOf((*MockLightRequestHandler)(nil).GetChildReadProofAt), arg0, arg1, arg2)
+}
+
+// GetExecutionProofAt mocks base method.
+func (m *MockLightRequestHandler) GetExecutionProofAt(arg0 common.Hash, arg1 string, arg2 []byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExecutionProofAt", arg0, arg1, arg2)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExecutionProofAt indicates an expected call of GetExecutionProofAt.
+func (mr *MockLightRequestHandlerMockRecorder) GetExecutionProofAt(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExecutionProofAt", reflect.TypeOf((*MockLightRequestHandler)(nil).GetExecutionProofAt), arg0, arg1, arg2)
+}
+
+// GetReadProofAt mocks base method.
+func (m *MockLightRequestHandler) GetReadProofAt(arg0 common.Hash, arg1 [][]byte) (common.Hash, [][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReadProofAt", arg0, arg1)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].([][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetReadProofAt indicates an expected call of GetReadProofAt.
+func (mr *MockLightRequestHandlerMockRecorder) GetReadProofAt(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReadProofAt", reflect.TypeOf((*MockLightRequestHandler)(nil).GetReadProofAt), arg0, arg1)
+}
@@ -55,6 +55,21 @@ func (mr *MockSyncerMockRecorder) CreateBlockResponse(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlockResponse", reflect.TypeOf((*MockSyncer)(nil).CreateBlockResponse), arg0, arg1)
 }
 
+// CreateStateResponse mocks base method.
+func (m *MockSyncer) CreateStateResponse(arg0 peer.ID, arg1 *messages.StateRequest) (*messages.StateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStateResponse", arg0, arg1)
+	ret0, _ := ret[0].(*messages.StateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStateResponse indicates an expected call of CreateStateResponse.
+func (mr *MockSyncerMockRecorder) CreateStateResponse(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStateResponse", reflect.TypeOf((*MockSyncer)(nil).CreateStateResponse), arg0, arg1)
+}
+
 // HandleBlockAnnounce mocks base method.
 func (m *MockSyncer) HandleBlockAnnounce(arg0 peer.ID, arg1 *BlockAnnounceMessage) error {
 	m.ctrl.T.Helper()
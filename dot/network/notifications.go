@@ -7,6 +7,7 @@
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/network/messages"
@@ -57,26 +58,36 @@ type handshakeReader struct {
 }
 
 type notificationsProtocol struct {
-	protocolID         protocol.ID
-	getHandshake       HandshakeGetter
-	handshakeDecoder   HandshakeDecoder
-	handshakeValidator HandshakeValidator
-	peersData          *peersData
-	maxSize            uint64
+	// protocolID is the primary name of the protocol, and fallbackProtocolIDs are the
+	// legacy names of the protocol for the peers not supporting the primary name
+	protocolID          protocol.ID
+	fallbackProtocolIDs []protocol.ID
+	getHandshake        HandshakeGetter
+	handshakeDecoder    HandshakeDecoder
+	handshakeValidator  HandshakeValidator
+	peersData           *peersData
+	maxSize             uint64
 }
 
-func newNotificationsProtocol(protocolID protocol.ID, handshakeGetter HandshakeGetter,
-	handshakeDecoder HandshakeDecoder, handshakeValidator HandshakeValidator, maxSize uint64) *notificationsProtocol {
+func newNotificationsProtocol(protocolID protocol.ID, fallbackProtocolIDs []protocol.ID,
+	handshakeGetter HandshakeGetter, handshakeDecoder HandshakeDecoder, handshakeValidator HandshakeValidator,
+	maxSize uint64) *notificationsProtocol {
 	return &notificationsProtocol{
-		protocolID:         protocolID,
-		getHandshake:       handshakeGetter,
-		handshakeValidator: handshakeValidator,
-		handshakeDecoder:   handshakeDecoder,
-		peersData:          newPeersData(),
-		maxSize:            maxSize,
+		protocolID:          protocolID,
+		fallbackProtocolIDs: fallbackProtocolIDs,
+		getHandshake:        handshakeGetter,
+		handshakeValidator:  handshakeValidator,
+		handshakeDecoder:    handshakeDecoder,
+		peersData:           newPeersData(),
+		maxSize:             maxSize,
 	}
 }
 
+// protocolIDs returns all the names of the protocol, the primary name first
+func (n *notificationsProtocol) protocolIDs() []protocol.ID {
+	return append([]protocol.ID{n.protocolID}, n.fallbackProtocolIDs...)
+}
+
 type handshakeData struct {
 	received  bool
 	validated bool
@@ -249,16 +260,18 @@ func closeOutboundStream(info *notificationsProtocol, peerID peer.ID, stream net
 	}
 }
 
-func (s *Service) sendData(peer peer.ID, hs Handshake, info *notificationsProtocol, msg NotificationsMessage) {
+// sendData sends the message to the peer, and returns true if the message was written to the peer stream.
+func (s *Service) sendData(peer peer.ID, hs Handshake, info *notificationsProtocol,
+	msg NotificationsMessage) (sent bool) {
 	if info.handshakeValidator == nil {
 		logger.Errorf("handshakeValidator is not set for protocol %s", info.protocolID)
-		return
+		return false
 	}
 
-	support, err := s.host.supportsProtocol(peer, info.protocolID)
+	support, err := s.host.supportsProtocol(peer, info.protocolIDs()...)
 	if err != nil {
 		logger.Errorf("could not check if protocol %s is supported by peer %s: %s", info.protocolID, peer, err)
-		return
+		return false
 	}
 
 	if !support {
@@ -267,20 +280,20 @@ func (s *Service) sendData(peer peer.ID, hs Handshake, info *notificationsProtoc
 			Reason: peerset.BadProtocolReason,
 		}, peer)
 
-		return
+		return false
 	}
 
 	stream, err := s.sendHandshake(peer, hs, info)
 	if err != nil {
 		logger.Debugf("failed to send handshake to peer %s on protocol %s: %s", peer, info.protocolID, err)
-		return
+		return false
 	}
 
 	_, isConsensusMsg := msg.(*ConsensusMessage)
 
 	if s.host.messageCache != nil && s.host.messageCache.exists(peer, msg) && !isConsensusMsg {
 		logger.Tracef("message has already been sent, ignoring: peer=%s msg=%s", peer, msg)
-		return
+		return false
 	}
 
 	// we've completed the handshake with the peer, send message directly
@@ -292,11 +305,11 @@ func (s *Service) sendData(peer peer.ID, hs Handshake, info *notificationsProtoc
 		if errors.Is(err, io.EOF) || errors.Is(err, network.ErrReset) {
 			closeOutboundStream(info, peer, stream)
 		}
-		return
+		return false
 	} else if s.host.messageCache != nil {
 		if _, err := s.host.messageCache.put(peer, msg); err != nil {
 			logger.Errorf("failed to add message to cache for peer %s: %w", peer, err)
-			return
+			return true
 		}
 	}
 
@@ -305,6 +318,7 @@ func (s *Service) sendData(peer peer.ID, hs Handshake, info *notificationsProtoc
 		Value:  peerset.GossipSuccessValue,
 		Reason: peerset.GossipSuccessReason,
 	}, peer)
+	return true
 }
 
 var errPeerDisconnected = errors.New("peer disconnected")
@@ -340,7 +354,7 @@ func (s *Service) sendHandshake(peer peer.ID, hs Handshake, info *notificationsP
 
 	logger.Tracef("sending outbound handshake to peer %s on protocol %s, message: %s",
 		peer, info.protocolID, hs)
-	stream, err := s.host.send(peer, info.protocolID, hs)
+	stream, err := s.host.send(peer, hs, info.protocolIDs()...)
 	if err != nil {
 		logger.Tracef("failed to send handshake to peer %s: %s", peer, err)
 		// don't need to close the stream here, as it's nil!
@@ -421,6 +435,43 @@ func (s *Service) broadcastExcluding(info *notificationsProtocol, excluding peer
 	}
 }
 
+// broadcastAndWait sends the message to the connected peers, apart from the excluded peer,
+// and returns the peers the message was sent to once all the sends are done.
+func (s *Service) broadcastAndWait(info *notificationsProtocol, excluding peer.ID,
+	msg NotificationsMessage) (sentTo []peer.ID) {
+	hs, err := info.getHandshake()
+	if err != nil {
+		logger.Errorf("failed to get handshake using protocol %s: %s", info.protocolID, err)
+		return nil
+	}
+
+	var (
+		wg     sync.WaitGroup
+		sentMu sync.Mutex
+	)
+	for _, p := range s.host.peers() {
+		if p == excluding {
+			continue
+		}
+
+		info.peersData.setMutex(p)
+
+		wg.Add(1)
+		go func(p peer.ID) {
+			defer wg.Done()
+			if !s.sendData(p, hs, info, msg) {
+				return
+			}
+			sentMu.Lock()
+			sentTo = append(sentTo, p)
+			sentMu.Unlock()
+		}(p)
+	}
+	wg.Wait()
+
+	return sentTo
+}
+
 func (s *Service) readHandshake(stream network.Stream, decoder HandshakeDecoder, maxSize uint64,
 ) <-chan *handshakeReader {
 	hsC := make(chan *handshakeReader)
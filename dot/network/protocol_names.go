@@ -0,0 +1,91 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// ProtocolNames returns the names of the given sub-protocol of the chain. See ProtocolNames.
+func (s *Service) ProtocolNames(subprotocol protocol.ID) []protocol.ID {
+	return ProtocolNames(s.blockState.GenesisHash(), s.cfg.ForkID, s.host.protocolID, subprotocol)
+}
+
+// ProtocolNames returns the names of the given sub-protocol, which starts with a slash. The first
+// name is the primary name, prefixed by the genesis hash and the fork id of the chain, and the second
+// one is the legacy name prefixed by the protocol id of the chain, which is used as a fallback for the
+// peers not supporting the primary name.
+func ProtocolNames(genesisHash common.Hash, forkID string, protocolID, subprotocol protocol.ID) []protocol.ID {
+	prefix := "/" + strings.TrimPrefix(genesisHash.String(), "0x")
+	if forkID != "" {
+		prefix += "/" + forkID
+	}
+
+	return []protocol.ID{
+		protocol.ID(prefix) + subprotocol,
+		protocolID + subprotocol,
+	}
+}
+
+// registerStreamHandler registers the stream handler for all the given names of a protocol,
+// the first one being its primary name, and records the name negotiated by the remote peer
+// of each inbound stream.
+func (s *Service) registerStreamHandler(names []protocol.ID, handler func(libp2pnetwork.Stream)) {
+	for _, name := range names {
+		s.host.registerStreamHandler(name, func(stream libp2pnetwork.Stream) {
+			s.host.negotiatedProtocols.set(stream.Conn().RemotePeer(), names[0], stream.Protocol())
+			handler(stream)
+		})
+	}
+}
+
+// NegotiatedProtocols returns the protocol names negotiated with the given peer, keyed by
+// the primary name of each protocol.
+func (s *Service) NegotiatedProtocols(peerID peer.ID) map[protocol.ID]protocol.ID {
+	return s.host.negotiatedProtocols.get(peerID)
+}
+
+// negotiatedProtocols records the name negotiated with each peer for the protocols registered
+// with fallback names, keyed by the primary name of the protocol.
+type negotiatedProtocols struct {
+	mutex sync.RWMutex
+	names map[peer.ID]map[protocol.ID]protocol.ID
+}
+
+func newNegotiatedProtocols() *negotiatedProtocols {
+	return &negotiatedProtocols{
+		names: make(map[peer.ID]map[protocol.ID]protocol.ID),
+	}
+}
+
+func (n *negotiatedProtocols) set(peerID peer.ID, primary, negotiated protocol.ID) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	names, has := n.names[peerID]
+	if !has {
+		names = make(map[protocol.ID]protocol.ID)
+		n.names[peerID] = names
+	}
+	names[primary] = negotiated
+}
+
+func (n *negotiatedProtocols) get(peerID peer.ID) map[protocol.ID]protocol.ID {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	return maps.Clone(n.names[peerID])
+}
+
+func (n *negotiatedProtocols) delete(peerID peer.ID) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	delete(n.names, peerID)
+}
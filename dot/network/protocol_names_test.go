@@ -0,0 +1,81 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Service_ProtocolNames(t *testing.T) {
+	t.Parallel()
+
+	genesisHash := common.Hash{0xaa, 0xbb}
+	const genesisHex = "aabb000000000000000000000000000000000000000000000000000000000000"
+
+	testCases := map[string]struct {
+		forkID string
+		names  []protocol.ID
+	}{
+		"without_fork_id": {
+			names: []protocol.ID{
+				"/" + genesisHex + "/sync/2",
+				"/gossamer/test/0/sync/2",
+			},
+		},
+		"with_fork_id": {
+			forkID: "fork",
+			names: []protocol.ID{
+				"/" + genesisHex + "/fork/sync/2",
+				"/gossamer/test/0/sync/2",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockState := NewMockBlockState(ctrl)
+			blockState.EXPECT().GenesisHash().Return(genesisHash)
+
+			service := &Service{
+				cfg:        &Config{ForkID: testCase.forkID},
+				host:       &host{protocolID: "/gossamer/test/0"},
+				blockState: blockState,
+			}
+
+			names := service.ProtocolNames(SyncID)
+			require.Equal(t, testCase.names, names)
+		})
+	}
+}
+
+func Test_negotiatedProtocols(t *testing.T) {
+	t.Parallel()
+
+	const peerA, peerB = peer.ID("a"), peer.ID("b")
+	negotiated := newNegotiatedProtocols()
+
+	negotiated.set(peerA, "/genesis/sync/2", "/gossamer/test/0/sync/2")
+	negotiated.set(peerA, "/genesis/block-announces/1", "/genesis/block-announces/1")
+	negotiated.set(peerB, "/genesis/sync/2", "/genesis/sync/2")
+
+	expected := map[protocol.ID]protocol.ID{
+		"/genesis/sync/2":            "/gossamer/test/0/sync/2",
+		"/genesis/block-announces/1": "/genesis/block-announces/1",
+	}
+	require.Equal(t, expected, negotiated.get(peerA))
+
+	negotiated.delete(peerA)
+	require.Nil(t, negotiated.get(peerA))
+	require.Equal(t, map[protocol.ID]protocol.ID{"/genesis/sync/2": "/genesis/sync/2"}, negotiated.get(peerB))
+}
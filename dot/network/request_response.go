@@ -25,9 +25,10 @@ type RequestResponseProtocol struct {
 	host            *host
 	requestTimeout  time.Duration
 	maxResponseSize uint64
-	protocolID      protocol.ID
-	responseBufMu   sync.Mutex
-	responseBuf     []byte
+	// protocolIDs are the names of the protocol, the primary name first
+	protocolIDs   []protocol.ID
+	responseBufMu sync.Mutex
+	responseBuf   []byte
 }
 
 func (rrp *RequestResponseProtocol) Do(to peer.ID, req, res messages.P2PMessage) error {
@@ -37,10 +38,11 @@ func (rrp *RequestResponseProtocol) Do(to peer.ID, req, res messages.P2PMessage)
 	ctx, cancel := context.WithTimeout(rrp.ctx, rrp.requestTimeout)
 	defer cancel()
 
-	stream, err := rrp.host.p2pHost.NewStream(ctx, to, rrp.protocolID)
+	stream, err := rrp.host.p2pHost.NewStream(ctx, to, rrp.protocolIDs...)
 	if err != nil {
 		return err
 	}
+	rrp.host.negotiatedProtocols.set(to, rrp.protocolIDs[0], stream.Protocol())
 
 	defer func() {
 		err := stream.Close()
@@ -19,6 +19,7 @@
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/internal/metrics"
 	"github.com/ChainSafe/gossamer/lib/common"
+	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
@@ -33,6 +34,8 @@
 
 	// the following are sub-protocols used by the node
 	SyncID          = "/sync/2"
+	StateID         = "/state/2"
+	WarpSyncID      = "/sync/warp"
 	lightID         = "/light/2"
 	blockAnnounceID = "/block-announces/1"
 	transactionsID  = "/transactions/1"
@@ -126,9 +129,11 @@ type Service struct {
 	lightRequestMu sync.RWMutex
 
 	// Service interfaces
-	blockState         BlockState
-	syncer             Syncer
-	transactionHandler TransactionHandler
+	blockState          BlockState
+	syncer              Syncer
+	transactionHandler  TransactionHandler
+	lightRequestHandler LightRequestHandler
+	warpSyncProvider    WarpSyncProvider
 
 	// Configuration options
 	noBootstrap bool
@@ -238,6 +243,16 @@ func (s *Service) SetTransactionHandler(handler TransactionHandler) {
 	s.transactionHandler = handler
 }
 
+// SetLightRequestHandler sets the LightRequestHandler used by the network service
+func (s *Service) SetLightRequestHandler(handler LightRequestHandler) {
+	s.lightRequestHandler = handler
+}
+
+// SetWarpSyncProvider sets the WarpSyncProvider used by the network service
+func (s *Service) SetWarpSyncProvider(provider WarpSyncProvider) {
+	s.warpSyncProvider = provider
+}
+
 // Start starts the network service
 func (s *Service) Start() error {
 	if s.syncer == nil {
@@ -252,12 +267,15 @@ func (s *Service) Start() error {
 		s.ctx, s.cancel = context.WithCancel(context.Background())
 	}
 
-	s.host.registerStreamHandler(s.host.protocolID+SyncID, s.handleSyncStream)
-	s.host.registerStreamHandler(s.host.protocolID+lightID, s.handleLightStream)
+	s.registerStreamHandler(s.ProtocolNames(SyncID), s.handleSyncStream)
+	s.registerStreamHandler(s.ProtocolNames(StateID), s.handleStateStream)
+	s.registerStreamHandler(s.ProtocolNames(WarpSyncID), s.handleWarpSyncStream)
+	s.registerStreamHandler(s.ProtocolNames(lightID), s.handleLightStream)
 
 	// register block announce protocol
+	blockAnnounceNames := s.ProtocolNames(blockAnnounceID)
 	err := s.RegisterNotificationsProtocol(
-		s.host.protocolID+blockAnnounceID,
+		blockAnnounceNames[0],
 		blockAnnounceMsgType,
 		s.getBlockAnnounceHandshake,
 		decodeBlockAnnounceHandshake,
@@ -266,6 +284,7 @@ func (s *Service) Start() error {
 		s.handleBlockAnnounceMessage,
 		nil,
 		maxBlockAnnounceNotificationSize,
+		blockAnnounceNames[1:]...,
 	)
 	if err != nil {
 		logger.Warnf("failed to register notifications protocol with block announce id %s: %s",
@@ -276,8 +295,9 @@ func (s *Service) Start() error {
 	txnBatchHandler := s.createBatchMessageHandler(txnBatch)
 
 	// register transactions protocol
+	transactionsNames := s.ProtocolNames(transactionsID)
 	err = s.RegisterNotificationsProtocol(
-		s.host.protocolID+transactionsID,
+		transactionsNames[0],
 		transactionMsgType,
 		s.getTransactionHandshake,
 		decodeTransactionHandshake,
@@ -286,6 +306,7 @@ func (s *Service) Start() error {
 		s.handleTransactionMessage,
 		txnBatchHandler,
 		maxTransactionsNotificationSize,
+		transactionsNames[1:]...,
 	)
 	if err != nil {
 		logger.Warnf("failed to register notifications protocol with transaction id %s: %s", transactionsID, err)
@@ -310,6 +331,7 @@ func (s *Service) Start() error {
 			prtl.peersData.deleteInboundHandshakeData(peerID)
 			prtl.peersData.deleteOutboundHandshakeData(peerID)
 		}
+		s.host.negotiatedProtocols.delete(peerID)
 	}
 
 	// log listening addresses to console
@@ -500,6 +522,8 @@ func (s *Service) Stop() error {
 
 // RegisterNotificationsProtocol registers a protocol with the network service with the given handler
 // messageID is a user-defined message ID for the message passed over this protocol.
+// protocolID is the primary name of the protocol, and the fallback protocol IDs are its legacy
+// names, used with the peers which don't support the primary name.
 func (s *Service) RegisterNotificationsProtocol(
 	protocolID protocol.ID,
 	messageID MessageType,
@@ -510,6 +534,7 @@ func (s *Service) RegisterNotificationsProtocol(
 	messageHandler NotificationsMessageHandler,
 	batchHandler NotificationsMessageBatchHandler,
 	maxSize uint64,
+	fallbackProtocolIDs ...protocol.ID,
 ) error {
 	s.notificationsMu.Lock()
 	defer s.notificationsMu.Unlock()
@@ -518,17 +543,18 @@ func (s *Service) RegisterNotificationsProtocol(
 		return errors.New("notifications protocol with message type already exists")
 	}
 
-	np := newNotificationsProtocol(protocolID, handshakeGetter, handshakeDecoder, handshakeValidator, maxSize)
+	np := newNotificationsProtocol(protocolID, fallbackProtocolIDs, handshakeGetter, handshakeDecoder,
+		handshakeValidator, maxSize)
 	s.notificationsProtocols[messageID] = np
 	decoder := createDecoder(np, handshakeDecoder, messageDecoder)
 	handlerWithValidate := s.createNotificationsMessageHandler(np, messageHandler, batchHandler)
 
-	s.host.registerStreamHandler(protocolID, func(stream libp2pnetwork.Stream) {
-		logger.Tracef("received stream using sub-protocol %s", protocolID)
+	s.registerStreamHandler(np.protocolIDs(), func(stream libp2pnetwork.Stream) {
+		logger.Tracef("received stream using sub-protocol %s", stream.Protocol())
 		s.readStream(stream, decoder, handlerWithValidate, maxSize)
 	})
 
-	logger.Infof("registered notifications sub-protocol %s", protocolID)
+	logger.Infof("registered notifications sub-protocol %s with fallbacks %v", protocolID, fallbackProtocolIDs)
 	return nil
 }
 
@@ -562,6 +588,26 @@ func (s *Service) GossipMessage(msg NotificationsMessage) {
 	logger.Errorf("message type %d not supported by any notifications protocol", msg.Type())
 }
 
+// PropagateMessage gossips the given message to the connected peers, waiting for
+// the message to be sent, and returns the peers it was sent to. The peers which
+// were already sent the message are not sent it again.
+func (s *Service) PropagateMessage(msg NotificationsMessage) (sentTo []peer.ID) {
+	if s.host == nil || msg == nil || s.IsStopped() {
+		return nil
+	}
+
+	s.notificationsMu.RLock()
+	prtl := s.notificationsProtocols[msg.Type()]
+	s.notificationsMu.RUnlock()
+
+	if prtl == nil {
+		logger.Errorf("message type %d not supported by any notifications protocol", msg.Type())
+		return nil
+	}
+
+	return s.broadcastAndWait(prtl, peer.ID(""), msg)
+}
+
 // SendMessage sends a message to the given peer
 func (s *Service) SendMessage(to peer.ID, msg NotificationsMessage) error {
 	s.notificationsMu.Lock()
@@ -587,13 +633,12 @@ func (s *Service) SendMessage(to peer.ID, msg NotificationsMessage) error {
 func (s *Service) GetRequestResponseProtocol(subprotocol string, requestTimeout time.Duration,
 	maxResponseSize uint64) *RequestResponseProtocol {
 
-	protocolID := s.host.protocolID + protocol.ID(subprotocol)
 	return &RequestResponseProtocol{
 		ctx:             s.ctx,
 		host:            s.host,
 		requestTimeout:  requestTimeout,
 		maxResponseSize: maxResponseSize,
-		protocolID:      protocolID,
+		protocolIDs:     s.ProtocolNames(protocol.ID(subprotocol)),
 		responseBuf:     make([]byte, maxResponseSize),
 		responseBufMu:   sync.Mutex{},
 	}
@@ -661,6 +706,42 @@ func (s *Service) RemoveReservedPeers(addrs ...string) error {
 	return s.host.removeReservedPeers(addrs...)
 }
 
+// PutValue stores the value with the given key in the DHT.
+func (s *Service) PutValue(ctx context.Context, key string, value []byte) error {
+	if s.host.discovery.dht == nil {
+		return ErrDHTNotStarted
+	}
+	return s.host.discovery.dht.PutValue(ctx, key, value)
+}
+
+// GetValue returns the value with the given key from the DHT.
+func (s *Service) GetValue(ctx context.Context, key string) ([]byte, error) {
+	if s.host.discovery.dht == nil {
+		return nil, ErrDHTNotStarted
+	}
+	return s.host.discovery.dht.GetValue(ctx, key)
+}
+
+// SignWithHostKey signs the message with the private key of the host, and returns the
+// signature with the protobuf encoded public key of the host.
+func (s *Service) SignWithHostKey(msg []byte) (signature, publicKey []byte, err error) {
+	privateKey := s.host.p2pHost.Peerstore().PrivKey(s.host.id())
+	if privateKey == nil {
+		return nil, nil, fmt.Errorf("private key of host %s not found", s.host.id())
+	}
+
+	signature, err = privateKey.Sign(msg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("signing message: %w", err)
+	}
+
+	publicKey, err = libp2pcrypto.MarshalPublicKey(privateKey.GetPublic())
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding public key: %w", err)
+	}
+	return signature, publicKey, nil
+}
+
 // NodeRoles Returns the roles the node is running as.
 func (s *Service) NodeRoles() common.NetworkRole {
 	return s.cfg.Roles
@@ -19,6 +19,7 @@ type BlockState interface {
 	BestBlockHeader() (*types.Header, error)
 	GenesisHash() common.Hash
 	GetHighestFinalisedHeader() (*types.Header, error)
+	GetHeaderByNumber(num uint) (*types.Header, error)
 }
 
 // Syncer is implemented by the syncing service
@@ -34,6 +35,9 @@ type Syncer interface {
 
 	// CreateBlockResponse is called upon receipt of a BlockRequestMessage to create the response
 	CreateBlockResponse(peer.ID, *messages.BlockRequestMessage) (*messages.BlockResponseMessage, error)
+
+	// CreateStateResponse is called upon receipt of a StateRequest to create the response
+	CreateStateResponse(peer.ID, *messages.StateRequest) (*messages.StateResponse, error)
 }
 
 // TransactionHandler is the interface used by the transactions sub-protocol
@@ -42,6 +46,25 @@ type TransactionHandler interface {
 	TransactionsCount() int
 }
 
+// LightRequestHandler is the interface used to serve the light client requests
+type LightRequestHandler interface {
+	// GetReadProofAt returns the storage proof of the given keys at the given block.
+	GetReadProofAt(block common.Hash, keys [][]byte) (common.Hash, [][]byte, error)
+	// GetChildReadProofAt returns the storage proof of the given keys in the child trie
+	// stored at the given prefixed child storage key, at the given block.
+	GetChildReadProofAt(block common.Hash, childStorageKey []byte, keys [][]byte) ([][]byte, error)
+	// GetExecutionProofAt executes the runtime method at the given block and returns
+	// the storage proof of the state read during the execution.
+	GetExecutionProofAt(block common.Hash, method string, data []byte) ([][]byte, error)
+}
+
+// WarpSyncProvider is the interface used to serve the warp sync requests
+type WarpSyncProvider interface {
+	// GenerateWarpSyncProof returns the proof of the authority set changes
+	// following the given finalised block.
+	GenerateWarpSyncProof(begin common.Hash) (*messages.WarpSyncProof, error)
+}
+
 // PeerSetHandler is the interface used by the connection manager to handle peerset.
 type PeerSetHandler interface {
 	Start(context.Context)
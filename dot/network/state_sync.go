@@ -0,0 +1,58 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// handleStateStream handles streams with the <protocol-id>/state/2 protocol ID
+func (s *Service) handleStateStream(stream libp2pnetwork.Stream) {
+	if stream == nil {
+		return
+	}
+
+	s.readStream(stream, decodeStateRequestMessage, s.handleStateRequestMessage, MaxBlockResponseSize)
+}
+
+func decodeStateRequestMessage(in []byte, _ peer.ID, _ bool) (messages.P2PMessage, error) {
+	msg := new(messages.StateRequest)
+	err := msg.Decode(in)
+	return msg, err
+}
+
+// handleStateRequestMessage handles inbound state request streams
+func (s *Service) handleStateRequestMessage(stream libp2pnetwork.Stream, msg messages.P2PMessage) error {
+	if msg == nil {
+		return nil
+	}
+
+	defer func() {
+		err := stream.Close()
+		if err != nil && err.Error() != ErrStreamReset.Error() {
+			logger.Warnf("failed to close stream: %s", err)
+		}
+	}()
+
+	req, ok := msg.(*messages.StateRequest)
+	if !ok {
+		return nil
+	}
+
+	resp, err := s.syncer.CreateStateResponse(stream.Conn().RemotePeer(), req)
+	if err != nil {
+		logger.Debugf("cannot create response for state request: %s", err)
+		return nil
+	}
+
+	err = s.host.writeToStream(stream, resp)
+	if err != nil {
+		logger.Debugf("failed to send StateResponse message to peer %s: %s", stream.Conn().RemotePeer(), err)
+		return err
+	}
+
+	return nil
+}
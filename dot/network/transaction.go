@@ -88,7 +88,7 @@ func decodeTransactionHandshake(_ []byte) (Handshake, error) {
 }
 
 func (s *Service) startTxnBatchProcessing(txnBatchCh chan *batchMessage, slotDuration time.Duration) {
-	protocolID := s.host.protocolID + transactionsID
+	protocolIDs := s.ProtocolNames(transactionsID)
 	ticker := time.NewTicker(slotDuration)
 	defer ticker.Stop()
 
@@ -107,7 +107,7 @@ func (s *Service) startTxnBatchProcessing(txnBatchCh chan *batchMessage, slotDur
 					propagate, err := s.handleTransactionMessage(txnMsg.peer, txnMsg.msg)
 					if err != nil {
 						logger.Warnf("could not handle transaction message: %s", err)
-						s.host.closeProtocolStream(protocolID, txnMsg.peer)
+						s.host.closeProtocolStream(txnMsg.peer, protocolIDs...)
 						continue
 					}
 
@@ -120,7 +120,7 @@ func (s *Service) startTxnBatchProcessing(txnBatchCh chan *batchMessage, slotDur
 
 					hasSeen, err := s.gossip.hasSeen(txnMsg.msg)
 					if err != nil {
-						s.host.closeProtocolStream(protocolID, txnMsg.peer)
+						s.host.closeProtocolStream(txnMsg.peer, protocolIDs...)
 						logger.Debugf("could not check if message was seen before: %s", err)
 						continue
 					}
@@ -26,6 +26,8 @@
 	MaxGrandpaNotificationSize       uint64 = 1024 * 1024      // 1mb
 	maxTransactionsNotificationSize  uint64 = 1024 * 1024 * 16 // 16mb
 	maxBlockAnnounceNotificationSize uint64 = 1024 * 1024      // 1mb
+	// MaxBeefyNotificationSize is maximum size for a beefy notification message.
+	MaxBeefyNotificationSize uint64 = 1024 * 1024 // 1mb
 
 )
 
@@ -0,0 +1,63 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// handleWarpSyncStream handles streams with the <protocol-id>/sync/warp protocol ID
+func (s *Service) handleWarpSyncStream(stream libp2pnetwork.Stream) {
+	if stream == nil {
+		return
+	}
+
+	s.readStream(stream, decodeWarpSyncRequestMessage, s.handleWarpSyncRequestMessage, MaxBlockResponseSize)
+}
+
+func decodeWarpSyncRequestMessage(in []byte, _ peer.ID, _ bool) (messages.P2PMessage, error) {
+	msg := new(messages.WarpSyncRequest)
+	err := msg.Decode(in)
+	return msg, err
+}
+
+// handleWarpSyncRequestMessage handles inbound warp sync request streams
+func (s *Service) handleWarpSyncRequestMessage(stream libp2pnetwork.Stream, msg messages.P2PMessage) error {
+	if msg == nil {
+		return nil
+	}
+
+	defer func() {
+		err := stream.Close()
+		if err != nil && err.Error() != ErrStreamReset.Error() {
+			logger.Warnf("failed to close stream: %s", err)
+		}
+	}()
+
+	req, ok := msg.(*messages.WarpSyncRequest)
+	if !ok {
+		return nil
+	}
+
+	if s.warpSyncProvider == nil {
+		logger.Debugf("cannot create response for warp sync request: %s", errNoWarpSyncProvider)
+		return nil
+	}
+
+	proof, err := s.warpSyncProvider.GenerateWarpSyncProof(req.Begin)
+	if err != nil {
+		logger.Debugf("cannot create response for warp sync request: %s", err)
+		return nil
+	}
+
+	err = s.host.writeToStream(stream, proof)
+	if err != nil {
+		logger.Debugf("failed to send WarpSyncProof message to peer %s: %s", stream.Conn().RemotePeer(), err)
+		return err
+	}
+
+	return nil
+}
@@ -132,6 +132,11 @@ func (nodeBuilder) initNode(config *cfg.Config) error {
 		return fmt.Errorf("failed to parse log level: %w", err)
 	}
 	logger.Patch(log.SetLevel(globalLogLevel))
+
+	if err := patchLogFormat(config.LogFormat); err != nil {
+		return err
+	}
+
 	logger.Infof(
 		"🕸️ initialising node with name %s, id %s, base path %s and chain-spec %s...",
 		config.Name, config.ID, config.BasePath, config.ChainSpec)
@@ -144,7 +149,7 @@ func (nodeBuilder) initNode(config *cfg.Config) error {
 
 	if !gen.IsRaw() {
 		// genesis is human-readable, convert to raw
-		err = gen.ToRaw()
+		err = buildRawGenesis(gen)
 		if err != nil {
 			return fmt.Errorf("failed to convert genesis-spec to raw genesis: %w", err)
 		}
@@ -172,13 +177,15 @@ func (nodeBuilder) initNode(config *cfg.Config) error {
 		return fmt.Errorf("cannot parse log level: %w", err)
 	}
 
+	prunerConfig, err := pruner.NewConfig(config.Pruning, config.RetainBlocks)
+	if err != nil {
+		return fmt.Errorf("parsing pruning configuration: %w", err)
+	}
+
 	stateConfig := state.Config{
-		Path:     config.BasePath,
-		LogLevel: stateLogLevel,
-		PrunerCfg: pruner.Config{
-			Mode:           config.Pruning,
-			RetainedBlocks: config.RetainBlocks,
-		},
+		Path:      config.BasePath,
+		LogLevel:  stateLogLevel,
+		PrunerCfg: prunerConfig,
 		Telemetry: telemetryMailer,
 		Metrics:   metrics.NewIntervalConfig(config.PrometheusExternal),
 	}
@@ -266,6 +273,10 @@ func newNode(config *cfg.Config,
 
 	logger.Patch(log.SetLevel(globalLogLevel))
 
+	if err := patchLogFormat(config.LogFormat); err != nil {
+		return nil, err
+	}
+
 	logger.Infof(
 		"🕸️ initialising node services with global configuration name %s, id %s and base path %s...",
 		config.Name, config.ID, config.BasePath)
@@ -381,6 +392,8 @@ func newNode(config *cfg.Config,
 	if networkSrvc != nil {
 		networkSrvc.SetSyncer(syncer)
 		networkSrvc.SetTransactionHandler(coreSrvc)
+		networkSrvc.SetLightRequestHandler(coreSrvc)
+		networkSrvc.SetWarpSyncProvider(fg)
 	}
 	nodeSrvcs = append(nodeSrvcs, syncer)
 
@@ -390,6 +403,26 @@ func newNode(config *cfg.Config,
 	}
 	nodeSrvcs = append(nodeSrvcs, bp)
 
+	mmrSrvc, err := createMMRService(config, stateSrvc, ns)
+	if err != nil {
+		return nil, fmt.Errorf("creating mmr service: %w", err)
+	}
+	nodeSrvcs = append(nodeSrvcs, mmrSrvc)
+
+	if networkSrvc != nil {
+		authorityDiscoverySrvc, err := createAuthorityDiscoveryService(config, ks, stateSrvc, networkSrvc)
+		if err != nil {
+			return nil, fmt.Errorf("creating authority discovery service: %w", err)
+		}
+		nodeSrvcs = append(nodeSrvcs, authorityDiscoverySrvc)
+
+		beefySrvc, err := createBEEFYService(config, ks, stateSrvc, networkSrvc)
+		if err != nil {
+			return nil, fmt.Errorf("creating beefy service: %w", err)
+		}
+		nodeSrvcs = append(nodeSrvcs, beefySrvc)
+	}
+
 	// check if rpc service is enabled
 	if enabled := config.RPC.IsRPCEnabled() || config.RPC.IsWSEnabled(); enabled {
 		var rpcSrvc *rpc.HTTPServer
@@ -458,6 +491,22 @@ func setupTelemetry(config *cfg.Config, genesisData *genesis.Data) (mailer Telem
 		telemetryEndpoints, telemetryLogger)
 }
 
+// patchLogFormat sets the format of the global logger and of its child loggers.
+// The format is left unchanged if empty.
+func patchLogFormat(logFormat string) error {
+	if logFormat == "" {
+		return nil
+	}
+
+	format, err := log.ParseFormat(logFormat)
+	if err != nil {
+		return fmt.Errorf("cannot parse log format: %w", err)
+	}
+
+	log.Patch(log.SetFormat(format))
+	return nil
+}
+
 // stores the global node name to reuse
 func storeGlobalNodeName(name, basepath string) (err error) {
 	db, err := database.LoadDatabase(basepath, false)
@@ -68,12 +68,17 @@ func (c *DotUpCodecRequest) Method() (string, error) {
 		if len(parts) < 2 {
 			return "", fmt.Errorf("rpc error method %s not found", m)
 		}
-		service, method := parts[0], parts[1]
-		r, n := utf8.DecodeRuneInString(method) // get the first rune, and it's length
-		if unicode.IsLower(r) {
-			upMethod := service + "." + string(unicode.ToUpper(r)) + method[n:]
-			return upMethod, err
+		// the methods of the new JSON-RPC API are versioned, such as chainHead_v1_follow,
+		// and are mapped to service methods prefixed with their version, such as V1Follow
+		service, method := parts[0], ""
+		for _, part := range parts[1:] {
+			r, n := utf8.DecodeRuneInString(part) // get the first rune, and it's length
+			if !unicode.IsLower(r) {
+				return m, err
+			}
+			method += string(unicode.ToUpper(r)) + part[n:]
 		}
+		return service + "." + method, err
 	}
 	return m, err
 }
@@ -37,6 +37,13 @@
 		),
 		expected: "chain.GetBlockHash",
 	},
+	{
+		rpcDataBody: fmt.Sprintf(
+			`{"jsonrpc":"2.0","method":"%s","params":[true],"id":1}`,
+			"chainHead_v1_follow",
+		),
+		expected: "chainHead.V1Follow",
+	},
 }
 
 func TestAliasesMethodReplace(t *testing.T) {
@@ -8,6 +8,7 @@
 	"fmt"
 	"net"
 	"strings"
+	"unicode"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
 	"github.com/go-playground/validator/v10"
@@ -44,8 +45,25 @@ func snakeCaseFormat(method string) (string, error) {
 	}
 
 	service, funcName := parts[0], parts[1]
-	funcName = strings.ToLower(string(funcName[0])) + funcName[1:]
-	return strings.Join([]string{service, funcName}, "_"), nil
+	return strings.Join([]string{service, rpcMethodName(funcName)}, "_"), nil
+}
+
+// rpcMethodName returns the RPC name of the given service method name. The version prefix
+// of the new JSON-RPC API methods is separated by an underscore, e.g. V1Follow becomes v1_follow.
+func rpcMethodName(funcName string) string {
+	if funcName == "" {
+		return funcName
+	}
+
+	versionEnd := 1
+	for versionEnd < len(funcName) && unicode.IsDigit(rune(funcName[versionEnd])) {
+		versionEnd++
+	}
+	if funcName[0] == 'V' && versionEnd > 1 && versionEnd < len(funcName) {
+		return "v" + funcName[1:versionEnd] + "_" + rpcMethodName(funcName[versionEnd:])
+	}
+
+	return strings.ToLower(string(funcName[0])) + funcName[1:]
 }
 
 func rpcValidator(cfg *HTTPServerConfig, validate *validator.Validate) func(r *rpc.RequestInfo, i interface{}) error {
@@ -118,6 +118,16 @@ func (h *HTTPServer) RegisterModules(mods []string) {
 			srvc = modules.NewSyncStateModule(h.serverConfig.SyncStateAPI)
 		case "payment":
 			srvc = modules.NewPaymentModule(h.serverConfig.BlockAPI)
+		case "chainHead":
+			srvc = modules.NewChainHeadModule()
+		case "chainSpec":
+			srvc = modules.NewChainSpecModule(h.serverConfig.SystemAPI, h.serverConfig.BlockAPI)
+		case "transaction":
+			srvc = modules.NewTransactionModule()
+		case "archive":
+			srvc = modules.NewArchiveModule(h.serverConfig.BlockAPI, h.serverConfig.StorageAPI)
+		case "mmr":
+			srvc = modules.NewMmrModule(h.serverConfig.BlockAPI, h.serverConfig.StorageAPI)
 		default:
 			h.logger.Warn("Unrecognised module: " + mod)
 			continue
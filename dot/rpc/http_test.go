@@ -28,7 +28,6 @@
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
-	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -349,11 +348,6 @@ func newCoreServiceTest(t *testing.T) *core.Service {
 	rtCfg.CodeHash, err = cfg.StorageState.(*state.InmemoryStorageState).LoadCodeHash(nil)
 	require.NoError(t, err)
 
-	nodeStorage := runtime.NodeStorage{
-		BaseDB: stateSrvc.Base,
-	}
-	rtCfg.NodeStorage = nodeStorage
-
 	cfg.Runtime, err = wazero_runtime.NewRuntimeFromGenesis(rtCfg)
 	require.NoError(t, err)
 
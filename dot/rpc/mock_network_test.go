@@ -14,6 +14,7 @@
 
 	network "github.com/ChainSafe/gossamer/dot/network"
 	peerset "github.com/ChainSafe/gossamer/dot/peerset"
+	common "github.com/ChainSafe/gossamer/lib/common"
 	peer "github.com/libp2p/go-libp2p/core/peer"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -67,6 +68,34 @@ func (mr *MockNetworkMockRecorder) IsSynced() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSynced", reflect.TypeOf((*MockNetwork)(nil).IsSynced))
 }
 
+// Peers mocks base method.
+func (m *MockNetwork) Peers() []common.PeerInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Peers")
+	ret0, _ := ret[0].([]common.PeerInfo)
+	return ret0
+}
+
+// Peers indicates an expected call of Peers.
+func (mr *MockNetworkMockRecorder) Peers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peers", reflect.TypeOf((*MockNetwork)(nil).Peers))
+}
+
+// PropagateMessage mocks base method.
+func (m *MockNetwork) PropagateMessage(arg0 network.NotificationsMessage) []peer.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PropagateMessage", arg0)
+	ret0, _ := ret[0].([]peer.ID)
+	return ret0
+}
+
+// PropagateMessage indicates an expected call of PropagateMessage.
+func (mr *MockNetworkMockRecorder) PropagateMessage(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropagateMessage", reflect.TypeOf((*MockNetwork)(nil).PropagateMessage), arg0)
+}
+
 // ReportPeer mocks base method.
 func (m *MockNetwork) ReportPeer(arg0 peerset.ReputationChange, arg1 peer.ID) {
 	m.ctrl.T.Helper()
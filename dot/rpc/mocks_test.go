@@ -105,7 +105,7 @@ func (mr *MockTransactionStateAPIMockRecorder) AddToPool(arg0 any) *gomock.Call
 }
 
 // FreeStatusNotifierChannel mocks base method.
-func (m *MockTransactionStateAPI) FreeStatusNotifierChannel(arg0 chan transaction.Status) {
+func (m *MockTransactionStateAPI) FreeStatusNotifierChannel(arg0 chan transaction.StatusNotification) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "FreeStatusNotifierChannel", arg0)
 }
@@ -117,10 +117,10 @@ func (mr *MockTransactionStateAPIMockRecorder) FreeStatusNotifierChannel(arg0 an
 }
 
 // GetStatusNotifierChannel mocks base method.
-func (m *MockTransactionStateAPI) GetStatusNotifierChannel(arg0 types.Extrinsic) chan transaction.Status {
+func (m *MockTransactionStateAPI) GetStatusNotifierChannel(arg0 types.Extrinsic) chan transaction.StatusNotification {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStatusNotifierChannel", arg0)
-	ret0, _ := ret[0].(chan transaction.Status)
+	ret0, _ := ret[0].(chan transaction.StatusNotification)
 	return ret0
 }
 
@@ -143,3 +143,17 @@ func (mr *MockTransactionStateAPIMockRecorder) Pending() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockTransactionStateAPI)(nil).Pending))
 }
+
+// RemoveExtrinsics mocks base method.
+func (m *MockTransactionStateAPI) RemoveExtrinsics(arg0 []common.Hash) []common.Hash {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveExtrinsics", arg0)
+	ret0, _ := ret[0].([]common.Hash)
+	return ret0
+}
+
+// RemoveExtrinsics indicates an expected call of RemoveExtrinsics.
+func (mr *MockTransactionStateAPIMockRecorder) RemoveExtrinsics(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExtrinsics", reflect.TypeOf((*MockTransactionStateAPI)(nil).RemoveExtrinsics), arg0)
+}
@@ -51,7 +51,7 @@ func NewMockAnyBlockAPI(ctrl *gomock.Controller) *modulesmocks.MockBlockAPI {
 // NewMockAnyAPI creates and return an rpc CoreAPI interface mock
 func NewMockAnyAPI(ctrl *gomock.Controller) *modulesmocks.MockCoreAPI {
 	m := modulesmocks.NewMockCoreAPI(ctrl)
-	m.EXPECT().InsertKey(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	m.EXPECT().InsertKey(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	m.EXPECT().HasKey(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
 	m.EXPECT().GetRuntimeVersion(gomock.Any()).
 		Return(runtime.Version{SpecName: []byte(`mock-spec`)}, nil).AnyTimes()
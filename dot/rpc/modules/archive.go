@@ -0,0 +1,214 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// maxArchiveStorageQueryItems is the maximum number of items of an archive storage query,
+// the items exceeding it are discarded.
+const maxArchiveStorageQueryItems = 1024
+
+// ArchiveHashRequest holds the hash of the block of an archive request
+type ArchiveHashRequest struct {
+	Hash common.Hash `json:"hash"`
+}
+
+// ArchiveHeightRequest holds the height of an archive_v1_hashByHeight request
+type ArchiveHeightRequest struct {
+	Height uint `json:"height"`
+}
+
+// ArchiveCallRequest holds the parameters of an archive_v1_call request
+type ArchiveCallRequest struct {
+	Hash           common.Hash `json:"hash"`
+	Function       string      `json:"function"`
+	CallParameters string      `json:"callParameters"`
+}
+
+// ArchiveStorageRequest holds the parameters of an archive_v1_storage request
+type ArchiveStorageRequest struct {
+	Hash      common.Hash        `json:"hash"`
+	Items     []StorageQueryItem `json:"items"`
+	ChildTrie *string            `json:"childTrie"`
+}
+
+// ArchiveCallResponse is the result of an archive_v1_call request
+type ArchiveCallResponse struct {
+	Success bool   `json:"success"`
+	Value   string `json:"value,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ArchiveStorageResponse is the result of an archive_v1_storage request
+type ArchiveStorageResponse struct {
+	Result         []StorageResultItem `json:"result"`
+	DiscardedItems uint                `json:"discardedItems"`
+}
+
+// ArchiveModule is an RPC module of the new JSON-RPC API providing
+// access to the finalised and non-finalised blocks of the chain.
+type ArchiveModule struct {
+	blockAPI   BlockAPI
+	storageAPI StorageAPI
+}
+
+// NewArchiveModule creates a new archive module.
+func NewArchiveModule(blockAPI BlockAPI, storageAPI StorageAPI) *ArchiveModule {
+	return &ArchiveModule{
+		blockAPI:   blockAPI,
+		storageAPI: storageAPI,
+	}
+}
+
+// V1Body returns the hex encoded extrinsics of the given block, or null if the block is unknown.
+func (am *ArchiveModule) V1Body(_ *http.Request, req *ArchiveHashRequest, res *[]string) error {
+	body, err := am.blockAPI.GetBlockBody(req.Hash)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting block body: %w", err)
+	}
+
+	extrinsics, err := body.AsEncodedExtrinsics()
+	if err != nil {
+		return fmt.Errorf("encoding extrinsics: %w", err)
+	}
+
+	*res = make([]string, len(extrinsics))
+	for i, extrinsic := range extrinsics {
+		(*res)[i] = extrinsic.String()
+	}
+	return nil
+}
+
+// V1Call calls the given runtime function with the given hex encoded parameters at the given block,
+// or returns null if the block is unknown.
+func (am *ArchiveModule) V1Call(_ *http.Request, req *ArchiveCallRequest, res **ArchiveCallResponse) error {
+	_, err := am.blockAPI.GetHeader(req.Hash)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting header: %w", err)
+	}
+
+	callParameters, err := common.HexToBytes(req.CallParameters)
+	if err != nil {
+		return fmt.Errorf("decoding call parameters: %w", err)
+	}
+
+	rt, release, err := RuntimeAt(am.blockAPI, am.storageAPI, req.Hash)
+	if err != nil {
+		return fmt.Errorf("getting runtime: %w", err)
+	}
+	defer release()
+
+	output, err := rt.Exec(req.Function, callParameters)
+	if err != nil {
+		*res = &ArchiveCallResponse{Error: err.Error()}
+		return nil
+	}
+
+	*res = &ArchiveCallResponse{
+		Success: true,
+		Value:   common.BytesToHex(output),
+	}
+	return nil
+}
+
+// V1FinalizedHeight returns the number of the highest finalised block.
+func (am *ArchiveModule) V1FinalizedHeight(_ *http.Request, _ *EmptyRequest, res *uint) error {
+	finalisedHash, err := am.blockAPI.GetHighestFinalisedHash()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised hash: %w", err)
+	}
+
+	header, err := am.blockAPI.GetHeader(finalisedHash)
+	if err != nil {
+		return fmt.Errorf("getting header: %w", err)
+	}
+
+	*res = header.Number
+	return nil
+}
+
+// V1GenesisHash returns the hash of the genesis block.
+func (am *ArchiveModule) V1GenesisHash(_ *http.Request, _ *EmptyRequest, res *string) error {
+	genesisHash, err := am.blockAPI.GetHashByNumber(0)
+	if err != nil {
+		return fmt.Errorf("getting genesis hash: %w", err)
+	}
+
+	*res = genesisHash.String()
+	return nil
+}
+
+// V1HashByHeight returns the hashes of the blocks with the given number.
+func (am *ArchiveModule) V1HashByHeight(_ *http.Request, req *ArchiveHeightRequest, res *[]string) error {
+	hashes, err := am.blockAPI.GetHashesByNumber(req.Height)
+	if err != nil {
+		return fmt.Errorf("getting hashes by number: %w", err)
+	}
+
+	*res = make([]string, len(hashes))
+	for i, hash := range hashes {
+		(*res)[i] = hash.String()
+	}
+	return nil
+}
+
+// V1Header returns the hex encoded SCALE encoded header of the given block, or null if the block is unknown.
+func (am *ArchiveModule) V1Header(_ *http.Request, req *ArchiveHashRequest, res **string) error {
+	header, err := am.blockAPI.GetHeader(req.Hash)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting header: %w", err)
+	}
+
+	encodedHeader, err := scale.Marshal(*header)
+	if err != nil {
+		return fmt.Errorf("encoding header: %w", err)
+	}
+
+	hexHeader := common.BytesToHex(encodedHeader)
+	*res = &hexHeader
+	return nil
+}
+
+// V1Storage returns the storage items matching the given query items at the given block,
+// or returns null if the block is unknown.
+func (am *ArchiveModule) V1Storage(_ *http.Request, req *ArchiveStorageRequest, res **ArchiveStorageResponse) error {
+	_, err := am.blockAPI.GetHeader(req.Hash)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting header: %w", err)
+	}
+
+	items := req.Items
+	var discardedItems uint
+	if len(items) > maxArchiveStorageQueryItems {
+		discardedItems = uint(len(items) - maxArchiveStorageQueryItems)
+		items = items[:maxArchiveStorageQueryItems]
+	}
+
+	results, err := QueryStorage(am.storageAPI, req.Hash, items, req.ChildTrie)
+	if err != nil {
+		return fmt.Errorf("querying storage: %w", err)
+	}
+
+	*res = &ArchiveStorageResponse{
+		Result:         results,
+		DiscardedItems: discardedItems,
+	}
+	return nil
+}
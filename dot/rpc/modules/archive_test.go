@@ -0,0 +1,187 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestArchiveModule_V1Body(t *testing.T) {
+	errTest := errors.New("test error")
+	hash := common.Hash{1}
+
+	tests := map[string]struct {
+		body      *types.Body
+		err       error
+		expErrMsg string
+		expRes    []string
+	}{
+		"unknown_block": {
+			err: database.ErrNotFound,
+		},
+		"GetBlockBody_error": {
+			err:       errTest,
+			expErrMsg: "getting block body: test error",
+		},
+		"happy_path": {
+			body:   types.NewBody([]types.Extrinsic{{1, 2}, {3}}),
+			expRes: []string{"0x080102", "0x0403"},
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+			mockBlockAPI.EXPECT().GetBlockBody(hash).Return(tt.body, tt.err)
+			am := NewArchiveModule(mockBlockAPI, nil)
+
+			var res []string
+			err := am.V1Body(nil, &ArchiveHashRequest{Hash: hash}, &res)
+			if tt.expErrMsg != "" {
+				assert.EqualError(t, err, tt.expErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expRes, res)
+		})
+	}
+}
+
+func TestArchiveModule_V1FinalizedHeight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	finalisedHash := common.Hash{1}
+	mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPI.EXPECT().GetHighestFinalisedHash().Return(finalisedHash, nil)
+	mockBlockAPI.EXPECT().GetHeader(finalisedHash).Return(&types.Header{Number: 21}, nil)
+	am := NewArchiveModule(mockBlockAPI, nil)
+
+	var res uint
+	err := am.V1FinalizedHeight(nil, &EmptyRequest{}, &res)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(21), res)
+}
+
+func TestArchiveModule_V1HashByHeight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	hashes := []common.Hash{{1}, {2}}
+	mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPI.EXPECT().GetHashesByNumber(uint(3)).Return(hashes, nil)
+	am := NewArchiveModule(mockBlockAPI, nil)
+
+	var res []string
+	err := am.V1HashByHeight(nil, &ArchiveHeightRequest{Height: 3}, &res)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{hashes[0].String(), hashes[1].String()}, res)
+}
+
+func TestArchiveModule_V1Header(t *testing.T) {
+	header := types.NewHeader(common.Hash{1}, common.Hash{2}, common.Hash{3}, 4, types.NewDigest())
+	encodedHeader, err := scale.Marshal(*header)
+	require.NoError(t, err)
+	hexHeader := common.BytesToHex(encodedHeader)
+
+	tests := map[string]struct {
+		header *types.Header
+		err    error
+		expRes *string
+	}{
+		"unknown_block": {
+			err: database.ErrNotFound,
+		},
+		"happy_path": {
+			header: header,
+			expRes: &hexHeader,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			hash := common.Hash{1}
+			mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+			mockBlockAPI.EXPECT().GetHeader(hash).Return(tt.header, tt.err)
+			am := NewArchiveModule(mockBlockAPI, nil)
+
+			var res *string
+			err := am.V1Header(nil, &ArchiveHashRequest{Hash: hash}, &res)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expRes, res)
+		})
+	}
+}
+
+func TestArchiveModule_V1Storage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	hash := common.Hash{1}
+	root := common.Hash{2}
+	mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPI.EXPECT().GetHeader(hash).Return(&types.Header{}, nil)
+	mockStorageAPI := mocks.NewMockStorageAPI(ctrl)
+	mockStorageAPI.EXPECT().GetStateRootFromBlock(&hash).Return(&root, nil)
+	mockStorageAPI.EXPECT().GetStorage(&root, []byte{1}).Return([]byte{5}, nil)
+	mockStorageAPI.EXPECT().GetKeysWithPrefix(&root, []byte{2}).Return([][]byte{{2, 1}, {2, 2}}, nil)
+	mockStorageAPI.EXPECT().GetStorage(&root, []byte{2, 1}).Return([]byte{6}, nil)
+	mockStorageAPI.EXPECT().GetStorage(&root, []byte{2, 2}).Return(nil, nil)
+	am := NewArchiveModule(mockBlockAPI, mockStorageAPI)
+
+	req := &ArchiveStorageRequest{
+		Hash: hash,
+		Items: []StorageQueryItem{
+			{Key: "0x01", Type: StorageQueryTypeValue},
+			{Key: "0x02", Type: StorageQueryTypeDescendantsHashes},
+		},
+	}
+	var res *ArchiveStorageResponse
+	err := am.V1Storage(nil, req, &res)
+	require.NoError(t, err)
+
+	value := "0x05"
+	valueHash, err := common.Blake2bHash([]byte{6})
+	require.NoError(t, err)
+	hexHash := valueHash.String()
+	expected := &ArchiveStorageResponse{
+		Result: []StorageResultItem{
+			{Key: "0x01", Value: &value},
+			{Key: "0x0201", Hash: &hexHash},
+		},
+	}
+	assert.Equal(t, expected, res)
+}
+
+func TestArchiveModule_V1Storage_unsupportedType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	hash := common.Hash{1}
+	root := common.Hash{2}
+	mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPI.EXPECT().GetHeader(hash).Return(&types.Header{}, nil)
+	mockStorageAPI := mocks.NewMockStorageAPI(ctrl)
+	mockStorageAPI.EXPECT().GetStateRootFromBlock(&hash).Return(&root, nil)
+	am := NewArchiveModule(mockBlockAPI, mockStorageAPI)
+
+	req := &ArchiveStorageRequest{
+		Hash:  hash,
+		Items: []StorageQueryItem{{Key: "0x01", Type: StorageQueryTypeClosestDescendantMerkleValue}},
+	}
+	var res *ArchiveStorageResponse
+	err := am.V1Storage(nil, req, &res)
+	assert.ErrorIs(t, err, ErrUnsupportedStorageQueryType)
+	assert.Nil(t, res)
+}
@@ -4,7 +4,9 @@
 package modules
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -12,10 +14,14 @@
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
-var ErrProvidedKeyDoesNotMatch = errors.New("generated public key does not equal provided public key")
+var (
+	ErrProvidedKeyDoesNotMatch = errors.New("generated public key does not equal provided public key")
+	ErrInvalidExtrinsicOrHash  = errors.New("invalid extrinsic or hash")
+)
 
 // AuthorModule holds a pointer to the API
 type AuthorModule struct {
@@ -47,6 +53,33 @@ type ExtrinsicOrHash struct {
 	Extrinsic []byte
 }
 
+// UnmarshalJSON decodes either a `{"hash": "0x..."}` or an `{"extrinsic": "0x..."}` JSON object.
+// The hash of the extrinsic is set when decoding an extrinsic.
+func (e *ExtrinsicOrHash) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Hash      *common.Hash `json:"hash"`
+		Extrinsic *string      `json:"extrinsic"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case raw.Hash != nil:
+		e.Hash = *raw.Hash
+	case raw.Extrinsic != nil:
+		e.Extrinsic, err = common.HexToBytes(*raw.Extrinsic)
+		if err != nil {
+			return fmt.Errorf("decoding extrinsic: %w", err)
+		}
+		e.Hash = types.Extrinsic(e.Extrinsic).Hash()
+	default:
+		return fmt.Errorf("%w: expected hash or extrinsic", ErrInvalidExtrinsicOrHash)
+	}
+	return nil
+}
+
 // ExtrinsicOrHashRequest is a array of ExtrinsicOrHash
 type ExtrinsicOrHashRequest []ExtrinsicOrHash
 
@@ -59,8 +92,8 @@ type ExtrinsicOrHash struct {
 // RemoveExtrinsicsResponse is a array of hash used to Remove extrinsics
 type RemoveExtrinsicsResponse []common.Hash
 
-// KeyRotateResponse is a byte array used to rotate
-type KeyRotateResponse []byte
+// KeyRotateResponse is the hex encoded concatenation of the new session public keys
+type KeyRotateResponse string
 
 // HasSessionKeyResponse is the response to the RPC call author_hasSessionKeys
 type HasSessionKeyResponse bool
@@ -147,12 +180,7 @@ func (am *AuthorModule) HasSessionKeys(r *http.Request, req *HasSessionKeyReques
 func (am *AuthorModule) InsertKey(r *http.Request, req *KeyInsertRequest, _ *KeyInsertResponse) error {
 	keyReq := *req
 
-	keyBytes, err := common.HexToBytes(req.Seed)
-	if err != nil {
-		return err
-	}
-
-	keyPair, err := keystore.DecodeKeyPairFromHex(keyBytes, keystore.DetermineKeyType(keyReq.Type))
+	keyPair, err := keystore.DecodeKeyPairFromSecretURI(keyReq.Seed, keystore.DetermineKeyType(keyReq.Type))
 	if err != nil {
 		return err
 	}
@@ -162,7 +190,7 @@ func (am *AuthorModule) InsertKey(r *http.Request, req *KeyInsertRequest, _ *Key
 		return ErrProvidedKeyDoesNotMatch
 	}
 
-	err = am.coreAPI.InsertKey(keyPair, keyReq.Type)
+	err = am.coreAPI.InsertKey(keyPair, keyReq.Type, keyReq.Seed)
 	if err != nil {
 		return err
 	}
@@ -191,19 +219,91 @@ func (am *AuthorModule) PendingExtrinsics(r *http.Request, req *EmptyRequest, re
 	return nil
 }
 
-// RemoveExtrinsic Remove given extrinsic from the pool and temporarily ban it to prevent reimporting
-func (am *AuthorModule) RemoveExtrinsic(r *http.Request, _ *ExtrinsicOrHashRequest, _ *RemoveExtrinsicsResponse) error {
+// RemoveExtrinsic removes the given extrinsics, and the extrinsics depending on them, from the pool.
+// It returns the hashes of all the removed extrinsics.
+func (am *AuthorModule) RemoveExtrinsic(r *http.Request, req *ExtrinsicOrHashRequest,
+	res *RemoveExtrinsicsResponse) error {
+	hashes := make([]common.Hash, len(*req))
+	for i, extrinsicOrHash := range *req {
+		hashes[i] = extrinsicOrHash.Hash
+	}
+
+	removed := am.txStateAPI.RemoveExtrinsics(hashes)
+	if removed == nil {
+		removed = []common.Hash{}
+	}
+	*res = RemoveExtrinsicsResponse(removed)
 	return nil
 }
 
 // RotateKeys Generate new session keys and returns the corresponding public keys
 func (am *AuthorModule) RotateKeys(r *http.Request, req *EmptyRequest, res *KeyRotateResponse) error {
+	publicKeys, err := am.coreAPI.GenerateSessionKeys()
+	if err != nil {
+		return fmt.Errorf("generating session keys: %w", err)
+	}
+
+	*res = KeyRotateResponse(common.BytesToHex(publicKeys))
 	return nil
 }
 
-// SubmitAndWatchExtrinsic Submit and subscribe to watch an extrinsic until unsubscribed
+// SubmitAndWatchExtrinsic submits an extrinsic and returns its status once submitted.
+// Over HTTP, the call is one-shot: the returned status is future or ready, and broadcast
+// to the peers the extrinsic was propagated to, and the later status updates, from the
+// inclusion in a block to the finalisation, cannot be watched. Watching the extrinsic
+// status updates is only available over the websocket connection, where the call is
+// handled as a subscription.
 func (am *AuthorModule) SubmitAndWatchExtrinsic(r *http.Request, req *Extrinsic, res *ExtrinsicStatus) error {
-	return nil
+	extBytes, err := common.HexToBytes(req.Data)
+	if err != nil {
+		return err
+	}
+	ext := types.Extrinsic(extBytes)
+
+	statusChan := am.txStateAPI.GetStatusNotifierChannel(ext)
+	defer am.txStateAPI.FreeStatusNotifierChannel(statusChan)
+
+	err = am.coreAPI.HandleSubmittedExtrinsic(ext)
+	if err != nil {
+		return err
+	}
+
+	// the status updates are sent to the channel before the extrinsic submission returns
+	for {
+		select {
+		case notification := <-statusChan:
+			res.update(notification)
+		default:
+			return nil
+		}
+	}
+}
+
+// update sets the status of the extrinsic from the given status notification.
+func (s *ExtrinsicStatus) update(notification transaction.StatusNotification) {
+	switch notification.Status {
+	case transaction.Future:
+		s.IsFuture = true
+	case transaction.Ready:
+		s.IsReady = true
+	case transaction.Broadcast:
+		s.IsBroadcast = true
+		s.AsBroadcast = notification.PeersBroadcastedTo
+	case transaction.Finalized:
+		s.IsFinalized = true
+		if notification.BlockHash != nil {
+			s.AsFinalized = *notification.BlockHash
+		}
+	case transaction.Usurped:
+		s.IsUsurped = true
+		if notification.UsurpedBy != nil {
+			s.AsUsurped = *notification.UsurpedBy
+		}
+	case transaction.Dropped:
+		s.IsDropped = true
+	case transaction.Invalid:
+		s.IsInvalid = true
+	}
 }
 
 // SubmitExtrinsic Submit a fully formatted extrinsic for block inclusion
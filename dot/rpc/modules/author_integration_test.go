@@ -33,6 +33,7 @@
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/triedb"
 	"github.com/ChainSafe/gossamer/tests/utils/config"
 	cscale "github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
@@ -53,9 +54,6 @@ func useInstanceFromGenesis(t *testing.T, rtStorage *storage.TrieState) (instanc
 	cfg := wazero_runtime.Config{
 		Storage: rtStorage,
 		LogLvl:  log.Warn,
-		NodeStorage: runtime.NodeStorage{
-			BaseDB: runtime.NewInMemoryDB(t),
-		},
 	}
 
 	runtimeInstance, err := wazero_runtime.NewRuntimeFromGenesis(cfg)
@@ -80,7 +78,6 @@ func useInstanceFromRuntimeV0929(t *testing.T, rtStorage *storage.TrieState) (in
 		NodeStorage: runtime.NodeStorage{
 			LocalStorage:      runtime.NewInMemoryDB(t),
 			PersistentStorage: runtime.NewInMemoryDB(t),
-			BaseDB:            runtime.NewInMemoryDB(t),
 		},
 	}
 
@@ -165,11 +162,6 @@ func TestAuthorModule_SubmitExtrinsic_Integration(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	telemetryMock := NewMockTelemetry(ctrl)
-	telemetryMock.EXPECT().
-		SendMessage(
-			telemetry.NewTxpoolImport(0, 1),
-		)
-
 	integrationTestController.stateSrv.Transaction = state.NewTransactionState(telemetryMock)
 
 	genesisHash := integrationTestController.genesisHeader.Hash()
@@ -182,7 +174,7 @@ func TestAuthorModule_SubmitExtrinsic_Integration(t *testing.T) {
 	extBytes := common.MustHexToBytes(extHex)
 
 	net2test := NewMockNetwork(ctrl)
-	net2test.EXPECT().GossipMessage(&network.TransactionMessage{Extrinsics: []types.Extrinsic{extBytes}})
+	net2test.EXPECT().PropagateMessage(&network.TransactionMessage{Extrinsics: []types.Extrinsic{extBytes}})
 	integrationTestController.network = net2test
 
 	// setup auth module
@@ -207,11 +199,12 @@ func TestAuthorModule_SubmitExtrinsic_Integration(t *testing.T) {
 	}
 
 	expectedHash := ExtrinsicHashResponse(expectedExtrinsic.Hash().String())
-	txOnPool := integrationTestController.stateSrv.Transaction.PendingInPool()
+	// the submitted extrinsic is ready since it does not require any tag
+	pending := integrationTestController.stateSrv.Transaction.Pending()
 
 	// compare results
-	require.Len(t, txOnPool, 1)
-	require.Equal(t, expected, txOnPool[0])
+	require.Len(t, pending, 1)
+	require.Equal(t, expected, pending[0])
 	require.Equal(t, expectedHash, *res)
 }
 
@@ -577,11 +570,6 @@ func TestAuthorModule_SubmitExtrinsic_WithVersion_V0929(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	telemetryMock := NewMockTelemetry(ctrl)
-	telemetryMock.EXPECT().
-		SendMessage(
-			telemetry.NewTxpoolImport(0, 1),
-		)
-
 	integrationTestController.stateSrv.Transaction = state.NewTransactionState(telemetryMock)
 
 	genesisHash := integrationTestController.genesisHeader.Hash()
@@ -589,7 +577,7 @@ func TestAuthorModule_SubmitExtrinsic_WithVersion_V0929(t *testing.T) {
 	extHex := common.BytesToHex(extrinsic)
 
 	net2test := NewMockNetwork(ctrl)
-	net2test.EXPECT().GossipMessage(&network.TransactionMessage{Extrinsics: []types.Extrinsic{extrinsic}})
+	net2test.EXPECT().PropagateMessage(&network.TransactionMessage{Extrinsics: []types.Extrinsic{extrinsic}})
 	integrationTestController.network = net2test
 
 	// setup auth module
@@ -613,22 +601,26 @@ func TestAuthorModule_SubmitExtrinsic_WithVersion_V0929(t *testing.T) {
 	}
 
 	expectedHash := ExtrinsicHashResponse(expectedExtrinsic.Hash().String())
-	txOnPool := integrationTestController.stateSrv.Transaction.PendingInPool()
+	// the submitted extrinsic is ready since it does not require any tag
+	pending := integrationTestController.stateSrv.Transaction.Pending()
 
 	// compare results
-	require.Len(t, txOnPool, 1)
-	require.Equal(t, expected, txOnPool[0])
+	require.Len(t, pending, 1)
+	require.Equal(t, expected, pending[0])
 	require.Equal(t, expectedHash, *res)
 }
 
 type coreNetwork interface {
 	GossipMessage(network.NotificationsMessage)
+	PropagateMessage(network.NotificationsMessage) []peer.ID
+	Peers() []common.PeerInfo
 	IsSynced() bool
 	ReportPeer(change peerset.ReputationChange, p peer.ID)
 }
 
 type coreStorageState interface {
 	TrieState(root *common.Hash) (*storage.TrieState, error)
+	TrieStateWithRecorder(root common.Hash, recorder *triedb.Recorder) (*storage.TrieState, error)
 	StoreTrie(*storage.TrieState, *types.Header) error
 	GetStateRootFromBlock(bhash *common.Hash) (*common.Hash, error)
 	GenerateTrieProof(stateRoot common.Hash, keys [][]byte) ([][]byte, error)
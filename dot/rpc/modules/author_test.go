@@ -4,6 +4,7 @@
 package modules
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -319,10 +320,12 @@ func TestAuthorModule_InsertKey(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	mockCoreAPIHappyBabe := mocks.NewMockCoreAPI(ctrl)
-	mockCoreAPIHappyBabe.EXPECT().InsertKey(kp1, "babe").Return(nil)
+	mockCoreAPIHappyBabe.EXPECT().InsertKey(kp1, "babe",
+		"0x6246ddf254e0b4b4e7dffefc8adf69d212b98ac2b579c362b473fec8c40b4c0a").Return(nil)
 
 	mockCoreAPIHappyGran := mocks.NewMockCoreAPI(ctrl)
-	mockCoreAPIHappyGran.EXPECT().InsertKey(kp2, "gran").Return(nil)
+	mockCoreAPIHappyGran.EXPECT().InsertKey(kp2, "gran",
+		"0xb48004c6e1625282313b07d1c9950935e86894a2e4f21fb1ffee9854d180c781").Return(nil)
 
 	type fields struct {
 		logger     Infoer
@@ -494,3 +497,119 @@ type args struct {
 		})
 	}
 }
+
+func TestAuthorModule_RotateKeys(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		coreAPIBuilder func(ctrl *gomock.Controller) CoreAPI
+		expRes         KeyRotateResponse
+		errMessage     string
+	}{
+		"generate_session_keys_error": {
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI {
+				mockCoreAPI := mocks.NewMockCoreAPI(ctrl)
+				mockCoreAPI.EXPECT().GenerateSessionKeys().Return(nil, errors.New("test error"))
+				return mockCoreAPI
+			},
+			errMessage: "generating session keys: test error",
+		},
+		"ok": {
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI {
+				mockCoreAPI := mocks.NewMockCoreAPI(ctrl)
+				mockCoreAPI.EXPECT().GenerateSessionKeys().Return([]byte{1, 2, 3, 4}, nil)
+				return mockCoreAPI
+			},
+			expRes: "0x01020304",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			am := &AuthorModule{
+				coreAPI: testCase.coreAPIBuilder(ctrl),
+			}
+
+			var res KeyRotateResponse
+			err := am.RotateKeys(nil, nil, &res)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expRes, res)
+		})
+	}
+}
+
+func TestAuthorModule_RemoveExtrinsic(t *testing.T) {
+	t.Parallel()
+
+	extrinsic := []byte{1, 2, 3}
+	extrinsicHash := types.Extrinsic(extrinsic).Hash()
+	dependentHash := common.Hash{2}
+
+	var req ExtrinsicOrHashRequest
+	err := json.Unmarshal([]byte(`[{"hash":"0x0100000000000000000000000000000000000000000000000000000000000000"},`+
+		`{"extrinsic":"0x010203"}]`), &req)
+	require.NoError(t, err)
+	require.Equal(t, ExtrinsicOrHashRequest{
+		{Hash: common.Hash{1}},
+		{Hash: extrinsicHash, Extrinsic: extrinsic},
+	}, req)
+
+	ctrl := gomock.NewController(t)
+	mockTransactionStateAPI := mocks.NewMockTransactionStateAPI(ctrl)
+	mockTransactionStateAPI.EXPECT().RemoveExtrinsics([]common.Hash{{1}, extrinsicHash}).
+		Return([]common.Hash{extrinsicHash, dependentHash})
+
+	am := &AuthorModule{
+		txStateAPI: mockTransactionStateAPI,
+	}
+
+	var res RemoveExtrinsicsResponse
+	err = am.RemoveExtrinsic(nil, &req, &res)
+	require.NoError(t, err)
+	assert.Equal(t, RemoveExtrinsicsResponse{extrinsicHash, dependentHash}, res)
+}
+
+func TestAuthorModule_SubmitAndWatchExtrinsic(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	ext := types.Extrinsic{1, 2, 3}
+	statusChan := make(chan transaction.StatusNotification, 2)
+
+	mockTransactionStateAPI := mocks.NewMockTransactionStateAPI(ctrl)
+	mockTransactionStateAPI.EXPECT().GetStatusNotifierChannel(ext).Return(statusChan)
+	mockTransactionStateAPI.EXPECT().FreeStatusNotifierChannel(statusChan)
+
+	mockCoreAPI := mocks.NewMockCoreAPI(ctrl)
+	mockCoreAPI.EXPECT().HandleSubmittedExtrinsic(ext).DoAndReturn(func(types.Extrinsic) error {
+		statusChan <- transaction.StatusNotification{Status: transaction.Ready}
+		statusChan <- transaction.StatusNotification{
+			Status:             transaction.Broadcast,
+			PeersBroadcastedTo: []string{"peer"},
+		}
+		return nil
+	})
+
+	am := &AuthorModule{
+		coreAPI:    mockCoreAPI,
+		txStateAPI: mockTransactionStateAPI,
+	}
+
+	var res ExtrinsicStatus
+	err := am.SubmitAndWatchExtrinsic(nil, &Extrinsic{Data: "0x010203"}, &res)
+	require.NoError(t, err)
+	assert.Equal(t, ExtrinsicStatus{
+		IsReady:     true,
+		IsBroadcast: true,
+		AsBroadcast: []string{"peer"},
+	}, res)
+}
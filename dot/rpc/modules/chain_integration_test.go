@@ -6,7 +6,6 @@
 package modules
 
 import (
-	"path/filepath"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/state"
@@ -365,13 +364,6 @@ func newTestStateService(t *testing.T) *state.Service {
 
 	rtCfg.Storage = rtstorage.NewTrieState(genesisTrie)
 
-	if stateSrvc != nil {
-		rtCfg.NodeStorage.BaseDB = stateSrvc.Base
-	} else {
-		rtCfg.NodeStorage.BaseDB, err = database.LoadDatabase(filepath.Join(testDatadirPath, "offline_storage"), false)
-		require.NoError(t, err)
-	}
-
 	rt, err := wazero_runtime.NewRuntimeFromGenesis(rtCfg)
 	require.NoError(t, err)
 
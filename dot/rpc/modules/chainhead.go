@@ -0,0 +1,63 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"net/http"
+)
+
+// ChainHeadModule is an RPC module of the new JSON-RPC API following the head of the chain.
+// Its methods are bound to a follow subscription, and are handled by the websocket handler.
+// They remain here so they are added to the rpc_methods list.
+type ChainHeadModule struct{}
+
+// NewChainHeadModule creates a new chain head module.
+func NewChainHeadModule() *ChainHeadModule {
+	return &ChainHeadModule{}
+}
+
+// V1Follow handled by websocket handler
+func (*ChainHeadModule) V1Follow(_ *http.Request, _ *EmptyRequest, _ *string) error {
+	return ErrSubscriptionTransport
+}
+
+// V1Unfollow handled by websocket handler
+func (*ChainHeadModule) V1Unfollow(_ *http.Request, _ *EmptyRequest, _ *interface{}) error {
+	return ErrSubscriptionTransport
+}
+
+// V1Header handled by websocket handler
+func (*ChainHeadModule) V1Header(_ *http.Request, _ *EmptyRequest, _ *string) error {
+	return ErrSubscriptionTransport
+}
+
+// V1Body handled by websocket handler
+func (*ChainHeadModule) V1Body(_ *http.Request, _ *EmptyRequest, _ *interface{}) error {
+	return ErrSubscriptionTransport
+}
+
+// V1Call handled by websocket handler
+func (*ChainHeadModule) V1Call(_ *http.Request, _ *EmptyRequest, _ *interface{}) error {
+	return ErrSubscriptionTransport
+}
+
+// V1Storage handled by websocket handler
+func (*ChainHeadModule) V1Storage(_ *http.Request, _ *EmptyRequest, _ *interface{}) error {
+	return ErrSubscriptionTransport
+}
+
+// V1Unpin handled by websocket handler
+func (*ChainHeadModule) V1Unpin(_ *http.Request, _ *EmptyRequest, _ *interface{}) error {
+	return ErrSubscriptionTransport
+}
+
+// V1Continue handled by websocket handler
+func (*ChainHeadModule) V1Continue(_ *http.Request, _ *EmptyRequest, _ *interface{}) error {
+	return ErrSubscriptionTransport
+}
+
+// V1StopOperation handled by websocket handler
+func (*ChainHeadModule) V1StopOperation(_ *http.Request, _ *EmptyRequest, _ *interface{}) error {
+	return ErrSubscriptionTransport
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ChainSpecModule is an RPC module of the new JSON-RPC API providing
+// access to the chain specification the node is running.
+type ChainSpecModule struct {
+	systemAPI SystemAPI
+	blockAPI  BlockAPI
+}
+
+// NewChainSpecModule creates a new chain spec module.
+func NewChainSpecModule(systemAPI SystemAPI, blockAPI BlockAPI) *ChainSpecModule {
+	return &ChainSpecModule{
+		systemAPI: systemAPI,
+		blockAPI:  blockAPI,
+	}
+}
+
+// V1ChainName returns the name of the chain.
+func (cm *ChainSpecModule) V1ChainName(_ *http.Request, _ *EmptyRequest, res *string) error {
+	*res = cm.systemAPI.ChainName()
+	return nil
+}
+
+// V1GenesisHash returns the hash of the genesis block.
+func (cm *ChainSpecModule) V1GenesisHash(_ *http.Request, _ *EmptyRequest, res *string) error {
+	genesisHash, err := cm.blockAPI.GetHashByNumber(0)
+	if err != nil {
+		return fmt.Errorf("getting genesis hash: %w", err)
+	}
+
+	*res = genesisHash.String()
+	return nil
+}
+
+// V1Properties returns the properties of the chain.
+func (cm *ChainSpecModule) V1Properties(_ *http.Request, _ *EmptyRequest, res *interface{}) error {
+	*res = cm.systemAPI.Properties()
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestChainSpecModule_V1ChainName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockSystemAPI := mocks.NewMockSystemAPI(ctrl)
+	mockSystemAPI.EXPECT().ChainName().Return("polkadot")
+	cm := NewChainSpecModule(mockSystemAPI, nil)
+
+	var res string
+	err := cm.V1ChainName(nil, &EmptyRequest{}, &res)
+	assert.NoError(t, err)
+	assert.Equal(t, "polkadot", res)
+}
+
+func TestChainSpecModule_V1GenesisHash(t *testing.T) {
+	errTest := errors.New("test error")
+
+	tests := map[string]struct {
+		hash   common.Hash
+		err    error
+		expErr error
+		expRes string
+	}{
+		"GetHashByNumber_error": {
+			err:    errTest,
+			expErr: errTest,
+		},
+		"happy_path": {
+			hash:   common.Hash{1},
+			expRes: common.Hash{1}.String(),
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+			mockBlockAPI.EXPECT().GetHashByNumber(uint(0)).Return(tt.hash, tt.err)
+			cm := NewChainSpecModule(nil, mockBlockAPI)
+
+			var res string
+			err := cm.V1GenesisHash(nil, &EmptyRequest{}, &res)
+			assert.ErrorIs(t, err, tt.expErr)
+			assert.Equal(t, tt.expRes, res)
+		})
+	}
+}
+
+func TestChainSpecModule_V1Properties(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	properties := map[string]interface{}{"tokenSymbol": "DOT"}
+	mockSystemAPI := mocks.NewMockSystemAPI(ctrl)
+	mockSystemAPI.EXPECT().Properties().Return(properties)
+	cm := NewChainSpecModule(mockSystemAPI, nil)
+
+	var res interface{}
+	err := cm.V1Properties(nil, &EmptyRequest{}, &res)
+	assert.NoError(t, err)
+	assert.Equal(t, properties, res)
+}
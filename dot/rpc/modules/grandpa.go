@@ -52,33 +52,24 @@ type ProveFinalityRequest struct {
 	BlockNumber uint32 `json:"blockNumber"`
 }
 
-// ProveFinalityResponse is an optional SCALE encoded proof array
-type ProveFinalityResponse []string
-
-// ProveFinality for the provided block number, the Justification for the last block in the set is written to the
-// response. The response is a SCALE encoded proof array.  The proof array is empty if the block number is
-// not finalized.
-// Returns error which are included in the response if they occur.
-func (gm *GrandpaModule) ProveFinality(r *http.Request, req *ProveFinalityRequest, res *ProveFinalityResponse) error {
-	blockHash, err := gm.blockAPI.GetHashByNumber(uint(req.BlockNumber))
+// ProveFinality writes to the response the hex encoded SCALE encoded finality proof of the finalised
+// block with the given number. The proof contains the justification of the last block of the authority
+// set of the block, or the latest justification if the block belongs to the current authority set,
+// followed by the headers of the blocks after the requested block up to the justified block.
+// The response is nil if the justification proving the finality of the block is not stored.
+func (gm *GrandpaModule) ProveFinality(r *http.Request, req *ProveFinalityRequest, res **string) error {
+	proof, err := gm.blockFinalityAPI.ProveFinality(uint(req.BlockNumber))
 	if err != nil {
-		return err
-	}
-	hasJustification, err := gm.blockAPI.HasJustification(blockHash)
-	if err != nil {
-		return fmt.Errorf("checking for justification: %w", err)
+		return fmt.Errorf("proving finality of block #%d: %w", req.BlockNumber, err)
 	}
 
-	if !hasJustification {
-		*res = append(*res, "GRANDPA prove finality rpc failed: Block not covered by authority set changes")
+	if proof == nil {
+		*res = nil
 		return nil
 	}
-	justification, err := gm.blockAPI.GetJustification(blockHash)
-	if err != nil {
-		return fmt.Errorf("getting justification: %w", err)
-	}
-	*res = append(*res, common.BytesToHex(justification))
 
+	encodedProof := common.BytesToHex(proof)
+	*res = &encodedProof
 	return nil
 }
 
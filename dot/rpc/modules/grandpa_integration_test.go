@@ -8,12 +8,10 @@
 import (
 	"testing"
 
-	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/keystore"
-	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
@@ -22,35 +20,6 @@
 
 var kr, _ = keystore.NewEd25519Keyring()
 
-func TestGrandpaProveFinality(t *testing.T) {
-	testStateService := newTestStateService(t)
-
-	state.AddBlocksToState(t, testStateService.Block, 3, false)
-	bestBlock, err := testStateService.Block.BestBlock()
-
-	if err != nil {
-		t.Errorf("Fail: bestblock failed")
-	}
-
-	gmSvc := NewGrandpaModule(testStateService.Block, nil)
-
-	testStateService.Block.SetJustification(bestBlock.Header.ParentHash, make([]byte, 10))
-	testStateService.Block.SetJustification(bestBlock.Header.Hash(), make([]byte, 11))
-
-	expectedResponse := &ProveFinalityResponse{"0x0000000000000000000000"}
-
-	res := new(ProveFinalityResponse)
-	err = gmSvc.ProveFinality(nil, &ProveFinalityRequest{
-		BlockNumber: uint32(bestBlock.Header.Number),
-	}, res)
-
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	assert.Equal(t, *expectedResponse, *res)
-}
-
 func TestRoundState(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -10,7 +10,6 @@
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
 	"github.com/ChainSafe/gossamer/dot/types"
-	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/keystore"
@@ -22,73 +21,45 @@ func TestGrandpaModule_ProveFinality(t *testing.T) {
 	t.Parallel()
 
 	mockError := errors.New("test mock error")
+	proof := "0x0102"
 
 	tests := map[string]struct {
-		blockAPIBuilder func(ctrl *gomock.Controller) BlockAPI
-		request         *ProveFinalityRequest
-		expErr          error
-		exp             ProveFinalityResponse
+		blockFinalityAPIBuilder func(ctrl *gomock.Controller) BlockFinalityAPI
+		request                 *ProveFinalityRequest
+		expErr                  error
+		exp                     *string
 	}{
-		"error_during_get_hash_by_number": {
-			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
-				mockBlockAPI := NewMockBlockAPI(ctrl)
-				mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{}, mockError)
-				return mockBlockAPI
+		"error_during_prove_finality": {
+			blockFinalityAPIBuilder: func(ctrl *gomock.Controller) BlockFinalityAPI {
+				mockBlockFinalityAPI := mocks.NewMockBlockFinalityAPI(ctrl)
+				mockBlockFinalityAPI.EXPECT().ProveFinality(uint(1)).Return(nil, mockError)
+				return mockBlockFinalityAPI
 			},
 			request: &ProveFinalityRequest{
 				BlockNumber: 1,
 			},
 			expErr: mockError,
 		},
-		"error_during_has_justification": {
-			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
-				mockBlockAPI := NewMockBlockAPI(ctrl)
-				mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{2}, nil)
-				mockBlockAPI.EXPECT().HasJustification(common.Hash{2}).Return(false, mockError)
-				return mockBlockAPI
+		"justification_not_stored": {
+			blockFinalityAPIBuilder: func(ctrl *gomock.Controller) BlockFinalityAPI {
+				mockBlockFinalityAPI := mocks.NewMockBlockFinalityAPI(ctrl)
+				mockBlockFinalityAPI.EXPECT().ProveFinality(uint(2)).Return(nil, nil)
+				return mockBlockFinalityAPI
 			},
 			request: &ProveFinalityRequest{
 				BlockNumber: 2,
 			},
-			expErr: mockError,
-		},
-		"has_justification_is_false": {
-			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
-				mockBlockAPI := NewMockBlockAPI(ctrl)
-				mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{2}, nil)
-				mockBlockAPI.EXPECT().HasJustification(common.Hash{2}).Return(false, nil)
-				return mockBlockAPI
-			},
-			request: &ProveFinalityRequest{
-				BlockNumber: 2,
-			},
-			exp: ProveFinalityResponse{"GRANDPA prove finality rpc failed: Block not covered by authority set changes"},
-		},
-		"error_during_getJustification": {
-			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
-				mockBlockAPI := NewMockBlockAPI(ctrl)
-				mockBlockAPI.EXPECT().GetHashByNumber(uint(3)).Return(common.Hash{3}, nil)
-				mockBlockAPI.EXPECT().HasJustification(common.Hash{3}).Return(true, nil)
-				mockBlockAPI.EXPECT().GetJustification(common.Hash{3}).Return(nil, mockError)
-				return mockBlockAPI
-			},
-			request: &ProveFinalityRequest{
-				BlockNumber: 3,
-			},
-			expErr: mockError,
 		},
 		"happy_path": {
-			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
-				mockBlockAPI := NewMockBlockAPI(ctrl)
-				mockBlockAPI.EXPECT().GetHashByNumber(uint(4)).Return(common.Hash{4}, nil)
-				mockBlockAPI.EXPECT().HasJustification(common.Hash{4}).Return(true, nil)
-				mockBlockAPI.EXPECT().GetJustification(common.Hash{4}).Return([]byte(`justification`), nil)
-				return mockBlockAPI
+			blockFinalityAPIBuilder: func(ctrl *gomock.Controller) BlockFinalityAPI {
+				mockBlockFinalityAPI := mocks.NewMockBlockFinalityAPI(ctrl)
+				mockBlockFinalityAPI.EXPECT().ProveFinality(uint(3)).Return([]byte{1, 2}, nil)
+				return mockBlockFinalityAPI
 			},
 			request: &ProveFinalityRequest{
-				BlockNumber: 4,
+				BlockNumber: 3,
 			},
-			exp: ProveFinalityResponse{common.BytesToHex([]byte(`justification`))},
+			exp: &proof,
 		},
 	}
 	for name, tt := range tests {
@@ -97,13 +68,13 @@ func TestGrandpaModule_ProveFinality(t *testing.T) {
 			t.Parallel()
 			ctrl := gomock.NewController(t)
 			gm := &GrandpaModule{
-				blockAPI: tt.blockAPIBuilder(ctrl),
+				blockFinalityAPI: tt.blockFinalityAPIBuilder(ctrl),
 			}
-			res := ProveFinalityResponse(nil)
+			var res *string
 			err := gm.ProveFinality(nil, tt.request, &res)
 			assert.Equal(t, tt.exp, res)
 			if tt.expErr != nil {
-				assert.ErrorContains(t, err, tt.expErr.Error())
+				assert.ErrorIs(t, err, tt.expErr)
 			} else {
 				assert.NoError(t, err)
 			}
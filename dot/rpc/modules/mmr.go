@@ -0,0 +1,138 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/mmr"
+)
+
+// MmrRootRequest holds the optional block hash of an mmr_root request
+type MmrRootRequest struct {
+	At *common.Hash `json:"at"`
+}
+
+// MmrGenerateProofRequest holds the parameters of an mmr_generateProof request
+type MmrGenerateProofRequest struct {
+	BlockNumbers         []uint32     `json:"blockNumbers"`
+	BestKnownBlockNumber *uint32      `json:"bestKnownBlockNumber"`
+	At                   *common.Hash `json:"at"`
+}
+
+// MmrVerifyProofRequest holds the proof of an mmr_verifyProof request
+type MmrVerifyProofRequest struct {
+	Proof MmrLeavesProof `json:"proof"`
+}
+
+// MmrLeavesProof is the proof of MMR leaves generated at the given block
+type MmrLeavesProof struct {
+	BlockHash common.Hash `json:"blockHash"`
+	// hex SCALE encoded leaves
+	Leaves string `json:"leaves"`
+	// hex SCALE encoded leaf proof
+	Proof string `json:"proof"`
+}
+
+// MmrModule holds the RPC implementation of the MMR methods
+type MmrModule struct {
+	blockAPI   BlockAPI
+	storageAPI StorageAPI
+}
+
+// NewMmrModule returns a pointer to MmrModule
+func NewMmrModule(blockAPI BlockAPI, storageAPI StorageAPI) *MmrModule {
+	return &MmrModule{
+		blockAPI:   blockAPI,
+		storageAPI: storageAPI,
+	}
+}
+
+// Root returns the MMR root at the given block, or at the best block if no block is given.
+func (m *MmrModule) Root(_ *http.Request, req *MmrRootRequest, res *common.Hash) error {
+	var hash common.Hash
+	if req.At == nil {
+		hash = m.blockAPI.BestBlockHash()
+	} else {
+		hash = *req.At
+	}
+
+	rt, release, err := RuntimeAt(m.blockAPI, m.storageAPI, hash)
+	if err != nil {
+		return fmt.Errorf("getting runtime: %w", err)
+	}
+	defer release()
+
+	root, err := mmr.Root(rt)
+	if err != nil {
+		return err
+	}
+
+	*res = root
+	return nil
+}
+
+// GenerateProof generates the MMR proof of the leaves of the given blocks, for the MMR as it was
+// at the best known block number, using the state of the given block or of the highest finalised
+// block if no block is given.
+func (m *MmrModule) GenerateProof(_ *http.Request, req *MmrGenerateProofRequest, res *MmrLeavesProof) error {
+	var hash common.Hash
+	if req.At != nil {
+		hash = *req.At
+	} else {
+		var err error
+		hash, err = m.blockAPI.GetHighestFinalisedHash()
+		if err != nil {
+			return fmt.Errorf("getting highest finalised hash: %w", err)
+		}
+	}
+
+	rt, release, err := RuntimeAt(m.blockAPI, m.storageAPI, hash)
+	if err != nil {
+		return fmt.Errorf("getting runtime: %w", err)
+	}
+	defer release()
+
+	leaves, proof, err := mmr.GenerateProof(rt, req.BlockNumbers, req.BestKnownBlockNumber)
+	if err != nil {
+		return err
+	}
+
+	*res = MmrLeavesProof{
+		BlockHash: hash,
+		Leaves:    common.BytesToHex(leaves),
+		Proof:     common.BytesToHex(proof),
+	}
+	return nil
+}
+
+// VerifyProof verifies the MMR proof against the MMR at the block the proof was generated at.
+// It returns true if the proof is valid, and an error otherwise.
+func (m *MmrModule) VerifyProof(_ *http.Request, req *MmrVerifyProofRequest, res *bool) error {
+	leaves, err := common.HexToBytes(req.Proof.Leaves)
+	if err != nil {
+		return fmt.Errorf("decoding leaves: %w", err)
+	}
+
+	proof, err := common.HexToBytes(req.Proof.Proof)
+	if err != nil {
+		return fmt.Errorf("decoding proof: %w", err)
+	}
+
+	rt, release, err := RuntimeAt(m.blockAPI, m.storageAPI, req.Proof.BlockHash)
+	if err != nil {
+		return fmt.Errorf("getting runtime: %w", err)
+	}
+	defer release()
+
+	err = mmr.VerifyProof(rt, leaves, proof)
+	if err != nil {
+		return err
+	}
+
+	*res = true
+	return nil
+}
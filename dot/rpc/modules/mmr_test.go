@@ -0,0 +1,233 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/mmr"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	mocksruntime "github.com/ChainSafe/gossamer/lib/runtime/mocks"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newMmrTestStorageAPI returns a storage API returning the state of the block with the
+// given hash, and expects the state to be set as the storage of the runtime if given.
+func newMmrTestStorageAPI(ctrl *gomock.Controller, hash common.Hash, rt *mocksruntime.MockInstance) StorageAPI {
+	stateRoot := common.Hash{0xff}
+	trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+	storageAPI := mocks.NewMockStorageAPI(ctrl)
+	storageAPI.EXPECT().GetStateRootFromBlock(&hash).Return(&stateRoot, nil)
+	storageAPI.EXPECT().TrieState(&stateRoot).Return(trieState, nil)
+	if rt != nil {
+		rt.EXPECT().SetContextStorage(trieState)
+	}
+	return storageAPI
+}
+
+func TestMmrModule_Root(t *testing.T) {
+	t.Parallel()
+
+	bestHash := common.Hash{1}
+	atHash := common.Hash{2}
+	root := common.Hash{3}
+	errTest := errors.New("test error")
+
+	tests := map[string]struct {
+		buildAPIs  func(ctrl *gomock.Controller) (BlockAPI, StorageAPI)
+		req        *MmrRootRequest
+		exp        common.Hash
+		errWrapped error
+		errMessage string
+	}{
+		"best_block": {
+			buildAPIs: func(ctrl *gomock.Controller) (BlockAPI, StorageAPI) {
+				rt := mocksruntime.NewMockInstance(ctrl)
+				rt.EXPECT().Exec(runtime.MmrAPIMmrRoot, []byte{}).Return(append([]byte{0}, root[:]...), nil)
+				blockAPI := mocks.NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().BestBlockHash().Return(bestHash)
+				blockAPI.EXPECT().GetRuntime(bestHash).Return(rt, nil)
+				return blockAPI, newMmrTestStorageAPI(ctrl, bestHash, rt)
+			},
+			req: &MmrRootRequest{},
+			exp: root,
+		},
+		"given_block": {
+			buildAPIs: func(ctrl *gomock.Controller) (BlockAPI, StorageAPI) {
+				rt := mocksruntime.NewMockInstance(ctrl)
+				rt.EXPECT().Exec(runtime.MmrAPIMmrRoot, []byte{}).Return(append([]byte{0}, root[:]...), nil)
+				blockAPI := mocks.NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().GetRuntime(atHash).Return(rt, nil)
+				return blockAPI, newMmrTestStorageAPI(ctrl, atHash, rt)
+			},
+			req: &MmrRootRequest{At: &atHash},
+			exp: root,
+		},
+		"unknown_block": {
+			buildAPIs: func(ctrl *gomock.Controller) (BlockAPI, StorageAPI) {
+				storageAPI := mocks.NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&atHash).Return(nil, errTest)
+				return mocks.NewMockBlockAPI(ctrl), storageAPI
+			},
+			req:        &MmrRootRequest{At: &atHash},
+			errWrapped: errTest,
+			errMessage: "getting runtime: getting state root: test error",
+		},
+		"get_runtime_error": {
+			buildAPIs: func(ctrl *gomock.Controller) (BlockAPI, StorageAPI) {
+				blockAPI := mocks.NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().BestBlockHash().Return(bestHash)
+				blockAPI.EXPECT().GetRuntime(bestHash).Return(nil, errTest)
+				return blockAPI, newMmrTestStorageAPI(ctrl, bestHash, nil)
+			},
+			req:        &MmrRootRequest{},
+			errWrapped: errTest,
+			errMessage: "getting runtime: getting runtime: test error",
+		},
+		"pallet_not_included": {
+			buildAPIs: func(ctrl *gomock.Controller) (BlockAPI, StorageAPI) {
+				rt := mocksruntime.NewMockInstance(ctrl)
+				rt.EXPECT().Exec(runtime.MmrAPIMmrRoot, []byte{}).Return([]byte{1, byte(mmr.ErrPalletNotIncluded)}, nil)
+				blockAPI := mocks.NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().BestBlockHash().Return(bestHash)
+				blockAPI.EXPECT().GetRuntime(bestHash).Return(rt, nil)
+				return blockAPI, newMmrTestStorageAPI(ctrl, bestHash, rt)
+			},
+			req:        &MmrRootRequest{},
+			errWrapped: mmr.ErrPalletNotIncluded,
+			errMessage: "getting mmr root: mmr: pallet not included in the runtime",
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			module := NewMmrModule(tt.buildAPIs(ctrl))
+			var res common.Hash
+			err := module.Root(nil, tt.req, &res)
+
+			assert.ErrorIs(t, err, tt.errWrapped)
+			if tt.errWrapped != nil {
+				assert.EqualError(t, err, tt.errMessage)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
+
+func TestMmrModule_GenerateProof(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	finalisedHash := common.Hash{1}
+	bestKnownBlockNumber := uint32(5)
+	leafProof := mmr.LeafProof{
+		LeafIndices: []uint64{1},
+		LeafCount:   4,
+		Items:       []common.Hash{{2}, {3}},
+	}
+	leaves := [][]byte{{4, 5}}
+
+	params := scale.MustMarshal(struct {
+		BlockNumbers         []uint32
+		BestKnownBlockNumber *uint32
+	}{[]uint32{2}, &bestKnownBlockNumber})
+	ret := append([]byte{0}, scale.MustMarshal(struct {
+		Leaves [][]byte
+		Proof  mmr.LeafProof
+	}{leaves, leafProof})...)
+
+	rt := mocksruntime.NewMockInstance(ctrl)
+	rt.EXPECT().Exec(runtime.MmrAPIGenerateProof, params).Return(ret, nil)
+	blockAPI := mocks.NewMockBlockAPI(ctrl)
+	blockAPI.EXPECT().GetHighestFinalisedHash().Return(finalisedHash, nil)
+	blockAPI.EXPECT().GetRuntime(finalisedHash).Return(rt, nil)
+
+	module := NewMmrModule(blockAPI, newMmrTestStorageAPI(ctrl, finalisedHash, rt))
+	var res MmrLeavesProof
+	err := module.GenerateProof(nil, &MmrGenerateProofRequest{
+		BlockNumbers:         []uint32{2},
+		BestKnownBlockNumber: &bestKnownBlockNumber,
+	}, &res)
+	require.NoError(t, err)
+
+	expected := MmrLeavesProof{
+		BlockHash: finalisedHash,
+		Leaves:    common.BytesToHex(scale.MustMarshal(leaves)),
+		Proof:     common.BytesToHex(scale.MustMarshal(leafProof)),
+	}
+	assert.Equal(t, expected, res)
+}
+
+func TestMmrModule_VerifyProof(t *testing.T) {
+	t.Parallel()
+
+	blockHash := common.Hash{1}
+	leafProof := mmr.LeafProof{
+		LeafIndices: []uint64{1},
+		LeafCount:   4,
+		Items:       []common.Hash{{2}, {3}},
+	}
+	leaves := [][]byte{{4, 5}}
+	params := scale.MustMarshal(struct {
+		Leaves [][]byte
+		Proof  mmr.LeafProof
+	}{leaves, leafProof})
+	proof := MmrLeavesProof{
+		BlockHash: blockHash,
+		Leaves:    common.BytesToHex(scale.MustMarshal(leaves)),
+		Proof:     common.BytesToHex(scale.MustMarshal(leafProof)),
+	}
+
+	tests := map[string]struct {
+		ret        []byte
+		exp        bool
+		errWrapped error
+		errMessage string
+	}{
+		"valid_proof": {
+			ret: []byte{0},
+			exp: true,
+		},
+		"invalid_proof": {
+			ret:        []byte{1, byte(mmr.ErrVerify)},
+			errWrapped: mmr.ErrVerify,
+			errMessage: "verifying mmr proof: mmr: proof verification failed",
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			rt := mocksruntime.NewMockInstance(ctrl)
+			rt.EXPECT().Exec(runtime.MmrAPIVerifyProof, params).Return(tt.ret, nil)
+			blockAPI := mocks.NewMockBlockAPI(ctrl)
+			blockAPI.EXPECT().GetRuntime(blockHash).Return(rt, nil)
+
+			module := NewMmrModule(blockAPI, newMmrTestStorageAPI(ctrl, blockHash, rt))
+			var res bool
+			err := module.VerifyProof(nil, &MmrVerifyProofRequest{Proof: proof}, &res)
+
+			assert.ErrorIs(t, err, tt.errWrapped)
+			if tt.errWrapped != nil {
+				assert.EqualError(t, err, tt.errMessage)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
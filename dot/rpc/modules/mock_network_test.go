@@ -1,5 +1,10 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: github.com/ChainSafe/gossamer/dot/core (interfaces: Network)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock_network_test.go -package modules github.com/ChainSafe/gossamer/dot/core Network
+//
 
 // Package modules is a generated GoMock package.
 package modules
@@ -9,8 +14,9 @@
 
 	network "github.com/ChainSafe/gossamer/dot/network"
 	peerset "github.com/ChainSafe/gossamer/dot/peerset"
-	gomock "go.uber.org/mock/gomock"
+	common "github.com/ChainSafe/gossamer/lib/common"
 	peer "github.com/libp2p/go-libp2p/core/peer"
+	gomock "go.uber.org/mock/gomock"
 )
 
 // MockNetwork is a mock of Network interface.
@@ -43,7 +49,7 @@ func (m *MockNetwork) GossipMessage(arg0 network.NotificationsMessage) {
 }
 
 // GossipMessage indicates an expected call of GossipMessage.
-func (mr *MockNetworkMockRecorder) GossipMessage(arg0 interface{}) *gomock.Call {
+func (mr *MockNetworkMockRecorder) GossipMessage(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GossipMessage", reflect.TypeOf((*MockNetwork)(nil).GossipMessage), arg0)
 }
@@ -62,6 +68,34 @@ func (mr *MockNetworkMockRecorder) IsSynced() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSynced", reflect.TypeOf((*MockNetwork)(nil).IsSynced))
 }
 
+// Peers mocks base method.
+func (m *MockNetwork) Peers() []common.PeerInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Peers")
+	ret0, _ := ret[0].([]common.PeerInfo)
+	return ret0
+}
+
+// Peers indicates an expected call of Peers.
+func (mr *MockNetworkMockRecorder) Peers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peers", reflect.TypeOf((*MockNetwork)(nil).Peers))
+}
+
+// PropagateMessage mocks base method.
+func (m *MockNetwork) PropagateMessage(arg0 network.NotificationsMessage) []peer.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PropagateMessage", arg0)
+	ret0, _ := ret[0].([]peer.ID)
+	return ret0
+}
+
+// PropagateMessage indicates an expected call of PropagateMessage.
+func (mr *MockNetworkMockRecorder) PropagateMessage(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropagateMessage", reflect.TypeOf((*MockNetwork)(nil).PropagateMessage), arg0)
+}
+
 // ReportPeer mocks base method.
 func (m *MockNetwork) ReportPeer(arg0 peerset.ReputationChange, arg1 peer.ID) {
 	m.ctrl.T.Helper()
@@ -69,7 +103,7 @@ func (m *MockNetwork) ReportPeer(arg0 peerset.ReputationChange, arg1 peer.ID) {
 }
 
 // ReportPeer indicates an expected call of ReportPeer.
-func (mr *MockNetworkMockRecorder) ReportPeer(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockNetworkMockRecorder) ReportPeer(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportPeer", reflect.TypeOf((*MockNetwork)(nil).ReportPeer), arg0, arg1)
 }
@@ -19,6 +19,7 @@
 	ed25519 "github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	genesis "github.com/ChainSafe/gossamer/lib/genesis"
 	runtime "github.com/ChainSafe/gossamer/lib/runtime"
+	storage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	transaction "github.com/ChainSafe/gossamer/lib/transaction"
 	trie "github.com/ChainSafe/gossamer/pkg/trie"
 	gomock "go.uber.org/mock/gomock"
@@ -164,6 +165,21 @@ func (mr *MockStorageAPIMockRecorder) RegisterStorageObserver(arg0 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterStorageObserver", reflect.TypeOf((*MockStorageAPI)(nil).RegisterStorageObserver), arg0)
 }
 
+// TrieState mocks base method.
+func (m *MockStorageAPI) TrieState(arg0 *common.Hash) (*storage.TrieState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrieState", arg0)
+	ret0, _ := ret[0].(*storage.TrieState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrieState indicates an expected call of TrieState.
+func (mr *MockStorageAPIMockRecorder) TrieState(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrieState", reflect.TypeOf((*MockStorageAPI)(nil).TrieState), arg0)
+}
+
 // UnregisterStorageObserver mocks base method.
 func (m *MockStorageAPI) UnregisterStorageObserver(arg0 state.Observer) {
 	m.ctrl.T.Helper()
@@ -237,6 +253,36 @@ func (mr *MockBlockAPIMockRecorder) FreeImportedBlockNotifierChannel(arg0 any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreeImportedBlockNotifierChannel", reflect.TypeOf((*MockBlockAPI)(nil).FreeImportedBlockNotifierChannel), arg0)
 }
 
+// GetAllDescendants mocks base method.
+func (m *MockBlockAPI) GetAllDescendants(arg0 common.Hash) ([]common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllDescendants", arg0)
+	ret0, _ := ret[0].([]common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllDescendants indicates an expected call of GetAllDescendants.
+func (mr *MockBlockAPIMockRecorder) GetAllDescendants(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllDescendants", reflect.TypeOf((*MockBlockAPI)(nil).GetAllDescendants), arg0)
+}
+
+// GetBlockBody mocks base method.
+func (m *MockBlockAPI) GetBlockBody(arg0 common.Hash) (*types.Body, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockBody", arg0)
+	ret0, _ := ret[0].(*types.Body)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockBody indicates an expected call of GetBlockBody.
+func (mr *MockBlockAPIMockRecorder) GetBlockBody(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockBody", reflect.TypeOf((*MockBlockAPI)(nil).GetBlockBody), arg0)
+}
+
 // GetBlockByHash mocks base method.
 func (m *MockBlockAPI) GetBlockByHash(arg0 common.Hash) (*types.Block, error) {
 	m.ctrl.T.Helper()
@@ -296,6 +342,21 @@ func (mr *MockBlockAPIMockRecorder) GetHashByNumber(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHashByNumber", reflect.TypeOf((*MockBlockAPI)(nil).GetHashByNumber), arg0)
 }
 
+// GetHashesByNumber mocks base method.
+func (m *MockBlockAPI) GetHashesByNumber(arg0 uint) ([]common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHashesByNumber", arg0)
+	ret0, _ := ret[0].([]common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHashesByNumber indicates an expected call of GetHashesByNumber.
+func (mr *MockBlockAPIMockRecorder) GetHashesByNumber(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHashesByNumber", reflect.TypeOf((*MockBlockAPI)(nil).GetHashesByNumber), arg0)
+}
+
 // GetHeader mocks base method.
 func (m *MockBlockAPI) GetHeader(arg0 common.Hash) (*types.Header, error) {
 	m.ctrl.T.Helper()
@@ -385,6 +446,20 @@ func (mr *MockBlockAPIMockRecorder) HasJustification(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasJustification", reflect.TypeOf((*MockBlockAPI)(nil).HasJustification), arg0)
 }
 
+// PinBlock mocks base method.
+func (m *MockBlockAPI) PinBlock(arg0 common.Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinBlock", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinBlock indicates an expected call of PinBlock.
+func (mr *MockBlockAPIMockRecorder) PinBlock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinBlock", reflect.TypeOf((*MockBlockAPI)(nil).PinBlock), arg0)
+}
+
 // RangeInMemory mocks base method.
 func (m *MockBlockAPI) RangeInMemory(arg0, arg1 common.Hash) ([]common.Hash, error) {
 	m.ctrl.T.Helper()
@@ -415,6 +490,18 @@ func (mr *MockBlockAPIMockRecorder) RegisterRuntimeUpdatedChannel(arg0 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRuntimeUpdatedChannel", reflect.TypeOf((*MockBlockAPI)(nil).RegisterRuntimeUpdatedChannel), arg0)
 }
 
+// UnpinBlock mocks base method.
+func (m *MockBlockAPI) UnpinBlock(arg0 common.Hash) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UnpinBlock", arg0)
+}
+
+// UnpinBlock indicates an expected call of UnpinBlock.
+func (mr *MockBlockAPIMockRecorder) UnpinBlock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinBlock", reflect.TypeOf((*MockBlockAPI)(nil).UnpinBlock), arg0)
+}
+
 // UnregisterRuntimeUpdatedChannel mocks base method.
 func (m *MockBlockAPI) UnregisterRuntimeUpdatedChannel(arg0 uint32) bool {
 	m.ctrl.T.Helper()
@@ -688,6 +775,32 @@ func (m *MockTransactionStateAPI) EXPECT() *MockTransactionStateAPIMockRecorder
 	return m.recorder
 }
 
+// FreeStatusNotifierChannel mocks base method.
+func (m *MockTransactionStateAPI) FreeStatusNotifierChannel(arg0 chan transaction.StatusNotification) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "FreeStatusNotifierChannel", arg0)
+}
+
+// FreeStatusNotifierChannel indicates an expected call of FreeStatusNotifierChannel.
+func (mr *MockTransactionStateAPIMockRecorder) FreeStatusNotifierChannel(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreeStatusNotifierChannel", reflect.TypeOf((*MockTransactionStateAPI)(nil).FreeStatusNotifierChannel), arg0)
+}
+
+// GetStatusNotifierChannel mocks base method.
+func (m *MockTransactionStateAPI) GetStatusNotifierChannel(arg0 types.Extrinsic) chan transaction.StatusNotification {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusNotifierChannel", arg0)
+	ret0, _ := ret[0].(chan transaction.StatusNotification)
+	return ret0
+}
+
+// GetStatusNotifierChannel indicates an expected call of GetStatusNotifierChannel.
+func (mr *MockTransactionStateAPIMockRecorder) GetStatusNotifierChannel(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusNotifierChannel", reflect.TypeOf((*MockTransactionStateAPI)(nil).GetStatusNotifierChannel), arg0)
+}
+
 // Pending mocks base method.
 func (m *MockTransactionStateAPI) Pending() []*transaction.ValidTransaction {
 	m.ctrl.T.Helper()
@@ -702,6 +815,20 @@ func (mr *MockTransactionStateAPIMockRecorder) Pending() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockTransactionStateAPI)(nil).Pending))
 }
 
+// RemoveExtrinsics mocks base method.
+func (m *MockTransactionStateAPI) RemoveExtrinsics(arg0 []common.Hash) []common.Hash {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveExtrinsics", arg0)
+	ret0, _ := ret[0].([]common.Hash)
+	return ret0
+}
+
+// RemoveExtrinsics indicates an expected call of RemoveExtrinsics.
+func (mr *MockTransactionStateAPIMockRecorder) RemoveExtrinsics(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExtrinsics", reflect.TypeOf((*MockTransactionStateAPI)(nil).RemoveExtrinsics), arg0)
+}
+
 // MockCoreAPI is a mock of CoreAPI interface.
 type MockCoreAPI struct {
 	ctrl     *gomock.Controller
@@ -740,6 +867,21 @@ func (mr *MockCoreAPIMockRecorder) DecodeSessionKeys(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecodeSessionKeys", reflect.TypeOf((*MockCoreAPI)(nil).DecodeSessionKeys), arg0)
 }
 
+// GenerateSessionKeys mocks base method.
+func (m *MockCoreAPI) GenerateSessionKeys() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSessionKeys")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateSessionKeys indicates an expected call of GenerateSessionKeys.
+func (mr *MockCoreAPIMockRecorder) GenerateSessionKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSessionKeys", reflect.TypeOf((*MockCoreAPI)(nil).GenerateSessionKeys))
+}
+
 // GetMetadata mocks base method.
 func (m *MockCoreAPI) GetMetadata(arg0 *common.Hash) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -816,17 +958,17 @@ func (mr *MockCoreAPIMockRecorder) HasKey(arg0, arg1 any) *gomock.Call {
 }
 
 // InsertKey mocks base method.
-func (m *MockCoreAPI) InsertKey(arg0 core.KeyPair, arg1 string) error {
+func (m *MockCoreAPI) InsertKey(arg0 core.KeyPair, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertKey", arg0, arg1)
+	ret := m.ctrl.Call(m, "InsertKey", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InsertKey indicates an expected call of InsertKey.
-func (mr *MockCoreAPIMockRecorder) InsertKey(arg0, arg1 any) *gomock.Call {
+func (mr *MockCoreAPIMockRecorder) InsertKey(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertKey", reflect.TypeOf((*MockCoreAPI)(nil).InsertKey), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertKey", reflect.TypeOf((*MockCoreAPI)(nil).InsertKey), arg0, arg1, arg2)
 }
 
 // MockSystemAPI is a mock of SystemAPI interface.
@@ -1015,6 +1157,21 @@ func (mr *MockBlockFinalityAPIMockRecorder) PreVotes() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreVotes", reflect.TypeOf((*MockBlockFinalityAPI)(nil).PreVotes))
 }
 
+// ProveFinality mocks base method.
+func (m *MockBlockFinalityAPI) ProveFinality(arg0 uint) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProveFinality", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProveFinality indicates an expected call of ProveFinality.
+func (mr *MockBlockFinalityAPIMockRecorder) ProveFinality(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProveFinality", reflect.TypeOf((*MockBlockFinalityAPI)(nil).ProveFinality), arg0)
+}
+
 // MockRuntimeStorageAPI is a mock of RuntimeStorageAPI interface.
 type MockRuntimeStorageAPI struct {
 	ctrl     *gomock.Controller
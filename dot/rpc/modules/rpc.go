@@ -12,6 +12,8 @@
 	UnsafeMethods = []string{
 		"system_addReservedPeer",
 		"system_removeReservedPeer",
+		"system_addLogFilter",
+		"system_resetLogFilter",
 		"author_submitExtrinsic",
 		"author_removeExtrinsic",
 		"author_insertKey",
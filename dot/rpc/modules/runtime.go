@@ -0,0 +1,87 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/blocktree"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+)
+
+// RuntimeBlockAPI is the interface for the block state methods used to get the runtime of a block
+type RuntimeBlockAPI interface {
+	BestBlockHash() common.Hash
+	GetRuntime(blockHash common.Hash) (instance runtime.Instance, err error)
+}
+
+// RuntimeStorageStateAPI is the interface for the storage state methods used to get the state of a block
+type RuntimeStorageStateAPI interface {
+	GetStateRootFromBlock(bhash *common.Hash) (*common.Hash, error)
+	TrieState(root *common.Hash) (*rtstorage.TrieState, error)
+}
+
+// RuntimeAt returns the runtime instance of the given block, with its storage set to the
+// state of the block. The runtime of a block which is no longer in the block tree is created
+// from the code in the state of the block. The release function must be called once the
+// runtime instance is no longer used.
+func RuntimeAt(blockAPI RuntimeBlockAPI, storageAPI RuntimeStorageStateAPI, hash common.Hash) (
+	instance runtime.Instance, release func(), err error) {
+	stateRoot, err := storageAPI.GetStateRootFromBlock(&hash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting state root: %w", err)
+	}
+
+	trieState, err := storageAPI.TrieState(stateRoot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	instance, err = blockAPI.GetRuntime(hash)
+	switch {
+	case errors.Is(err, blocktree.ErrNodeNotFound):
+		instance, err = newRuntimeFromState(blockAPI, trieState)
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating runtime from state: %w", err)
+		}
+		return instance, instance.Stop, nil
+	case err != nil:
+		return nil, nil, fmt.Errorf("getting runtime: %w", err)
+	}
+
+	instance.SetContextStorage(trieState)
+	return instance, func() {}, nil
+}
+
+// newRuntimeFromState creates a runtime instance from the code in the given state, using
+// the keystore, node storage and network of the runtime of the best block.
+func newRuntimeFromState(blockAPI RuntimeBlockAPI, trieState *rtstorage.TrieState) (
+	instance runtime.Instance, err error) {
+	bestRuntime, err := blockAPI.GetRuntime(blockAPI.BestBlockHash())
+	if err != nil {
+		return nil, fmt.Errorf("getting best block runtime: %w", err)
+	}
+
+	codeHash, err := trieState.LoadCodeHash()
+	if err != nil {
+		return nil, fmt.Errorf("loading code hash: %w", err)
+	}
+
+	config := wazero_runtime.Config{
+		Storage:     trieState,
+		Keystore:    bestRuntime.Keystore(),
+		NodeStorage: bestRuntime.NodeStorage(),
+		Network:     bestRuntime.NetworkService(),
+		CodeHash:    codeHash,
+	}
+	if bestRuntime.Validator() {
+		config.Role = common.AuthorityRole
+	}
+
+	return wazero_runtime.NewInstance(trieState.LoadCode(), config)
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
+	"github.com/ChainSafe/gossamer/lib/blocktree"
+	"github.com/ChainSafe/gossamer/lib/common"
+	mocksruntime "github.com/ChainSafe/gossamer/lib/runtime/mocks"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_RuntimeAt(t *testing.T) {
+	t.Parallel()
+
+	blockHash := common.Hash{1}
+	bestHash := common.Hash{2}
+	stateRoot := common.Hash{3}
+	trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+	errTest := errors.New("test error")
+
+	tests := map[string]struct {
+		buildAPIs  func(ctrl *gomock.Controller) (RuntimeBlockAPI, RuntimeStorageStateAPI)
+		errWrapped error
+		errMessage string
+	}{
+		"state_root_error": {
+			buildAPIs: func(ctrl *gomock.Controller) (RuntimeBlockAPI, RuntimeStorageStateAPI) {
+				storageAPI := mocks.NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&blockHash).Return(nil, errTest)
+				return mocks.NewMockBlockAPI(ctrl), storageAPI
+			},
+			errWrapped: errTest,
+			errMessage: "getting state root: test error",
+		},
+		"trie_state_error": {
+			buildAPIs: func(ctrl *gomock.Controller) (RuntimeBlockAPI, RuntimeStorageStateAPI) {
+				storageAPI := mocks.NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&blockHash).Return(&stateRoot, nil)
+				storageAPI.EXPECT().TrieState(&stateRoot).Return(nil, errTest)
+				return mocks.NewMockBlockAPI(ctrl), storageAPI
+			},
+			errWrapped: errTest,
+			errMessage: "getting trie state: test error",
+		},
+		"get_runtime_error": {
+			buildAPIs: func(ctrl *gomock.Controller) (RuntimeBlockAPI, RuntimeStorageStateAPI) {
+				storageAPI := mocks.NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&blockHash).Return(&stateRoot, nil)
+				storageAPI.EXPECT().TrieState(&stateRoot).Return(trieState, nil)
+				blockAPI := mocks.NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().GetRuntime(blockHash).Return(nil, errTest)
+				return blockAPI, storageAPI
+			},
+			errWrapped: errTest,
+			errMessage: "getting runtime: test error",
+		},
+		"pruned_block_best_runtime_error": {
+			buildAPIs: func(ctrl *gomock.Controller) (RuntimeBlockAPI, RuntimeStorageStateAPI) {
+				storageAPI := mocks.NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&blockHash).Return(&stateRoot, nil)
+				storageAPI.EXPECT().TrieState(&stateRoot).Return(trieState, nil)
+				blockAPI := mocks.NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().GetRuntime(blockHash).
+					Return(nil, fmt.Errorf("while getting runtime: %w", blocktree.ErrNodeNotFound))
+				blockAPI.EXPECT().BestBlockHash().Return(bestHash)
+				blockAPI.EXPECT().GetRuntime(bestHash).Return(nil, errTest)
+				return blockAPI, storageAPI
+			},
+			errWrapped: errTest,
+			errMessage: "creating runtime from state: getting best block runtime: test error",
+		},
+		"block_tree_runtime": {
+			buildAPIs: func(ctrl *gomock.Controller) (RuntimeBlockAPI, RuntimeStorageStateAPI) {
+				storageAPI := mocks.NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&blockHash).Return(&stateRoot, nil)
+				storageAPI.EXPECT().TrieState(&stateRoot).Return(trieState, nil)
+				rt := mocksruntime.NewMockInstance(ctrl)
+				rt.EXPECT().SetContextStorage(trieState)
+				blockAPI := mocks.NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().GetRuntime(blockHash).Return(rt, nil)
+				return blockAPI, storageAPI
+			},
+		},
+	}
+
+	for name, testCase := range tests {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockAPI, storageAPI := testCase.buildAPIs(ctrl)
+			instance, release, err := RuntimeAt(blockAPI, storageAPI, blockHash)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			require.NotNil(t, instance)
+			release()
+		})
+	}
+}
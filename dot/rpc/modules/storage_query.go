@@ -0,0 +1,126 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// Storage query item types of the new JSON-RPC API
+const (
+	StorageQueryTypeValue                        = "value"
+	StorageQueryTypeHash                         = "hash"
+	StorageQueryTypeDescendantsValues            = "descendantsValues"
+	StorageQueryTypeDescendantsHashes            = "descendantsHashes"
+	StorageQueryTypeClosestDescendantMerkleValue = "closestDescendantMerkleValue"
+)
+
+var (
+	// ErrUnknownStorageQueryType is returned when a storage query item type is unknown
+	ErrUnknownStorageQueryType = errors.New("unknown storage query type")
+	// ErrUnsupportedStorageQueryType is returned when a storage query item type is not supported
+	ErrUnsupportedStorageQueryType = errors.New("unsupported storage query type")
+)
+
+// StorageQueryItem is an item of a storage query of the new JSON-RPC API
+type StorageQueryItem struct {
+	Key  string `json:"key"`
+	Type string `json:"type"`
+}
+
+// StorageResultItem is an item of the result of a storage query of the new JSON-RPC API
+type StorageResultItem struct {
+	Key   string  `json:"key"`
+	Value *string `json:"value,omitempty"`
+	Hash  *string `json:"hash,omitempty"`
+}
+
+// QueryStorage returns the storage items matching the given query items in the state of the given block,
+// or in the state of the given child trie if childTrie is not nil. Keys without a value are omitted.
+func QueryStorage(storageAPI StorageQueryAPI, blockHash common.Hash, items []StorageQueryItem,
+	childTrie *string) (results []StorageResultItem, err error) {
+	root, err := storageAPI.GetStateRootFromBlock(&blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting state root from block %s: %w", blockHash, err)
+	}
+
+	get := func(key []byte) ([]byte, error) {
+		return storageAPI.GetStorage(root, key)
+	}
+	keysWithPrefix := func(prefix []byte) ([][]byte, error) {
+		return storageAPI.GetKeysWithPrefix(root, prefix)
+	}
+
+	if childTrie != nil {
+		keyToChild, err := common.HexToBytes(*childTrie)
+		if err != nil {
+			return nil, fmt.Errorf("decoding child trie key: %w", err)
+		}
+
+		child, err := storageAPI.GetStorageChild(root, keyToChild)
+		if err != nil {
+			return nil, fmt.Errorf("getting child trie: %w", err)
+		}
+
+		get = func(key []byte) ([]byte, error) {
+			return child.Get(key), nil
+		}
+		keysWithPrefix = func(prefix []byte) ([][]byte, error) {
+			return child.GetKeysWithPrefix(prefix), nil
+		}
+	}
+
+	results = []StorageResultItem{}
+	for _, item := range items {
+		key, err := common.HexToBytes(item.Key)
+		if err != nil {
+			return nil, fmt.Errorf("decoding key: %w", err)
+		}
+
+		var keys [][]byte
+		switch item.Type {
+		case StorageQueryTypeValue, StorageQueryTypeHash:
+			keys = [][]byte{key}
+		case StorageQueryTypeDescendantsValues, StorageQueryTypeDescendantsHashes:
+			keys, err = keysWithPrefix(key)
+			if err != nil {
+				return nil, fmt.Errorf("getting keys with prefix 0x%x: %w", key, err)
+			}
+		case StorageQueryTypeClosestDescendantMerkleValue:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedStorageQueryType, item.Type)
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownStorageQueryType, item.Type)
+		}
+
+		for _, key := range keys {
+			value, err := get(key)
+			if err != nil {
+				return nil, fmt.Errorf("getting value of key 0x%x: %w", key, err)
+			}
+			if value == nil {
+				continue
+			}
+
+			result := StorageResultItem{Key: common.BytesToHex(key)}
+			switch item.Type {
+			case StorageQueryTypeValue, StorageQueryTypeDescendantsValues:
+				encodedValue := common.BytesToHex(value)
+				result.Value = &encodedValue
+			case StorageQueryTypeHash, StorageQueryTypeDescendantsHashes:
+				hash, err := common.Blake2bHash(value)
+				if err != nil {
+					return nil, fmt.Errorf("hashing value of key 0x%x: %w", key, err)
+				}
+				encodedHash := hash.String()
+				result.Hash = &encodedHash
+			}
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
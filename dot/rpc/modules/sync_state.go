@@ -4,10 +4,13 @@
 package modules
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 )
 
 // GenSyncSpecRequest represents request to get chain specification.
@@ -40,6 +43,7 @@ func (ss *SyncStateModule) GenSyncSpec(_ *http.Request, req *GenSyncSpecRequest,
 // syncState implements SyncStateAPI.
 type syncState struct {
 	chainSpecification *genesis.Genesis
+	storageAPI         StorageAPI
 }
 
 // NewStateSync creates an instance of SyncStateAPI given a chain specification.
@@ -71,18 +75,57 @@ func NewStateSync(gData *genesis.Data, storageAPI StorageAPI) (SyncStateAPI, err
 	tmpGen.ID = gData.ID
 	tmpGen.Bootnodes = common.BytesToStringArray(gData.Bootnodes)
 	tmpGen.ProtocolID = gData.ProtocolID
-	return syncState{chainSpecification: tmpGen}, nil
+	return syncState{chainSpecification: tmpGen, storageAPI: storageAPI}, nil
 }
 
 // GenSyncSpec returns the JSON serialised chain specification running the node
-// (i.e. the current state), with a sync state.
+// (i.e. the current state), with a sync state. The raw chain specification holds
+// the key-values of the current state, and of its default child tries.
 func (s syncState) GenSyncSpec(raw bool) (*genesis.Genesis, error) {
-	if raw {
-		err := s.chainSpecification.ToRaw()
+	if !raw {
+		return s.chainSpecification, nil
+	}
+
+	top, childrenDefault, err := s.rawStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	rawSpecification := *s.chainSpecification
+	rawSpecification.Genesis = genesis.Fields{
+		Raw:             map[string]map[string]string{"top": top},
+		ChildrenDefault: childrenDefault,
+	}
+	return &rawSpecification, nil
+}
+
+// rawStorage returns the hex encoded key-values of the current state, without the child trie
+// roots, and the hex encoded key-values of each child trie keyed by its hex encoded child storage key.
+func (s syncState) rawStorage() (top map[string]string, childrenDefault map[string]map[string]string, err error) {
+	entries, err := s.storageAPI.Entries(nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting storage entries: %w", err)
+	}
+
+	top = make(map[string]string, len(entries))
+	childrenDefault = make(map[string]map[string]string)
+	for key, value := range entries {
+		if !bytes.HasPrefix([]byte(key), inmemory_trie.ChildStorageKeyPrefix) {
+			top[common.BytesToHex([]byte(key))] = common.BytesToHex(value)
+			continue
+		}
+
+		keyToChild := []byte(key)[len(inmemory_trie.ChildStorageKeyPrefix):]
+		child, err := s.storageAPI.GetStorageChild(nil, keyToChild)
 		if err != nil {
-			return nil, err
+			return nil, nil, fmt.Errorf("getting child trie at key 0x%x: %w", keyToChild, err)
 		}
-	}
 
-	return s.chainSpecification, nil
+		childEntries := make(map[string]string)
+		for childKey, childValue := range child.Entries() {
+			childEntries[common.BytesToHex([]byte(childKey))] = common.BytesToHex(childValue)
+		}
+		childrenDefault[common.BytesToHex(keyToChild)] = childEntries
+	}
+	return top, childrenDefault, nil
 }
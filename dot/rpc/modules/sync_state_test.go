@@ -11,6 +11,7 @@
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 	"go.uber.org/mock/gomock"
 
 	"github.com/stretchr/testify/assert"
@@ -121,6 +122,7 @@ type args struct {
 					Runtime: new(genesis.Runtime),
 				},
 			},
+				storageAPI: mockStorageAPI,
 			},
 		},
 		{
@@ -146,45 +148,92 @@ type args struct {
 }
 
 func Test_syncState_GenSyncSpec(t *testing.T) {
-	type fields struct {
-		chainSpecification genesis.Genesis
+	t.Parallel()
+
+	child := inmemory_trie.NewEmptyTrie()
+	child.Put([]byte{3}, []byte{4})
+	childRoot := child.MustHash()
+	entries := map[string][]byte{
+		string([]byte{1}): {2},
+		string(append(inmemory_trie.ChildStorageKeyPrefix, []byte("child")...)): childRoot.ToBytes(),
 	}
-	type args struct {
-		raw bool
+
+	chainSpecification := genesis.Genesis{
+		Name: "name",
+		Genesis: genesis.Fields{
+			Raw:     map[string]map[string]string{"top": {"0x3a636f6465": "0x0102"}},
+			Runtime: &genesis.Runtime{System: &genesis.System{Code: "0x0102"}},
+		},
 	}
-	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		expErr error
-		exp    genesis.Genesis
+
+	tests := map[string]struct {
+		storageAPIBuilder func(ctrl *gomock.Controller) StorageAPI
+		raw               bool
+		exp               *genesis.Genesis
+		expErr            string
 	}{
-		{
-			name:   "GenSyncSpec False",
-			fields: fields{genesis.Genesis{}},
-			exp:    genesis.Genesis{},
+		"not_raw": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				return nil
+			},
+			exp: &chainSpecification,
 		},
-		{
-			name:   "GenSyncSpec True",
-			fields: fields{genesis.Genesis{}},
-			args: args{
-				raw: true,
+		"raw": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := mocks.NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().Entries((*common.Hash)(nil)).Return(entries, nil)
+				storageAPI.EXPECT().GetStorageChild((*common.Hash)(nil), []byte("child")).Return(child, nil)
+				return storageAPI
 			},
-			exp: genesis.Genesis{},
+			raw: true,
+			exp: &genesis.Genesis{
+				Name: "name",
+				Genesis: genesis.Fields{
+					Raw: map[string]map[string]string{"top": {"0x01": "0x02"}},
+					ChildrenDefault: map[string]map[string]string{
+						"0x6368696c64": {"0x03": "0x04"},
+					},
+				},
+			},
+		},
+		"entries_error": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := mocks.NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().Entries((*common.Hash)(nil)).Return(nil, errors.New("entries error"))
+				return storageAPI
+			},
+			raw:    true,
+			expErr: "getting storage entries: entries error",
+		},
+		"child_trie_error": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := mocks.NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().Entries((*common.Hash)(nil)).Return(entries, nil)
+				storageAPI.EXPECT().GetStorageChild((*common.Hash)(nil), []byte("child")).
+					Return(nil, errors.New("child error"))
+				return storageAPI
+			},
+			raw:    true,
+			expErr: "getting child trie at key 0x6368696c64: child error",
 		},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
 			s := syncState{
-				chainSpecification: &tt.fields.chainSpecification,
+				chainSpecification: &chainSpecification,
+				storageAPI:         tt.storageAPIBuilder(ctrl),
 			}
-			res, err := s.GenSyncSpec(tt.args.raw)
-			if tt.expErr != nil {
-				assert.EqualError(t, err, tt.expErr.Error())
+			res, err := s.GenSyncSpec(tt.raw)
+			if tt.expErr != "" {
+				assert.EqualError(t, err, tt.expErr)
 			} else {
 				assert.NoError(t, err)
 			}
-			assert.Equal(t, tt.exp, *res)
+			assert.Equal(t, tt.exp, res)
 		})
 	}
 }
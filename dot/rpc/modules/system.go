@@ -6,10 +6,12 @@
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"math/big"
 	"net/http"
 	"strings"
 
+	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/pkg/scale"
@@ -165,6 +167,9 @@ func (sm *SystemModule) AccountNextIndex(r *http.Request, req *StringRequest, re
 		return errors.New("account address must be valid")
 	}
 	addressPubKey := crypto.PublicAddressToByteArray(common.Address(req.String))
+	if addressPubKey == nil {
+		return errors.New("account address must be valid")
+	}
 
 	// check pending transactions for extrinsics singed by addressPubKey
 	pending := sm.txStateAPI.Pending()
@@ -289,3 +294,47 @@ func (sm *SystemModule) RemoveReservedPeer(r *http.Request, req *StringRequest,
 
 	return sm.networkAPI.RemoveReservedPeers(req.String)
 }
+
+// AddLogFilter adds log filter directives overriding the log level of modules at runtime.
+// The directives are a comma separated list of 'module=level', such as 'sync=trace,network=debug',
+// where a directive without module such as 'debug' applies to all the modules.
+func (sm *SystemModule) AddLogFilter(r *http.Request, req *StringRequest, res *[]byte) error {
+	options, err := parseLogFilter(req.String)
+	if err != nil {
+		return err
+	}
+
+	log.Patch(options...)
+	return nil
+}
+
+// ResetLogFilter removes all the log filter directives added with AddLogFilter,
+// so modules log again at the level set when the node started.
+func (sm *SystemModule) ResetLogFilter(r *http.Request, req *EmptyRequest, res *[]byte) error {
+	log.Patch(log.ResetModuleLevels())
+	return nil
+}
+
+// parseLogFilter parses the comma separated log filter directives
+// into the log options overriding the level of their modules.
+func parseLogFilter(directives string) (options []log.Option, err error) {
+	if strings.TrimSpace(directives) == "" {
+		return nil, errors.New("cannot add an empty log filter")
+	}
+
+	for _, directive := range strings.Split(directives, ",") {
+		module, levelString, found := strings.Cut(directive, "=")
+		if !found {
+			module, levelString = "", directive
+		}
+
+		level, err := log.ParseLevel(strings.TrimSpace(levelString))
+		if err != nil {
+			return nil, fmt.Errorf("parsing log filter directive %q: %w", directive, err)
+		}
+
+		options = append(options, log.SetModuleLevel(strings.TrimSpace(module), level))
+	}
+
+	return options, nil
+}
@@ -4,13 +4,16 @@
 package modules
 
 import (
+	"bytes"
 	"errors"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
 	testdata "github.com/ChainSafe/gossamer/dot/rpc/modules/test_data"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/multiformats/go-multiaddr"
@@ -281,6 +284,14 @@ type args struct {
 			args:      args{},
 			expErr:    errors.New("account address must be valid"),
 		},
+		{
+			name:      "invalid_address_checksum",
+			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPI, mockTxStateAPI, nil, nil),
+			args: args{
+				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+			},
+			expErr: errors.New("account address must be valid"),
+		},
 		{
 			name:      "found_in_pending_transactions",
 			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPI, mockTxStateAPI, nil, nil),
@@ -293,7 +304,7 @@ type args struct {
 			name:      "not_found_in_pending_transactions",
 			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPI, mockTxStateAPI, nil, nil),
 			args: args{
-				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKuxvW"},
 			},
 			exp: U64Response(3),
 		},
@@ -301,7 +312,7 @@ type args struct {
 			name:      "GetMetadata Err",
 			sysModule: NewSystemModule(nil, nil, mockCoreAPIErr, mockStorageAPI, mockTxStateAPI, nil, nil),
 			args: args{
-				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKuxvW"},
 			},
 			expErr: errors.New("getMetadata error"),
 		},
@@ -309,7 +320,7 @@ type args struct {
 			name:      "Magic Number Mismatch",
 			sysModule: NewSystemModule(nil, nil, mockCoreAPIMagicNumMismatch, mockStorageAPI, mockTxStateAPI, nil, nil),
 			args: args{
-				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKuxvW"},
 			},
 			expErr: errors.New("magic number mismatch: expected 0x6174656d, found 0xe03056ea"),
 		},
@@ -317,7 +328,7 @@ type args struct {
 			name:      "GetStorage Err",
 			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPIErr, mockTxStateAPI, nil, nil),
 			args: args{
-				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKuxvW"},
 			},
 			expErr: errors.New("getStorage error"),
 		},
@@ -644,3 +655,98 @@ type args struct {
 		})
 	}
 }
+
+func TestSystemModule_AddLogFilter(t *testing.T) {
+	sm := NewSystemModule(nil, nil, nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		name   string
+		req    *StringRequest
+		expErr error
+	}{
+		{
+			name: "OK",
+			req:  &StringRequest{"sync=trace,network=debug"},
+		},
+		{
+			name:   "Empty StringRequest Error",
+			req:    &StringRequest{" "},
+			expErr: errors.New("cannot add an empty log filter"),
+		},
+		{
+			name:   "Invalid Level Error",
+			req:    &StringRequest{"sync=verbose"},
+			expErr: errors.New(`parsing log filter directive "sync=verbose": level is not recognised: verbose`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := []byte(nil)
+			err := sm.AddLogFilter(nil, tt.req, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, []byte(nil), res)
+
+			err = sm.ResetLogFilter(nil, &EmptyRequest{}, &res)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_parseLogFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		directives string
+		// loggedModules are the modules logging at the trace level
+		loggedModules []string
+	}{
+		"module": {
+			directives:    "sync=trace",
+			loggedModules: []string{"sync"},
+		},
+		"modules_with_spaces": {
+			directives:    " sync = trace, core=5 ,network=info",
+			loggedModules: []string{"sync", "core"},
+		},
+		"all_modules": {
+			directives:    "trace,network=info",
+			loggedModules: []string{"sync", "core"},
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			options, err := parseLogFilter(tt.directives)
+			require.NoError(t, err)
+
+			buffer := bytes.NewBuffer(nil)
+			logger := log.New(log.SetWriter(buffer), log.SetLevel(log.Info))
+			modules := []string{"sync", "core", "network"}
+			moduleLoggers := make([]*log.Logger, len(modules))
+			for i, module := range modules {
+				moduleLoggers[i] = logger.New(log.AddContext("pkg", module))
+			}
+
+			logger.Patch(options...)
+			for i, module := range modules {
+				moduleLoggers[i].Trace(module)
+			}
+
+			var loggedModules []string
+			for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+				if line == "" {
+					continue
+				}
+				_, module, _ := strings.Cut(line, "pkg=")
+				loggedModules = append(loggedModules, module)
+			}
+			assert.Equal(t, tt.loggedModules, loggedModules)
+		})
+	}
+}
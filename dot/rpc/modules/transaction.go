@@ -0,0 +1,28 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"net/http"
+)
+
+// TransactionModule is an RPC module of the new JSON-RPC API broadcasting transactions.
+// Its methods are bound to the broadcast operations of the connection, and are handled
+// by the websocket handler. They remain here so they are added to the rpc_methods list.
+type TransactionModule struct{}
+
+// NewTransactionModule creates a new transaction module.
+func NewTransactionModule() *TransactionModule {
+	return &TransactionModule{}
+}
+
+// V1Broadcast handled by websocket handler
+func (*TransactionModule) V1Broadcast(_ *http.Request, _ *EmptyRequest, _ *string) error {
+	return ErrSubscriptionTransport
+}
+
+// V1Stop handled by websocket handler
+func (*TransactionModule) V1Stop(_ *http.Request, _ *EmptyRequest, _ *interface{}) error {
+	return ErrSubscriptionTransport
+}
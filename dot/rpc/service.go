@@ -7,7 +7,6 @@
 	"fmt"
 	"net/http"
 	"reflect"
-	"strings"
 	"unicode"
 	"unicode/utf8"
 )
@@ -75,8 +74,7 @@ func (s *Service) BuildMethodNames(rcvr interface{}, name string) {
 			continue
 		}
 
-		s.rpcMethods = append(s.rpcMethods,
-			fmt.Sprintf("%s_%s%s", name, strings.ToLower(string(method.Name[0])), method.Name[1:]))
+		s.rpcMethods = append(s.rpcMethods, fmt.Sprintf("%s_%s", name, rpcMethodName(method.Name)))
 	}
 }
 
@@ -0,0 +1,951 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package subscription
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/modules"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+const chainHeadFollowEventMethod = "chainHead_v1_followEvent"
+
+const (
+	// maxPinnedBlocks is the maximum number of blocks pinned by a follow subscription,
+	// the subscription is stopped when it is reached.
+	maxPinnedBlocks = 512
+	// maxChainHeadOperations is the maximum number of ongoing operations of a follow subscription.
+	maxChainHeadOperations = 16
+)
+
+var (
+	errInvalidBlockHash     = errors.New("invalid block hash")
+	errDuplicateBlockHashes = errors.New("duplicate block hashes")
+	errTooManyPinnedBlocks  = errors.New("too many pinned blocks")
+	errCoreAPINotSet        = errors.New("error CoreAPI not set")
+	// errUnknownFollowSubscription is returned by the operations of a follow subscription which
+	// does not exist or was unfollowed.
+	errUnknownFollowSubscription = errors.New("unknown follow subscription")
+)
+
+type chainHeadInitializedEvent struct {
+	Event                 string                 `json:"event"`
+	FinalizedBlockHashes  []string               `json:"finalizedBlockHashes"`
+	FinalizedBlockRuntime *chainHeadRuntimeEvent `json:"finalizedBlockRuntime,omitempty"`
+}
+
+type chainHeadNewBlockEvent struct {
+	Event           string                 `json:"event"`
+	BlockHash       string                 `json:"blockHash"`
+	ParentBlockHash string                 `json:"parentBlockHash"`
+	NewRuntime      *chainHeadRuntimeEvent `json:"newRuntime"`
+}
+
+type chainHeadBestBlockChangedEvent struct {
+	Event         string `json:"event"`
+	BestBlockHash string `json:"bestBlockHash"`
+}
+
+type chainHeadFinalizedEvent struct {
+	Event                string   `json:"event"`
+	FinalizedBlockHashes []string `json:"finalizedBlockHashes"`
+	PrunedBlockHashes    []string `json:"prunedBlockHashes"`
+}
+
+type chainHeadOperationEvent struct {
+	Event       string `json:"event"`
+	OperationID string `json:"operationId"`
+}
+
+type chainHeadOperationBodyDoneEvent struct {
+	chainHeadOperationEvent
+	Value []string `json:"value"`
+}
+
+type chainHeadOperationCallDoneEvent struct {
+	chainHeadOperationEvent
+	Output string `json:"output"`
+}
+
+type chainHeadOperationStorageItemsEvent struct {
+	chainHeadOperationEvent
+	Items []modules.StorageResultItem `json:"items"`
+}
+
+type chainHeadOperationErrorEvent struct {
+	chainHeadOperationEvent
+	Error string `json:"error"`
+}
+
+type chainHeadStopEvent struct {
+	Event string `json:"event"`
+}
+
+type chainHeadRuntimeEvent struct {
+	Type  string                `json:"type"`
+	Spec  *chainHeadRuntimeSpec `json:"spec,omitempty"`
+	Error string                `json:"error,omitempty"`
+}
+
+type chainHeadRuntimeSpec struct {
+	SpecName           string            `json:"specName"`
+	ImplName           string            `json:"implName"`
+	SpecVersion        uint32            `json:"specVersion"`
+	ImplVersion        uint32            `json:"implVersion"`
+	TransactionVersion uint32            `json:"transactionVersion"`
+	APIs               map[string]uint32 `json:"apis"`
+}
+
+// chainHeadOperationResponse is the result of the chainHead_v1 body, call and storage methods.
+type chainHeadOperationResponse struct {
+	Result         string `json:"result"`
+	OperationID    string `json:"operationId,omitempty"`
+	DiscardedItems *uint  `json:"discardedItems,omitempty"`
+}
+
+// ChainHeadFollowListener follows the blocks descending from the last finalised block, and pins
+// the blocks it reports until they are unpinned, so their header, body and storage can be queried.
+type ChainHeadFollowListener struct {
+	wsconn        *WSConn
+	subID         uint32
+	withRuntime   bool
+	importedChan  chan *types.Block
+	finalisedChan chan *types.FinalisationInfo
+	done          chan struct{}
+	cancel        chan struct{}
+	cancelTimeout time.Duration
+
+	// blocks are the headers of the reported blocks which are not pruned, including
+	// the last reported finalised block. They are only accessed by the listening goroutine.
+	blocks          map[common.Hash]*types.Header
+	runtimeVersions map[common.Hash]runtime.Version
+	finalisedHash   common.Hash
+	bestHash        common.Hash
+
+	mu                sync.Mutex
+	stopped           bool
+	pinned            map[common.Hash]struct{}
+	operations        map[string]struct{}
+	operationsCounter uint64
+}
+
+func (c *WSConn) initChainHeadFollowListener(reqID float64, params interface{}) (Listener, error) {
+	values, err := methodParams(params, 1)
+	if err != nil {
+		c.safeSendError(reqID, errorCode(err), err.Error())
+		return nil, err
+	}
+
+	withRuntime, ok := values[0].(bool)
+	if !ok {
+		err = fmt.Errorf("%w: %T, expected type bool", errUnexpectedType, values[0])
+		c.safeSendError(reqID, errorCode(err), err.Error())
+		return nil, err
+	}
+
+	if c.BlockAPI == nil {
+		c.safeSendError(reqID, nil, errBlockAPINotSet.Error())
+		return nil, errBlockAPINotSet
+	}
+
+	if withRuntime && c.CoreAPI == nil {
+		c.safeSendError(reqID, nil, errCoreAPINotSet.Error())
+		return nil, errCoreAPINotSet
+	}
+
+	listener := &ChainHeadFollowListener{
+		wsconn:          c,
+		withRuntime:     withRuntime,
+		importedChan:    c.BlockAPI.GetImportedBlockNotifierChannel(),
+		finalisedChan:   c.BlockAPI.GetFinalisedNotifierChannel(),
+		cancel:          make(chan struct{}, 1),
+		done:            make(chan struct{}, 1),
+		cancelTimeout:   defaultCancelTimeout,
+		blocks:          make(map[common.Hash]*types.Header),
+		runtimeVersions: make(map[common.Hash]runtime.Version),
+		pinned:          make(map[common.Hash]struct{}),
+		operations:      make(map[string]struct{}),
+	}
+
+	c.mu.Lock()
+	listener.subID = atomic.AddUint32(&c.qtyListeners, 1)
+	c.Subscriptions[listener.subID] = listener
+	c.mu.Unlock()
+
+	c.safeSend(newResultResponseJSON(strconv.FormatUint(uint64(listener.subID), 10), reqID))
+	return listener, nil
+}
+
+// Listen reports the finalised block and its descendants, and then
+// starts a goroutine reporting the imported and finalised blocks.
+func (l *ChainHeadFollowListener) Listen() {
+	go func() {
+		defer func() {
+			l.wsconn.BlockAPI.FreeImportedBlockNotifierChannel(l.importedChan)
+			l.wsconn.BlockAPI.FreeFinalisedNotifierChannel(l.finalisedChan)
+			l.unpinAll()
+			close(l.done)
+		}()
+
+		err := l.initialise()
+		if err != nil {
+			logger.Debugf("stopping follow subscription %d: initialising: %s", l.subID, err)
+			l.sendStop()
+			return
+		}
+
+		for {
+			select {
+			case <-l.cancel:
+				return
+			case block, ok := <-l.importedChan:
+				if !ok {
+					return
+				}
+
+				if block == nil {
+					continue
+				}
+
+				err = l.reportBlock(block.Header.Hash())
+				if err == nil {
+					err = l.updateBestBlock()
+				}
+			case info, ok := <-l.finalisedChan:
+				if !ok {
+					return
+				}
+
+				if info == nil {
+					continue
+				}
+
+				err = l.handleFinalisedBlock(info.Header.Hash())
+			}
+
+			if err != nil {
+				logger.Debugf("stopping follow subscription %d: %s", l.subID, err)
+				l.sendStop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the goroutine reporting the blocks.
+func (l *ChainHeadFollowListener) Stop() error {
+	l.mu.Lock()
+	l.stopped = true
+	l.mu.Unlock()
+
+	return cancelWithTimeout(l.cancel, l.done, l.cancelTimeout)
+}
+
+// initialise reports the last finalised block, its descendants and the best block.
+func (l *ChainHeadFollowListener) initialise() error {
+	finalisedHash, err := l.wsconn.BlockAPI.GetHighestFinalisedHash()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised hash: %w", err)
+	}
+
+	finalisedHeader, err := l.wsconn.BlockAPI.GetHeader(finalisedHash)
+	if err != nil {
+		return fmt.Errorf("getting finalised header: %w", err)
+	}
+
+	event := chainHeadInitializedEvent{
+		Event:                "initialized",
+		FinalizedBlockHashes: []string{finalisedHash.String()},
+	}
+	if l.withRuntime {
+		event.FinalizedBlockRuntime = l.runtimeEvent(finalisedHash)
+	}
+
+	l.blocks[finalisedHash] = finalisedHeader
+	l.finalisedHash = finalisedHash
+	err = l.pin(finalisedHash)
+	if err != nil {
+		return err
+	}
+	l.send(event)
+
+	descendants, err := l.wsconn.BlockAPI.GetAllDescendants(finalisedHash)
+	if err != nil {
+		return fmt.Errorf("getting descendants of finalised block: %w", err)
+	}
+
+	for _, hash := range descendants {
+		err = l.reportBlock(hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return l.updateBestBlock()
+}
+
+// reportBlock reports the block with the given hash, and its ancestors which are not reported yet,
+// with newBlock events. The block is ignored if it does not descend from the last finalised block.
+func (l *ChainHeadFollowListener) reportBlock(hash common.Hash) error {
+	finalisedHeader := l.blocks[l.finalisedHash]
+
+	var unreported []*types.Header
+	for {
+		if _, ok := l.blocks[hash]; ok {
+			break
+		}
+
+		header, err := l.wsconn.BlockAPI.GetHeader(hash)
+		if err != nil {
+			return fmt.Errorf("getting header of block %s: %w", hash, err)
+		}
+
+		if header.Number <= finalisedHeader.Number {
+			logger.Debugf("ignoring block %s not descending from finalised block %s", hash, l.finalisedHash)
+			return nil
+		}
+
+		unreported = append(unreported, header)
+		hash = header.ParentHash
+	}
+
+	for i := len(unreported) - 1; i >= 0; i-- {
+		header := unreported[i]
+		hash := header.Hash()
+
+		event := chainHeadNewBlockEvent{
+			Event:           "newBlock",
+			BlockHash:       hash.String(),
+			ParentBlockHash: header.ParentHash.String(),
+		}
+		if l.withRuntime {
+			event.NewRuntime = l.newRuntimeEvent(hash, header.ParentHash)
+		}
+
+		l.blocks[hash] = header
+		err := l.pin(hash)
+		if err != nil {
+			return err
+		}
+		l.send(event)
+	}
+
+	return nil
+}
+
+// updateBestBlock reports the best block with a bestBlockChanged event if it changed.
+func (l *ChainHeadFollowListener) updateBestBlock() error {
+	bestHash := l.wsconn.BlockAPI.BestBlockHash()
+	if bestHash == l.bestHash {
+		return nil
+	}
+
+	err := l.reportBlock(bestHash)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := l.blocks[bestHash]; !ok {
+		return nil
+	}
+
+	l.bestHash = bestHash
+	l.send(chainHeadBestBlockChangedEvent{
+		Event:         "bestBlockChanged",
+		BestBlockHash: bestHash.String(),
+	})
+	return nil
+}
+
+// handleFinalisedBlock reports the blocks finalised since the last finalised block, and the
+// reported blocks which are pruned since they do not descend from the new finalised block.
+func (l *ChainHeadFollowListener) handleFinalisedBlock(hash common.Hash) error {
+	if hash == l.finalisedHash {
+		return nil
+	}
+
+	err := l.reportBlock(hash)
+	if err != nil {
+		return err
+	}
+
+	finalisedHeader, ok := l.blocks[hash]
+	if !ok {
+		return nil
+	}
+
+	// the finalised blocks are reported from the lowest to the highest
+	var finalised []common.Hash
+	for finalisedHash := hash; finalisedHash != l.finalisedHash; {
+		finalised = append([]common.Hash{finalisedHash}, finalised...)
+		finalisedHash = l.blocks[finalisedHash].ParentHash
+	}
+
+	// the blocks which are finalised or do not descend from the new finalised block
+	// are forgotten, and the latter are reported as pruned.
+	forgotten := map[common.Hash]struct{}{l.finalisedHash: {}}
+	for _, finalisedHash := range finalised[:len(finalised)-1] {
+		forgotten[finalisedHash] = struct{}{}
+	}
+
+	var pruned []common.Hash
+	for blockHash, header := range l.blocks {
+		_, isForgotten := forgotten[blockHash]
+		if isForgotten || blockHash == hash ||
+			header.Number > finalisedHeader.Number && l.isDescendantOf(header, finalisedHeader) {
+			continue
+		}
+		pruned = append(pruned, blockHash)
+	}
+
+	for _, blockHash := range pruned {
+		forgotten[blockHash] = struct{}{}
+	}
+	for blockHash := range forgotten {
+		delete(l.blocks, blockHash)
+		delete(l.runtimeVersions, blockHash)
+	}
+	l.finalisedHash = hash
+
+	// the best block changes before the finalized event if it is pruned
+	err = l.updateBestBlock()
+	if err != nil {
+		return err
+	}
+
+	l.send(chainHeadFinalizedEvent{
+		Event:                "finalized",
+		FinalizedBlockHashes: hashesToStrings(finalised),
+		PrunedBlockHashes:    hashesToStrings(pruned),
+	})
+	return nil
+}
+
+// isDescendantOf returns true if the reported block with the given header descends from the given ancestor.
+func (l *ChainHeadFollowListener) isDescendantOf(header, ancestor *types.Header) bool {
+	for header.Number > ancestor.Number {
+		parent, ok := l.blocks[header.ParentHash]
+		if !ok {
+			return false
+		}
+		header = parent
+	}
+	return header.Hash() == ancestor.Hash()
+}
+
+// newRuntimeEvent returns the runtime of the given block if it differs from the runtime of its parent.
+func (l *ChainHeadFollowListener) newRuntimeEvent(hash, parentHash common.Hash) *chainHeadRuntimeEvent {
+	event := l.runtimeEvent(hash)
+	parentVersion, ok := l.runtimeVersions[parentHash]
+	version, hasVersion := l.runtimeVersions[hash]
+	if ok && hasVersion && parentVersion.SpecVersion == version.SpecVersion &&
+		parentVersion.ImplVersion == version.ImplVersion &&
+		parentVersion.TransactionVersion == version.TransactionVersion {
+		return nil
+	}
+	return event
+}
+
+// runtimeEvent returns the runtime of the given block, and stores its version.
+func (l *ChainHeadFollowListener) runtimeEvent(hash common.Hash) *chainHeadRuntimeEvent {
+	version, err := l.wsconn.CoreAPI.GetRuntimeVersion(&hash)
+	if err != nil {
+		return &chainHeadRuntimeEvent{
+			Type:  "invalid",
+			Error: err.Error(),
+		}
+	}
+	l.runtimeVersions[hash] = version
+
+	apis := make(map[string]uint32, len(version.APIItems))
+	for _, apiItem := range version.APIItems {
+		apis["0x"+hex.EncodeToString(apiItem.Name[:])] = apiItem.Ver
+	}
+
+	return &chainHeadRuntimeEvent{
+		Type: "valid",
+		Spec: &chainHeadRuntimeSpec{
+			SpecName:           string(version.SpecName),
+			ImplName:           string(version.ImplName),
+			SpecVersion:        version.SpecVersion,
+			ImplVersion:        version.ImplVersion,
+			TransactionVersion: version.TransactionVersion,
+			APIs:               apis,
+		},
+	}
+}
+
+// pin pins the block in the block state, so its header, body and state
+// are kept until it is unpinned, even if it is pruned.
+func (l *ChainHeadFollowListener) pin(hash common.Hash) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.pinned[hash]; ok {
+		return nil
+	}
+
+	err := l.wsconn.BlockAPI.PinBlock(hash)
+	if err != nil {
+		return fmt.Errorf("pinning block %s: %w", hash, err)
+	}
+
+	l.pinned[hash] = struct{}{}
+	if len(l.pinned) > maxPinnedBlocks {
+		return fmt.Errorf("%w: %d", errTooManyPinnedBlocks, len(l.pinned))
+	}
+	return nil
+}
+
+func (l *ChainHeadFollowListener) isPinned(hash common.Hash) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, ok := l.pinned[hash]
+	return ok
+}
+
+// unpin unpins the given blocks, or none of them if any of them is not pinned or is duplicated.
+func (l *ChainHeadFollowListener) unpin(hashes []common.Hash) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	seen := make(map[common.Hash]struct{}, len(hashes))
+	for _, hash := range hashes {
+		if _, ok := seen[hash]; ok {
+			return fmt.Errorf("%w: %s", errDuplicateBlockHashes, hash)
+		}
+		seen[hash] = struct{}{}
+
+		if _, ok := l.pinned[hash]; !ok {
+			return fmt.Errorf("%w: %s", errInvalidBlockHash, hash)
+		}
+	}
+
+	for _, hash := range hashes {
+		delete(l.pinned, hash)
+		l.wsconn.BlockAPI.UnpinBlock(hash)
+	}
+	return nil
+}
+
+// unpinAll unpins all the blocks pinned by the follow subscription once it stops.
+func (l *ChainHeadFollowListener) unpinAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for hash := range l.pinned {
+		delete(l.pinned, hash)
+		l.wsconn.BlockAPI.UnpinBlock(hash)
+	}
+}
+
+func (l *ChainHeadFollowListener) isStopped() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.stopped
+}
+
+// startOperation registers a new operation and returns its id, or returns false
+// if the maximum number of ongoing operations is reached or the subscription ended.
+func (l *ChainHeadFollowListener) startOperation() (operationID string, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.stopped || len(l.operations) >= maxChainHeadOperations {
+		return "", false
+	}
+
+	l.operationsCounter++
+	operationID = strconv.FormatUint(l.operationsCounter, 10)
+	l.operations[operationID] = struct{}{}
+	return operationID, true
+}
+
+// finishOperation sends the events of the given operation unless it was stopped.
+func (l *ChainHeadFollowListener) finishOperation(operationID string, events ...interface{}) {
+	l.mu.Lock()
+	_, ok := l.operations[operationID]
+	delete(l.operations, operationID)
+	stopped := l.stopped
+	l.mu.Unlock()
+
+	if !ok || stopped {
+		return
+	}
+
+	for _, event := range events {
+		l.send(event)
+	}
+}
+
+func (l *ChainHeadFollowListener) stopOperation(operationID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.operations, operationID)
+}
+
+func (l *ChainHeadFollowListener) operationError(operationID string, err error) chainHeadOperationErrorEvent {
+	return chainHeadOperationErrorEvent{
+		chainHeadOperationEvent: chainHeadOperationEvent{Event: "operationError", OperationID: operationID},
+		Error:                   err.Error(),
+	}
+}
+
+func (l *ChainHeadFollowListener) send(event interface{}) {
+	l.wsconn.safeSend(newNewAPIResponse(chainHeadFollowEventMethod, l.subID, event))
+}
+
+func (l *ChainHeadFollowListener) sendStop() {
+	l.mu.Lock()
+	l.stopped = true
+	l.mu.Unlock()
+
+	l.send(chainHeadStopEvent{Event: "stop"})
+}
+
+// followListener returns the active follow listener of the given subscription id, or nil if there is none.
+func (c *WSConn) followListener(followSubscription interface{}) *ChainHeadFollowListener {
+	listener := c.followSubscription(followSubscription)
+	if listener == nil || listener.isStopped() {
+		return nil
+	}
+	return listener
+}
+
+// followSubscription returns the follow listener of the given subscription id, which
+// may have ended, or nil if there is none.
+func (c *WSConn) followSubscription(followSubscription interface{}) *ChainHeadFollowListener {
+	subID, err := parseSubscribeID([]interface{}{followSubscription})
+	if err != nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	listener, ok := c.Subscriptions[subID].(*ChainHeadFollowListener)
+	if !ok {
+		return nil
+	}
+	return listener
+}
+
+func (c *WSConn) handleChainHeadUnfollow(reqID float64, params interface{}) {
+	values, err := methodParams(params, 1)
+	if err != nil {
+		c.sendMethodResult(reqID, nil, err)
+		return
+	}
+
+	listener := c.followListener(values[0])
+	if listener == nil {
+		c.sendMethodResult(reqID, nil, nil)
+		return
+	}
+
+	c.mu.Lock()
+	delete(c.Subscriptions, listener.subID)
+	c.mu.Unlock()
+
+	err = listener.Stop()
+	if err != nil {
+		logger.Warnf("failed to stop follow subscription %d: %s", listener.subID, err)
+	}
+	c.sendMethodResult(reqID, nil, nil)
+}
+
+func (c *WSConn) handleChainHeadHeader(reqID float64, params interface{}) {
+	listener, hash, _, err := c.followedBlockParams(params, 2)
+	if err != nil || listener == nil || listener.isStopped() {
+		c.sendMethodResult(reqID, nil, err)
+		return
+	}
+
+	header, err := c.BlockAPI.GetHeader(hash)
+	if err != nil {
+		c.sendMethodResult(reqID, nil, fmt.Errorf("getting header: %w", err))
+		return
+	}
+
+	encodedHeader, err := scale.Marshal(*header)
+	if err != nil {
+		c.sendMethodResult(reqID, nil, fmt.Errorf("encoding header: %w", err))
+		return
+	}
+
+	c.sendMethodResult(reqID, common.BytesToHex(encodedHeader), nil)
+}
+
+func (c *WSConn) handleChainHeadBody(reqID float64, params interface{}) {
+	listener, hash, _, err := c.followedBlockParams(params, 2)
+	if err != nil {
+		c.sendMethodResult(reqID, nil, err)
+		return
+	}
+
+	operationID, ok := c.startChainHeadOperation(reqID, listener, chainHeadOperationResponse{})
+	if !ok {
+		return
+	}
+
+	go func() {
+		body, err := c.BlockAPI.GetBlockBody(hash)
+		if err != nil {
+			listener.finishOperation(operationID, listener.operationError(operationID, err))
+			return
+		}
+
+		extrinsics, err := body.AsEncodedExtrinsics()
+		if err != nil {
+			listener.finishOperation(operationID, listener.operationError(operationID, err))
+			return
+		}
+
+		value := make([]string, len(extrinsics))
+		for i, extrinsic := range extrinsics {
+			value[i] = extrinsic.String()
+		}
+
+		listener.finishOperation(operationID, chainHeadOperationBodyDoneEvent{
+			chainHeadOperationEvent: chainHeadOperationEvent{Event: "operationBodyDone", OperationID: operationID},
+			Value:                   value,
+		})
+	}()
+}
+
+func (c *WSConn) handleChainHeadCall(reqID float64, params interface{}) {
+	listener, hash, values, err := c.followedBlockParams(params, 4)
+	if err != nil {
+		c.sendMethodResult(reqID, nil, err)
+		return
+	}
+
+	if c.StorageAPI == nil {
+		c.sendMethodResult(reqID, nil, errStorageNotSet)
+		return
+	}
+
+	function, err := stringParam(values[2])
+	if err != nil {
+		c.sendMethodResult(reqID, nil, err)
+		return
+	}
+
+	encodedParameters, err := stringParam(values[3])
+	if err != nil {
+		c.sendMethodResult(reqID, nil, err)
+		return
+	}
+
+	callParameters, err := common.HexToBytes(encodedParameters)
+	if err != nil {
+		c.sendMethodResult(reqID, nil, fmt.Errorf("%w: decoding call parameters: %s", errUnexpectedType, err))
+		return
+	}
+
+	operationID, ok := c.startChainHeadOperation(reqID, listener, chainHeadOperationResponse{})
+	if !ok {
+		return
+	}
+
+	go func() {
+		rt, release, err := modules.RuntimeAt(c.BlockAPI, c.StorageAPI, hash)
+		if err != nil {
+			listener.finishOperation(operationID, listener.operationError(operationID, err))
+			return
+		}
+		defer release()
+
+		output, err := rt.Exec(function, callParameters)
+		if err != nil {
+			listener.finishOperation(operationID, listener.operationError(operationID, err))
+			return
+		}
+
+		listener.finishOperation(operationID, chainHeadOperationCallDoneEvent{
+			chainHeadOperationEvent: chainHeadOperationEvent{Event: "operationCallDone", OperationID: operationID},
+			Output:                  common.BytesToHex(output),
+		})
+	}()
+}
+
+func (c *WSConn) handleChainHeadStorage(reqID float64, params interface{}) {
+	listener, hash, values, err := c.followedBlockParams(params, 3, 4)
+	if err != nil {
+		c.sendMethodResult(reqID, nil, err)
+		return
+	}
+
+	if c.StorageAPI == nil {
+		c.sendMethodResult(reqID, nil, errStorageNotSet)
+		return
+	}
+
+	var items []modules.StorageQueryItem
+	err = decodeParam(values[2], &items)
+	if err != nil {
+		c.sendMethodResult(reqID, nil, err)
+		return
+	}
+
+	var childTrie *string
+	if len(values) == 4 {
+		err = decodeParam(values[3], &childTrie)
+		if err != nil {
+			c.sendMethodResult(reqID, nil, err)
+			return
+		}
+	}
+
+	// the storage items are all queried, so none of them is discarded
+	var discardedItems uint
+	operationID, ok := c.startChainHeadOperation(reqID, listener,
+		chainHeadOperationResponse{DiscardedItems: &discardedItems})
+	if !ok {
+		return
+	}
+
+	go func() {
+		results, err := modules.QueryStorage(c.StorageAPI, hash, items, childTrie)
+		if err != nil {
+			listener.finishOperation(operationID, listener.operationError(operationID, err))
+			return
+		}
+
+		doneEvent := chainHeadOperationEvent{Event: "operationStorageDone", OperationID: operationID}
+		if len(results) == 0 {
+			listener.finishOperation(operationID, doneEvent)
+			return
+		}
+
+		listener.finishOperation(operationID, chainHeadOperationStorageItemsEvent{
+			chainHeadOperationEvent: chainHeadOperationEvent{Event: "operationStorageItems", OperationID: operationID},
+			Items:                   results,
+		}, doneEvent)
+	}()
+}
+
+func (c *WSConn) handleChainHeadUnpin(reqID float64, params interface{}) {
+	values, err := methodParams(params, 2)
+	if err != nil {
+		c.sendMethodResult(reqID, nil, err)
+		return
+	}
+
+	listener := c.followListener(values[0])
+	if listener == nil {
+		c.sendMethodResult(reqID, nil, nil)
+		return
+	}
+
+	var hashes []common.Hash
+	if _, ok := values[1].(string); ok {
+		hashes = make([]common.Hash, 1)
+		err = decodeParam(values[1], &hashes[0])
+	} else {
+		err = decodeParam(values[1], &hashes)
+	}
+	if err != nil {
+		c.sendMethodResult(reqID, nil, err)
+		return
+	}
+
+	c.sendMethodResult(reqID, nil, listener.unpin(hashes))
+}
+
+// handleChainHeadContinue is a no-op since the operations never wait for continue.
+func (c *WSConn) handleChainHeadContinue(reqID float64, params interface{}) {
+	_, err := methodParams(params, 2)
+	c.sendMethodResult(reqID, nil, err)
+}
+
+func (c *WSConn) handleChainHeadStopOperation(reqID float64, params interface{}) {
+	values, err := methodParams(params, 2)
+	if err != nil {
+		c.sendMethodResult(reqID, nil, err)
+		return
+	}
+
+	operationID, err := stringParam(values[1])
+	if err != nil {
+		c.sendMethodResult(reqID, nil, err)
+		return
+	}
+
+	listener := c.followListener(values[0])
+	if listener != nil {
+		listener.stopOperation(operationID)
+	}
+	c.sendMethodResult(reqID, nil, nil)
+}
+
+// followedBlockParams returns the follow listener and the block hash of the params of a chainHead method,
+// checking the block is pinned if the follow subscription is active. The listener is nil if the follow
+// subscription does not exist, and is stopped if the follow subscription ended.
+func (c *WSConn) followedBlockParams(params interface{}, lengths ...int) (
+	listener *ChainHeadFollowListener, hash common.Hash, values []interface{}, err error) {
+	values, err = methodParams(params, lengths...)
+	if err != nil {
+		return nil, hash, nil, err
+	}
+
+	err = decodeParam(values[1], &hash)
+	if err != nil {
+		return nil, hash, nil, err
+	}
+
+	listener = c.followSubscription(values[0])
+	if listener == nil || listener.isStopped() {
+		return listener, hash, values, nil
+	}
+
+	if !listener.isPinned(hash) {
+		return nil, hash, values, fmt.Errorf("%w: %s", errInvalidBlockHash, hash)
+	}
+
+	return listener, hash, values, nil
+}
+
+// startChainHeadOperation starts an operation of the follow listener and sends its id in the given response,
+// or sends the limitReached result if the maximum number of operations is reached or the follow subscription
+// ended, or an error if the follow subscription does not exist.
+func (c *WSConn) startChainHeadOperation(reqID float64, listener *ChainHeadFollowListener,
+	response chainHeadOperationResponse) (operationID string, ok bool) {
+	if listener == nil {
+		c.sendMethodResult(reqID, nil, errUnknownFollowSubscription)
+		return "", false
+	}
+
+	operationID, ok = listener.startOperation()
+	if !ok {
+		c.sendMethodResult(reqID, chainHeadOperationResponse{Result: "limitReached"}, nil)
+		return "", false
+	}
+
+	response.Result = "started"
+	response.OperationID = operationID
+	c.sendMethodResult(reqID, response, nil)
+	return operationID, true
+}
+
+func hashesToStrings(hashes []common.Hash) []string {
+	strs := make([]string, len(hashes))
+	for i, hash := range hashes {
+		strs[i] = hash.String()
+	}
+	return strs
+}
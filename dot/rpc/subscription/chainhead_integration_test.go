@@ -0,0 +1,244 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+//go:build integration
+
+package subscription
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestHeader(t *testing.T, parentHash common.Hash, number uint, stateRoot byte) *types.Header {
+	t.Helper()
+	return types.NewHeader(parentHash, common.Hash{stateRoot}, common.Hash{}, number, types.NewDigest())
+}
+
+func readMessage(t *testing.T, ws *websocket.Conn) string {
+	t.Helper()
+	_, msg, err := ws.ReadMessage()
+	require.NoError(t, err)
+	return string(msg)
+}
+
+func TestChainHeadFollowListener(t *testing.T) {
+	wsconn, ws, cancel := setupWSConn(t)
+	wsconn.Subscriptions = make(map[uint32]Listener)
+	defer cancel()
+
+	ctrl := gomock.NewController(t)
+
+	finalised := newTestHeader(t, common.Hash{}, 0, 0)
+	block1 := newTestHeader(t, finalised.Hash(), 1, 1)
+	fork1 := newTestHeader(t, finalised.Hash(), 1, 2)
+	block2 := newTestHeader(t, block1.Hash(), 2, 3)
+	headers := map[common.Hash]*types.Header{
+		finalised.Hash(): finalised,
+		block1.Hash():    block1,
+		fork1.Hash():     fork1,
+		block2.Hash():    block2,
+	}
+
+	importedChan := make(chan *types.Block)
+	finalisedChan := make(chan *types.FinalisationInfo)
+	bestHash := block1.Hash()
+
+	blockAPI := mocks.NewMockBlockAPI(ctrl)
+	blockAPI.EXPECT().GetImportedBlockNotifierChannel().Return(importedChan)
+	blockAPI.EXPECT().GetFinalisedNotifierChannel().Return(finalisedChan)
+	blockAPI.EXPECT().GetHighestFinalisedHash().Return(finalised.Hash(), nil)
+	blockAPI.EXPECT().GetAllDescendants(finalised.Hash()).
+		Return([]common.Hash{finalised.Hash(), block1.Hash(), fork1.Hash()}, nil)
+	blockAPI.EXPECT().GetHeader(gomock.Any()).DoAndReturn(func(hash common.Hash) (*types.Header, error) {
+		return headers[hash], nil
+	}).AnyTimes()
+	blockAPI.EXPECT().BestBlockHash().DoAndReturn(func() common.Hash {
+		return bestHash
+	}).AnyTimes()
+	// the reported blocks are pinned, and unpinned once the subscription stops
+	for _, header := range headers {
+		blockAPI.EXPECT().PinBlock(header.Hash()).Return(nil)
+		blockAPI.EXPECT().UnpinBlock(header.Hash())
+	}
+	blockAPI.EXPECT().FreeImportedBlockNotifierChannel(importedChan)
+	blockAPI.EXPECT().FreeFinalisedNotifierChannel(finalisedChan)
+	wsconn.BlockAPI = blockAPI
+
+	listener, err := wsconn.initChainHeadFollowListener(1, []interface{}{false})
+	require.NoError(t, err)
+	require.Equal(t, `{"jsonrpc":"2.0","result":"1","id":1}`+"\n", readMessage(t, ws))
+
+	listener.Listen()
+
+	const eventFormat = `{"jsonrpc":"2.0","method":"chainHead_v1_followEvent",` +
+		`"params":{"result":%s,"subscription":"1"}}` + "\n"
+	require.Equal(t, fmt.Sprintf(eventFormat,
+		fmt.Sprintf(`{"event":"initialized","finalizedBlockHashes":["%s"]}`, finalised.Hash())),
+		readMessage(t, ws))
+	require.Equal(t, fmt.Sprintf(eventFormat,
+		fmt.Sprintf(`{"event":"newBlock","blockHash":"%s","parentBlockHash":"%s","newRuntime":null}`,
+			block1.Hash(), finalised.Hash())),
+		readMessage(t, ws))
+	require.Equal(t, fmt.Sprintf(eventFormat,
+		fmt.Sprintf(`{"event":"newBlock","blockHash":"%s","parentBlockHash":"%s","newRuntime":null}`,
+			fork1.Hash(), finalised.Hash())),
+		readMessage(t, ws))
+	require.Equal(t, fmt.Sprintf(eventFormat,
+		fmt.Sprintf(`{"event":"bestBlockChanged","bestBlockHash":"%s"}`, block1.Hash())),
+		readMessage(t, ws))
+
+	// the header of a pinned block is returned
+	wsconn.handleChainHeadHeader(2, []interface{}{"1", block1.Hash().String()})
+	encodedHeader, err := scale.Marshal(*block1)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf(`{"jsonrpc":"2.0","result":"%s","id":2}`+"\n", common.BytesToHex(encodedHeader)),
+		readMessage(t, ws))
+
+	// the header of a block which is not pinned is an error
+	wsconn.handleChainHeadHeader(3, []interface{}{"1", block2.Hash().String()})
+	require.Equal(t, fmt.Sprintf(`{"jsonrpc":"2.0","error":{"code":-32801,"message":"invalid block hash: %s"},"id":3}`+
+		"\n", block2.Hash()), readMessage(t, ws))
+
+	bestHash = block2.Hash()
+	importedChan <- &types.Block{Header: *block2}
+	require.Equal(t, fmt.Sprintf(eventFormat,
+		fmt.Sprintf(`{"event":"newBlock","blockHash":"%s","parentBlockHash":"%s","newRuntime":null}`,
+			block2.Hash(), block1.Hash())),
+		readMessage(t, ws))
+	require.Equal(t, fmt.Sprintf(eventFormat,
+		fmt.Sprintf(`{"event":"bestBlockChanged","bestBlockHash":"%s"}`, block2.Hash())),
+		readMessage(t, ws))
+
+	finalisedChan <- &types.FinalisationInfo{Header: *block2}
+	require.Equal(t, fmt.Sprintf(eventFormat,
+		fmt.Sprintf(`{"event":"finalized","finalizedBlockHashes":["%s","%s"],"prunedBlockHashes":["%s"]}`,
+			block1.Hash(), block2.Hash(), fork1.Hash())),
+		readMessage(t, ws))
+
+	// pruned blocks remain pinned until they are unpinned
+	wsconn.handleChainHeadUnpin(4, []interface{}{"1", []interface{}{fork1.Hash().String(), fork1.Hash().String()}})
+	require.Equal(t, fmt.Sprintf(`{"jsonrpc":"2.0","error":{"code":-32804,"message":"duplicate block hashes: %s"},`+
+		`"id":4}`+"\n", fork1.Hash()), readMessage(t, ws))
+
+	wsconn.handleChainHeadUnpin(5, []interface{}{"1", fork1.Hash().String()})
+	require.Equal(t, `{"jsonrpc":"2.0","result":null,"id":5}`+"\n", readMessage(t, ws))
+
+	wsconn.handleChainHeadUnfollow(6, []interface{}{"1"})
+	require.Equal(t, `{"jsonrpc":"2.0","result":null,"id":6}`+"\n", readMessage(t, ws))
+
+	// the header of an unfollowed subscription is null, and its operations are an error
+	wsconn.handleChainHeadHeader(7, []interface{}{"1", block1.Hash().String()})
+	require.Equal(t, `{"jsonrpc":"2.0","result":null,"id":7}`+"\n", readMessage(t, ws))
+
+	wsconn.handleChainHeadBody(8, []interface{}{"1", block1.Hash().String()})
+	require.Equal(t, `{"jsonrpc":"2.0","error":{"code":-32602,"message":"unknown follow subscription"},"id":8}`+
+		"\n", readMessage(t, ws))
+}
+
+func TestChainHeadFollowListener_Body(t *testing.T) {
+	wsconn, ws, cancel := setupWSConn(t)
+	wsconn.Subscriptions = make(map[uint32]Listener)
+	defer cancel()
+
+	ctrl := gomock.NewController(t)
+
+	finalised := newTestHeader(t, common.Hash{}, 0, 0)
+	importedChan := make(chan *types.Block)
+	finalisedChan := make(chan *types.FinalisationInfo)
+
+	blockAPI := mocks.NewMockBlockAPI(ctrl)
+	blockAPI.EXPECT().GetImportedBlockNotifierChannel().Return(importedChan)
+	blockAPI.EXPECT().GetFinalisedNotifierChannel().Return(finalisedChan)
+	blockAPI.EXPECT().GetHighestFinalisedHash().Return(finalised.Hash(), nil)
+	blockAPI.EXPECT().GetHeader(finalised.Hash()).Return(finalised, nil)
+	blockAPI.EXPECT().GetAllDescendants(finalised.Hash()).Return([]common.Hash{finalised.Hash()}, nil)
+	blockAPI.EXPECT().BestBlockHash().Return(finalised.Hash())
+	blockAPI.EXPECT().GetBlockBody(finalised.Hash()).Return(types.NewBody([]types.Extrinsic{{1, 2}}), nil)
+	blockAPI.EXPECT().PinBlock(finalised.Hash()).Return(nil)
+	blockAPI.EXPECT().UnpinBlock(finalised.Hash())
+	blockAPI.EXPECT().FreeImportedBlockNotifierChannel(importedChan)
+	blockAPI.EXPECT().FreeFinalisedNotifierChannel(finalisedChan)
+	wsconn.BlockAPI = blockAPI
+	wsconn.StorageAPI = mocks.NewMockStorageAPI(ctrl)
+
+	listener, err := wsconn.initChainHeadFollowListener(1, []interface{}{false})
+	require.NoError(t, err)
+	readMessage(t, ws)
+
+	listener.Listen()
+	// initialized and bestBlockChanged events
+	readMessage(t, ws)
+	readMessage(t, ws)
+
+	wsconn.handleChainHeadBody(2, []interface{}{"1", finalised.Hash().String()})
+	require.Equal(t, `{"jsonrpc":"2.0","result":{"result":"started","operationId":"1"},"id":2}`+"\n",
+		readMessage(t, ws))
+	require.Equal(t, `{"jsonrpc":"2.0","method":"chainHead_v1_followEvent","params":{"result":`+
+		`{"event":"operationBodyDone","operationId":"1","value":["0x080102"]},"subscription":"1"}}`+"\n",
+		readMessage(t, ws))
+
+	err = listener.Stop()
+	require.NoError(t, err)
+
+	// the operations of an ended subscription reach the limit
+	wsconn.handleChainHeadBody(3, []interface{}{"1", finalised.Hash().String()})
+	require.Equal(t, `{"jsonrpc":"2.0","result":{"result":"limitReached"},"id":3}`+"\n", readMessage(t, ws))
+	wsconn.handleChainHeadCall(4, []interface{}{"1", finalised.Hash().String(), "Core_version", "0x"})
+	require.Equal(t, `{"jsonrpc":"2.0","result":{"result":"limitReached"},"id":4}`+"\n", readMessage(t, ws))
+	wsconn.handleChainHeadStorage(5, []interface{}{"1", finalised.Hash().String(), []interface{}{}})
+	require.Equal(t, `{"jsonrpc":"2.0","result":{"result":"limitReached"},"id":5}`+"\n", readMessage(t, ws))
+
+	wsconn.handleChainHeadBody(6, []interface{}{"2", finalised.Hash().String()})
+	require.Equal(t, `{"jsonrpc":"2.0","error":{"code":-32602,"message":"unknown follow subscription"},"id":6}`+
+		"\n", readMessage(t, ws))
+}
+
+func TestTransactionBroadcastListener(t *testing.T) {
+	wsconn, ws, cancel := setupWSConn(t)
+	wsconn.Subscriptions = make(map[uint32]Listener)
+	defer cancel()
+
+	ctrl := gomock.NewController(t)
+
+	extrinsic := types.Extrinsic{1, 2, 3}
+	importedChan := make(chan *types.Block)
+	submitted := make(chan struct{})
+
+	blockAPI := mocks.NewMockBlockAPI(ctrl)
+	blockAPI.EXPECT().GetImportedBlockNotifierChannel().Return(importedChan)
+	blockAPI.EXPECT().FreeImportedBlockNotifierChannel(importedChan)
+	wsconn.BlockAPI = blockAPI
+
+	coreAPI := mocks.NewMockCoreAPI(ctrl)
+	coreAPI.EXPECT().HandleSubmittedExtrinsic(extrinsic).DoAndReturn(func(types.Extrinsic) error {
+		submitted <- struct{}{}
+		return nil
+	}).Times(2)
+	wsconn.CoreAPI = coreAPI
+
+	listener, err := wsconn.initTransactionBroadcastListener(1, []interface{}{"0x010203"})
+	require.NoError(t, err)
+	require.Equal(t, `{"jsonrpc":"2.0","result":"1","id":1}`+"\n", readMessage(t, ws))
+
+	listener.Listen()
+	<-submitted
+
+	// the transaction is submitted again on each imported block
+	importedChan <- &types.Block{}
+	<-submitted
+
+	wsconn.handleTransactionStop(2, []interface{}{"1"})
+	require.Equal(t, `{"jsonrpc":"2.0","result":null,"id":2}`+"\n", readMessage(t, ws))
+
+	wsconn.handleTransactionStop(3, []interface{}{"1"})
+	require.Equal(t, `{"jsonrpc":"2.0","error":{"code":-32602,"message":"invalid operation id"},"id":3}`+"\n",
+		readMessage(t, ws))
+}
@@ -8,18 +8,34 @@
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/ChainSafe/gossamer/pkg/trie"
 )
 
 // StorageAPI is the interface for the storage state
 type StorageAPI interface {
+	GetStorage(root *common.Hash, key []byte) ([]byte, error)
+	GetStorageChild(root *common.Hash, keyToChild []byte) (trie.Trie, error)
+	GetStateRootFromBlock(bhash *common.Hash) (*common.Hash, error)
+	GetKeysWithPrefix(root *common.Hash, prefix []byte) ([][]byte, error)
+	TrieState(root *common.Hash) (*rtstorage.TrieState, error)
 	RegisterStorageObserver(observer state.Observer)
 	UnregisterStorageObserver(observer state.Observer)
 }
 
 // BlockAPI is the interface for the block state
 type BlockAPI interface {
+	GetHeader(hash common.Hash) (*types.Header, error)
+	GetBlockBody(hash common.Hash) (*types.Body, error)
+	BestBlockHash() common.Hash
+	GetHighestFinalisedHash() (common.Hash, error)
+	GetAllDescendants(hash common.Hash) ([]common.Hash, error)
 	GetJustification(hash common.Hash) ([]byte, error)
+	GetHashByNumber(blockNumber uint) (common.Hash, error)
+	GetRuntime(blockHash common.Hash) (instance runtime.Instance, err error)
+	PinBlock(hash common.Hash) error
+	UnpinBlock(hash common.Hash)
 	GetImportedBlockNotifierChannel() chan *types.Block
 	FreeImportedBlockNotifierChannel(ch chan *types.Block)
 	GetFinalisedNotifierChannel() chan *types.FinalisationInfo
@@ -29,8 +45,8 @@ type BlockAPI interface {
 
 // TransactionStateAPI is the interface to get and free status notifier channels
 type TransactionStateAPI interface {
-	GetStatusNotifierChannel(ext types.Extrinsic) chan transaction.Status
-	FreeStatusNotifierChannel(ch chan transaction.Status)
+	GetStatusNotifierChannel(ext types.Extrinsic) chan transaction.StatusNotification
+	FreeStatusNotifierChannel(ch chan transaction.StatusNotification)
 }
 
 // CoreAPI is the interface for the core methods
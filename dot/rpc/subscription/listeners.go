@@ -7,12 +7,12 @@
 	"errors"
 	"fmt"
 	"math/big"
-	"reflect"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/transaction"
@@ -28,6 +28,10 @@
 	stateStorageMethod           = "state_storage"
 )
 
+// finalityTimeoutBlocks is the number of blocks imported after the block including a watched
+// extrinsic, after which the extrinsic watch ends if this block is still not finalised.
+const finalityTimeoutBlocks = 512
+
 var (
 	// ErrCannotCancel when is not possible to cancel a goroutine after `cancelTimeout` seconds
 	ErrCannotCancel = errors.New("cannot cancel listening goroutines")
@@ -296,13 +300,16 @@ type ExtrinsicSubmitListener struct {
 	subID         uint32
 	extrinsic     types.Extrinsic
 	importedChan  chan *types.Block
-	importedHash  common.Hash
 	finalisedChan chan *types.FinalisationInfo
+	// inBlock is the header of the last imported block including the extrinsic,
+	// and is nil if the extrinsic is not included in a block.
+	inBlock *types.Header
 	// txStatusChan is used to know when transaction/extrinsic becomes part of the
-	// ready queue or future queue.
+	// ready queue or future queue, is broadcast, retracted, usurped, dropped or
+	// becomes invalid.
 	// we are using transaction.PriorityQueue for ready queue and transaction.Pool
 	// for future queue.
-	txStatusChan  chan transaction.Status
+	txStatusChan  chan transaction.StatusNotification
 	done          chan struct{}
 	cancel        chan struct{}
 	cancelTimeout time.Duration
@@ -310,7 +317,7 @@ type ExtrinsicSubmitListener struct {
 
 // NewExtrinsicSubmitListener constructor to build new ExtrinsicSubmitListener
 func NewExtrinsicSubmitListener(conn *WSConn, extBytes []byte,
-	importedChan chan *types.Block, txStatusChan chan transaction.Status,
+	importedChan chan *types.Block, txStatusChan chan transaction.StatusNotification,
 	finalisedChan chan *types.FinalisationInfo) *ExtrinsicSubmitListener {
 	return &ExtrinsicSubmitListener{
 		wsconn:        conn,
@@ -324,7 +331,9 @@ func NewExtrinsicSubmitListener(conn *WSConn, extBytes []byte,
 	}
 }
 
-// Listen implementation of Listen interface to listen for importedChan changes
+// Listen implementation of Listen interface to listen for importedChan changes.
+// The listener stops once the extrinsic reaches a final status: finalized,
+// finalityTimeout, usurped, dropped or invalid.
 func (l *ExtrinsicSubmitListener) Listen() {
 	// listen for imported blocks with extrinsic
 	go func() {
@@ -349,40 +358,146 @@ func (l *ExtrinsicSubmitListener) Listen() {
 				if block == nil {
 					continue
 				}
-				bodyHasExtrinsic, err := block.Body.HasExtrinsic(l.extrinsic)
-				if err != nil {
-					fmt.Printf("error %v\n", err)
-				}
-
-				if bodyHasExtrinsic {
-					resM := make(map[string]interface{})
-					resM["inBlock"] = block.Header.Hash().String()
 
-					l.importedHash = block.Header.Hash()
-					l.wsconn.safeSend(newSubscriptionResponse(authorExtrinsicUpdatesMethod, l.subID, resM))
+				if l.handleImportedBlock(block) {
+					return
 				}
-
 			case info, ok := <-l.finalisedChan:
 				if !ok {
 					return
 				}
 
-				if reflect.DeepEqual(l.importedHash, info.Header.Hash()) {
-					resM := make(map[string]interface{})
-					resM["finalised"] = info.Header.Hash().String()
-					l.wsconn.safeSend(newSubscriptionResponse(authorExtrinsicUpdatesMethod, l.subID, resM))
+				if l.handleFinalisedBlock(info) {
+					return
 				}
-			case txStatus, ok := <-l.txStatusChan:
+			case notification, ok := <-l.txStatusChan:
 				if !ok {
 					return
 				}
 
-				l.wsconn.safeSend(newSubscriptionResponse(authorExtrinsicUpdatesMethod, l.subID, txStatus.String()))
+				// the extrinsic is retracted from a block of the previous best chain on re-orgs,
+				// which may not be the last block including the extrinsic.
+				if notification.Status == transaction.Retracted && l.inBlock != nil &&
+					notification.BlockHash != nil && *notification.BlockHash == l.inBlock.Hash() {
+					l.inBlock = nil
+				}
+
+				l.sendStatus(notification)
+				if isFinalStatus(notification.Status) {
+					return
+				}
 			}
 		}
 	}()
 }
 
+// handleImportedBlock sends the inBlock status if the block includes the extrinsic, or the
+// finalityTimeout status if the block including the extrinsic is not finalised after
+// finalityTimeoutBlocks blocks. It returns true if the extrinsic reached a final status.
+func (l *ExtrinsicSubmitListener) handleImportedBlock(block *types.Block) (final bool) {
+	bodyHasExtrinsic, err := block.Body.HasExtrinsic(l.extrinsic)
+	if err != nil {
+		logger.Debugf("failed to check if block %s has extrinsic: %s", block.Header.Hash(), err)
+		return false
+	}
+
+	if bodyHasExtrinsic {
+		header := block.Header
+		l.inBlock = &header
+		blockHash := header.Hash()
+		l.sendStatus(transaction.StatusNotification{
+			Status:    transaction.InBlock,
+			BlockHash: &blockHash,
+		})
+		return false
+	}
+
+	if l.inBlock == nil || block.Header.Number < l.inBlock.Number+finalityTimeoutBlocks {
+		return false
+	}
+
+	inBlockHash := l.inBlock.Hash()
+	l.sendStatus(transaction.StatusNotification{
+		Status:    transaction.FinalityTimeout,
+		BlockHash: &inBlockHash,
+	})
+	return true
+}
+
+// handleFinalisedBlock sends the finalized status if the block including the extrinsic
+// is finalised, or the retracted status if the block is on a fork which is not finalised.
+// It returns true if the extrinsic reached a final status.
+func (l *ExtrinsicSubmitListener) handleFinalisedBlock(info *types.FinalisationInfo) (final bool) {
+	if l.inBlock == nil || info.Header.Number < l.inBlock.Number {
+		return false
+	}
+
+	inBlockHash := l.inBlock.Hash()
+	if info.Header.Hash() != inBlockHash {
+		finalisedHash, err := l.wsconn.BlockAPI.GetHashByNumber(l.inBlock.Number)
+		if err != nil {
+			logger.Debugf("failed to get finalised block hash for block number %d: %s", l.inBlock.Number, err)
+			return false
+		}
+
+		if finalisedHash != inBlockHash {
+			// the block including the extrinsic is on a fork which will never be finalised,
+			// so retract it and wait for the extrinsic to be included in another block.
+			l.inBlock = nil
+			l.sendStatus(transaction.StatusNotification{
+				Status:    transaction.Retracted,
+				BlockHash: &inBlockHash,
+			})
+			return false
+		}
+	}
+
+	l.sendStatus(transaction.StatusNotification{
+		Status:    transaction.Finalized,
+		BlockHash: &inBlockHash,
+	})
+	return true
+}
+
+func (l *ExtrinsicSubmitListener) sendStatus(notification transaction.StatusNotification) {
+	l.wsconn.safeSend(newSubscriptionResponse(authorExtrinsicUpdatesMethod, l.subID,
+		extrinsicStatusResult(notification)))
+}
+
+// extrinsicStatusResult returns the author_extrinsicUpdate result for the status notification,
+// which is either the status name, or an object mapping the status name to its value.
+func extrinsicStatusResult(notification transaction.StatusNotification) interface{} {
+	var value interface{}
+	switch notification.Status {
+	case transaction.Broadcast:
+		value = notification.PeersBroadcastedTo
+	case transaction.InBlock, transaction.Retracted, transaction.FinalityTimeout, transaction.Finalized:
+		if notification.BlockHash != nil {
+			value = notification.BlockHash.String()
+		}
+	case transaction.Usurped:
+		if notification.UsurpedBy != nil {
+			value = notification.UsurpedBy.String()
+		}
+	}
+
+	if value == nil {
+		return notification.Status.String()
+	}
+	return map[string]interface{}{notification.Status.String(): value}
+}
+
+// isFinalStatus returns true if no status update follows the given status.
+func isFinalStatus(status transaction.Status) bool {
+	switch status {
+	case transaction.Finalized, transaction.FinalityTimeout, transaction.Usurped,
+		transaction.Dropped, transaction.Invalid:
+		return true
+	default:
+		return false
+	}
+}
+
 // Stop to cancel the running goroutines to this listener
 func (l *ExtrinsicSubmitListener) Stop() error {
 	return cancelWithTimeout(l.cancel, l.done, l.cancelTimeout)
@@ -465,9 +580,13 @@ func (g *GrandpaJustificationListener) Listen() {
 				}
 
 				just, err := g.wsconn.BlockAPI.GetJustification(info.Header.Hash())
-				if err != nil {
+				if errors.Is(err, database.ErrNotFound) {
+					// the block was finalised by the justification of a descendant block
+					continue
+				} else if err != nil {
 					g.wsconn.safeSendError(float64(g.subID), big.NewInt(InvalidRequestCode),
 						fmt.Sprintf("failed to retrieve justification: %v", err))
+					continue
 				}
 
 				g.wsconn.safeSend(newSubscriptionResponse(grandpaJustificationsMethod, g.subID, common.BytesToHex(just)))
@@ -17,6 +17,7 @@
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/runtime"
@@ -180,7 +181,7 @@ func TestExtrinsicSubmitListener_Listen(t *testing.T) {
 
 	notifyImportedChan := make(chan *types.Block, 100)
 	notifyFinalizedChan := make(chan *types.FinalisationInfo, 100)
-	txStatusChan := make(chan transaction.Status)
+	txStatusChan := make(chan transaction.StatusNotification)
 
 	BlockAPI := mocks.NewMockBlockAPI(ctrl)
 	BlockAPI.EXPECT().FreeImportedBlockNotifierChannel(gomock.Any())
@@ -235,7 +236,7 @@ func TestExtrinsicSubmitListener_Listen(t *testing.T) {
 
 	_, msg, err = ws.ReadMessage()
 	require.NoError(t, err)
-	resFinalised := map[string]interface{}{"finalised": block.Header.Hash().String()}
+	resFinalised := map[string]interface{}{"finalized": block.Header.Hash().String()}
 	expectedFinalizedBytes, err := json.Marshal(
 		newSubscriptionResponse(authorExtrinsicUpdatesMethod, esl.subID, resFinalised))
 	require.NoError(t, err)
@@ -292,6 +293,58 @@ func TestGrandpaJustification_Listen(t *testing.T) {
 		require.NoError(t, sub.Stop())
 		wsconn.Wsconn.Close()
 	})
+
+	t.Run("When_block_has_no_justification_it_is_skipped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		wsconn, ws, cancel := setupWSConn(t)
+		defer cancel()
+
+		mockedJust := grandpa.Justification{
+			Round: 2,
+			Commit: grandpa.Commit{
+				Number: 2,
+			},
+		}
+
+		mockedJustBytes, err := scale.Marshal(mockedJust)
+		require.NoError(t, err)
+
+		headerWithoutJustification := types.NewEmptyHeader()
+		headerWithoutJustification.Number = 1
+		headerWithJustification := types.NewEmptyHeader()
+		headerWithJustification.Number = 2
+
+		blockStateMock := mocks.NewMockBlockAPI(ctrl)
+		blockStateMock.EXPECT().GetJustification(headerWithoutJustification.Hash()).Return(nil, database.ErrNotFound)
+		blockStateMock.EXPECT().GetJustification(headerWithJustification.Hash()).Return(mockedJustBytes, nil)
+		blockStateMock.EXPECT().FreeFinalisedNotifierChannel(gomock.Any())
+		wsconn.BlockAPI = blockStateMock
+
+		finchannel := make(chan *types.FinalisationInfo)
+		sub := GrandpaJustificationListener{
+			subID:         11,
+			wsconn:        wsconn,
+			cancel:        make(chan struct{}, 1),
+			done:          make(chan struct{}, 1),
+			finalisedCh:   finchannel,
+			cancelTimeout: time.Second * 5,
+		}
+
+		sub.Listen()
+		finchannel <- &types.FinalisationInfo{Header: *headerWithoutJustification}
+		finchannel <- &types.FinalisationInfo{Header: *headerWithJustification}
+
+		_, msg, err := ws.ReadMessage()
+		require.NoError(t, err)
+
+		expected := `{"jsonrpc":"2.0","method":"grandpa_justifications","params":{"result":"%s","subscription":11}}` + "\n"
+		expected = fmt.Sprintf(expected, common.BytesToHex(mockedJustBytes))
+
+		require.Equal(t, expected, string(msg))
+		require.NoError(t, sub.Stop())
+		wsconn.Wsconn.Close()
+	})
 }
 
 func TestRuntimeChannelListener_Listen(t *testing.T) {
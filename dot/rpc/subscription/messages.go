@@ -3,6 +3,10 @@
 
 package subscription
 
+import (
+	"strconv"
+)
+
 // BaseResponseJSON for base json response
 type BaseResponseJSON struct {
 	Jsonrpc string `json:"jsonrpc"`
@@ -22,6 +26,16 @@ type Params struct {
 // InvalidRequestMessage error message for invalid request parameters
 const InvalidRequestMessage = "Invalid request"
 
+// Error codes of the new JSON-RPC API
+const (
+	// InvalidParamsCode error code returned for invalid method parameters
+	InvalidParamsCode = -32602
+	// InvalidBlockHashCode error code returned for block hashes not pinned by a follow subscription
+	InvalidBlockHashCode = -32801
+	// InvalidDuplicateHashesCode error code returned when unpinning the same block hash more than once
+	InvalidDuplicateHashesCode = -32804
+)
+
 func newSubcriptionBaseResponseJSON() BaseResponseJSON {
 	return BaseResponseJSON{
 		Jsonrpc: "2.0",
@@ -69,3 +83,43 @@ func newBooleanResponseJSON(value bool, reqID float64) BooleanResponse {
 		ID:      reqID,
 	}
 }
+
+// NewAPIParams for json param notifications of the new JSON-RPC API,
+// whose subscription ids are strings
+type NewAPIParams struct {
+	Result         interface{} `json:"result"`
+	SubscriptionID string      `json:"subscription"`
+}
+
+// NewAPIResponseJSON for json notifications of the new JSON-RPC API
+type NewAPIResponseJSON struct {
+	Jsonrpc string       `json:"jsonrpc"`
+	Method  string       `json:"method"`
+	Params  NewAPIParams `json:"params"`
+}
+
+func newNewAPIResponse(method string, subID uint32, result interface{}) NewAPIResponseJSON {
+	return NewAPIResponseJSON{
+		Jsonrpc: "2.0",
+		Method:  method,
+		Params: NewAPIParams{
+			Result:         result,
+			SubscriptionID: strconv.FormatUint(uint64(subID), 10),
+		},
+	}
+}
+
+// ResultResponseJSON for json responses of any result
+type ResultResponseJSON struct {
+	Jsonrpc string      `json:"jsonrpc"`
+	Result  interface{} `json:"result"`
+	ID      float64     `json:"id"`
+}
+
+func newResultResponseJSON(result interface{}, reqID float64) ResultResponseJSON {
+	return ResultResponseJSON{
+		Jsonrpc: "2.0",
+		Result:  result,
+		ID:      reqID,
+	}
+}
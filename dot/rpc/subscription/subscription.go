@@ -4,8 +4,10 @@
 package subscription
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
 )
 
@@ -19,10 +21,24 @@
 	stateSubscribeStorage          string = "state_subscribeStorage"
 	stateSubscribeRuntimeVersion   string = "state_subscribeRuntimeVersion"
 	grandpaSubscribeJustifications string = "grandpa_subscribeJustifications"
+	chainHeadV1Follow              string = "chainHead_v1_follow"
+	chainHeadV1Unfollow            string = "chainHead_v1_unfollow"
+	chainHeadV1Header              string = "chainHead_v1_header"
+	chainHeadV1Body                string = "chainHead_v1_body"
+	chainHeadV1Call                string = "chainHead_v1_call"
+	chainHeadV1Storage             string = "chainHead_v1_storage"
+	chainHeadV1Unpin               string = "chainHead_v1_unpin"
+	chainHeadV1Continue            string = "chainHead_v1_continue"
+	chainHeadV1StopOperation       string = "chainHead_v1_stopOperation"
+	transactionV1Broadcast         string = "transaction_v1_broadcast"
+	transactionV1Stop              string = "transaction_v1_stop"
 )
 
 type setupListener func(reqid float64, params interface{}) (Listener, error)
 
+// methodHandler handles the methods bound to the listeners of the connection, and sends their response.
+type methodHandler func(reqID float64, params interface{})
+
 var (
 	errUknownParamSubscribeID = errors.New("invalid params format type")
 	errCannotParseID          = errors.New("could not parse param id")
@@ -46,6 +62,35 @@ func (c *WSConn) getSetupListener(method string) setupListener {
 		return c.initRuntimeVersionListener
 	case grandpaSubscribeJustifications:
 		return c.initGrandpaJustificationListener
+	case chainHeadV1Follow:
+		return c.initChainHeadFollowListener
+	case transactionV1Broadcast:
+		return c.initTransactionBroadcastListener
+	default:
+		return nil
+	}
+}
+
+func (c *WSConn) getMethodHandler(method string) methodHandler {
+	switch method {
+	case chainHeadV1Unfollow:
+		return c.handleChainHeadUnfollow
+	case chainHeadV1Header:
+		return c.handleChainHeadHeader
+	case chainHeadV1Body:
+		return c.handleChainHeadBody
+	case chainHeadV1Call:
+		return c.handleChainHeadCall
+	case chainHeadV1Storage:
+		return c.handleChainHeadStorage
+	case chainHeadV1Unpin:
+		return c.handleChainHeadUnpin
+	case chainHeadV1Continue:
+		return c.handleChainHeadContinue
+	case chainHeadV1StopOperation:
+		return c.handleChainHeadStopOperation
+	case transactionV1Stop:
+		return c.handleTransactionStop
 	default:
 		return nil
 	}
@@ -91,3 +136,63 @@ func parseSubscribeID(p interface{}) (uint32, error) {
 
 	return id, nil
 }
+
+// methodParams returns the positional params of a method, checking their number is one of the given lengths.
+func methodParams(params interface{}, lengths ...int) ([]interface{}, error) {
+	values, ok := params.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %T, expected type []interface{}", errUnexpectedType, params)
+	}
+
+	for _, length := range lengths {
+		if len(values) == length {
+			return values, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: expected %v params, got: %d", errUnexpectedParamLen, lengths, len(values))
+}
+
+func stringParam(param interface{}) (string, error) {
+	str, ok := param.(string)
+	if !ok {
+		return "", fmt.Errorf("%w: %T, expected type string", errUnexpectedType, param)
+	}
+	return str, nil
+}
+
+// decodeParam decodes the given JSON decoded param into the value pointed to by v.
+func decodeParam(param interface{}, v interface{}) error {
+	encoded, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf("encoding param: %w", err)
+	}
+
+	err = json.Unmarshal(encoded, v)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errUnexpectedType, err)
+	}
+	return nil
+}
+
+// sendMethodResult sends the result of a method, or its error with the error code of the new JSON-RPC API.
+func (c *WSConn) sendMethodResult(reqID float64, result interface{}, err error) {
+	if err != nil {
+		c.safeSendError(reqID, errorCode(err), err.Error())
+		return
+	}
+	c.safeSend(newResultResponseJSON(result, reqID))
+}
+
+func errorCode(err error) *big.Int {
+	switch {
+	case errors.Is(err, errUnexpectedType), errors.Is(err, errUnexpectedParamLen),
+		errors.Is(err, errInvalidOperationID), errors.Is(err, errUnknownFollowSubscription):
+		return big.NewInt(InvalidParamsCode)
+	case errors.Is(err, errInvalidBlockHash):
+		return big.NewInt(InvalidBlockHashCode)
+	case errors.Is(err, errDuplicateBlockHashes):
+		return big.NewInt(InvalidDuplicateHashesCode)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package subscription
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+var errInvalidOperationID = errors.New("invalid operation id")
+
+// TransactionBroadcastListener submits a transaction to the pool, which gossips it to the connected
+// peers, and submits it again on each imported block until the operation is stopped or the transaction
+// is not valid anymore, so it is gossiped again if it was dropped from the pool.
+type TransactionBroadcastListener struct {
+	wsconn        *WSConn
+	operationID   uint32
+	extrinsic     types.Extrinsic
+	importedChan  chan *types.Block
+	done          chan struct{}
+	cancel        chan struct{}
+	cancelTimeout time.Duration
+}
+
+func (c *WSConn) initTransactionBroadcastListener(reqID float64, params interface{}) (Listener, error) {
+	values, err := methodParams(params, 1)
+	if err != nil {
+		c.safeSendError(reqID, errorCode(err), err.Error())
+		return nil, err
+	}
+
+	encodedExtrinsic, err := stringParam(values[0])
+	if err != nil {
+		c.safeSendError(reqID, errorCode(err), err.Error())
+		return nil, err
+	}
+
+	extrinsic, err := common.HexToBytes(encodedExtrinsic)
+	if err != nil {
+		err = fmt.Errorf("%w: decoding transaction: %s", errUnexpectedType, err)
+		c.safeSendError(reqID, errorCode(err), err.Error())
+		return nil, err
+	}
+
+	if c.BlockAPI == nil {
+		c.safeSendError(reqID, nil, errBlockAPINotSet.Error())
+		return nil, errBlockAPINotSet
+	}
+
+	if c.CoreAPI == nil {
+		c.safeSendError(reqID, nil, errCoreAPINotSet.Error())
+		return nil, errCoreAPINotSet
+	}
+
+	listener := &TransactionBroadcastListener{
+		wsconn:        c,
+		extrinsic:     extrinsic,
+		importedChan:  c.BlockAPI.GetImportedBlockNotifierChannel(),
+		cancel:        make(chan struct{}, 1),
+		done:          make(chan struct{}, 1),
+		cancelTimeout: defaultCancelTimeout,
+	}
+
+	c.mu.Lock()
+	listener.operationID = atomic.AddUint32(&c.qtyListeners, 1)
+	c.Subscriptions[listener.operationID] = listener
+	c.mu.Unlock()
+
+	c.safeSend(newResultResponseJSON(strconv.FormatUint(uint64(listener.operationID), 10), reqID))
+	return listener, nil
+}
+
+// Listen starts a goroutine submitting the transaction, and submitting it again on each imported block.
+func (l *TransactionBroadcastListener) Listen() {
+	go func() {
+		defer func() {
+			l.wsconn.BlockAPI.FreeImportedBlockNotifierChannel(l.importedChan)
+			close(l.done)
+		}()
+
+		if !l.submit() {
+			return
+		}
+
+		for {
+			select {
+			case <-l.cancel:
+				return
+			case block, ok := <-l.importedChan:
+				if !ok {
+					return
+				}
+
+				if block == nil {
+					continue
+				}
+
+				if !l.submit() {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// submit submits the transaction, which is ignored if it is already in the pool.
+// It returns false if the transaction is not valid anymore.
+func (l *TransactionBroadcastListener) submit() (valid bool) {
+	err := l.wsconn.CoreAPI.HandleSubmittedExtrinsic(l.extrinsic)
+	if err != nil {
+		logger.Debugf("stopping broadcast of transaction %s: %s", l.extrinsic, err)
+		return false
+	}
+	return true
+}
+
+// Stop stops the goroutine broadcasting the transaction.
+func (l *TransactionBroadcastListener) Stop() error {
+	return cancelWithTimeout(l.cancel, l.done, l.cancelTimeout)
+}
+
+func (c *WSConn) handleTransactionStop(reqID float64, params interface{}) {
+	values, err := methodParams(params, 1)
+	if err != nil {
+		c.sendMethodResult(reqID, nil, err)
+		return
+	}
+
+	operationID, err := parseSubscribeID(values)
+	if err != nil {
+		c.sendMethodResult(reqID, nil, fmt.Errorf("%w: %s", errInvalidOperationID, err))
+		return
+	}
+
+	c.mu.Lock()
+	listener, ok := c.Subscriptions[operationID].(*TransactionBroadcastListener)
+	if ok {
+		delete(c.Subscriptions, operationID)
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		c.sendMethodResult(reqID, nil, errInvalidOperationID)
+		return
+	}
+
+	err = listener.Stop()
+	if err != nil {
+		logger.Warnf("failed to stop broadcast operation %d: %s", operationID, err)
+	}
+	c.sendMethodResult(reqID, nil, nil)
+}
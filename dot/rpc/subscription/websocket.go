@@ -94,6 +94,11 @@ func (c *WSConn) HandleConn() {
 		logger.Tracef("websocket message received: %s", string(rawBytes))
 		logger.Debugf("ws method %s called with params %v", wsMessage.Method, wsMessage.Params)
 
+		if handleMethod := c.getMethodHandler(wsMessage.Method); handleMethod != nil {
+			handleMethod(wsMessage.ID, wsMessage.Params)
+			continue
+		}
+
 		if !strings.Contains(wsMessage.Method, "_unsubscribe") && !strings.Contains(wsMessage.Method, "_unwatch") {
 			setupListener := c.getSetupListener(wsMessage.Method)
 
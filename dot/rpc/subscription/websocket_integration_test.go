@@ -491,7 +491,7 @@ func TestWSConn_InitExtrinsicWatchTest(t *testing.T) {
 			if testCase.setBlocAPI {
 				wsconn.BlockAPI = modules.NewMockAnyBlockAPI(ctrl)
 				transactionStateAPI := NewMockTransactionStateAPI(ctrl)
-				transactionStateAPI.EXPECT().GetStatusNotifierChannel(gomock.Any()).Return(make(chan transaction.Status)).Times(1)
+				transactionStateAPI.EXPECT().GetStatusNotifierChannel(gomock.Any()).Return(make(chan transaction.StatusNotification)).Times(1)
 				wsconn.TxStateAPI = transactionStateAPI
 			}
 
@@ -523,7 +523,7 @@ func TestWSConn_InitExtrinsicWatch(t *testing.T) {
 	wsconn.StorageAPI = modules.NewMockAnyStorageAPI(ctrl)
 	wsconn.BlockAPI = modules.NewMockAnyBlockAPI(ctrl)
 	transactionStateAPI := NewMockTransactionStateAPI(ctrl)
-	transactionStateAPI.EXPECT().GetStatusNotifierChannel(gomock.Any()).Return(make(chan transaction.Status)).Times(1)
+	transactionStateAPI.EXPECT().GetStatusNotifierChannel(gomock.Any()).Return(make(chan transaction.StatusNotification)).Times(1)
 	wsconn.TxStateAPI = transactionStateAPI
 
 	// test initExtrinsicWatch with invalid transaction
@@ -11,12 +11,14 @@
 
 	cfg "github.com/ChainSafe/gossamer/config"
 
+	"github.com/ChainSafe/gossamer/dot/authoritydiscovery"
 	"github.com/ChainSafe/gossamer/dot/core"
 	"github.com/ChainSafe/gossamer/dot/digest"
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/rpc"
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
 	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	"github.com/ChainSafe/gossamer/dot/sync"
 	"github.com/ChainSafe/gossamer/dot/system"
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -25,6 +27,7 @@
 	"github.com/ChainSafe/gossamer/internal/metrics"
 	"github.com/ChainSafe/gossamer/internal/pprof"
 	"github.com/ChainSafe/gossamer/lib/babe"
+	"github.com/ChainSafe/gossamer/lib/beefy"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
@@ -32,6 +35,7 @@
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/mmr"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
@@ -101,11 +105,19 @@ func (nodeBuilder) createStateService(config *cfg.Config) (*state.Service, error
 		return nil, err
 	}
 
+	prunerConfig, err := pruner.NewConfig(config.Pruning, config.RetainBlocks)
+	if err != nil {
+		return nil, fmt.Errorf("parsing pruning configuration: %w", err)
+	}
+
 	stateConfig := state.Config{
-		Path:              config.BasePath,
-		LogLevel:          stateLogLevel,
-		Metrics:           metrics.NewIntervalConfig(config.PrometheusExternal),
-		GenesisBABEConfig: babeCfg,
+		Path:                 config.BasePath,
+		LogLevel:             stateLogLevel,
+		PrunerCfg:            prunerConfig,
+		Metrics:              metrics.NewIntervalConfig(config.PrometheusExternal),
+		GenesisBABEConfig:    babeCfg,
+		StorageBackend:       state.StorageBackend(config.State.Backend),
+		JustificationsWindow: config.State.JustificationsWindow,
 	}
 
 	stateSrvc := state.NewService(stateConfig)
@@ -144,8 +156,7 @@ func (nodeBuilder) createRuntimeStorage(st *state.Service) (*runtime.NodeStorage
 
 	return &runtime.NodeStorage{
 		LocalStorage:      localStorage,
-		PersistentStorage: database.NewTable(st.DB(), "offlinestorage"),
-		BaseDB:            st.Base,
+		PersistentStorage: st.OffchainStorage(),
 	}, nil
 }
 
@@ -280,6 +291,19 @@ func (nodeBuilder) createBABEServiceWithBuilder(config *cfg.Config, st *state.Se
 
 // Core Service
 
+// offchainWorkersEnabled returns true if the runtime offchain workers
+// should run given the core configuration.
+func offchainWorkersEnabled(config *cfg.CoreConfig) bool {
+	switch config.OffchainWorker {
+	case cfg.AlwaysOffchainWorker:
+		return true
+	case cfg.NeverOffchainWorker:
+		return false
+	default:
+		return config.Role == common.AuthorityRole
+	}
+}
+
 // createCoreService creates the core service from the provided core configuration
 func (nodeBuilder) createCoreService(config *cfg.Config, ks *keystore.GlobalKeystore,
 	st *state.Service, net *network.Service) (
@@ -315,6 +339,7 @@ func (nodeBuilder) createCoreService(config *cfg.Config, ks *keystore.GlobalKeys
 		CodeSubstitutes:      codeSubs,
 		CodeSubstitutedState: st.Base,
 		OnBlockImport:        digest.NewBlockImportHandler(st.Epoch, st.Grandpa),
+		OffchainWorkers:      offchainWorkersEnabled(config.Core),
 	}
 
 	// create new core service
@@ -355,6 +380,7 @@ func (nodeBuilder) createNetworkService(config *cfg.Config, stateSrvc *state.Ser
 		Port:              config.Network.Port,
 		Bootnodes:         config.Network.Bootnodes,
 		ProtocolID:        config.Network.ProtocolID,
+		ForkID:            config.Network.ForkID,
 		NoBootstrap:       config.Network.NoBootstrap,
 		NoMDNS:            config.Network.NoMDNS,
 		MinPeers:          config.Network.MinPeers,
@@ -368,6 +394,8 @@ func (nodeBuilder) createNetworkService(config *cfg.Config, stateSrvc *state.Ser
 		Metrics:           metrics.NewIntervalConfig(config.PrometheusExternal),
 		NodeKey:           config.Network.NodeKey,
 		ListenAddress:     config.Network.ListenAddress,
+
+		DHTRecordValidator: authoritydiscovery.RecordValidator{},
 	}
 
 	networkSrvc, err := network.NewService(&networkConfig)
@@ -379,6 +407,61 @@ func (nodeBuilder) createNetworkService(config *cfg.Config, stateSrvc *state.Ser
 	return networkSrvc, nil
 }
 
+// createAuthorityDiscoveryService creates the service publishing and resolving the
+// addresses of the authorities in the DHT of the network service
+func createAuthorityDiscoveryService(config *cfg.Config, ks *keystore.GlobalKeystore, stateSrvc *state.Service,
+	networkSrvc *network.Service) (*authoritydiscovery.Service, error) {
+	logLevel, err := log.ParseLevel(config.Log.Core)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse core log level: %w", err)
+	}
+
+	return authoritydiscovery.NewService(&authoritydiscovery.Config{
+		LogLvl:       logLevel,
+		Network:      networkSrvc,
+		BlockState:   stateSrvc.Block,
+		StorageState: stateSrvc.Storage,
+		Keystore:     ks.Audi,
+		PublicIP:     config.Network.PublicIP,
+		PublicDNS:    config.Network.PublicDNS,
+	})
+}
+
+// createBEEFYService creates the BEEFY service voting for the blocks finalised by GRANDPA
+// and gossiping the votes over the network service
+func createBEEFYService(config *cfg.Config, ks *keystore.GlobalKeystore, stateSrvc *state.Service,
+	networkSrvc *network.Service) (*beefy.Service, error) {
+	logLevel, err := log.ParseLevel(config.Log.Grandpa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse grandpa log level: %w", err)
+	}
+
+	return beefy.NewService(&beefy.Config{
+		LogLvl:       logLevel,
+		BlockState:   stateSrvc.Block,
+		StorageState: stateSrvc.Storage,
+		Network:      networkSrvc,
+		Keystore:     ks.Beef,
+		Authority:    config.Core.GrandpaAuthority,
+	})
+}
+
+// createMMRService creates the service canonicalising the MMR nodes indexed offchain
+// by the runtime when their block is finalised
+func createMMRService(config *cfg.Config, stateSrvc *state.Service, ns *runtime.NodeStorage) (*mmr.Service, error) {
+	logLevel, err := log.ParseLevel(config.Log.Runtime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse runtime log level: %w", err)
+	}
+
+	return mmr.NewService(&mmr.Config{
+		LogLvl:          logLevel,
+		BlockState:      stateSrvc.Block,
+		StorageState:    stateSrvc.Storage,
+		OffchainStorage: ns.PersistentStorage,
+	}), nil
+}
+
 // RPC Service
 
 // createRPCService creates the RPC service from the provided core configuration
@@ -476,14 +559,15 @@ func (nodeBuilder) createGRANDPAService(config *cfg.Config, st *state.Service, k
 		return nil, fmt.Errorf("failed to parse grandpa log level: %w", err)
 	}
 	gsCfg := &grandpa.Config{
-		LogLvl:       grandpaLogLevel,
-		BlockState:   st.Block,
-		GrandpaState: st.Grandpa,
-		Voters:       voters,
-		Authority:    config.Core.GrandpaAuthority,
-		Network:      net,
-		Interval:     config.Core.GrandpaInterval,
-		Telemetry:    telemetryMailer,
+		LogLvl:          grandpaLogLevel,
+		BlockState:      st.Block,
+		GrandpaState:    st.Grandpa,
+		Voters:          voters,
+		Authority:       config.Core.GrandpaAuthority,
+		Network:         net,
+		Interval:        config.Core.GrandpaInterval,
+		Telemetry:       telemetryMailer,
+		FinalityGrandpa: config.Core.GrandpaVoter == cfg.FinalityGrandpaVoter,
 	}
 
 	if config.Core.GrandpaAuthority {
@@ -521,21 +605,43 @@ func (nodeBuilder) newSyncService(config *cfg.Config, st *state.Service, fg Bloc
 		blockRequestTimeout,
 		network.MaxBlockResponseSize)
 
+	const stateRequestTimeout = time.Second * 30
+	stateRequestMaker := net.GetRequestResponseProtocol(
+		network.StateID,
+		stateRequestTimeout,
+		network.MaxBlockResponseSize)
+
+	const warpSyncRequestTimeout = time.Second * 30
+	warpSyncRequestMaker := net.GetRequestResponseProtocol(
+		network.WarpSyncID,
+		warpSyncRequestTimeout,
+		network.MaxBlockResponseSize)
+
+	// the warp sync proves the block whose state is downloaded
+	warpSync := config.Network.SyncMode == cfg.WarpSyncMode
+	stateSync := config.Network.SyncMode == cfg.FastSyncMode || warpSync
+
 	syncCfg := &sync.Config{
-		LogLvl:             syncLogLevel,
-		Network:            net,
-		BlockState:         st.Block,
-		StorageState:       st.Storage,
-		TransactionState:   st.Transaction,
-		FinalityGadget:     fg,
-		BabeVerifier:       verifier,
-		BlockImportHandler: cs,
-		MinPeers:           config.Network.MinPeers,
-		MaxPeers:           config.Network.MaxPeers,
-		SlotDuration:       slotDuration,
-		Telemetry:          telemetryMailer,
-		BadBlocks:          genesisData.BadBlocks,
-		RequestMaker:       requestMaker,
+		LogLvl:               syncLogLevel,
+		Network:              net,
+		BlockState:           st.Block,
+		StorageState:         st.Storage,
+		TransactionState:     st.Transaction,
+		FinalityGadget:       fg,
+		BabeVerifier:         verifier,
+		BlockImportHandler:   cs,
+		MinPeers:             config.Network.MinPeers,
+		MaxPeers:             config.Network.MaxPeers,
+		SlotDuration:         slotDuration,
+		Telemetry:            telemetryMailer,
+		BadBlocks:            genesisData.BadBlocks,
+		RequestMaker:         requestMaker,
+		StateSync:            stateSync,
+		StateRequestMaker:    stateRequestMaker,
+		WarpSync:             warpSync,
+		WarpSyncRequestMaker: warpSyncRequestMaker,
+		GrandpaState:         st.Grandpa,
+		EpochState:           st.Epoch,
 	}
 
 	return sync.NewService(syncCfg)
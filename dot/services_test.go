@@ -6,9 +6,11 @@
 import (
 	"testing"
 
+	cfg "github.com/ChainSafe/gossamer/config"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
@@ -31,22 +33,19 @@ func Test_createRuntimeStorage(t *testing.T) {
 	require.NoError(t, err)
 
 	tests := []struct {
-		name           string
-		service        *state.Service
-		expectedBaseDB *state.BaseState
-		err            error
+		name    string
+		service *state.Service
+		err     error
 	}{
 		{
-			name:           "working example",
-			service:        stateSrvc,
-			expectedBaseDB: stateSrvc.Base,
+			name:    "working example",
+			service: stateSrvc,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := builder.createRuntimeStorage(tt.service)
 			assert.ErrorIs(t, err, tt.err)
-			assert.Equal(t, tt.expectedBaseDB, got.BaseDB)
 			assert.NotNil(t, got.LocalStorage)
 			assert.NotNil(t, got.PersistentStorage)
 		})
@@ -123,6 +122,44 @@ func Test_newInMemoryDB(t *testing.T) {
 	}
 }
 
+func Test_offchainWorkersEnabled(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config  cfg.CoreConfig
+		enabled bool
+	}{
+		"always": {
+			config:  cfg.CoreConfig{Role: common.FullNodeRole, OffchainWorker: cfg.AlwaysOffchainWorker},
+			enabled: true,
+		},
+		"never_for_authority": {
+			config: cfg.CoreConfig{Role: common.AuthorityRole, OffchainWorker: cfg.NeverOffchainWorker},
+		},
+		"when_validator_for_full_node": {
+			config: cfg.CoreConfig{Role: common.FullNodeRole, OffchainWorker: cfg.WhenValidatorOffchainWorker},
+		},
+		"when_validator_for_authority": {
+			config:  cfg.CoreConfig{Role: common.AuthorityRole, OffchainWorker: cfg.WhenValidatorOffchainWorker},
+			enabled: true,
+		},
+		"unset_for_authority": {
+			config:  cfg.CoreConfig{Role: common.AuthorityRole},
+			enabled: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			enabled := offchainWorkersEnabled(&testCase.config)
+			assert.Equal(t, testCase.enabled, enabled)
+		})
+	}
+}
+
 func newStateService(t *testing.T, ctrl *gomock.Controller) *state.Service {
 	t.Helper()
 
@@ -6,8 +6,11 @@
 import (
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/ChainSafe/gossamer/dot/state/pruner"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
 )
@@ -109,3 +112,39 @@ func (s *BaseState) loadSkipToEpoch() (uint64, error) {
 
 	return binary.LittleEndian.Uint64(data), nil
 }
+
+func (s *BaseState) storePruningMode(mode pruner.Mode) error {
+	return s.db.Put(common.PruningKey, []byte(mode))
+}
+
+// loadPruningMode returns the pruning mode stored in the database,
+// defaulting to the archive mode if no mode was stored.
+func (s *BaseState) loadPruningMode() (pruner.Mode, error) {
+	data, err := s.db.Get(common.PruningKey)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return pruner.Archive, nil
+		}
+		return "", err
+	}
+
+	return pruner.Mode(data), nil
+}
+
+func (s *BaseState) storeStorageBackend(backend StorageBackend) error {
+	return s.db.Put(common.StorageBackendKey, []byte(backend))
+}
+
+// loadStorageBackend returns the storage backend stored in the database,
+// defaulting to the in-memory storage backend if no backend was stored.
+func (s *BaseState) loadStorageBackend() (StorageBackend, error) {
+	data, err := s.db.Get(common.StorageBackendKey)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return InmemoryStorageBackend, nil
+		}
+		return "", err
+	}
+
+	return StorageBackend(data), nil
+}
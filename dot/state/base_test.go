@@ -6,6 +6,7 @@
 import (
 	"testing"
 
+	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
@@ -63,3 +64,35 @@ func TestStoreAndLoadGenesisData(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expected, gen)
 }
+
+func TestStoreAndLoadPruningMode(t *testing.T) {
+	db := NewInMemoryDB(t)
+	base := NewBaseState(db)
+
+	mode, err := base.loadPruningMode()
+	require.NoError(t, err)
+	require.Equal(t, pruner.Archive, mode)
+
+	err = base.storePruningMode(pruner.Full)
+	require.NoError(t, err)
+
+	mode, err = base.loadPruningMode()
+	require.NoError(t, err)
+	require.Equal(t, pruner.Full, mode)
+}
+
+func TestStoreAndLoadStorageBackend(t *testing.T) {
+	db := NewInMemoryDB(t)
+	base := NewBaseState(db)
+
+	backend, err := base.loadStorageBackend()
+	require.NoError(t, err)
+	require.Equal(t, InmemoryStorageBackend, backend)
+
+	err = base.storeStorageBackend(TrieDBStorageBackend)
+	require.NoError(t, err)
+
+	backend, err = base.loadStorageBackend()
+	require.NoError(t, err)
+	require.Equal(t, TrieDBStorageBackend, backend)
+}
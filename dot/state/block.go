@@ -29,14 +29,15 @@
 )
 
 var (
-	headerPrefix        = []byte("hdr") // headerPrefix + hash -> header
-	blockBodyPrefix     = []byte("blb") // blockBodyPrefix + hash -> body
-	headerHashPrefix    = []byte("hsh") // headerHashPrefix + encodedBlockNum -> hash
-	arrivalTimePrefix   = []byte("arr") // arrivalTimePrefix || hash -> arrivalTime
-	receiptPrefix       = []byte("rcp") // receiptPrefix + hash -> receipt
-	messageQueuePrefix  = []byte("mqp") // messageQueuePrefix + hash -> message queue
-	justificationPrefix = []byte("jcp") // justificationPrefix + hash -> justification
-	firstSlotNumberKey  = []byte("fsn") // firstSlotNumberKey -> First slot number
+	headerPrefix             = []byte("hdr") // headerPrefix + hash -> header
+	blockBodyPrefix          = []byte("blb") // blockBodyPrefix + hash -> body
+	headerHashPrefix         = []byte("hsh") // headerHashPrefix + encodedBlockNum -> hash
+	arrivalTimePrefix        = []byte("arr") // arrivalTimePrefix || hash -> arrivalTime
+	receiptPrefix            = []byte("rcp") // receiptPrefix + hash -> receipt
+	messageQueuePrefix       = []byte("mqp") // messageQueuePrefix + hash -> message queue
+	justificationPrefix      = []byte("jcp") // justificationPrefix + hash -> justification
+	beefyJustificationPrefix = []byte("bjp") // beefyJustificationPrefix + hash -> beefy justification
+	firstSlotNumberKey       = []byte("fsn") // firstSlotNumberKey -> First slot number
 
 	errNilBlockTree = errors.New("blocktree is nil")
 	errNilBlockBody = errors.New("block body is nil")
@@ -62,6 +63,11 @@ type BlockState struct {
 	lastSetID         uint64
 	unfinalisedBlocks *hashToBlockMap
 	tries             *Tries
+	offchainIndex     *offchainIndex
+
+	// batcher creates batches of the database holding the tables
+	// of all the states, to write to several tables atomically.
+	batcher NewBatcher
 
 	// State variables
 	pausedLock sync.RWMutex
@@ -76,6 +82,16 @@ type BlockState struct {
 	runtimeUpdateSubscriptions     map[uint32]chan<- runtime.Version
 
 	telemetry Telemetry
+
+	// pinned holds the pinned blocks, whose header, body and state are kept until unpinned.
+	pinned     map[common.Hash]*pinnedBlock
+	pinnedLock sync.Mutex
+
+	// justificationsWindow is the number of the latest finalised blocks whose justifications
+	// are kept, and grandpaState gives the last blocks of the authority sets whose
+	// justifications are always kept.
+	justificationsWindow uint
+	grandpaState         *GrandpaState
 }
 
 // NewBlockState will create a new BlockState backed by the database located at basePath
@@ -84,11 +100,14 @@ func NewBlockState(db database.Database, trs *Tries, telemetry Telemetry) (*Bloc
 		dbPath:                     db.Path(),
 		baseState:                  NewBaseState(db),
 		db:                         database.NewTable(db, blockPrefix),
+		batcher:                    db,
 		unfinalisedBlocks:          newHashToBlockMap(),
 		tries:                      trs,
+		offchainIndex:              newOffchainIndex(db),
 		imported:                   make(map[chan *types.Block]struct{}),
 		finalised:                  make(map[chan *types.FinalisationInfo]struct{}),
 		runtimeUpdateSubscriptions: make(map[uint32]chan<- runtime.Version),
+		pinned:                     make(map[common.Hash]*pinnedBlock),
 		telemetry:                  telemetry,
 		pause:                      make(chan struct{}),
 	}
@@ -118,11 +137,14 @@ func NewBlockStateFromGenesis(db database.Database, trs *Tries, header *types.He
 		bt:                         blocktree.NewBlockTreeFromRoot(header),
 		baseState:                  NewBaseState(db),
 		db:                         database.NewTable(db, blockPrefix),
+		batcher:                    db,
 		unfinalisedBlocks:          newHashToBlockMap(),
 		tries:                      trs,
+		offchainIndex:              newOffchainIndex(db),
 		imported:                   make(map[chan *types.Block]struct{}),
 		finalised:                  make(map[chan *types.FinalisationInfo]struct{}),
 		runtimeUpdateSubscriptions: make(map[uint32]chan<- runtime.Version),
+		pinned:                     make(map[common.Hash]*pinnedBlock),
 		genesisHash:                header.Hash(),
 		lastFinalised:              header.Hash(),
 		telemetry:                  telemetryMailer,
@@ -544,6 +566,14 @@ func (bs *BlockState) AddBlockWithArrivalTime(block *types.Block, arrivalTime ti
 	return nil
 }
 
+// SetOffchainIndexChanges records the offchain index changes made by the runtime when
+// executing the unfinalised block with the given hash, where a nil value clears the key.
+// The changes are written to the offchain storage when the block is finalised, and
+// discarded if the block is pruned.
+func (bs *BlockState) SetOffchainIndexChanges(hash common.Hash, changes map[string][]byte) error {
+	return bs.offchainIndex.set(hash, changes)
+}
+
 // GetAllBlocksAtNumber returns all unfinalised blocks with the given number
 func (bs *BlockState) GetAllBlocksAtNumber(num uint) ([]common.Hash, error) {
 	return bs.bt.GetHashesAtNumber(num), nil
@@ -939,12 +969,9 @@ func (bs *BlockState) GetRuntime(blockHash common.Hash) (instance runtime.Instan
 	runtimeInstance, err := bs.bt.GetBlockRuntime(blockHash)
 
 	if err != nil {
-		// in this case the node is not in the blocktree which mean
-		// it is a finalized node already persisted in database
-		if errors.Is(err, blocktree.ErrNodeNotFound) {
-			panic(err.Error() + " see https://github.com/ChainSafe/gossamer/issues/3066")
-		}
-
+		// blocktree.ErrNodeNotFound is wrapped if the block is not in the blocktree,
+		// which means it is a finalised block already persisted in the database
+		// or a pruned block, so the caller can create the runtime from its state.
 		return nil, fmt.Errorf("while getting runtime: %w", err)
 	}
 
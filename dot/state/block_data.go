@@ -86,3 +86,18 @@ func (bs *BlockState) GetJustification(hash common.Hash) ([]byte, error) {
 
 	return data, nil
 }
+
+// HasBeefyJustification returns if the db contains a BEEFY justification at the given hash
+func (bs *BlockState) HasBeefyJustification(hash common.Hash) (bool, error) {
+	return bs.db.Has(prefixKey(hash, beefyJustificationPrefix))
+}
+
+// SetBeefyJustification sets a BEEFY justification in the database
+func (bs *BlockState) SetBeefyJustification(hash common.Hash, data []byte) error {
+	return bs.db.Put(prefixKey(hash, beefyJustificationPrefix), data)
+}
+
+// GetBeefyJustification retrieves a BEEFY justification from the database
+func (bs *BlockState) GetBeefyJustification(hash common.Hash) ([]byte, error) {
+	return bs.db.Get(prefixKey(hash, beefyJustificationPrefix))
+}
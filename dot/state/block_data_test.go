@@ -81,3 +81,30 @@ func TestGetSet_ReceiptMessageQueue_Justification(t *testing.T) {
 		}
 	}
 }
+
+func TestBlockState_BeefyJustification(t *testing.T) {
+	t.Parallel()
+
+	s := newTestBlockState(t, newTriesEmpty())
+	hash := common.Hash{1}
+
+	has, err := s.HasBeefyJustification(hash)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	err = s.SetBeefyJustification(hash, []byte{1, 2})
+	require.NoError(t, err)
+
+	has, err = s.HasBeefyJustification(hash)
+	require.NoError(t, err)
+	require.True(t, has)
+
+	justification, err := s.GetBeefyJustification(hash)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2}, justification)
+
+	// the grandpa justification of the block is not affected
+	has, err = s.HasJustification(hash)
+	require.NoError(t, err)
+	require.False(t, has)
+}
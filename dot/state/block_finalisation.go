@@ -7,10 +7,16 @@
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 )
 
 var errSetIDLowerThanHighest = errors.New("set id lower than highest")
@@ -151,21 +157,14 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 		return fmt.Errorf("failed to set highest round and set ID: %w", err)
 	}
 
-	if round > 0 {
-		bs.notifyFinalized(hash, round, setID)
-	}
-
 	pruned := bs.bt.Prune(hash)
-	for _, hash := range pruned {
-		blockHeader := bs.unfinalisedBlocks.delete(hash)
-		if blockHeader == nil {
-			continue
-		}
 
-		bs.tries.delete(blockHeader.StateRoot)
-		logger.Tracef("pruned block number %d with hash %s", blockHeader.Number, hash)
+	if round > 0 {
+		bs.notifyFinalized(hash, round, setID, bs.staleHeaders(pruned))
 	}
 
+	bs.handlePrunedBlocks(pruned)
+
 	header, err := bs.GetHeader(hash)
 	if err != nil {
 		return fmt.Errorf("failed to get finalised header, hash: %s, error: %s", hash, err)
@@ -189,11 +188,113 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 	bs.lastRound = round
 	bs.lastSetID = setID
 
+	if err := bs.pruneJustifications(header.Number); err != nil {
+		return fmt.Errorf("pruning justifications: %w", err)
+	}
+
 	logger.Infof(
 		"🔨 finalised block #%d (%s), round %d, set id %d", header.Number, hash, round, setID)
 	return nil
 }
 
+// SetStateSyncedFinalisedHeader sets the given header as the highest finalised
+// block header, when its state was downloaded from peers instead of being built
+// by executing its ancestor blocks. The given state trie, header and justification
+// are written in one database batch, so an interrupted state sync does not leave
+// a finalised block without its state or justification. The block tree is reset
+// with the header as its root, and the runtime of the block is created from the
+// given state if its code differs from the code of the previous highest finalised block.
+func (bs *BlockState) SetStateSyncedFinalisedHeader(header *types.Header, justification []byte,
+	state *rtstorage.TrieState) error {
+	bs.lock.Lock()
+	defer bs.lock.Unlock()
+
+	if bs.IsPaused() {
+		return errors.New("blockstate service is paused")
+	}
+
+	stateTrie, ok := state.Trie().(*inmemory.InMemoryTrie)
+	if !ok {
+		return fmt.Errorf("%w: %T", errUnsupportedTrie, state.Trie())
+	}
+
+	previousRuntime, err := bs.bt.GetBlockRuntime(bs.lastFinalised)
+	if err != nil {
+		return fmt.Errorf("getting runtime of last finalised block: %w", err)
+	}
+
+	round, setID, err := bs.GetHighestRoundAndSetID()
+	if err != nil {
+		return fmt.Errorf("getting highest round and set ID: %w", err)
+	}
+
+	hash := header.Hash()
+	encodedHeader, err := scale.Marshal(*header)
+	if err != nil {
+		return fmt.Errorf("encoding header: %w", err)
+	}
+
+	// the block body is not downloaded when syncing the state
+	encodedBody, err := scale.Marshal(*types.NewBody(nil))
+	if err != nil {
+		return fmt.Errorf("encoding block body: %w", err)
+	}
+
+	arrivalTime := make([]byte, 8)
+	binary.LittleEndian.PutUint64(arrivalTime, uint64(time.Now().UnixNano()))
+
+	batch := bs.batcher.NewBatch()
+	err = stateTrie.PutDirty(database.NewTableBatch(batch, storagePrefix))
+	if err != nil {
+		return fmt.Errorf("putting state trie: %w", err)
+	}
+
+	blockBatch := database.NewTableBatch(batch, blockPrefix)
+	entries := []struct {
+		key   []byte
+		value []byte
+	}{
+		{key: headerKey(hash), value: encodedHeader},
+		{key: blockBodyKey(hash), value: encodedBody},
+		{key: arrivalTimeKey(hash), value: arrivalTime},
+		{key: headerHashKey(uint64(header.Number)), value: hash.ToBytes()},
+		{key: finalisedHashKey(round, setID), value: hash.ToBytes()},
+	}
+
+	for _, entry := range entries {
+		err = blockBatch.Put(entry.key, entry.value)
+		if err != nil {
+			return fmt.Errorf("putting key 0x%x: %w", entry.key, err)
+		}
+	}
+
+	// the warp synced block is proven by the warp sync proof instead of its justification
+	if justification != nil {
+		err = blockBatch.Put(prefixKey(hash, justificationPrefix), justification)
+		if err != nil {
+			return fmt.Errorf("putting justification: %w", err)
+		}
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("flushing state synced block: %w", err)
+	}
+
+	bs.bt = blocktree.NewBlockTreeFromRoot(header)
+	bs.unfinalisedBlocks = newHashToBlockMap()
+	bs.lastFinalised = hash
+
+	bs.StoreRuntime(hash, previousRuntime)
+	err = bs.HandleRuntimeChanges(state, previousRuntime, hash)
+	if err != nil {
+		return fmt.Errorf("handling runtime changes: %w", err)
+	}
+
+	logger.Infof("🔨 finalised state synced block #%d (%s)", header.Number, hash)
+	return nil
+}
+
 func (bs *BlockState) deleteFromTries(lastFinalised common.Hash) error {
 	lastFinalisedHeader, err := bs.GetHeader(lastFinalised)
 	if err != nil {
@@ -264,6 +365,10 @@ func (bs *BlockState) handleFinalisedBlock(currentFinalizedHash common.Hash) err
 			return err
 		}
 
+		if err = bs.offchainIndex.apply(subchainHash); err != nil {
+			return fmt.Errorf("applying offchain index changes of block %s: %w", subchainHash, err)
+		}
+
 		// delete from the unfinalisedBlockMap and delete reference to in-memory trie
 		blockHeader := bs.unfinalisedBlocks.delete(subchainHash)
 		if blockHeader == nil {
@@ -4,13 +4,17 @@
 package state
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func TestHighestRoundAndSetID(t *testing.T) {
@@ -164,3 +168,129 @@ func TestSetFinalisedHash_retrieveBlockNumber1SlotNumber(t *testing.T) {
 
 	require.Equal(t, firstSlot, veryFirstSlot)
 }
+
+func TestBlockState_SetFinalisedHash_offchainIndex(t *testing.T) {
+	bs := newTestBlockState(t, newTriesEmpty())
+
+	newBlock := func(t *testing.T, parentHash common.Hash, number uint, slot uint64) *types.Block {
+		t.Helper()
+		digest := types.NewDigest()
+		preDigest, err := types.NewBabeSecondaryPlainPreDigest(0, slot).ToPreRuntimeDigest()
+		require.NoError(t, err)
+		err = digest.Add(*preDigest)
+		require.NoError(t, err)
+
+		block := &types.Block{
+			Header: types.Header{
+				ParentHash: parentHash,
+				Number:     number,
+				Digest:     digest,
+			},
+			Body: types.Body{},
+		}
+		err = bs.AddBlock(block)
+		require.NoError(t, err)
+		return block
+	}
+
+	block1 := newBlock(t, testGenesisHeader.Hash(), 1, 1)
+	err := bs.SetOffchainIndexChanges(block1.Header.Hash(), map[string][]byte{
+		"key-a": []byte("value-a"),
+		"key-b": []byte("value-b"),
+	})
+	require.NoError(t, err)
+
+	block2 := newBlock(t, block1.Header.Hash(), 2, 2)
+	err = bs.SetOffchainIndexChanges(block2.Header.Hash(), map[string][]byte{
+		"key-b": nil,
+		"key-c": []byte("value-c"),
+	})
+	require.NoError(t, err)
+
+	forkBlock := newBlock(t, testGenesisHeader.Hash(), 1, 3)
+	err = bs.SetOffchainIndexChanges(forkBlock.Header.Hash(), map[string][]byte{
+		"key-d": []byte("value-d"),
+	})
+	require.NoError(t, err)
+
+	// the changes of the unfinalised blocks are kept in the database
+	blockHashes := []common.Hash{block1.Header.Hash(), block2.Header.Hash(), forkBlock.Header.Hash()}
+	for _, hash := range blockHashes {
+		has, err := bs.offchainIndex.changes.Has(hash.ToBytes())
+		require.NoError(t, err)
+		require.True(t, has)
+	}
+
+	err = bs.SetFinalisedHash(block2.Header.Hash(), 1, 0)
+	require.NoError(t, err)
+
+	offchainStorage := bs.offchainIndex.storage
+	value, err := offchainStorage.Get([]byte("key-a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value-a"), value)
+
+	value, err = offchainStorage.Get([]byte("key-c"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value-c"), value)
+
+	_, err = offchainStorage.Get([]byte("key-b"))
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	// the changes of the pruned fork are discarded
+	_, err = offchainStorage.Get([]byte("key-d"))
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	// the changes of the finalised and pruned blocks are deleted from the database
+	for _, hash := range blockHashes {
+		has, err := bs.offchainIndex.changes.Has(hash.ToBytes())
+		require.NoError(t, err)
+		require.False(t, has)
+	}
+}
+
+func TestBlockState_SetStateSyncedFinalisedHeader(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
+
+	db := NewInMemoryDB(t)
+	bs, err := NewBlockStateFromGenesis(db, newTriesEmpty(), testGenesisHeader, telemetryMock)
+	require.NoError(t, err)
+
+	code := []byte{1}
+	stateTrie := inmemory_trie.NewEmptyTrie()
+	err = stateTrie.Put(common.CodeKey, code)
+	require.NoError(t, err)
+	err = stateTrie.Put([]byte("key"), bytes.Repeat([]byte{2}, 40))
+	require.NoError(t, err)
+	stateRoot := stateTrie.MustHash()
+
+	// the runtime is not changed since the state has the same code
+	runtimeInstance := NewMockInstance(ctrl)
+	runtimeInstance.EXPECT().GetCodeHash().Return(common.MustBlake2bHash(code))
+	bs.StoreRuntime(testGenesisHeader.Hash(), runtimeInstance)
+
+	header := &types.Header{
+		ParentHash: common.Hash{1},
+		Number:     512,
+		StateRoot:  stateRoot,
+		Digest:     types.NewDigest(),
+	}
+	justification := []byte{3}
+
+	err = bs.SetStateSyncedFinalisedHeader(header, justification, rtstorage.NewTrieState(stateTrie))
+	require.NoError(t, err)
+
+	finalisedHeader, err := bs.GetHighestFinalisedHeader()
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), finalisedHeader.Hash())
+
+	storedJustification, err := bs.GetJustification(header.Hash())
+	require.NoError(t, err)
+	require.Equal(t, justification, storedJustification)
+
+	storedTrie := inmemory_trie.NewEmptyTrie()
+	err = storedTrie.Load(database.NewTable(db, storagePrefix), stateRoot)
+	require.NoError(t, err)
+	require.Equal(t, bytes.Repeat([]byte{2}, 40), storedTrie.Get([]byte("key")))
+}
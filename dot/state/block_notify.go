@@ -70,7 +70,7 @@ func (bs *BlockState) notifyImported(block *types.Block) {
 	}
 }
 
-func (bs *BlockState) notifyFinalized(hash common.Hash, round, setID uint64) {
+func (bs *BlockState) notifyFinalized(hash common.Hash, round, setID uint64, staleHeaders []types.Header) {
 	bs.finalisedLock.RLock()
 	defer bs.finalisedLock.RUnlock()
 
@@ -86,9 +86,10 @@ func (bs *BlockState) notifyFinalized(hash common.Hash, round, setID uint64) {
 
 	logger.Debug("notifying finalised block channels...")
 	info := &types.FinalisationInfo{
-		Header: *header,
-		Round:  round,
-		SetID:  setID,
+		Header:       *header,
+		Round:        round,
+		SetID:        setID,
+		StaleHeaders: staleHeaders,
 	}
 
 	for ch := range bs.finalised {
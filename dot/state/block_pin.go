@@ -0,0 +1,134 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// pinnedBlock holds the pin references of a block.
+type pinnedBlock struct {
+	references uint
+	// stateNumber is the highest block number whose state is needed by the
+	// pinned block, which is its number if it was finalised when pinned, or
+	// the number of the highest finalised block otherwise, since its state
+	// may be built on the state of any non-finalised ancestor.
+	stateNumber uint
+	// pruned is true if the block was pruned from the block tree while pinned,
+	// in which case its header, body and trie are kept until it is unpinned.
+	pruned bool
+}
+
+// PinBlock pins the block with the given hash, so its header, body and state are
+// kept until it is unpinned, even if it is pruned or its state is older than the
+// state retained by the state pruner. A block can be pinned multiple times, and
+// is released once unpinned as many times.
+func (bs *BlockState) PinBlock(hash common.Hash) error {
+	bs.pinnedLock.Lock()
+	defer bs.pinnedLock.Unlock()
+
+	pinned, ok := bs.pinned[hash]
+	if ok {
+		pinned.references++
+		return nil
+	}
+
+	header, err := bs.GetHeader(hash)
+	if err != nil {
+		return fmt.Errorf("getting header: %w", err)
+	}
+
+	finalisedHeader, err := bs.GetHighestFinalisedHeader()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	bs.pinned[hash] = &pinnedBlock{
+		references:  1,
+		stateNumber: min(header.Number, finalisedHeader.Number),
+	}
+	return nil
+}
+
+// UnpinBlock removes a pin reference of the block with the given hash. Once it has
+// no pin reference left, the block is deleted if it was pruned while pinned.
+func (bs *BlockState) UnpinBlock(hash common.Hash) {
+	bs.pinnedLock.Lock()
+	defer bs.pinnedLock.Unlock()
+
+	pinned, ok := bs.pinned[hash]
+	if !ok {
+		return
+	}
+
+	pinned.references--
+	if pinned.references > 0 {
+		return
+	}
+	delete(bs.pinned, hash)
+
+	if pinned.pruned {
+		bs.deletePrunedBlock(hash)
+	}
+}
+
+// LowestPinnedBlockNumber returns the lowest block number whose state is needed
+// by the pinned blocks, and false if no block is pinned.
+func (bs *BlockState) LowestPinnedBlockNumber() (number uint, ok bool) {
+	bs.pinnedLock.Lock()
+	defer bs.pinnedLock.Unlock()
+
+	for _, pinned := range bs.pinned {
+		if !ok || pinned.stateNumber < number {
+			number = pinned.stateNumber
+			ok = true
+		}
+	}
+	return number, ok
+}
+
+// staleHeaders returns the headers of the given blocks pruned from the block tree,
+// which must be called before the blocks are deleted.
+func (bs *BlockState) staleHeaders(pruned []common.Hash) (headers []types.Header) {
+	for _, hash := range pruned {
+		header := bs.unfinalisedBlocks.getBlockHeader(hash)
+		if header != nil {
+			headers = append(headers, *header)
+		}
+	}
+	return headers
+}
+
+// handlePrunedBlocks deletes the blocks pruned from the block tree, apart from the pinned
+// blocks which are deleted once unpinned.
+func (bs *BlockState) handlePrunedBlocks(pruned []common.Hash) {
+	bs.pinnedLock.Lock()
+	defer bs.pinnedLock.Unlock()
+
+	for _, hash := range pruned {
+		err := bs.offchainIndex.discard(hash)
+		if err != nil {
+			logger.Errorf("discarding offchain index changes of pruned block %s: %s", hash, err)
+		}
+
+		if pinned, ok := bs.pinned[hash]; ok {
+			pinned.pruned = true
+			continue
+		}
+		bs.deletePrunedBlock(hash)
+	}
+}
+
+func (bs *BlockState) deletePrunedBlock(hash common.Hash) {
+	blockHeader := bs.unfinalisedBlocks.delete(hash)
+	if blockHeader == nil {
+		return
+	}
+
+	bs.tries.delete(blockHeader.StateRoot)
+	logger.Tracef("pruned block number %d with hash %s", blockHeader.Number, hash)
+}
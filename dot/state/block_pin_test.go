@@ -0,0 +1,86 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockState_PinBlock(t *testing.T) {
+	bs := newTestBlockState(t, newTriesEmpty())
+
+	newBlock := func(t *testing.T, parentHash common.Hash, number uint, slot uint64) *types.Block {
+		t.Helper()
+		digest := types.NewDigest()
+		preDigest, err := types.NewBabeSecondaryPlainPreDigest(0, slot).ToPreRuntimeDigest()
+		require.NoError(t, err)
+		err = digest.Add(*preDigest)
+		require.NoError(t, err)
+
+		block := &types.Block{
+			Header: types.Header{
+				ParentHash: parentHash,
+				Number:     number,
+				Digest:     digest,
+			},
+			Body: types.Body{},
+		}
+		err = bs.AddBlock(block)
+		require.NoError(t, err)
+		return block
+	}
+
+	block1 := newBlock(t, testGenesisHeader.Hash(), 1, 1)
+	block2 := newBlock(t, block1.Header.Hash(), 2, 2)
+	forkBlock := newBlock(t, testGenesisHeader.Hash(), 1, 3)
+	forkHash := forkBlock.Header.Hash()
+
+	_, ok := bs.LowestPinnedBlockNumber()
+	assert.False(t, ok)
+
+	err := bs.PinBlock(common.Hash{9})
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	// the fork block is pinned twice, and its state may
+	// be built on the state of the finalised genesis block.
+	err = bs.PinBlock(forkHash)
+	require.NoError(t, err)
+	err = bs.PinBlock(forkHash)
+	require.NoError(t, err)
+	number, ok := bs.LowestPinnedBlockNumber()
+	require.True(t, ok)
+	assert.Equal(t, uint(0), number)
+
+	err = bs.SetFinalisedHash(block2.Header.Hash(), 1, 0)
+	require.NoError(t, err)
+
+	// the pruned fork block is kept until it is unpinned as many times as it was pinned
+	bs.UnpinBlock(forkHash)
+	header, err := bs.GetHeader(forkHash)
+	require.NoError(t, err)
+	assert.Equal(t, forkBlock.Header, *header)
+	body, err := bs.GetBlockBody(forkHash)
+	require.NoError(t, err)
+	assert.Equal(t, &forkBlock.Body, body)
+
+	bs.UnpinBlock(forkHash)
+	_, err = bs.GetHeader(forkHash)
+	require.ErrorIs(t, err, database.ErrNotFound)
+	_, ok = bs.LowestPinnedBlockNumber()
+	assert.False(t, ok)
+
+	// a finalised block needs its own state
+	err = bs.PinBlock(block1.Header.Hash())
+	require.NoError(t, err)
+	number, ok = bs.LowestPinnedBlockNumber()
+	require.True(t, ok)
+	assert.Equal(t, uint(1), number)
+}
@@ -14,6 +14,7 @@
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
@@ -25,6 +26,7 @@
 	errEpochNotInDatabase      = errors.New("epoch data not found in the database")
 	errHashNotPersisted        = errors.New("hash with next epoch not found in database")
 	errNoFirstNonOriginBlock   = errors.New("no first non origin block")
+	errNoBabeAuthorities       = errors.New("no babe authorities in state")
 )
 
 var (
@@ -214,6 +216,78 @@ func (s *EpochState) SetEpochDataRaw(epoch uint64, raw *types.EpochDataRaw) erro
 	return s.db.Put(epochDataKey(epoch), enc)
 }
 
+// SetStateSyncedEpochData stores the BABE data of the current and next epochs
+// read from the given state, when it was downloaded from peers instead of being
+// built by importing the blocks which announced the epochs.
+func (s *EpochState) SetStateSyncedEpochData(state *rtstorage.TrieState) error {
+	var genesisSlot uint64
+	_, err := getPalletStorageValue(state, "Babe", "GenesisSlot", &genesisSlot)
+	if err != nil {
+		return fmt.Errorf("getting genesis slot: %w", err)
+	}
+
+	err = s.blockState.setFirstNonOriginSlotNumber(genesisSlot)
+	if err != nil {
+		return fmt.Errorf("setting first non origin slot number: %w", err)
+	}
+
+	var epoch uint64
+	_, err = getPalletStorageValue(state, "Babe", "EpochIndex", &epoch)
+	if err != nil {
+		return fmt.Errorf("getting epoch index: %w", err)
+	}
+
+	err = s.StoreCurrentEpoch(epoch)
+	if err != nil {
+		return fmt.Errorf("storing current epoch: %w", err)
+	}
+
+	storageItems := []struct {
+		epoch       uint64
+		authorities string
+		randomness  string
+		config      string
+	}{
+		{epoch: epoch, authorities: "Authorities", randomness: "Randomness", config: "EpochConfig"},
+		{epoch: epoch + 1, authorities: "NextAuthorities", randomness: "NextRandomness", config: "NextEpochConfig"},
+	}
+
+	for _, items := range storageItems {
+		epochData := types.EpochDataRaw{}
+		found, err := getPalletStorageValue(state, "Babe", items.authorities, &epochData.Authorities)
+		if err != nil {
+			return fmt.Errorf("getting authorities of epoch %d: %w", items.epoch, err)
+		} else if !found {
+			return fmt.Errorf("%w: for epoch %d", errNoBabeAuthorities, items.epoch)
+		}
+
+		_, err = getPalletStorageValue(state, "Babe", items.randomness, &epochData.Randomness)
+		if err != nil {
+			return fmt.Errorf("getting randomness of epoch %d: %w", items.epoch, err)
+		}
+
+		err = s.SetEpochDataRaw(items.epoch, &epochData)
+		if err != nil {
+			return fmt.Errorf("setting data of epoch %d: %w", items.epoch, err)
+		}
+
+		configData := types.ConfigData{}
+		found, err = getPalletStorageValue(state, "Babe", items.config, &configData)
+		if err != nil {
+			return fmt.Errorf("getting config of epoch %d: %w", items.epoch, err)
+		} else if !found {
+			continue
+		}
+
+		err = s.StoreConfigData(items.epoch, &configData)
+		if err != nil {
+			return fmt.Errorf("storing config of epoch %d: %w", items.epoch, err)
+		}
+	}
+
+	return nil
+}
+
 // GetEpochDataRaw returns the raw epoch data for a given epoch persisted in database
 // otherwise will try to get the data from the in-memory map using the header
 // if the header params is nil then it will search only in database
@@ -12,7 +12,9 @@
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/pkg/scale"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 	"github.com/ChainSafe/gossamer/tests/utils/config"
 
 	"github.com/stretchr/testify/require"
@@ -127,6 +129,56 @@ func TestEpochState_ConfigData(t *testing.T) {
 	require.Equal(t, data, ret)
 }
 
+func TestEpochState_SetStateSyncedEpochData(t *testing.T) {
+	s := newEpochStateFromGenesis(t)
+
+	authorities := []types.AuthorityRaw{{Key: [32]byte{1}, Weight: 1}}
+	nextAuthorities := []types.AuthorityRaw{{Key: [32]byte{2}, Weight: 1}}
+	config := types.ConfigData{C1: 1, C2: 4, SecondarySlots: 2}
+
+	values := map[string]any{
+		"GenesisSlot":     uint64(1000),
+		"EpochIndex":      uint64(7),
+		"Authorities":     authorities,
+		"Randomness":      [32]byte{77},
+		"NextAuthorities": nextAuthorities,
+		"NextRandomness":  [32]byte{88},
+		"EpochConfig":     config,
+	}
+
+	state := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+	for item, value := range values {
+		key, err := palletStorageKey("Babe", item)
+		require.NoError(t, err)
+		err = state.Put(key, scale.MustMarshal(value))
+		require.NoError(t, err)
+	}
+
+	err := s.SetStateSyncedEpochData(state)
+	require.NoError(t, err)
+
+	firstSlot, err := s.blockState.getFirstNonOriginSlotNumber()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), firstSlot)
+
+	epoch, err := s.GetCurrentEpoch()
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), epoch)
+
+	epochData, err := s.GetEpochDataRaw(7, nil)
+	require.NoError(t, err)
+	require.Equal(t, &types.EpochDataRaw{Authorities: authorities, Randomness: [32]byte{77}}, epochData)
+
+	nextEpochData, err := s.GetEpochDataRaw(8, nil)
+	require.NoError(t, err)
+	require.Equal(t, &types.EpochDataRaw{Authorities: nextAuthorities, Randomness: [32]byte{88}}, nextEpochData)
+
+	// the next epoch config is not set so the config of the current epoch applies
+	nextConfig, err := s.GetConfigData(8, nil)
+	require.NoError(t, err)
+	require.Equal(t, &config, nextConfig)
+}
+
 func createAndImportBlockOne(t *testing.T, slotNumber uint64, blockState *BlockState) (blockOneHeader *types.Header) {
 	babeHeader := types.NewBabeDigest()
 	err := babeHeader.SetValue(*types.NewBabePrimaryPreDigest(0, slotNumber, [32]byte{}, [64]byte{}))
@@ -450,6 +450,20 @@ func (s *GrandpaState) GetSetIDChange(setID uint64) (blockNumber uint, err error
 	return common.BytesToUint(num), nil
 }
 
+// getAuthoritySetChanges returns the numbers of the last blocks of the authority sets.
+func (s *GrandpaState) getAuthoritySetChanges() (map[uint]struct{}, error) {
+	changes := make(map[uint]struct{})
+	for setID := genesisSetID + 1; ; setID++ {
+		number, err := s.GetSetIDChange(setID)
+		if errors.Is(err, database.ErrNotFound) {
+			return changes, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("getting block number of set id %d change: %w", setID, err)
+		}
+		changes[number] = struct{}{}
+	}
+}
+
 // GetSetIDByBlockNumber returns the set ID for a given block number
 func (s *GrandpaState) GetSetIDByBlockNumber(blockNumber uint) (uint64, error) {
 	curr, err := s.GetCurrentSetID()
@@ -470,7 +484,10 @@ func (s *GrandpaState) GetSetIDByBlockNumber(blockNumber uint) (uint64, error) {
 		}
 
 		changeLower, err := s.GetSetIDChange(curr)
-		if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			// the set changes prior to a state synced authority set are unknown
+			changeLower = 0
+		} else if err != nil {
 			return 0, err
 		}
 
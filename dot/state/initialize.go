@@ -76,7 +76,7 @@ func (s *Service) Initialise(gen *genesis.Genesis, header *types.Header, t trie.
 	}
 
 	// create storage state from genesis trie
-	storageState, err := NewStorageState(db, blockState, tries)
+	storageState, err := s.newStorageState(db, blockState, tries)
 	if err != nil {
 		return fmt.Errorf("failed to create storage state from trie: %s", err)
 	}
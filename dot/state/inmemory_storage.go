@@ -16,10 +16,18 @@
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 	"github.com/ChainSafe/gossamer/pkg/trie/inmemory/proof"
+	"github.com/ChainSafe/gossamer/pkg/trie/triedb"
 )
 
 // storagePrefix storage key prefix.
 var storagePrefix = "storage"
+
+// journalPrefix pruner journal key prefix.
+var journalPrefix = "journal"
+
+// prunerReferencesPrefix pruner node and value references key prefix.
+var prunerReferencesPrefix = "prunerrefs"
+
 var codeKey = common.CodeKey
 
 // ErrTrieDoesNotExist is returned when attempting to interact with a trie that is not stored in the StorageState
@@ -34,13 +42,12 @@ type InmemoryStorageState struct {
 	blockState *BlockState
 	tries      *Tries
 
-	db GetterPutterNewBatcher
+	db database.Table
 	sync.RWMutex
 
 	// change notifiers
-	observerListMutex sync.RWMutex
-	observerList      []Observer
-	pruner            pruner.Pruner
+	storageObservers
+	pruner pruner.Pruner
 }
 
 // NewStorageState creates a new StorageState backed by the given block state
@@ -49,13 +56,21 @@ func NewStorageState(db database.Database, blockState *BlockState,
 	tries *Tries) (*InmemoryStorageState, error) {
 	storageTable := database.NewTable(db, storagePrefix)
 
-	return &InmemoryStorageState{
-		blockState:   blockState,
-		tries:        tries,
-		db:           storageTable,
+	storageState := &InmemoryStorageState{
+		blockState: blockState,
+		tries:      tries,
+		db:         storageTable,
+		pruner:     &pruner.ArchiveNode{},
+	}
+	storageState.storageObservers = storageObservers{
+		storageState: storageState,
 		observerList: []Observer{},
-		pruner:       &pruner.ArchiveNode{},
-	}, nil
+	}
+	return storageState, nil
+}
+
+func (s *InmemoryStorageState) setPruner(p pruner.Pruner) {
+	s.pruner = p
 }
 
 // StoreTrie stores the given trie in the StorageState and writes it to the database
@@ -64,14 +79,9 @@ func (s *InmemoryStorageState) StoreTrie(ts *storage.TrieState, header *types.He
 	s.tries.softSet(root, ts.Trie())
 
 	if header != nil {
-		insertedNodeHashes, deletedNodeHashes, err := ts.GetChangedNodeHashes()
+		err := s.StoreJournalRecord(ts, header)
 		if err != nil {
-			return fmt.Errorf("getting trie changed node hashes for block hash %s: %w", header.Hash(), err)
-		}
-
-		err = s.pruner.StoreJournalRecord(deletedNodeHashes, insertedNodeHashes, header.Hash(), int64(header.Number))
-		if err != nil {
-			return fmt.Errorf("storing journal record: %w", err)
+			return err
 		}
 	}
 
@@ -89,6 +99,23 @@ func (s *InmemoryStorageState) StoreTrie(ts *storage.TrieState, header *types.He
 	return nil
 }
 
+// StoreJournalRecord stores the journal record of the trie nodes changed by the given
+// trie state of the given block for the state pruner, without writing the trie to the database.
+func (s *InmemoryStorageState) StoreJournalRecord(ts *storage.TrieState, header *types.Header) error {
+	insertedNodeHashes, deletedNodeHashes, err := ts.GetChangedNodeHashes()
+	if err != nil {
+		return fmt.Errorf("getting trie changed node hashes for block hash %s: %w", header.Hash(), err)
+	}
+
+	// the hashed values are not tracked by the in-memory trie
+	err = s.pruner.StoreJournalRecord(deletedNodeHashes, insertedNodeHashes, nil, nil,
+		header.Hash(), int64(header.Number))
+	if err != nil {
+		return fmt.Errorf("storing journal record: %w", err)
+	}
+	return nil
+}
+
 // TrieState returns the TrieState for a given state root.
 // If no state root is provided, it returns the TrieState for the current chain head.
 func (s *InmemoryStorageState) TrieState(root *common.Hash) (*storage.TrieState, error) {
@@ -122,6 +149,14 @@ func (s *InmemoryStorageState) TrieState(root *common.Hash) (*storage.TrieState,
 	return next, nil
 }
 
+// TrieStateWithRecorder returns the TrieState for the given state root, recording
+// the trie nodes and values read from the database in the given recorder. The trie
+// nodes are resolved lazily from the database, which shares the trie database format.
+func (s *InmemoryStorageState) TrieStateWithRecorder(root common.Hash, recorder *triedb.Recorder) (
+	*storage.TrieState, error) {
+	return newRecordingTrieState(s.db, root, recorder)
+}
+
 // LoadFromDB loads an encoded trie from the DB where the key is `root`
 func (s *InmemoryStorageState) LoadFromDB(root common.Hash) (trie.Trie, error) {
 	t := inmemory_trie.NewTrie(nil, s.db)
@@ -5,9 +5,15 @@
 
 import (
 	"encoding/json"
+	"sync"
 
+	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/triedb"
 )
 
 // GetPutDeleter has methods to get, put and delete key values.
@@ -71,3 +77,28 @@ type BabeConfigurer interface {
 type Telemetry interface {
 	SendMessage(msg json.Marshaler)
 }
+
+// StorageState is the state of the storage tries of the blocks.
+type StorageState interface {
+	sync.Locker
+	StoreTrie(ts *storage.TrieState, header *types.Header) error
+	StoreJournalRecord(ts *storage.TrieState, header *types.Header) error
+	TrieState(root *common.Hash) (*storage.TrieState, error)
+	TrieStateWithRecorder(root common.Hash, recorder *triedb.Recorder) (*storage.TrieState, error)
+	LoadFromDB(root common.Hash) (trie.Trie, error)
+	ExistsStorage(root *common.Hash, key []byte) (bool, error)
+	GetStorage(root *common.Hash, key []byte) ([]byte, error)
+	GetStorageByBlockHash(bhash *common.Hash, key []byte) ([]byte, error)
+	GetStateRootFromBlock(bhash *common.Hash) (*common.Hash, error)
+	StorageRoot() (common.Hash, error)
+	Entries(root *common.Hash) (map[string][]byte, error)
+	GetKeysWithPrefix(root *common.Hash, prefix []byte) ([][]byte, error)
+	GetStorageChild(root *common.Hash, keyToChild []byte) (trie.Trie, error)
+	GetStorageFromChild(root *common.Hash, keyToChild, key []byte) ([]byte, error)
+	LoadCode(hash *common.Hash) ([]byte, error)
+	LoadCodeHash(hash *common.Hash) (common.Hash, error)
+	GenerateTrieProof(stateRoot common.Hash, keys [][]byte) (encodedProofNodes [][]byte, err error)
+	RegisterStorageObserver(o Observer)
+	UnregisterStorageObserver(o Observer)
+	setPruner(p pruner.Pruner)
+}
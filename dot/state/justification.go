@@ -0,0 +1,88 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// lastPrunedJustificationKey is the key of the number of the highest block whose
+// justification was pruned.
+var lastPrunedJustificationKey = []byte("lastprunedjustification")
+
+// SetJustificationsWindow sets the number of the latest finalised blocks whose justifications
+// are kept. The justifications of the last blocks of the authority sets of the given grandpa
+// state are always kept, since they prove the finality of all the blocks of their set.
+// A window of 0 keeps all the justifications.
+func (bs *BlockState) SetJustificationsWindow(window uint, grandpaState *GrandpaState) {
+	bs.lock.Lock()
+	defer bs.lock.Unlock()
+	bs.justificationsWindow = window
+	bs.grandpaState = grandpaState
+}
+
+// pruneJustifications deletes the justifications of the finalised blocks which are out of
+// the justifications window, except the justifications of the last blocks of the authority sets.
+func (bs *BlockState) pruneJustifications(finalisedNumber uint) error {
+	if bs.justificationsWindow == 0 || bs.grandpaState == nil || finalisedNumber <= bs.justificationsWindow {
+		return nil
+	}
+	pruneUpTo := finalisedNumber - bs.justificationsWindow
+
+	var lastPruned uint
+	encodedLastPruned, err := bs.db.Get(lastPrunedJustificationKey)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+	case err != nil:
+		return fmt.Errorf("getting last pruned justification: %w", err)
+	default:
+		lastPruned = common.BytesToUint(encodedLastPruned)
+	}
+
+	if pruneUpTo <= lastPruned {
+		return nil
+	}
+
+	authoritySetChanges, err := bs.grandpaState.getAuthoritySetChanges()
+	if err != nil {
+		return fmt.Errorf("getting authority set changes: %w", err)
+	}
+
+	batch := bs.db.NewBatch()
+	for number := lastPruned + 1; number <= pruneUpTo; number++ {
+		if _, ok := authoritySetChanges[number]; ok {
+			continue
+		}
+
+		// the blocks below a warp or state synced block are not stored
+		hash, err := bs.db.Get(headerHashKey(uint64(number)))
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("getting hash of block #%d: %w", number, err)
+		}
+
+		err = batch.Del(prefixKey(common.NewHash(hash), justificationPrefix))
+		if err != nil {
+			return fmt.Errorf("deleting justification of block #%d: %w", number, err)
+		}
+	}
+
+	err = batch.Put(lastPrunedJustificationKey, common.UintToBytes(pruneUpTo))
+	if err != nil {
+		return fmt.Errorf("setting last pruned justification: %w", err)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("flushing batch: %w", err)
+	}
+
+	logger.Tracef("pruned justifications of blocks #%d to #%d", lastPruned+1, pruneUpTo)
+	return nil
+}
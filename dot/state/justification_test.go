@@ -0,0 +1,74 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockState_pruneJustifications(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+	headers, _ := AddBlocksToState(t, bs, 8, false)
+
+	gs, err := NewGrandpaStateFromGenesis(NewInMemoryDB(t), bs, testAuths, nil)
+	require.NoError(t, err)
+	// the block #3 is the last block of the set 0
+	err = gs.setChangeSetIDAtBlock(1, 3)
+	require.NoError(t, err)
+
+	bs.SetJustificationsWindow(2, gs)
+
+	for _, header := range headers {
+		err = bs.SetJustification(header.Hash(), []byte{byte(header.Number)})
+		require.NoError(t, err)
+	}
+
+	assertJustifications := func(t *testing.T, expNumbers ...uint) {
+		t.Helper()
+		var numbers []uint
+		for _, header := range headers {
+			has, err := bs.HasJustification(header.Hash())
+			require.NoError(t, err)
+			if has {
+				numbers = append(numbers, header.Number)
+			}
+		}
+		assert.Equal(t, expNumbers, numbers)
+	}
+
+	err = bs.SetFinalisedHash(headers[3].Hash(), 1, 0)
+	require.NoError(t, err)
+	assertJustifications(t, 3, 4, 5, 6, 7, 8)
+
+	// the justification of the last block of the set 0 is kept
+	err = bs.SetFinalisedHash(headers[7].Hash(), 2, 1)
+	require.NoError(t, err)
+	assertJustifications(t, 3, 7, 8)
+}
+
+func TestBlockState_pruneJustifications_keepAll(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+	headers, _ := AddBlocksToState(t, bs, 3, false)
+
+	for _, header := range headers {
+		err := bs.SetJustification(header.Hash(), []byte{byte(header.Number)})
+		require.NoError(t, err)
+	}
+
+	err := bs.SetFinalisedHash(headers[2].Hash(), 1, 0)
+	require.NoError(t, err)
+
+	for _, header := range headers {
+		has, err := bs.HasJustification(header.Hash())
+		require.NoError(t, err)
+		assert.True(t, has)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+const (
+	// offchainStoragePrefix is the prefix of the persistent offchain storage table
+	offchainStoragePrefix = "offlinestorage"
+	// offchainIndexChangesPrefix is the prefix of the table of the offchain index
+	// changes of the unfinalised blocks, keyed by block hash
+	offchainIndexChangesPrefix = "offchainindex"
+)
+
+// offchainIndexChange is a change of an offchain index key, where a nil value clears the key.
+type offchainIndexChange struct {
+	Key   []byte
+	Value *[]byte
+}
+
+// offchainIndex records the offchain index changes made by the runtime when executing
+// the unfinalised blocks. The changes of a block are kept in the database until the
+// block is finalised, when they are written to the persistent offchain storage, or
+// pruned, when they are discarded.
+type offchainIndex struct {
+	storage database.Table
+	changes database.Table
+}
+
+func newOffchainIndex(db database.Database) *offchainIndex {
+	return &offchainIndex{
+		storage: database.NewTable(db, offchainStoragePrefix),
+		changes: database.NewTable(db, offchainIndexChangesPrefix),
+	}
+}
+
+// set records the offchain index changes of the block with the given hash, where
+// a nil value clears the key.
+func (o *offchainIndex) set(hash common.Hash, changes map[string][]byte) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	encodedChanges := make([]offchainIndexChange, len(keys))
+	for i, key := range keys {
+		encodedChanges[i].Key = []byte(key)
+		if value := changes[key]; value != nil {
+			encodedChanges[i].Value = &value
+		}
+	}
+
+	encoded, err := scale.Marshal(encodedChanges)
+	if err != nil {
+		return fmt.Errorf("encoding offchain index changes: %w", err)
+	}
+
+	err = o.changes.Put(hash.ToBytes(), encoded)
+	if err != nil {
+		return fmt.Errorf("storing offchain index changes: %w", err)
+	}
+	return nil
+}
+
+// apply writes the offchain index changes of the finalised block with the given
+// hash to the offchain storage, and deletes them from the recorded changes.
+func (o *offchainIndex) apply(hash common.Hash) error {
+	encoded, err := o.changes.Get(hash.ToBytes())
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting offchain index changes: %w", err)
+	}
+
+	var changes []offchainIndexChange
+	err = scale.Unmarshal(encoded, &changes)
+	if err != nil {
+		return fmt.Errorf("decoding offchain index changes: %w", err)
+	}
+
+	batch := o.storage.NewBatch()
+	for _, change := range changes {
+		if change.Value == nil {
+			err = batch.Del(change.Key)
+		} else {
+			err = batch.Put(change.Key, *change.Value)
+		}
+		if err != nil {
+			return fmt.Errorf("writing offchain index key 0x%x: %w", change.Key, err)
+		}
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("flushing offchain index changes: %w", err)
+	}
+
+	return o.discard(hash)
+}
+
+// discard deletes the offchain index changes of the block with the given hash.
+func (o *offchainIndex) discard(hash common.Hash) error {
+	err := o.changes.Del(hash.ToBytes())
+	if err != nil {
+		return fmt.Errorf("deleting offchain index changes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// palletStorageKey returns the storage key of a plain storage item of a pallet,
+// which is twox128(pallet) ++ twox128(item).
+func palletStorageKey(pallet, item string) ([]byte, error) {
+	palletHash, err := common.Twox128Hash([]byte(pallet))
+	if err != nil {
+		return nil, fmt.Errorf("hashing pallet name: %w", err)
+	}
+
+	itemHash, err := common.Twox128Hash([]byte(item))
+	if err != nil {
+		return nil, fmt.Errorf("hashing storage item name: %w", err)
+	}
+
+	return append(palletHash, itemHash...), nil
+}
+
+// getPalletStorageValue decodes the value of a plain storage item of a pallet from
+// the given state into dst. It returns false if the value is not in the state.
+func getPalletStorageValue(state *rtstorage.TrieState, pallet, item string, dst any) (found bool, err error) {
+	key, err := palletStorageKey(pallet, item)
+	if err != nil {
+		return false, err
+	}
+
+	encoded := state.Get(key)
+	if encoded == nil {
+		return false, nil
+	}
+
+	if err := scale.Unmarshal(encoded, dst); err != nil {
+		return false, fmt.Errorf("decoding %s::%s: %w", pallet, item, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,438 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pruner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "pruner"))
+
+// JournalDatabase is the database interface for the pruner journal.
+type JournalDatabase interface {
+	Put(key, value []byte) error
+	Path() string
+	NewBatch() database.Batch
+	NewIterator() (database.Iterator, error)
+}
+
+// NewBatcher creates a new database batch.
+type NewBatcher interface {
+	NewBatch() database.Batch
+}
+
+// ReferencesDatabase is the database interface for the node and value references.
+type ReferencesDatabase interface {
+	Get(key []byte) ([]byte, error)
+	NewBatch() database.Batch
+}
+
+// BlockState is the block state interface used to find canonical blocks
+// and the state still needed by the pinned blocks.
+type BlockState interface {
+	GetHashByNumber(num uint) (common.Hash, error)
+	LowestPinnedBlockNumber() (number uint, ok bool)
+}
+
+// journalRecord holds the database keys of the nodes and hashed values inserted
+// and deleted in the state trie of a block, compared to its parent state trie.
+// The key of a node is its hash, and the key of a hashed value is the partial
+// key of its node followed by its hash.
+type journalRecord struct {
+	InsertedKeys [][]byte
+	DeletedKeys  [][]byte
+}
+
+// journalKey is the database key of the journal record of a block.
+// The block number is big endian encoded so records are iterated
+// in ascending block number order.
+func journalKey(blockNumber uint64, blockHash common.Hash) []byte {
+	key := make([]byte, 8+common.HashLength)
+	binary.BigEndian.PutUint64(key, blockNumber)
+	copy(key[8:], blockHash[:])
+	return key
+}
+
+type keyedJournalRecord struct {
+	blockNumber uint64
+	blockHash   common.Hash
+	record      journalRecord
+}
+
+// FullNode prunes the state trie nodes which are no longer needed by the
+// state tries of the last retained finalised blocks.
+// It stores a journal record of inserted and deleted node hashes for each
+// imported block. Once a block is finalised and older than the number of
+// retained blocks, the nodes deleted by the canonical block at that number
+// and the nodes inserted by the non-canonical blocks at that number are
+// released, and removed from the storage database once no longer referenced.
+type FullNode struct {
+	journalDB      JournalDatabase
+	storageDB      NewBatcher
+	blockState     BlockState
+	retainedBlocks uint32
+
+	mutex sync.Mutex
+	// referencesDB stores, for each node or value database key, the number of
+	// journal records which inserted it and of canonical states still holding
+	// it. The inserts of a canonical block stay referenced once its record is
+	// pruned, until a later canonical block deletes them, so a node shared by
+	// a non-canonical block and the canonical state is never deleted.
+	referencesDB ReferencesDatabase
+}
+
+// NewFullNode creates a full node pruner, using the journal records and the
+// references stored in the database from a previous run.
+func NewFullNode(journalDB JournalDatabase, referencesDB ReferencesDatabase, storageDB NewBatcher,
+	retainedBlocks uint32, blockState BlockState) *FullNode {
+	return &FullNode{
+		journalDB:      journalDB,
+		referencesDB:   referencesDB,
+		storageDB:      storageDB,
+		blockState:     blockState,
+		retainedBlocks: retainedBlocks,
+	}
+}
+
+// AddStateReferences adds a reference to each of the given node hashes and value
+// database keys, which must be all the keys of the finalised state trie when the
+// full pruning starts, since the nodes and values written before are not journaled.
+func (p *FullNode) AddStateReferences(nodeHashes map[common.Hash]struct{}, valueKeys map[string]struct{}) error {
+	keys := make([][]byte, 0, len(nodeHashes)+len(valueKeys))
+	for key := range databaseKeys(nodeHashes, valueKeys) {
+		keys = append(keys, []byte(key))
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	err := p.addReferences(keys)
+	if err != nil {
+		return fmt.Errorf("adding references: %w", err)
+	}
+	return nil
+}
+
+// StoreJournalRecord stores the inserted and deleted node hashes and hashed
+// value database keys of the state trie of the block with the given hash and number.
+func (p *FullNode) StoreJournalRecord(deletedNodeHashes, insertedNodeHashes map[common.Hash]struct{},
+	deletedValueKeys, insertedValueKeys map[string]struct{}, blockHash common.Hash, blockNum int64) error {
+	if blockNum == 0 {
+		// the genesis state is never pruned
+		return nil
+	}
+
+	record := newJournalRecord(
+		databaseKeys(deletedNodeHashes, deletedValueKeys),
+		databaseKeys(insertedNodeHashes, insertedValueKeys))
+	encodedRecord, err := scale.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encoding journal record: %w", err)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// Add the references before storing the record, so a pruned record never
+	// releases references which were not added.
+	err = p.addReferences(record.InsertedKeys)
+	if err != nil {
+		return fmt.Errorf("adding references: %w", err)
+	}
+
+	err = p.journalDB.Put(journalKey(uint64(blockNum), blockHash), encodedRecord)
+	if err != nil {
+		return fmt.Errorf("putting journal record in database: %w", err)
+	}
+	return nil
+}
+
+// databaseKeys returns the set of the database keys of the given nodes and values.
+func databaseKeys(nodeHashes map[common.Hash]struct{}, valueKeys map[string]struct{}) map[string]struct{} {
+	keys := make(map[string]struct{}, len(nodeHashes)+len(valueKeys))
+	for nodeHash := range nodeHashes {
+		keys[string(nodeHash[:])] = struct{}{}
+	}
+	for valueKey := range valueKeys {
+		keys[valueKey] = struct{}{}
+	}
+	return keys
+}
+
+// newJournalRecord creates a journal record from the changed database keys
+// of a state trie. Keys both deleted and inserted, for example when a
+// storage value is modified and then reverted, were already present in the
+// parent state trie and are still present in the block state trie, so they
+// are removed from both sets.
+func newJournalRecord(deletedKeys, insertedKeys map[string]struct{}) (record journalRecord) {
+	record.InsertedKeys = make([][]byte, 0, len(insertedKeys))
+	for key := range insertedKeys {
+		_, deleted := deletedKeys[key]
+		if deleted {
+			continue
+		}
+		record.InsertedKeys = append(record.InsertedKeys, []byte(key))
+	}
+
+	record.DeletedKeys = make([][]byte, 0, len(deletedKeys))
+	for key := range deletedKeys {
+		_, inserted := insertedKeys[key]
+		if inserted {
+			continue
+		}
+		record.DeletedKeys = append(record.DeletedKeys, []byte(key))
+	}
+
+	sortKeys(record.InsertedKeys)
+	sortKeys(record.DeletedKeys)
+	return record
+}
+
+func sortKeys(keys [][]byte) {
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+}
+
+// Prune prunes the journal records of the blocks with a number lower or equal
+// to the finalised block number minus the number of retained blocks, and to the
+// lowest block number whose state is needed by the pinned blocks.
+// For each such block number, the references of the nodes deleted by the canonical
+// block and of the nodes inserted by the non-canonical blocks are released, and the
+// nodes no longer referenced are deleted from the storage database.
+func (p *FullNode) Prune(finalisedNumber uint) (err error) {
+	if uint64(finalisedNumber) <= uint64(p.retainedBlocks) {
+		return nil
+	}
+	pruneUpTo := uint64(finalisedNumber) - uint64(p.retainedBlocks)
+	if pinnedNumber, ok := p.blockState.LowestPinnedBlockNumber(); ok && uint64(pinnedNumber) < pruneUpTo {
+		pruneUpTo = uint64(pinnedNumber)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	records, err := p.loadRecords(pruneUpTo)
+	if err != nil {
+		return fmt.Errorf("loading journal records: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	// Find the canonical block hashes before modifying the node references,
+	// so an error does not leave the references in an inconsistent state.
+	canonicalHashes := make(map[uint64]common.Hash)
+	for _, keyedRecord := range records {
+		_, ok := canonicalHashes[keyedRecord.blockNumber]
+		if ok {
+			continue
+		}
+
+		canonicalHash, err := p.blockState.GetHashByNumber(uint(keyedRecord.blockNumber))
+		if err != nil {
+			return fmt.Errorf("getting canonical block hash for block number %d: %w",
+				keyedRecord.blockNumber, err)
+		}
+		canonicalHashes[keyedRecord.blockNumber] = canonicalHash
+	}
+
+	storageBatch := p.storageDB.NewBatch()
+	journalBatch := p.journalDB.NewBatch()
+	references := make(map[string]uint32)
+
+	prunedKeys := 0
+	for start := 0; start < len(records); {
+		blockNumber := records[start].blockNumber
+		end := start + 1
+		for end < len(records) && records[end].blockNumber == blockNumber {
+			end++
+		}
+
+		keys, err := p.keysToPrune(records[start:end], canonicalHashes[blockNumber], references)
+		if err != nil {
+			return fmt.Errorf("releasing references of block number %d: %w", blockNumber, err)
+		}
+		for _, key := range keys {
+			err = storageBatch.Del(key)
+			if err != nil {
+				return fmt.Errorf("deleting key 0x%x: %w", key, err)
+			}
+		}
+		prunedKeys += len(keys)
+
+		for _, keyedRecord := range records[start:end] {
+			err = journalBatch.Del(journalKey(keyedRecord.blockNumber, keyedRecord.blockHash))
+			if err != nil {
+				return fmt.Errorf("deleting journal record for block %s: %w", keyedRecord.blockHash, err)
+			}
+		}
+
+		start = end
+	}
+
+	referencesBatch := p.referencesDB.NewBatch()
+	for key, count := range references {
+		if count == 0 {
+			err = referencesBatch.Del([]byte(key))
+		} else {
+			err = referencesBatch.Put([]byte(key), encodeReferences(count))
+		}
+		if err != nil {
+			return fmt.Errorf("writing references of key 0x%x: %w", key, err)
+		}
+	}
+
+	// Flush the storage batch first so a journal record is never deleted
+	// without its pruned nodes being deleted from the storage database, and
+	// the references batch last so the references of a record are never
+	// released twice. An interruption in between only leaks nodes.
+	err = storageBatch.Flush()
+	if err != nil {
+		return fmt.Errorf("flushing storage batch: %w", err)
+	}
+
+	err = journalBatch.Flush()
+	if err != nil {
+		return fmt.Errorf("flushing journal batch: %w", err)
+	}
+
+	err = referencesBatch.Flush()
+	if err != nil {
+		return fmt.Errorf("flushing references batch: %w", err)
+	}
+
+	logger.Debugf("pruned %d nodes and values from %d journal records up to block number %d",
+		prunedKeys, len(records), pruneUpTo)
+
+	return nil
+}
+
+// keysToPrune releases the references of the given journal records, all at the
+// same block number, and returns the node and value database keys which are no
+// longer referenced and can be deleted from the storage database. The inserts of
+// the canonical block are still referenced by the canonical state. The updated
+// reference counts are set in the given references map.
+func (p *FullNode) keysToPrune(records []keyedJournalRecord, canonicalHash common.Hash,
+	references map[string]uint32) (keys [][]byte, err error) {
+	unreferenced := make(map[string]struct{})
+	for _, keyedRecord := range records {
+		released := keyedRecord.record.InsertedKeys
+		if keyedRecord.blockHash == canonicalHash {
+			released = keyedRecord.record.DeletedKeys
+		}
+
+		for _, key := range released {
+			count, err := p.references(key, references)
+			if err != nil {
+				return nil, err
+			}
+
+			if count > 0 {
+				count--
+			}
+			references[string(key)] = count
+			if count == 0 {
+				unreferenced[string(key)] = struct{}{}
+			}
+		}
+	}
+
+	keys = make([][]byte, 0, len(unreferenced))
+	for key := range unreferenced {
+		keys = append(keys, []byte(key))
+	}
+	return keys, nil
+}
+
+// references returns the reference count of the given key, from the given
+// references map if present or from the references database otherwise.
+func (p *FullNode) references(key []byte, references map[string]uint32) (count uint32, err error) {
+	count, ok := references[string(key)]
+	if ok {
+		return count, nil
+	}
+
+	encoded, err := p.referencesDB.Get(key)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf("getting references of key 0x%x: %w", key, err)
+	}
+	return binary.BigEndian.Uint32(encoded), nil
+}
+
+// addReferences adds a reference to each of the given keys in the references database.
+func (p *FullNode) addReferences(keys [][]byte) error {
+	references := make(map[string]uint32, len(keys))
+	batch := p.referencesDB.NewBatch()
+	for _, key := range keys {
+		count, err := p.references(key, references)
+		if err != nil {
+			return err
+		}
+
+		references[string(key)] = count + 1
+		err = batch.Put(key, encodeReferences(count+1))
+		if err != nil {
+			return fmt.Errorf("writing references of key 0x%x: %w", key, err)
+		}
+	}
+	return batch.Flush()
+}
+
+func encodeReferences(count uint32) []byte {
+	encoded := make([]byte, 4)
+	binary.BigEndian.PutUint32(encoded, count)
+	return encoded
+}
+
+// loadRecords loads the journal records with a block number lower or equal to
+// maxBlockNumber, sorted by ascending block number.
+func (p *FullNode) loadRecords(maxBlockNumber uint64) (records []keyedJournalRecord, err error) {
+	iterator, err := p.journalDB.NewIterator()
+	if err != nil {
+		return nil, fmt.Errorf("creating journal iterator: %w", err)
+	}
+	defer iterator.Release()
+
+	prefixLength := len(p.journalDB.Path())
+	for iterator.First(); iterator.Valid(); iterator.Next() {
+		key := iterator.Key()[prefixLength:]
+		if len(key) != 8+common.HashLength {
+			return nil, fmt.Errorf("malformed journal key: 0x%x", key)
+		}
+
+		blockNumber := binary.BigEndian.Uint64(key[:8])
+		if blockNumber > maxBlockNumber {
+			break
+		}
+
+		keyedRecord := keyedJournalRecord{
+			blockNumber: blockNumber,
+			blockHash:   common.NewHash(key[8:]),
+		}
+		err = scale.Unmarshal(iterator.Value(), &keyedRecord.record)
+		if err != nil {
+			return nil, fmt.Errorf("decoding journal record for block %s: %w",
+				keyedRecord.blockHash, err)
+		}
+
+		records = append(records, keyedRecord)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,285 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pruner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type canonicalHashes map[uint]common.Hash
+
+func (c canonicalHashes) GetHashByNumber(num uint) (common.Hash, error) {
+	hash, ok := c[num]
+	if !ok {
+		return common.Hash{}, errors.New("not found")
+	}
+	return hash, nil
+}
+
+func (canonicalHashes) LowestPinnedBlockNumber() (number uint, ok bool) {
+	return 0, false
+}
+
+type pinnedBlockState struct {
+	canonicalHashes
+	pinnedNumber uint
+}
+
+func (p pinnedBlockState) LowestPinnedBlockNumber() (number uint, ok bool) {
+	return p.pinnedNumber, true
+}
+
+func hashSet(hashes ...common.Hash) map[common.Hash]struct{} {
+	set := make(map[common.Hash]struct{}, len(hashes))
+	for _, hash := range hashes {
+		set[hash] = struct{}{}
+	}
+	return set
+}
+
+func assertNodesInDatabase(t *testing.T, db database.Table, present, absent []common.Hash) {
+	t.Helper()
+
+	for _, nodeHash := range present {
+		has, err := db.Has(nodeHash[:])
+		require.NoError(t, err)
+		assert.Truef(t, has, "node %s should be in database", nodeHash)
+	}
+
+	for _, nodeHash := range absent {
+		has, err := db.Has(nodeHash[:])
+		require.NoError(t, err)
+		assert.Falsef(t, has, "node %s should not be in database", nodeHash)
+	}
+}
+
+func Test_FullNode(t *testing.T) {
+	t.Parallel()
+
+	db, err := database.NewPebble(t.TempDir(), true)
+	require.NoError(t, err)
+	journalDB := database.NewTable(db, "journal")
+	referencesDB := database.NewTable(db, "references")
+	storageDB := database.NewTable(db, "storage")
+
+	nodeX := common.Hash{0x0} // in the genesis state
+	nodeA := common.Hash{0xa}
+	nodeB := common.Hash{0xb}
+	nodeC := common.Hash{0xc}
+	nodeR := common.Hash{0xd} // modified then reverted in block 2
+	allNodes := []common.Hash{nodeX, nodeA, nodeB, nodeC, nodeR}
+	for _, nodeHash := range allNodes {
+		err = storageDB.Put(nodeHash[:], []byte{1})
+		require.NoError(t, err)
+	}
+
+	// hashed values stored at their node partial key followed by their hash
+	valueX := string(append([]byte{1, 2}, common.Hash{0xe}.ToBytes()...)) // in the genesis state
+	valueA := string(append([]byte{3}, common.Hash{0xf}.ToBytes()...))
+	for _, valueKey := range []string{valueX, valueA} {
+		err = storageDB.Put([]byte(valueKey), []byte{1})
+		require.NoError(t, err)
+	}
+
+	blockHash1 := common.Hash{1}
+	forkBlockHash1 := common.Hash{1, 1}
+	blockHash2 := common.Hash{2}
+	blockHash3 := common.Hash{3}
+	blockState := canonicalHashes{
+		1: blockHash1,
+		2: blockHash2,
+		3: blockHash3,
+	}
+
+	const retainedBlocks = 1
+	pruner := NewFullNode(journalDB, referencesDB, storageDB, retainedBlocks, blockState)
+
+	err = pruner.StoreJournalRecord(hashSet(nodeX), hashSet(nodeA),
+		map[string]struct{}{valueX: {}}, map[string]struct{}{valueA: {}}, blockHash1, 1)
+	require.NoError(t, err)
+	err = pruner.StoreJournalRecord(hashSet(nodeX), hashSet(nodeA, nodeB), nil, nil, forkBlockHash1, 1)
+	require.NoError(t, err)
+	err = pruner.StoreJournalRecord(hashSet(nodeA, nodeR), hashSet(nodeC, nodeR), nil, nil, blockHash2, 2)
+	require.NoError(t, err)
+	err = pruner.StoreJournalRecord(hashSet(nodeC), hashSet(nodeA), nil, nil, blockHash3, 3)
+	require.NoError(t, err)
+
+	// Nothing to prune with only the retained blocks finalised.
+	err = pruner.Prune(1)
+	require.NoError(t, err)
+	assertNodesInDatabase(t, storageDB, allNodes, nil)
+
+	// Block 1 is pruned: node X and value X deleted by the canonical block are
+	// removed, as well as node B only inserted by the non-canonical block. Node A
+	// and value A are inserted by the canonical block so they are kept.
+	err = pruner.Prune(2)
+	require.NoError(t, err)
+	assertNodesInDatabase(t, storageDB,
+		[]common.Hash{nodeA, nodeC, nodeR},
+		[]common.Hash{nodeX, nodeB})
+	has, err := storageDB.Has([]byte(valueX))
+	require.NoError(t, err)
+	assert.False(t, has)
+	has, err = storageDB.Has([]byte(valueA))
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	// The journal records and references are read from the database after a restart.
+	pruner = NewFullNode(journalDB, referencesDB, storageDB, retainedBlocks, blockState)
+
+	// Block 2 is pruned: node A deleted by block 2 is re-inserted by block 3
+	// which is still in the journal, and node R was reverted, so both are kept.
+	err = pruner.Prune(3)
+	require.NoError(t, err)
+	assertNodesInDatabase(t, storageDB,
+		[]common.Hash{nodeA, nodeC, nodeR},
+		[]common.Hash{nodeX, nodeB})
+
+	// Block 3 is pruned: node C deleted by block 3 is removed.
+	err = pruner.Prune(4)
+	require.NoError(t, err)
+	assertNodesInDatabase(t, storageDB,
+		[]common.Hash{nodeA, nodeR},
+		[]common.Hash{nodeX, nodeB, nodeC})
+
+	records, err := pruner.loadRecords(^uint64(0))
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	// Node A and value A are still referenced by the canonical state.
+	references := make(map[string]uint32)
+	for _, key := range []string{string(nodeA[:]), valueA} {
+		count, err := pruner.references([]byte(key), references)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), count)
+	}
+	for _, nodeHash := range []common.Hash{nodeB, nodeC} {
+		_, err = referencesDB.Get(nodeHash[:])
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	}
+}
+
+func Test_FullNode_sharedNode(t *testing.T) {
+	t.Parallel()
+
+	db, err := database.NewPebble(t.TempDir(), true)
+	require.NoError(t, err)
+	journalDB := database.NewTable(db, "journal")
+	referencesDB := database.NewTable(db, "references")
+	storageDB := database.NewTable(db, "storage")
+
+	nodeX := common.Hash{0x0} // in the finalised state when pruning starts
+	nodeA := common.Hash{0xa}
+	nodeB := common.Hash{0xb}
+	allNodes := []common.Hash{nodeX, nodeA, nodeB}
+	for _, nodeHash := range allNodes {
+		err = storageDB.Put(nodeHash[:], []byte{1})
+		require.NoError(t, err)
+	}
+
+	blockHash1 := common.Hash{1}
+	blockHash2 := common.Hash{2}
+	forkBlockHash2 := common.Hash{2, 2}
+	forkBlockHash3 := common.Hash{3, 3}
+	blockState := canonicalHashes{
+		1: blockHash1,
+		2: blockHash2,
+		3: common.Hash{3},
+	}
+
+	const retainedBlocks = 1
+	pruner := NewFullNode(journalDB, referencesDB, storageDB, retainedBlocks, blockState)
+	err = pruner.AddStateReferences(hashSet(nodeX), nil)
+	require.NoError(t, err)
+
+	// Node A is inserted by the canonical block 1, and both nodes A and X are
+	// inserted again by non-canonical blocks after the records of the blocks
+	// which inserted them in the canonical state are pruned.
+	err = pruner.StoreJournalRecord(nil, hashSet(nodeA), nil, nil, blockHash1, 1)
+	require.NoError(t, err)
+	err = pruner.StoreJournalRecord(nil, hashSet(nodeB), nil, nil, blockHash2, 2)
+	require.NoError(t, err)
+	err = pruner.Prune(2)
+	require.NoError(t, err)
+
+	err = pruner.StoreJournalRecord(hashSet(nodeB), hashSet(nodeA, nodeX), nil, nil, forkBlockHash2, 2)
+	require.NoError(t, err)
+	err = pruner.StoreJournalRecord(nil, hashSet(nodeX), nil, nil, forkBlockHash3, 3)
+	require.NoError(t, err)
+
+	err = pruner.Prune(4)
+	require.NoError(t, err)
+	assertNodesInDatabase(t, storageDB, allNodes, nil)
+}
+
+func Test_FullNode_pinnedBlock(t *testing.T) {
+	t.Parallel()
+
+	db, err := database.NewPebble(t.TempDir(), true)
+	require.NoError(t, err)
+	journalDB := database.NewTable(db, "journal")
+	referencesDB := database.NewTable(db, "references")
+	storageDB := database.NewTable(db, "storage")
+
+	nodeX := common.Hash{0x0} // in the genesis state
+	nodeA := common.Hash{0xa}
+	nodeB := common.Hash{0xb}
+	allNodes := []common.Hash{nodeX, nodeA, nodeB}
+	for _, nodeHash := range allNodes {
+		err = storageDB.Put(nodeHash[:], []byte{1})
+		require.NoError(t, err)
+	}
+
+	blockHash1 := common.Hash{1}
+	blockHash2 := common.Hash{2}
+	blockState := pinnedBlockState{
+		canonicalHashes: canonicalHashes{
+			1: blockHash1,
+			2: blockHash2,
+		},
+		pinnedNumber: 1,
+	}
+
+	const retainedBlocks = 1
+	pruner := NewFullNode(journalDB, referencesDB, storageDB, retainedBlocks, blockState)
+
+	err = pruner.StoreJournalRecord(hashSet(nodeX), hashSet(nodeA), nil, nil, blockHash1, 1)
+	require.NoError(t, err)
+	err = pruner.StoreJournalRecord(hashSet(nodeA), hashSet(nodeB), nil, nil, blockHash2, 2)
+	require.NoError(t, err)
+
+	// Block 2 is not pruned since node A deleted by block 2 is
+	// in the state of the pinned block 1.
+	err = pruner.Prune(3)
+	require.NoError(t, err)
+	assertNodesInDatabase(t, storageDB,
+		[]common.Hash{nodeA, nodeB},
+		[]common.Hash{nodeX})
+
+	records, err := pruner.loadRecords(^uint64(0))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, blockHash2, records[0].blockHash)
+}
+
+func Test_newJournalRecord(t *testing.T) {
+	t.Parallel()
+
+	deleted := map[string]struct{}{"b": {}, "a": {}, "c": {}}
+	inserted := map[string]struct{}{"e": {}, "c": {}, "d": {}}
+
+	record := newJournalRecord(deleted, inserted)
+
+	expected := journalRecord{
+		InsertedKeys: [][]byte{[]byte("d"), []byte("e")},
+		DeletedKeys:  [][]byte{[]byte("a"), []byte("b")},
+	}
+	assert.Equal(t, expected, record)
+}
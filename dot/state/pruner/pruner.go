@@ -4,25 +4,51 @@
 package pruner
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+
 	"github.com/ChainSafe/gossamer/lib/common"
 )
 
 const (
 	// Archive pruner mode.
 	Archive = Mode("archive")
+	// Full pruner mode.
+	Full = Mode("full")
 )
 
-// Mode online pruning mode of historical state tries
+// ErrInvalidMode is returned when the pruning mode is not valid.
+var ErrInvalidMode = errors.New("invalid pruning mode")
+
+// Mode online pruning mode of historical state tries.
+// It is either `archive`, `full` or the number of finalised
+// blocks for which the state is retained, which implies the
+// full pruning mode.
 type Mode string
 
 // IsValid checks whether the pruning mode is valid
 func (p Mode) IsValid() bool {
 	switch p {
-	case Archive:
+	case Archive, Full:
 		return true
 	default:
-		return false
+		_, err := parseRetainedBlocks(p)
+		return err == nil
+	}
+}
+
+func parseRetainedBlocks(p Mode) (retainedBlocks uint32, err error) {
+	retained, err := strconv.ParseUint(string(p), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidMode, p)
+	}
+
+	if retained == 0 {
+		return 0, fmt.Errorf("%w: number of retained blocks cannot be zero", ErrInvalidMode)
 	}
+
+	return uint32(retained), nil
 }
 
 // Config holds state trie pruning mode and retained blocks
@@ -31,10 +57,29 @@ type Config struct {
 	RetainedBlocks uint32
 }
 
+// NewConfig returns the pruner configuration for the given pruning mode.
+// An empty mode defaults to the archive mode, and the given retained
+// blocks value is used for the full mode if the mode is not a number of
+// blocks to retain.
+func NewConfig(mode Mode, retainedBlocks uint32) (config Config, err error) {
+	switch mode {
+	case "", Archive:
+		return Config{Mode: Archive}, nil
+	case Full:
+		return Config{Mode: Full, RetainedBlocks: retainedBlocks}, nil
+	default:
+		retainedBlocks, err = parseRetainedBlocks(mode)
+		if err != nil {
+			return config, err
+		}
+		return Config{Mode: Full, RetainedBlocks: retainedBlocks}, nil
+	}
+}
+
 // Pruner is implemented by FullNode and ArchiveNode.
 type Pruner interface {
 	StoreJournalRecord(deletedNodeHashes, insertedNodeHashes map[common.Hash]struct{},
-		blockHash common.Hash, blockNum int64) error
+		deletedValueKeys, insertedValueKeys map[string]struct{}, blockHash common.Hash, blockNum int64) error
 }
 
 // ArchiveNode is a no-op since we don't prune nodes in archive mode.
@@ -42,6 +87,6 @@ type ArchiveNode struct{
 
 // StoreJournalRecord for archive node doesn't do anything.
 func (*ArchiveNode) StoreJournalRecord(_, _ map[common.Hash]struct{},
-	_ common.Hash, _ int64) error {
+	_, _ map[string]struct{}, _ common.Hash, _ int64) error {
 	return nil
 }
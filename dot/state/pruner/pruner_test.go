@@ -0,0 +1,93 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pruner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Mode_IsValid(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		mode  Mode
+		valid bool
+	}{
+		"archive":         {mode: Archive, valid: true},
+		"full":            {mode: Full, valid: true},
+		"retained_blocks": {mode: "256", valid: true},
+		"zero":            {mode: "0"},
+		"negative":        {mode: "-1"},
+		"unknown":         {mode: "unknown"},
+		"empty":           {mode: ""},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.valid, testCase.mode.IsValid())
+		})
+	}
+}
+
+func Test_NewConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		mode           Mode
+		retainedBlocks uint32
+		config         Config
+		errWrapped     error
+		errMessage     string
+	}{
+		"empty": {
+			retainedBlocks: 512,
+			config:         Config{Mode: Archive},
+		},
+		"archive": {
+			mode:           Archive,
+			retainedBlocks: 512,
+			config:         Config{Mode: Archive},
+		},
+		"full": {
+			mode:           Full,
+			retainedBlocks: 512,
+			config:         Config{Mode: Full, RetainedBlocks: 512},
+		},
+		"retained_blocks": {
+			mode:           "256",
+			retainedBlocks: 512,
+			config:         Config{Mode: Full, RetainedBlocks: 256},
+		},
+		"zero_retained_blocks": {
+			mode:       "0",
+			errWrapped: ErrInvalidMode,
+			errMessage: "invalid pruning mode: number of retained blocks cannot be zero",
+		},
+		"invalid": {
+			mode:       "invalid",
+			errWrapped: ErrInvalidMode,
+			errMessage: "invalid pruning mode: invalid",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config, err := NewConfig(testCase.mode, testCase.retainedBlocks)
+
+			require.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.config, config)
+		})
+	}
+}
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"path/filepath"
 
@@ -13,8 +15,11 @@
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/internal/metrics"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/ChainSafe/gossamer/pkg/trie/node"
+	"github.com/ChainSafe/gossamer/pkg/trie/triedb"
 )
 
 var logger = log.NewFromGlobal(
@@ -23,19 +28,22 @@
 
 // Service is the struct that holds storage, block and network states
 type Service struct {
-	dbPath            string
-	logLvl            log.Level
-	db                database.Database
-	isMemDB           bool // set to true if using an in-memory database; only used for testing.
-	Base              *BaseState
-	Storage           *InmemoryStorageState
-	Block             *BlockState
-	Transaction       *TransactionState
-	Epoch             *EpochState
-	Grandpa           *GrandpaState
-	Slot              *SlotState
-	closeCh           chan interface{}
-	genesisBABEConfig *types.BabeConfiguration
+	dbPath               string
+	logLvl               log.Level
+	db                   database.Database
+	isMemDB              bool // set to true if using an in-memory database; only used for testing.
+	Base                 *BaseState
+	Storage              StorageState
+	Block                *BlockState
+	Transaction          *TransactionState
+	Epoch                *EpochState
+	Grandpa              *GrandpaState
+	Slot                 *SlotState
+	closeCh              chan interface{}
+	prunerDone           chan struct{}
+	genesisBABEConfig    *types.BabeConfiguration
+	storageBackend       StorageBackend
+	justificationsWindow uint
 
 	PrunerCfg pruner.Config
 	Telemetry Telemetry
@@ -50,6 +58,16 @@ func (s *Service) Pause() error {
 	return s.Block.Pause()
 }
 
+// StorageBackend is the implementation of the storage state.
+type StorageBackend string
+
+const (
+	// InmemoryStorageBackend loads the state tries in memory.
+	InmemoryStorageBackend StorageBackend = "inmemory"
+	// TrieDBStorageBackend resolves the state trie nodes lazily from the database.
+	TrieDBStorageBackend StorageBackend = "triedb"
+)
+
 // Config is the default configuration used by state service.
 type Config struct {
 	Path              string
@@ -58,6 +76,12 @@ type Config struct {
 	Telemetry         Telemetry
 	Metrics           metrics.IntervalConfig
 	GenesisBABEConfig *types.BabeConfiguration
+	// StorageBackend defaults to the in-memory storage backend
+	StorageBackend StorageBackend
+	// JustificationsWindow is the number of the latest finalised blocks whose justifications
+	// are kept, in addition to the justifications of the last blocks of the authority sets.
+	// It defaults to 0 which keeps all the justifications.
+	JustificationsWindow uint
 }
 
 // NewService create a new instance of Service
@@ -65,16 +89,18 @@ func NewService(config Config) *Service {
 	logger.Patch(log.SetLevel(config.LogLevel))
 
 	return &Service{
-		dbPath:            config.Path,
-		logLvl:            config.LogLevel,
-		db:                nil,
-		isMemDB:           false,
-		Storage:           nil,
-		Block:             nil,
-		closeCh:           make(chan interface{}),
-		PrunerCfg:         config.PrunerCfg,
-		Telemetry:         config.Telemetry,
-		genesisBABEConfig: config.GenesisBABEConfig,
+		dbPath:               config.Path,
+		logLvl:               config.LogLevel,
+		db:                   nil,
+		isMemDB:              false,
+		Storage:              nil,
+		Block:                nil,
+		closeCh:              make(chan interface{}),
+		PrunerCfg:            config.PrunerCfg,
+		Telemetry:            config.Telemetry,
+		genesisBABEConfig:    config.GenesisBABEConfig,
+		storageBackend:       config.StorageBackend,
+		justificationsWindow: config.JustificationsWindow,
 	}
 }
 
@@ -90,6 +116,12 @@ func (s *Service) DB() database.Database {
 	return s.db
 }
 
+// OffchainStorage returns the persistent offchain storage, where the offchain
+// index changes of the finalised blocks are written
+func (s *Service) OffchainStorage() database.Table {
+	return database.NewTable(s.db, offchainStoragePrefix)
+}
+
 // SetupBase intitializes state.Base property with
 // the instance of a chain.NewBadger database
 func (s *Service) SetupBase() error {
@@ -139,17 +171,27 @@ func (s *Service) Start() (err error) {
 	logger.Debugf("start with latest state root: %s", stateRoot)
 
 	// create storage state
-	s.Storage, err = NewStorageState(s.db, s.Block, tries)
+	s.Storage, err = s.newStorageState(s.db, s.Block, tries)
 	if err != nil {
 		return fmt.Errorf("failed to create storage state: %w", err)
 	}
 
-	// load current storage state trie into memory
+	err = s.migrateStorageBackend(stateRoot)
+	if err != nil {
+		return fmt.Errorf("migrating storage backend: %w", err)
+	}
+
+	// load current storage state trie
 	_, err = s.Storage.LoadFromDB(stateRoot)
 	if err != nil {
 		return fmt.Errorf("failed to load storage trie from database: %w", err)
 	}
 
+	err = s.setupPruner(bestHeader.Number)
+	if err != nil {
+		return fmt.Errorf("setting up pruner: %w", err)
+	}
+
 	// create transaction queue
 	s.Transaction = NewTransactionState(s.Telemetry)
 
@@ -162,6 +204,7 @@ func (s *Service) Start() (err error) {
 	}
 
 	s.Grandpa = NewGrandpaState(s.db, s.Block, s.Telemetry)
+	s.Block.SetJustificationsWindow(s.justificationsWindow, s.Grandpa)
 	num, _ := s.Block.BestBlockNumber()
 	logger.Infof(
 		"created state service with head %s, highest number %d and genesis hash %s",
@@ -170,6 +213,56 @@ func (s *Service) Start() (err error) {
 	return nil
 }
 
+// newStorageState creates the storage state with the configured storage backend.
+func (s *Service) newStorageState(db database.Database, blockState *BlockState,
+	tries *Tries) (StorageState, error) {
+	switch s.storageBackend {
+	case "", InmemoryStorageBackend:
+		return NewStorageState(db, blockState, tries)
+	case TrieDBStorageBackend:
+		logger.Info("using the database trie storage backend")
+		return NewTrieDBStorageState(db, blockState), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errInvalidStorageBackend, s.storageBackend)
+	}
+}
+
+// migrateStorageBackend checks the state trie with the given root, including its
+// child tries, is complete in the database when the storage backend differs from
+// the one stored, before storing the configured storage backend. Both backends
+// share the database format, so the existing tries are read as they are.
+func (s *Service) migrateStorageBackend(stateRoot common.Hash) error {
+	backend := s.storageBackend
+	if backend == "" {
+		backend = InmemoryStorageBackend
+	}
+
+	storedBackend, err := s.Base.loadStorageBackend()
+	if err != nil {
+		return fmt.Errorf("loading storage backend: %w", err)
+	}
+
+	if storedBackend == backend {
+		return nil
+	}
+
+	logger.Infof("migrating storage backend from %s to %s, checking state trie with root %s...",
+		storedBackend, backend, stateRoot)
+
+	nodes, err := triedb.NewTrieDB(stateRoot, database.NewTable(s.db, storagePrefix)).CheckDB()
+	if err != nil {
+		return fmt.Errorf("checking state trie with root %s: %w", stateRoot, err)
+	}
+
+	err = s.Base.storeStorageBackend(backend)
+	if err != nil {
+		return fmt.Errorf("storing storage backend: %w", err)
+	}
+
+	logger.Infof("migrated storage backend to %s after checking %d state trie nodes", backend, nodes)
+	return nil
+}
+
 // Rewind rewinds the chain to the given block number.
 // If the given number of blocks is greater than the chain height, it will rewind to genesis.
 func (s *Service) Rewind(toBlock uint) error {
@@ -246,10 +339,142 @@ func (s *Service) Rewind(toBlock uint) error {
 	return nil
 }
 
+var errInvalidStorageBackend = errors.New("invalid storage backend")
+
+// ErrPrunedDatabase is returned when starting an archive node
+// with a database pruned by a full node.
+var ErrPrunedDatabase = errors.New("database was pruned")
+
+// setupPruner sets up the storage state pruner depending on the pruning mode,
+// and prunes the state of the blocks finalised since the last run.
+// In full mode, a goroutine is started to prune the state tries on finalisation.
+func (s *Service) setupPruner(finalisedNumber uint) error {
+	storedMode, err := s.Base.loadPruningMode()
+	if err != nil {
+		return fmt.Errorf("loading pruning mode: %w", err)
+	}
+
+	mode := s.PrunerCfg.Mode
+	if mode == "" {
+		mode = pruner.Archive
+	}
+
+	if mode == pruner.Archive {
+		if storedMode == pruner.Full {
+			return fmt.Errorf("%w: cannot run an archive node with a database pruned in %s mode",
+				ErrPrunedDatabase, storedMode)
+		}
+		return nil
+	}
+
+	fullNode := pruner.NewFullNode(database.NewTable(s.db, journalPrefix),
+		database.NewTable(s.db, prunerReferencesPrefix), database.NewTable(s.db, storagePrefix),
+		s.PrunerCfg.RetainedBlocks, s.Block)
+
+	if storedMode != mode {
+		// the nodes and values written before are not journaled, so the
+		// ones of the finalised state are referenced before pruning starts.
+		err = s.addFinalisedStateReferences(fullNode)
+		if err != nil {
+			return fmt.Errorf("adding finalised state references: %w", err)
+		}
+
+		err = s.Base.storePruningMode(mode)
+		if err != nil {
+			return fmt.Errorf("storing pruning mode: %w", err)
+		}
+	}
+
+	err = fullNode.Prune(finalisedNumber)
+	if err != nil {
+		return fmt.Errorf("pruning state: %w", err)
+	}
+
+	s.Storage.setPruner(fullNode)
+	s.prunerDone = make(chan struct{})
+	go s.pruneOnFinalisation(fullNode)
+
+	logger.Infof("online state pruning enabled, retaining %d finalised blocks", s.PrunerCfg.RetainedBlocks)
+	return nil
+}
+
+// addFinalisedStateReferences adds a reference to each node and value of the state
+// trie of the highest finalised block, including its child tries.
+func (s *Service) addFinalisedStateReferences(fullNode *pruner.FullNode) error {
+	header, err := s.Block.GetHighestFinalisedHeader()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	logger.Infof("loading state trie of finalised block #%d to start online state pruning", header.Number)
+
+	t := inmemory_trie.NewEmptyTrie()
+	err = t.Load(database.NewTable(s.db, storagePrefix), header.StateRoot)
+	if err != nil {
+		return fmt.Errorf("loading state trie with root %s: %w", header.StateRoot, err)
+	}
+
+	nodeHashes := make(map[common.Hash]struct{})
+	valueKeys := make(map[string]struct{})
+	populateStateKeys(t, nodeHashes, valueKeys)
+	return fullNode.AddStateReferences(nodeHashes, valueKeys)
+}
+
+// populateStateKeys adds the node hashes and the hashed value database keys
+// of the given trie and of its child tries to the given sets.
+func populateStateKeys(t *inmemory_trie.InMemoryTrie, nodeHashes map[common.Hash]struct{},
+	valueKeys map[string]struct{}) {
+	root := t.RootNode()
+	inmemory_trie.PopulateNodeHashes(root, nodeHashes)
+	populateValueKeys(root, valueKeys)
+
+	for _, child := range t.GetChildTries() {
+		populateStateKeys(child.(*inmemory_trie.InMemoryTrie), nodeHashes, valueKeys)
+	}
+}
+
+func populateValueKeys(n *node.Node, valueKeys map[string]struct{}) {
+	if n == nil {
+		return
+	}
+
+	if n.MustBeHashed {
+		hashedValue := common.MustBlake2bHash(n.StorageValue)
+		valueKeys[string(bytes.Join([][]byte{n.PartialKey, hashedValue[:]}, nil))] = struct{}{}
+	}
+
+	for _, child := range n.Children {
+		populateValueKeys(child, valueKeys)
+	}
+}
+
+func (s *Service) pruneOnFinalisation(fullNode *pruner.FullNode) {
+	defer close(s.prunerDone)
+
+	finalisedCh := s.Block.GetFinalisedNotifierChannel()
+	defer s.Block.FreeFinalisedNotifierChannel(finalisedCh)
+
+	for {
+		select {
+		case <-s.closeCh:
+			return
+		case info := <-finalisedCh:
+			err := fullNode.Prune(info.Header.Number)
+			if err != nil {
+				logger.Errorf("failed to prune state at finalised block %s: %s", info.Header.Hash(), err)
+			}
+		}
+	}
+}
+
 // Stop closes each state database
 func (s *Service) Stop() error {
 	close(s.closeCh)
 
+	if s.prunerDone != nil {
+		<-s.prunerDone
+	}
+
 	hash, err := s.Block.GetHighestFinalisedHash()
 	if err != nil {
 		return err
@@ -281,6 +506,7 @@ func (s *Service) Import(header *types.Header, t trie.Trie,
 		bt:                blocktree.NewEmptyBlockTree(),
 		db:                database.NewTable(s.db, blockPrefix),
 		unfinalisedBlocks: newHashToBlockMap(),
+		offchainIndex:     newOffchainIndex(s.db),
 	}
 
 	storage := &InmemoryStorageState{
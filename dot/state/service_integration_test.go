@@ -19,6 +19,7 @@
 	runtime "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/ChainSafe/gossamer/pkg/trie/triedb"
 	"github.com/ChainSafe/gossamer/tests/utils/config"
 	"go.uber.org/mock/gomock"
 
@@ -75,6 +76,51 @@ func TestService_Start(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestService_StartMigratingStorageBackend(t *testing.T) {
+	state := newTestService(t)
+
+	genData, genTrie, genesisHeader := newWestendDevGenesisWithTrieAndHeader(t)
+	err := state.Initialise(&genData, &genesisHeader, genTrie)
+	require.NoError(t, err)
+
+	err = state.SetupBase()
+	require.NoError(t, err)
+	err = state.Start()
+	require.NoError(t, err)
+	err = state.Stop()
+	require.NoError(t, err)
+
+	state = NewService(Config{
+		Path:              state.dbPath,
+		LogLevel:          log.Info,
+		Telemetry:         state.Telemetry,
+		GenesisBABEConfig: config.BABEConfigurationTestDefault,
+		StorageBackend:    TrieDBStorageBackend,
+	})
+	err = state.SetupBase()
+	require.NoError(t, err)
+	err = state.Start()
+	require.NoError(t, err)
+
+	backend, err := state.Base.loadStorageBackend()
+	require.NoError(t, err)
+	require.Equal(t, TrieDBStorageBackend, backend)
+	require.IsType(t, &TrieDBStorageState{}, state.Storage)
+
+	// a state trie with a missing node fails the check
+	err = state.Base.storeStorageBackend(InmemoryStorageBackend)
+	require.NoError(t, err)
+	stateRoot := genesisHeader.StateRoot
+	err = database.NewTable(state.db, storagePrefix).Del(stateRoot[:])
+	require.NoError(t, err)
+
+	err = state.migrateStorageBackend(stateRoot)
+	require.ErrorIs(t, err, triedb.ErrIncompleteDB)
+
+	err = state.Stop()
+	require.NoError(t, err)
+}
+
 func TestService_Initialise(t *testing.T) {
 	state := newTestService(t)
 
@@ -208,7 +254,7 @@ func TestService_StorageTriePruning(t *testing.T) {
 	for i := uint(1); i < totalBlock; i++ {
 		block, trieState := generateBlockWithRandomTrie(t, serv, &parentHash, i)
 
-		err = serv.Storage.blockState.AddBlock(block)
+		err = serv.Block.AddBlock(block)
 		require.NoError(t, err)
 
 		err = serv.Storage.StoreTrie(trieState, &block.Header)
@@ -266,7 +312,7 @@ type prunedBlock struct {
 		require.NoError(t, err)
 		block.Header.Digest = digest
 
-		err = serv.Storage.blockState.AddBlock(block)
+		err = serv.Block.AddBlock(block)
 		require.NoError(t, err)
 
 		err = serv.Storage.StoreTrie(trieState, nil)
@@ -284,7 +330,7 @@ type prunedBlock struct {
 	for i := uint(0); i < 3; i++ {
 		block, trieState := generateBlockWithRandomTrie(t, serv, &parentHash, i+1)
 
-		err = serv.Storage.blockState.AddBlock(block)
+		err = serv.Block.AddBlock(block)
 		require.NoError(t, err)
 
 		err = serv.Storage.StoreTrie(trieState, nil)
@@ -307,7 +353,7 @@ type prunedBlock struct {
 	time.Sleep(1 * time.Second)
 
 	for _, v := range prunedArr {
-		tr := serv.Storage.blockState.tries.get(v.hash)
+		tr := serv.Block.tries.get(v.hash)
 		require.Nil(t, tr)
 	}
 }
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"bytes"
+	"fmt"
 	"sync"
 	"time"
 
@@ -14,14 +16,18 @@
 	"github.com/ChainSafe/gossamer/lib/transaction"
 )
 
-// TransactionState represents the queue of transactions
+// TransactionState represents the queue of transactions. The queue holds the ready
+// transactions, and the pool holds the future transactions, requiring tags not yet
+// provided by the ready transactions, as well as the transactions awaiting revalidation.
 type TransactionState struct {
 	queue *transaction.PriorityQueue
 	pool  *transaction.Pool
+	// pushLock serialises the moves of transactions between the queue and the pool.
+	pushLock sync.Mutex
 
 	// notifierChannels are used to notify transaction status. It maps a channel to
 	// hex string of the extrinsic it is supposed to notify about.
-	notifierChannels map[chan transaction.Status]string
+	notifierChannels map[chan transaction.StatusNotification]string
 	notifierLock     sync.RWMutex
 
 	telemetry Telemetry
@@ -32,17 +38,210 @@ func NewTransactionState(telemetry Telemetry) *TransactionState {
 	return &TransactionState{
 		queue:            transaction.NewPriorityQueue(),
 		pool:             transaction.NewPool(),
-		notifierChannels: make(map[chan transaction.Status]string),
+		notifierChannels: make(map[chan transaction.StatusNotification]string),
 		telemetry:        telemetry,
 	}
 }
 
-// Push pushes a transaction to the queue, ordered by priority
+// Push pushes a transaction to the queue, ordered by priority, if all the tags it requires
+// are provided by the transactions in the queue. Otherwise the transaction is added to the
+// pool as a future transaction, and is moved to the queue once the tags it requires are provided.
+// Transactions providing the same tags as the pushed transaction are replaced by it if its priority
+// is higher, otherwise transaction.ErrTooLowPriority is returned and the transaction is dropped.
 func (s *TransactionState) Push(vt *transaction.ValidTransaction) (common.Hash, error) {
+	s.pushLock.Lock()
+	defer s.pushLock.Unlock()
+
+	hash, err := s.push(vt)
+	if err != nil {
+		return hash, err
+	}
+
+	s.promoteFutureTransactions()
+	return hash, nil
+}
+
+func (s *TransactionState) push(vt *transaction.ValidTransaction) (common.Hash, error) {
+	hash := vt.Extrinsic.Hash()
+	if s.queue.Exists(hash) {
+		return hash, transaction.ErrTransactionExists
+	}
+	// the transaction is pushed again once revalidated, or once it is not a future transaction anymore,
+	// in which case it is still in the pool and must not count towards the sender limit.
+	inPool := s.pool.Get(hash) != nil
+
+	sender := transaction.ExtrinsicSender(vt.Extrinsic)
+	if sender != "" {
+		senderCount := s.queue.SenderCount(sender) + s.pool.SenderCount(sender)
+		if inPool {
+			senderCount--
+		}
+		if senderCount >= transaction.MaxTransactionsPerSender {
+			s.notifyStatus(vt.Extrinsic, transaction.Dropped)
+			return hash, fmt.Errorf("%w: %d transactions", transaction.ErrSenderLimitReached,
+				transaction.MaxTransactionsPerSender)
+		}
+	}
+
+	usurped, err := s.usurpedBy(vt)
+	if err != nil {
+		s.notifyStatus(vt.Extrinsic, transaction.Dropped)
+		return hash, err
+	}
+	for _, usurpedTransaction := range usurped {
+		s.pool.Remove(usurpedTransaction.Extrinsic.Hash())
+		s.queue.RemoveExtrinsic(usurpedTransaction.Extrinsic)
+		s.NotifyStatus(usurpedTransaction.Extrinsic, transaction.StatusNotification{
+			Status:    transaction.Usurped,
+			UsurpedBy: &hash,
+		})
+	}
+
+	// the previous copy of the transaction is only removed from the pool once it is accepted
+	if inPool {
+		s.pool.Remove(hash)
+	}
+
+	if !s.isReady(vt) {
+		s.notifyStatus(vt.Extrinsic, transaction.Future)
+		return s.pool.Insert(vt), nil
+	}
+
 	s.notifyStatus(vt.Extrinsic, transaction.Ready)
 	return s.queue.Push(vt)
 }
 
+// usurpedBy returns the transactions in the queue and the pool providing the same tags
+// as the given transaction, or transaction.ErrTooLowPriority if any of them has a priority
+// higher or equal to the priority of the given transaction.
+func (s *TransactionState) usurpedBy(vt *transaction.ValidTransaction) (
+	usurped []*transaction.ValidTransaction, err error) {
+	hash := vt.Extrinsic.Hash()
+	seen := make(map[common.Hash]struct{})
+	for _, tag := range vt.Validity.Provides {
+		providers := s.pool.ProvidedBy(tag)
+		if provider := s.queue.ProvidedBy(tag); provider != nil {
+			providers = append(providers, provider)
+		}
+
+		for _, provider := range providers {
+			providerHash := provider.Extrinsic.Hash()
+			if _, ok := seen[providerHash]; ok || providerHash == hash {
+				continue
+			}
+			seen[providerHash] = struct{}{}
+
+			if provider.Validity.Priority >= vt.Validity.Priority {
+				return nil, fmt.Errorf("%w: transaction %s providing tag 0x%x has priority %d >= %d",
+					transaction.ErrTooLowPriority, providerHash, tag,
+					provider.Validity.Priority, vt.Validity.Priority)
+			}
+			usurped = append(usurped, provider)
+		}
+	}
+	return usurped, nil
+}
+
+// isReady returns true if all the tags required by the transaction
+// are provided by the transactions in the queue.
+func (s *TransactionState) isReady(vt *transaction.ValidTransaction) bool {
+	for _, tag := range vt.Validity.Requires {
+		if !s.queue.Provides(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// promoteFutureTransactions moves the future transactions of the pool,
+// whose required tags are all provided by the queue, to the queue.
+func (s *TransactionState) promoteFutureTransactions() {
+	for promoted := true; promoted; {
+		promoted = false
+		for _, vt := range s.pool.Transactions() {
+			if len(vt.Validity.Requires) == 0 || !s.isReady(vt) {
+				continue
+			}
+
+			hash, err := s.push(vt)
+			if err != nil {
+				logger.Debugf("failed to promote future transaction %s: %s", hash, err)
+				continue
+			}
+			promoted = true
+		}
+	}
+}
+
+// PruneExpired removes the transactions of the queue and the pool whose longevity
+// expired at the given block number. Transactions pushed after this call expire
+// after their longevity counted from this block number.
+func (s *TransactionState) PruneExpired(blockNumber uint) {
+	s.pushLock.Lock()
+	defer s.pushLock.Unlock()
+
+	expired := append(s.queue.PruneExpired(blockNumber), s.pool.PruneExpired(blockNumber)...)
+	for _, vt := range expired {
+		s.notifyStatus(vt.Extrinsic, transaction.Invalid)
+	}
+}
+
+// RemoveExtrinsics removes the transactions with the given hashes from the queue and the pool,
+// together with the transactions depending on the tags they provide, and notifies them as invalid.
+// It returns the hashes of all the removed transactions.
+func (s *TransactionState) RemoveExtrinsics(hashes []common.Hash) (removed []common.Hash) {
+	s.pushLock.Lock()
+	defer s.pushLock.Unlock()
+
+	pending := append(s.queue.Pending(), s.pool.Transactions()...)
+	remaining := make(map[common.Hash]*transaction.ValidTransaction, len(pending))
+	for _, vt := range pending {
+		remaining[vt.Extrinsic.Hash()] = vt
+	}
+
+	toRemove := make([]*transaction.ValidTransaction, 0, len(hashes))
+	for _, hash := range hashes {
+		vt, ok := remaining[hash]
+		if !ok {
+			continue
+		}
+		toRemove = append(toRemove, vt)
+		delete(remaining, hash)
+	}
+
+	// toRemove grows while iterating, so the dependents of the dependents are removed as well
+	for i := 0; i < len(toRemove); i++ {
+		for hash, vt := range remaining {
+			if !dependsOn(vt, toRemove[i]) {
+				continue
+			}
+			toRemove = append(toRemove, vt)
+			delete(remaining, hash)
+		}
+	}
+
+	removed = make([]common.Hash, len(toRemove))
+	for i, vt := range toRemove {
+		removed[i] = vt.Extrinsic.Hash()
+		s.pool.Remove(removed[i])
+		s.queue.RemoveExtrinsic(vt.Extrinsic)
+		s.notifyStatus(vt.Extrinsic, transaction.Invalid)
+	}
+	return removed
+}
+
+// dependsOn returns true if the transaction requires any of the tags provided by the given provider.
+func dependsOn(vt, provider *transaction.ValidTransaction) bool {
+	for _, required := range vt.Validity.Requires {
+		for _, provided := range provider.Validity.Provides {
+			if bytes.Equal(required, provided) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Pop removes and returns the head of the queue
 func (s *TransactionState) Pop() *transaction.ValidTransaction {
 	return s.queue.Pop()
@@ -100,17 +299,17 @@ func (s *TransactionState) AddToPool(vt *transaction.ValidTransaction) common.Ha
 }
 
 // GetStatusNotifierChannel creates and returns a status notifier channel.
-func (s *TransactionState) GetStatusNotifierChannel(ext types.Extrinsic) chan transaction.Status {
+func (s *TransactionState) GetStatusNotifierChannel(ext types.Extrinsic) chan transaction.StatusNotification {
 	s.notifierLock.Lock()
 	defer s.notifierLock.Unlock()
 
-	ch := make(chan transaction.Status, defaultBufferSize)
+	ch := make(chan transaction.StatusNotification, defaultBufferSize)
 	s.notifierChannels[ch] = ext.String()
 	return ch
 }
 
 // FreeStatusNotifierChannel deletes given status notifier channel from our map.
-func (s *TransactionState) FreeStatusNotifierChannel(ch chan transaction.Status) {
+func (s *TransactionState) FreeStatusNotifierChannel(ch chan transaction.StatusNotification) {
 	s.notifierLock.Lock()
 	defer s.notifierLock.Unlock()
 
@@ -118,6 +317,11 @@ func (s *TransactionState) FreeStatusNotifierChannel(ch chan transaction.Status)
 }
 
 func (s *TransactionState) notifyStatus(ext types.Extrinsic, status transaction.Status) {
+	s.NotifyStatus(ext, transaction.StatusNotification{Status: status})
+}
+
+// NotifyStatus sends the status update to the status notifier channels of the extrinsic.
+func (s *TransactionState) NotifyStatus(ext types.Extrinsic, notification transaction.StatusNotification) {
 	s.notifierLock.Lock()
 	defer s.notifierLock.Unlock()
 
@@ -131,11 +335,11 @@ func (s *TransactionState) notifyStatus(ext types.Extrinsic, status transaction.
 			continue
 		}
 		wg.Add(1)
-		go func(ch chan transaction.Status) {
+		go func(ch chan transaction.StatusNotification) {
 			defer wg.Done()
 
 			select {
-			case ch <- status:
+			case ch <- notification:
 			default:
 			}
 		}(ch)
@@ -99,23 +99,26 @@ func TestTransactionState_NotifierChannels(t *testing.T) {
 	for i := 0; i < expectedFutureCount; i++ {
 		dummyTransactions[i] = &transaction.ValidTransaction{
 			Extrinsic: ext,
-			Validity:  transaction.NewValidity(0, [][]byte{{}}, [][]byte{{}}, 0, false),
+			Validity:  transaction.NewValidity(0, nil, [][]byte{{}}, 0, false),
 		}
 
 		ts.AddToPool(dummyTransactions[i])
 	}
 
+	// the transaction is popped after each push, since pushing a transaction
+	// already in the queue does not notify its status.
 	for i := 0; i < expectedReadyCount; i++ {
 		ts.Push(dummyTransactions[i])
+		ts.Pop()
 	}
 
 	close(notifierChannel)
 
 	for status := range notifierChannel {
-		if status.String() == transaction.Future.String() {
+		if status.Status == transaction.Future {
 			futureCount++
 		}
-		if status.String() == transaction.Ready.String() {
+		if status.Status == transaction.Ready {
 			readyCount++
 		}
 	}
@@ -123,3 +126,139 @@ func TestTransactionState_NotifierChannels(t *testing.T) {
 	require.Equal(t, expectedFutureCount, futureCount)
 	require.Equal(t, expectedReadyCount, readyCount)
 }
+
+func TestTransactionState_PushFuture(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+
+	ts := NewTransactionState(telemetryMock)
+
+	nonce0 := &transaction.ValidTransaction{
+		Extrinsic: []byte("nonce0"),
+		Validity:  &transaction.Validity{Priority: 1, Provides: [][]byte{{0}}, Longevity: 64},
+	}
+	nonce1 := &transaction.ValidTransaction{
+		Extrinsic: []byte("nonce1"),
+		Validity: &transaction.Validity{Priority: 1, Requires: [][]byte{{0}},
+			Provides: [][]byte{{1}}, Longevity: 64},
+	}
+
+	// the transaction is kept in the pool until the tag it requires is provided
+	_, err := ts.Push(nonce1)
+	require.NoError(t, err)
+	require.Equal(t, []*transaction.ValidTransaction{nonce1}, ts.PendingInPool())
+	require.Nil(t, ts.Peek())
+
+	_, err = ts.Push(nonce0)
+	require.NoError(t, err)
+	require.Empty(t, ts.PendingInPool())
+	require.Equal(t, nonce0, ts.Pop())
+	require.Equal(t, nonce1, ts.Pop())
+	require.Nil(t, ts.Pop())
+}
+
+func TestTransactionState_PushUsurp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+
+	ts := NewTransactionState(telemetryMock)
+
+	low := &transaction.ValidTransaction{
+		Extrinsic: []byte("low"),
+		Validity:  &transaction.Validity{Priority: 1, Provides: [][]byte{{0}}, Longevity: 64},
+	}
+	high := &transaction.ValidTransaction{
+		Extrinsic: []byte("high"),
+		Validity:  &transaction.Validity{Priority: 2, Provides: [][]byte{{0}}, Longevity: 64},
+	}
+
+	_, err := ts.Push(low)
+	require.NoError(t, err)
+
+	_, err = ts.Push(high)
+	require.NoError(t, err)
+	require.False(t, ts.Exists(low.Extrinsic))
+
+	_, err = ts.Push(low)
+	require.ErrorIs(t, err, transaction.ErrTooLowPriority)
+	require.Equal(t, []*transaction.ValidTransaction{high}, ts.Pending())
+
+	// a pooled transaction pushed again is kept in the pool if it is rejected
+	ts.pool.Insert(low)
+	_, err = ts.Push(low)
+	require.ErrorIs(t, err, transaction.ErrTooLowPriority)
+	require.Equal(t, []*transaction.ValidTransaction{low}, ts.PendingInPool())
+}
+
+func TestTransactionState_PruneExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().SendMessage(gomock.Any())
+
+	ts := NewTransactionState(telemetryMock)
+
+	queued := &transaction.ValidTransaction{
+		Extrinsic: []byte("queued"),
+		Validity:  &transaction.Validity{Priority: 1, Longevity: 1},
+	}
+	pooled := &transaction.ValidTransaction{
+		Extrinsic: []byte("pooled"),
+		Validity:  &transaction.Validity{Priority: 1, Longevity: 1},
+	}
+
+	_, err := ts.Push(queued)
+	require.NoError(t, err)
+	ts.AddToPool(pooled)
+
+	ts.PruneExpired(1)
+	require.Len(t, ts.Pending(), 2)
+
+	ts.PruneExpired(2)
+	require.Empty(t, ts.Pending())
+}
+
+func TestTransactionState_RemoveExtrinsics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().SendMessage(gomock.Any())
+
+	ts := NewTransactionState(telemetryMock)
+
+	parent := &transaction.ValidTransaction{
+		Extrinsic: []byte("parent"),
+		Validity:  &transaction.Validity{Priority: 1, Provides: [][]byte{{0}}, Longevity: 64},
+	}
+	child := &transaction.ValidTransaction{
+		Extrinsic: []byte("child"),
+		Validity: &transaction.Validity{Priority: 1, Requires: [][]byte{{0}},
+			Provides: [][]byte{{1}}, Longevity: 64},
+	}
+	grandChild := &transaction.ValidTransaction{
+		Extrinsic: []byte("grandchild"),
+		Validity:  &transaction.Validity{Priority: 1, Requires: [][]byte{{1}}, Longevity: 64},
+	}
+	unrelated := &transaction.ValidTransaction{
+		Extrinsic: []byte("unrelated"),
+		Validity:  &transaction.Validity{Priority: 1, Longevity: 64},
+	}
+
+	for _, vt := range []*transaction.ValidTransaction{parent, child, unrelated} {
+		_, err := ts.Push(vt)
+		require.NoError(t, err)
+	}
+	ts.AddToPool(grandChild)
+
+	notifierChannel := ts.GetStatusNotifierChannel(grandChild.Extrinsic)
+	defer ts.FreeStatusNotifierChannel(notifierChannel)
+
+	removed := ts.RemoveExtrinsics([]common.Hash{parent.Extrinsic.Hash(), {1}})
+	require.ElementsMatch(t, []common.Hash{
+		parent.Extrinsic.Hash(),
+		child.Extrinsic.Hash(),
+		grandChild.Extrinsic.Hash(),
+	}, removed)
+	require.Equal(t, []*transaction.ValidTransaction{unrelated}, ts.Pending())
+
+	notification := <-notifierChannel
+	require.Equal(t, transaction.StatusNotification{Status: transaction.Invalid}, notification)
+}
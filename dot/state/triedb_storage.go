@@ -0,0 +1,330 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/state/pruner"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/ChainSafe/gossamer/pkg/trie/triedb"
+)
+
+var errUnsupportedTrie = errors.New("unsupported trie implementation")
+
+// TrieDBStorageState is the storage state resolving the state trie nodes lazily
+// from the database, instead of loading whole tries in memory. It shares the
+// database format of the InmemoryStorageState, so both can be used on the same
+// database.
+type TrieDBStorageState struct {
+	blockState *BlockState
+	db         database.Table
+	sync.RWMutex
+
+	// change notifiers
+	storageObservers
+	pruner pruner.Pruner
+}
+
+// NewTrieDBStorageState creates a new TrieDBStorageState backed by the given
+// block state and database.
+func NewTrieDBStorageState(db database.Database, blockState *BlockState) *TrieDBStorageState {
+	storageState := &TrieDBStorageState{
+		blockState: blockState,
+		db:         database.NewTable(db, storagePrefix),
+		pruner:     &pruner.ArchiveNode{},
+	}
+	storageState.storageObservers = storageObservers{
+		storageState: storageState,
+		observerList: []Observer{},
+	}
+	return storageState
+}
+
+func (s *TrieDBStorageState) setPruner(p pruner.Pruner) {
+	s.pruner = p
+}
+
+// StoreTrie writes the changes of the given trie state to the database
+func (s *TrieDBStorageState) StoreTrie(ts *storage.TrieState, header *types.Header) error {
+	var root common.Hash
+	switch t := ts.Trie().(type) {
+	case *triedb.TrieDB:
+		err := t.Commit()
+		if err != nil {
+			return fmt.Errorf("committing trie: %w", err)
+		}
+
+		root, err = t.Hash()
+		if err != nil {
+			return fmt.Errorf("hashing trie: %w", err)
+		}
+
+		insertedValueKeys, deletedValueKeys := t.GetChangedValueKeys()
+		err = s.storeJournalRecord(ts, header, deletedValueKeys, insertedValueKeys)
+		if err != nil {
+			return err
+		}
+	case *inmemory_trie.InMemoryTrie:
+		root = t.MustHash()
+
+		// the journal record is stored first, since writing
+		// the dirty nodes clears the changes of the trie.
+		err := s.StoreJournalRecord(ts, header)
+		if err != nil {
+			return err
+		}
+
+		err = t.WriteDirty(s.db)
+		if err != nil {
+			return fmt.Errorf("writing trie with root %s to database: %w", root, err)
+		}
+	default:
+		return fmt.Errorf("%w: %T", errUnsupportedTrie, t)
+	}
+
+	logger.Tracef("stored trie with root %s", root)
+
+	go s.notifyAll(root)
+	return nil
+}
+
+// StoreJournalRecord stores the journal record of the trie nodes changed by the given
+// trie state of the given block for the state pruner, without writing the trie to the
+// database. The hashed values of an in-memory trie are not tracked.
+func (s *TrieDBStorageState) StoreJournalRecord(ts *storage.TrieState, header *types.Header) error {
+	return s.storeJournalRecord(ts, header, nil, nil)
+}
+
+func (s *TrieDBStorageState) storeJournalRecord(ts *storage.TrieState, header *types.Header,
+	deletedValueKeys, insertedValueKeys map[string]struct{}) error {
+	if header == nil {
+		return nil
+	}
+
+	insertedNodeHashes, deletedNodeHashes, err := ts.GetChangedNodeHashes()
+	if err != nil {
+		return fmt.Errorf("getting trie changed node hashes for block hash %s: %w", header.Hash(), err)
+	}
+
+	err = s.pruner.StoreJournalRecord(deletedNodeHashes, insertedNodeHashes, deletedValueKeys, insertedValueKeys,
+		header.Hash(), int64(header.Number))
+	if err != nil {
+		return fmt.Errorf("storing journal record: %w", err)
+	}
+	return nil
+}
+
+// TrieState returns the TrieState for a given state root.
+// If no state root is provided, it returns the TrieState for the current chain head.
+func (s *TrieDBStorageState) TrieState(root *common.Hash) (*storage.TrieState, error) {
+	t, err := s.loadTrie(root)
+	if err != nil {
+		return nil, fmt.Errorf("while loading from database: %w", err)
+	}
+
+	logger.Tracef("returning trie with root %s to be modified", root)
+	return storage.NewTrieState(t), nil
+}
+
+// TrieStateWithRecorder returns the TrieState for the given state root, recording
+// the trie nodes and values read from the database in the given recorder.
+func (s *TrieDBStorageState) TrieStateWithRecorder(root common.Hash, recorder *triedb.Recorder) (
+	*storage.TrieState, error) {
+	return newRecordingTrieState(s.db, root, recorder)
+}
+
+// newRecordingTrieState returns the TrieState for the given state root, resolving its
+// nodes lazily from the database and recording them in the given recorder.
+func newRecordingTrieState(db database.Table, root common.Hash, recorder *triedb.Recorder) (
+	*storage.TrieState, error) {
+	// the empty trie root node is not stored in the database
+	if root != trie.EmptyHash {
+		has, err := db.Has(root[:])
+		if err != nil {
+			return nil, fmt.Errorf("checking root node is in database: %w", err)
+		} else if !has {
+			return nil, errTrieDoesNotExist(root)
+		}
+	}
+
+	t := triedb.NewTrieDB(root, db, triedb.WithRecorder(recorder))
+	return storage.NewTrieState(t), nil
+}
+
+// LoadFromDB returns the trie with the given root, resolving its nodes lazily from the database
+func (s *TrieDBStorageState) LoadFromDB(root common.Hash) (trie.Trie, error) {
+	return s.newTrieDB(root)
+}
+
+// newTrieDB returns the trie with the given root after checking its root node is in the database.
+func (s *TrieDBStorageState) newTrieDB(root common.Hash) (*triedb.TrieDB, error) {
+	// the empty trie root node is not stored in the database
+	if root != trie.EmptyHash {
+		has, err := s.db.Has(root[:])
+		if err != nil {
+			return nil, fmt.Errorf("checking root node is in database: %w", err)
+		} else if !has {
+			return nil, errTrieDoesNotExist(root)
+		}
+	}
+
+	return triedb.NewTrieDB(root, s.db, triedb.WithDeferredDeletions()), nil
+}
+
+func (s *TrieDBStorageState) loadTrie(root *common.Hash) (*triedb.TrieDB, error) {
+	if root == nil {
+		sr, err := s.blockState.BestBlockStateRoot()
+		if err != nil {
+			return nil, fmt.Errorf("while getting best block state root: %w", err)
+		}
+		root = &sr
+	}
+
+	t, err := s.newTrieDB(*root)
+	if err != nil {
+		return nil, fmt.Errorf("trie does not exist at root %s: %w", *root, err)
+	}
+
+	return t, nil
+}
+
+// ExistsStorage check if the key exists in the storage trie with the given storage hash
+// If no hash is provided, the current chain head is used
+func (s *TrieDBStorageState) ExistsStorage(root *common.Hash, key []byte) (bool, error) {
+	val, err := s.GetStorage(root, key)
+	return val != nil, err
+}
+
+// GetStorage gets the object from the trie using the given key and storage hash
+// If no hash is provided, the current chain head is used
+func (s *TrieDBStorageState) GetStorage(root *common.Hash, key []byte) ([]byte, error) {
+	t, err := s.loadTrie(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Get(key), nil
+}
+
+// GetStorageByBlockHash returns the value at the given key at the given block hash
+func (s *TrieDBStorageState) GetStorageByBlockHash(bhash *common.Hash, key []byte) ([]byte, error) {
+	root, err := s.GetStateRootFromBlock(bhash)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetStorage(root, key)
+}
+
+// GetStateRootFromBlock returns the state root hash of a given block hash
+func (s *TrieDBStorageState) GetStateRootFromBlock(bhash *common.Hash) (*common.Hash, error) {
+	if bhash == nil {
+		b := s.blockState.BestBlockHash()
+		bhash = &b
+	}
+
+	header, err := s.blockState.GetHeader(*bhash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &header.StateRoot, nil
+}
+
+// StorageRoot returns the root hash of the current storage trie
+func (s *TrieDBStorageState) StorageRoot() (common.Hash, error) {
+	return s.blockState.BestBlockStateRoot()
+}
+
+// Entries returns Entries from the trie with the given state root
+func (s *TrieDBStorageState) Entries(root *common.Hash) (map[string][]byte, error) {
+	t, err := s.loadTrie(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Entries(), nil
+}
+
+// GetKeysWithPrefix returns all that match the given prefix for the given hash
+// (or best block state root if hash is nil) in lexicographic order
+func (s *TrieDBStorageState) GetKeysWithPrefix(root *common.Hash, prefix []byte) ([][]byte, error) {
+	t, err := s.loadTrie(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetKeysWithPrefix(prefix), nil
+}
+
+// GetStorageChild returns a child trie, if it exists
+func (s *TrieDBStorageState) GetStorageChild(root *common.Hash, keyToChild []byte) (trie.Trie, error) {
+	t, err := s.loadTrie(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetChild(keyToChild)
+}
+
+// GetStorageFromChild get a value from a child trie
+func (s *TrieDBStorageState) GetStorageFromChild(root *common.Hash, keyToChild, key []byte) ([]byte, error) {
+	t, err := s.loadTrie(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.GetFromChild(keyToChild, key)
+}
+
+// LoadCode returns the runtime code (located at :code)
+func (s *TrieDBStorageState) LoadCode(hash *common.Hash) ([]byte, error) {
+	return s.GetStorage(hash, codeKey)
+}
+
+// LoadCodeHash returns the hash of the runtime code (located at :code)
+func (s *TrieDBStorageState) LoadCodeHash(hash *common.Hash) (common.Hash, error) {
+	code, err := s.LoadCode(hash)
+	if err != nil {
+		return common.NewHash([]byte{}), err
+	}
+
+	return common.Blake2bHash(code)
+}
+
+// GenerateTrieProof returns the encoded trie nodes and values
+// read to get the values of the keys on the state root trie
+func (s *TrieDBStorageState) GenerateTrieProof(stateRoot common.Hash, keys [][]byte) (
+	encodedProofNodes [][]byte, err error) {
+	recorder := triedb.NewRecorder()
+	t := triedb.NewTrieDB(stateRoot, s.db, triedb.WithRecorder(recorder))
+	for _, key := range keys {
+		t.Get(key)
+	}
+
+	records := recorder.Drain()
+	if len(records) == 0 && len(keys) > 0 && stateRoot != trie.EmptyHash {
+		return nil, errTrieDoesNotExist(stateRoot)
+	}
+
+	seen := make(map[common.Hash]struct{}, len(records))
+	encodedProofNodes = make([][]byte, 0, len(records))
+	for _, record := range records {
+		if _, ok := seen[record.Hash]; ok {
+			continue
+		}
+		seen[record.Hash] = struct{}{}
+		encodedProofNodes = append(encodedProofNodes, record.Data)
+	}
+
+	return encodedProofNodes, nil
+}
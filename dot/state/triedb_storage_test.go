@@ -0,0 +1,196 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory/proof"
+	"github.com/ChainSafe/gossamer/pkg/trie/triedb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrieDBStorage_StoreAndLoadTrie(t *testing.T) {
+	t.Parallel()
+
+	storage := NewTrieDBStorageState(NewInMemoryDB(t), newTestBlockState(t, newTriesEmpty()))
+	ts, err := storage.TrieState(&trie.EmptyHash)
+	require.NoError(t, err)
+
+	entries := map[string][]byte{
+		"key1":    []byte("value1"),
+		"key2":    []byte("value2"),
+		"xyzKey1": []byte("xyzValue1"),
+		"long":    []byte("newvaluewithmorethan32byteslength"),
+	}
+	ts.SetVersion(trie.V1)
+	for key, value := range entries {
+		require.NoError(t, ts.Put([]byte(key), value))
+	}
+	require.NoError(t, ts.SetChildStorage([]byte("child"), []byte("key"), []byte("childValue")))
+
+	err = storage.StoreTrie(ts, nil)
+	require.NoError(t, err)
+	root := ts.Trie().MustHash()
+
+	loaded, err := storage.LoadFromDB(root)
+	require.NoError(t, err)
+	assert.IsType(t, &triedb.TrieDB{}, loaded)
+	assert.Equal(t, root, loaded.MustHash())
+
+	value, err := storage.GetStorage(&root, []byte("long"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("newvaluewithmorethan32byteslength"), value)
+
+	keys, err := storage.GetKeysWithPrefix(&root, []byte("ke"))
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("key1"), []byte("key2")}, keys)
+
+	storageEntries, err := storage.Entries(&root)
+	require.NoError(t, err)
+	assert.Len(t, storageEntries, len(entries)+1)
+
+	value, err = storage.GetStorageFromChild(&root, []byte("child"), []byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("childValue"), value)
+
+	_, err = storage.LoadFromDB(common.Hash{1})
+	assert.ErrorIs(t, err, ErrTrieDoesNotExist)
+}
+
+func TestTrieDBStorage_StoreTrie_DoesNotCacheTries(t *testing.T) {
+	t.Parallel()
+
+	tries := newTriesEmpty()
+	storage := NewTrieDBStorageState(NewInMemoryDB(t), newTestBlockState(t, tries))
+	ts, err := storage.TrieState(&trie.EmptyHash)
+	require.NoError(t, err)
+
+	require.NoError(t, ts.Put([]byte("testkey"), []byte("testvalue")))
+
+	err = storage.StoreTrie(ts, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, tries.len())
+}
+
+func TestTrieDBStorage_GetStorageByBlockHash(t *testing.T) {
+	t.Parallel()
+
+	storage := NewTrieDBStorageState(NewInMemoryDB(t), newTestBlockState(t, newTriesEmpty()))
+	ts, err := storage.TrieState(&trie.EmptyHash)
+	require.NoError(t, err)
+
+	key := []byte("testkey")
+	value := []byte("testvalue")
+	require.NoError(t, ts.Put(key, value))
+
+	err = storage.StoreTrie(ts, nil)
+	require.NoError(t, err)
+
+	body, err := types.NewBodyFromBytes([]byte{})
+	require.NoError(t, err)
+
+	block := &types.Block{
+		Header: types.Header{
+			ParentHash: testGenesisHeader.Hash(),
+			Number:     1,
+			StateRoot:  ts.Trie().MustHash(),
+			Digest:     createPrimaryBABEDigest(t),
+		},
+		Body: *body,
+	}
+	err = storage.blockState.AddBlock(block)
+	require.NoError(t, err)
+
+	hash := block.Header.Hash()
+	res, err := storage.GetStorageByBlockHash(&hash, key)
+	require.NoError(t, err)
+	assert.Equal(t, value, res)
+}
+
+func TestTrieDBStorage_InmemoryStorageStateMigration(t *testing.T) {
+	t.Parallel()
+
+	db := NewInMemoryDB(t)
+	tries := newTriesEmpty()
+	blockState := newTestBlockState(t, tries)
+
+	inmemoryStorage, err := NewStorageState(db, blockState, tries)
+	require.NoError(t, err)
+	ts, err := inmemoryStorage.TrieState(&trie.EmptyHash)
+	require.NoError(t, err)
+
+	ts.SetVersion(trie.V1)
+	require.NoError(t, ts.Put([]byte("short"), []byte("value")))
+	require.NoError(t, ts.Put([]byte("long"), make([]byte, 40)))
+	require.NoError(t, ts.SetChildStorage([]byte("child"), []byte("key"), make([]byte, 50)))
+
+	err = inmemoryStorage.StoreTrie(ts, nil)
+	require.NoError(t, err)
+	inmemoryRoot := ts.Trie().MustHash()
+
+	// the tries written by the in-memory storage state are read as is
+	trieDBStorage := NewTrieDBStorageState(db, blockState)
+	expectedEntries, err := inmemoryStorage.Entries(&inmemoryRoot)
+	require.NoError(t, err)
+	entries, err := trieDBStorage.Entries(&inmemoryRoot)
+	require.NoError(t, err)
+	assert.Equal(t, expectedEntries, entries)
+
+	value, err := trieDBStorage.GetStorageFromChild(&inmemoryRoot, []byte("child"), []byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, make([]byte, 50), value)
+
+	// and the tries written by the database storage state can be read by the in-memory storage state
+	ts, err = trieDBStorage.TrieState(&inmemoryRoot)
+	require.NoError(t, err)
+	ts.SetVersion(trie.V1)
+	require.NoError(t, ts.Put([]byte("other"), make([]byte, 60)))
+	require.NoError(t, ts.SetChildStorage([]byte("child"), []byte("other"), []byte("value")))
+
+	err = trieDBStorage.StoreTrie(ts, nil)
+	require.NoError(t, err)
+	trieDBRoot := ts.Trie().MustHash()
+
+	tries.delete(inmemoryRoot)
+	loaded, err := inmemoryStorage.LoadFromDB(trieDBRoot)
+	require.NoError(t, err)
+	assert.Equal(t, trieDBRoot, loaded.MustHash())
+
+	value, err = loaded.GetFromChild([]byte("child"), []byte("other"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestTrieDBStorage_GenerateTrieProof(t *testing.T) {
+	t.Parallel()
+
+	storage := NewTrieDBStorageState(NewInMemoryDB(t), newTestBlockState(t, newTriesEmpty()))
+	ts, err := storage.TrieState(&trie.EmptyHash)
+	require.NoError(t, err)
+
+	ts.SetVersion(trie.V1)
+	require.NoError(t, ts.Put([]byte("no"), []byte("noValue")))
+	require.NoError(t, ts.Put([]byte("noot"), make([]byte, 40)))
+	require.NoError(t, ts.Put([]byte("not"), make([]byte, 50)))
+
+	err = storage.StoreTrie(ts, nil)
+	require.NoError(t, err)
+	root := ts.Trie().MustHash()
+
+	encodedProofNodes, err := storage.GenerateTrieProof(root, [][]byte{[]byte("noot"), []byte("no")})
+	require.NoError(t, err)
+
+	err = proof.Verify(encodedProofNodes, root[:], []byte("noot"), make([]byte, 40))
+	require.NoError(t, err)
+	err = proof.Verify(encodedProofNodes, root[:], []byte("no"), []byte("noValue"))
+	require.NoError(t, err)
+
+	_, err = storage.GenerateTrieProof(common.Hash{1}, [][]byte{[]byte("no")})
+	assert.ErrorIs(t, err, ErrTrieDoesNotExist)
+}
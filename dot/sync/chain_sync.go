@@ -34,6 +34,7 @@
 const (
 	bootstrap chainSyncState = iota
 	tip
+	stateSync
 )
 
 type blockOrigin byte
@@ -49,6 +50,8 @@ func (s chainSyncState) String() string {
 		return "bootstrap"
 	case tip:
 		return "tip"
+	case stateSync:
+		return "state"
 	default:
 		return "unknown"
 	}
@@ -125,6 +128,18 @@ type chainSync struct {
 	badBlocks          []string
 	requestMaker       network.RequestMaker
 	waitPeersDuration  time.Duration
+
+	// stateSync enables downloading the state of a finalised
+	// block instead of executing the blocks from genesis.
+	stateSync         bool
+	stateRequestMaker network.RequestMaker
+
+	// warpSync enables proving the state sync target block
+	// with the GRANDPA authority set changes from genesis.
+	warpSync             bool
+	warpSyncRequestMaker network.RequestMaker
+	grandpaState         GrandpaState
+	epochState           EpochState
 }
 
 type chainSyncConfig struct {
@@ -142,6 +157,13 @@ type chainSyncConfig struct {
 	telemetry          Telemetry
 	badBlocks          []string
 	waitPeersDuration  time.Duration
+	stateSync          bool
+	stateRequestMaker  network.RequestMaker
+
+	warpSync             bool
+	warpSyncRequestMaker network.RequestMaker
+	grandpaState         GrandpaState
+	epochState           EpochState
 }
 
 func newChainSync(cfg chainSyncConfig) *chainSync {
@@ -167,6 +189,13 @@ func newChainSync(cfg chainSyncConfig) *chainSync {
 		badBlocks:          cfg.badBlocks,
 		requestMaker:       cfg.requestMaker,
 		waitPeersDuration:  cfg.waitPeersDuration,
+		stateSync:          cfg.stateSync,
+		stateRequestMaker:  cfg.stateRequestMaker,
+
+		warpSync:             cfg.warpSync,
+		warpSyncRequestMaker: cfg.warpSyncRequestMaker,
+		grandpaState:         cfg.grandpaState,
+		epochState:           cfg.epochState,
 	}
 }
 
@@ -209,8 +238,39 @@ func (cs *chainSync) start() {
 	cs.wg.Add(1)
 	go cs.pendingBlocks.run(cs.finalisedCh, cs.stopCh, &cs.wg)
 
+	shouldStateSync, err := cs.shouldStateSync()
+	if err != nil {
+		logger.Errorf("checking if state sync is needed: %s", err)
+	}
+
+	if shouldStateSync {
+		// prevent the block announce handshakes from
+		// starting the bootstrap sync before the state sync
+		cs.syncMode.Store(stateSync)
+		isSyncedGauge.Set(0)
+		logger.Infof("🔁 switched sync mode to %s", stateSync.String())
+	}
+
 	// wait until we have a minimal workers in the sync worker pool
 	cs.waitWorkersAndTarget()
+
+	if !shouldStateSync {
+		return
+	}
+
+	err = cs.syncState()
+	if err != nil {
+		logger.Errorf("syncing state, falling back to full sync: %s", err)
+	}
+
+	cs.syncMode.Store(tip)
+	isSyncedGauge.Set(1)
+	logger.Infof("🔁 switched sync mode to %s", tip.String())
+
+	err = cs.switchToBootstrapIfBehind()
+	if err != nil {
+		logger.Errorf("switching to bootstrap sync: %s", err)
+	}
 }
 
 func (cs *chainSync) stop() error {
@@ -294,10 +354,16 @@ func (cs *chainSync) onBlockAnnounceHandshake(who peer.ID, bestHash common.Hash,
 	cs.workerPool.fromBlockAnnounce(who)
 	cs.peerViewSet.update(who, bestHash, bestNumber)
 
-	if cs.getSyncMode() == bootstrap {
+	if cs.getSyncMode() != tip {
 		return nil
 	}
 
+	return cs.switchToBootstrapIfBehind()
+}
+
+// switchToBootstrapIfBehind starts the bootstrap sync
+// if the best block is far behind the sync target.
+func (cs *chainSync) switchToBootstrapIfBehind() error {
 	bestBlockHeader, err := cs.blockState.BestBlockHeader()
 	if err != nil {
 		return err
@@ -330,7 +396,7 @@ func (cs *chainSync) onBlockAnnounce(announced announcedBlock) error {
 		return fmt.Errorf("while adding pending block header: %w", err)
 	}
 
-	if cs.getSyncMode() == bootstrap {
+	if cs.getSyncMode() != tip {
 		return nil
 	}
 
@@ -909,11 +975,6 @@ func (cs *chainSync) handleJustification(header *types.Header, justification []b
 		return fmt.Errorf("verifying block number %d justification: %w", header.Number, err)
 	}
 
-	err = cs.blockState.SetJustification(headerHash, justification)
-	if err != nil {
-		return fmt.Errorf("setting justification for block number %d: %w", header.Number, err)
-	}
-
 	return nil
 }
 
@@ -27,4 +27,13 @@
 	errStartAndEndMismatch        = errors.New("request start and end hash are not on the same chain")
 	errFailedToGetDescendant      = errors.New("failed to find descendant block")
 	errAlreadyInDisjointSet       = errors.New("already in disjoint set")
+
+	// state sync errors
+	errInvalidStateRequest     = errors.New("invalid state request")
+	errInvalidStateResponse    = errors.New("invalid state response")
+	errStateSyncTargetMismatch = errors.New("peers disagree on state sync target")
+	errStateSyncStopped        = errors.New("state sync stopped")
+	errStateRootMismatch       = errors.New("state root mismatch")
+	errMissingChildTrie        = errors.New("missing child trie")
+	errMissingRuntimeCode      = errors.New("missing runtime code")
 )
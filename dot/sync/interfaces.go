@@ -39,6 +39,8 @@ type BlockState interface {
 	GetAllBlocksAtNumber(num uint) ([]common.Hash, error)
 	IsDescendantOf(parent, child common.Hash) (bool, error)
 
+	SetStateSyncedFinalisedHeader(header *types.Header, justification []byte, state *rtstorage.TrieState) error
+
 	IsPaused() bool
 	Pause() error
 }
@@ -46,9 +48,24 @@ type BlockState interface {
 // StorageState is the interface for the storage state
 type StorageState interface {
 	TrieState(root *common.Hash) (*rtstorage.TrieState, error)
+	StoreJournalRecord(ts *rtstorage.TrieState, header *types.Header) error
+	GenerateTrieProof(stateRoot common.Hash, keys [][]byte) (encodedProofNodes [][]byte, err error)
 	sync.Locker
 }
 
+// GrandpaState is the interface for the grandpa state
+type GrandpaState interface {
+	GetCurrentSetID() (uint64, error)
+	GetAuthorities(setID uint64) ([]types.GrandpaVoter, error)
+	SetNextChange(authorities []types.GrandpaVoter, number uint) error
+	IncrementSetID() (newSetID uint64, err error)
+}
+
+// EpochState is the interface for the epoch state
+type EpochState interface {
+	SetStateSyncedEpochData(state *rtstorage.TrieState) error
+}
+
 // TransactionState is the interface for transaction queue methods
 type TransactionState interface {
 	RemoveExtrinsic(ext types.Extrinsic)
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ChainSafe/gossamer/dot/sync (interfaces: BlockState,StorageState,TransactionState,BabeVerifier,FinalityGadget,BlockImportHandler,Network)
+// Source: github.com/ChainSafe/gossamer/dot/sync (interfaces: BlockState,StorageState,GrandpaState,EpochState,TransactionState,BabeVerifier,FinalityGadget,BlockImportHandler,Network)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks_test.go -package=sync . BlockState,StorageState,TransactionState,BabeVerifier,FinalityGadget,BlockImportHandler,Network
+//	mockgen -destination=mocks_test.go -package=sync . BlockState,StorageState,GrandpaState,EpochState,TransactionState,BabeVerifier,FinalityGadget,BlockImportHandler,Network
 //
 
 // Package sync is a generated GoMock package.
@@ -369,6 +369,20 @@ func (mr *MockBlockStateMockRecorder) SetJustification(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetJustification", reflect.TypeOf((*MockBlockState)(nil).SetJustification), arg0, arg1)
 }
 
+// SetStateSyncedFinalisedHeader mocks base method.
+func (m *MockBlockState) SetStateSyncedFinalisedHeader(arg0 *types.Header, arg1 []byte, arg2 *storage.TrieState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStateSyncedFinalisedHeader", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStateSyncedFinalisedHeader indicates an expected call of SetStateSyncedFinalisedHeader.
+func (mr *MockBlockStateMockRecorder) SetStateSyncedFinalisedHeader(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStateSyncedFinalisedHeader", reflect.TypeOf((*MockBlockState)(nil).SetStateSyncedFinalisedHeader), arg0, arg1, arg2)
+}
+
 // StoreRuntime mocks base method.
 func (m *MockBlockState) StoreRuntime(arg0 common.Hash, arg1 runtime.Instance) {
 	m.ctrl.T.Helper()
@@ -404,6 +418,21 @@ func (m *MockStorageState) EXPECT() *MockStorageStateMockRecorder {
 	return m.recorder
 }
 
+// GenerateTrieProof mocks base method.
+func (m *MockStorageState) GenerateTrieProof(arg0 common.Hash, arg1 [][]byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateTrieProof", arg0, arg1)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateTrieProof indicates an expected call of GenerateTrieProof.
+func (mr *MockStorageStateMockRecorder) GenerateTrieProof(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTrieProof", reflect.TypeOf((*MockStorageState)(nil).GenerateTrieProof), arg0, arg1)
+}
+
 // Lock mocks base method.
 func (m *MockStorageState) Lock() {
 	m.ctrl.T.Helper()
@@ -416,6 +445,20 @@ func (mr *MockStorageStateMockRecorder) Lock() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockStorageState)(nil).Lock))
 }
 
+// StoreJournalRecord mocks base method.
+func (m *MockStorageState) StoreJournalRecord(arg0 *storage.TrieState, arg1 *types.Header) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreJournalRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreJournalRecord indicates an expected call of StoreJournalRecord.
+func (mr *MockStorageStateMockRecorder) StoreJournalRecord(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreJournalRecord", reflect.TypeOf((*MockStorageState)(nil).StoreJournalRecord), arg0, arg1)
+}
+
 // TrieState mocks base method.
 func (m *MockStorageState) TrieState(arg0 *common.Hash) (*storage.TrieState, error) {
 	m.ctrl.T.Helper()
@@ -443,6 +486,125 @@ func (mr *MockStorageStateMockRecorder) Unlock() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockStorageState)(nil).Unlock))
 }
 
+// MockGrandpaState is a mock of GrandpaState interface.
+type MockGrandpaState struct {
+	ctrl     *gomock.Controller
+	recorder *MockGrandpaStateMockRecorder
+}
+
+// MockGrandpaStateMockRecorder is the mock recorder for MockGrandpaState.
+type MockGrandpaStateMockRecorder struct {
+	mock *MockGrandpaState
+}
+
+// NewMockGrandpaState creates a new mock instance.
+func NewMockGrandpaState(ctrl *gomock.Controller) *MockGrandpaState {
+	mock := &MockGrandpaState{ctrl: ctrl}
+	mock.recorder = &MockGrandpaStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGrandpaState) EXPECT() *MockGrandpaStateMockRecorder {
+	return m.recorder
+}
+
+// GetAuthorities mocks base method.
+func (m *MockGrandpaState) GetAuthorities(arg0 uint64) ([]types.GrandpaVoter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorities", arg0)
+	ret0, _ := ret[0].([]types.GrandpaVoter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorities indicates an expected call of GetAuthorities.
+func (mr *MockGrandpaStateMockRecorder) GetAuthorities(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorities", reflect.TypeOf((*MockGrandpaState)(nil).GetAuthorities), arg0)
+}
+
+// GetCurrentSetID mocks base method.
+func (m *MockGrandpaState) GetCurrentSetID() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentSetID")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentSetID indicates an expected call of GetCurrentSetID.
+func (mr *MockGrandpaStateMockRecorder) GetCurrentSetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentSetID", reflect.TypeOf((*MockGrandpaState)(nil).GetCurrentSetID))
+}
+
+// IncrementSetID mocks base method.
+func (m *MockGrandpaState) IncrementSetID() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementSetID")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementSetID indicates an expected call of IncrementSetID.
+func (mr *MockGrandpaStateMockRecorder) IncrementSetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementSetID", reflect.TypeOf((*MockGrandpaState)(nil).IncrementSetID))
+}
+
+// SetNextChange mocks base method.
+func (m *MockGrandpaState) SetNextChange(arg0 []types.GrandpaVoter, arg1 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNextChange", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNextChange indicates an expected call of SetNextChange.
+func (mr *MockGrandpaStateMockRecorder) SetNextChange(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNextChange", reflect.TypeOf((*MockGrandpaState)(nil).SetNextChange), arg0, arg1)
+}
+
+// MockEpochState is a mock of EpochState interface.
+type MockEpochState struct {
+	ctrl     *gomock.Controller
+	recorder *MockEpochStateMockRecorder
+}
+
+// MockEpochStateMockRecorder is the mock recorder for MockEpochState.
+type MockEpochStateMockRecorder struct {
+	mock *MockEpochState
+}
+
+// NewMockEpochState creates a new mock instance.
+func NewMockEpochState(ctrl *gomock.Controller) *MockEpochState {
+	mock := &MockEpochState{ctrl: ctrl}
+	mock.recorder = &MockEpochStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEpochState) EXPECT() *MockEpochStateMockRecorder {
+	return m.recorder
+}
+
+// SetStateSyncedEpochData mocks base method.
+func (m *MockEpochState) SetStateSyncedEpochData(arg0 *storage.TrieState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStateSyncedEpochData", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStateSyncedEpochData indicates an expected call of SetStateSyncedEpochData.
+func (mr *MockEpochStateMockRecorder) SetStateSyncedEpochData(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStateSyncedEpochData", reflect.TypeOf((*MockEpochState)(nil).SetStateSyncedEpochData), arg0)
+}
+
 // MockTransactionState is a mock of TransactionState interface.
 type MockTransactionState struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,674 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/common/variadic"
+	"github.com/ChainSafe/gossamer/lib/grandpa"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// stateSyncTargetDepth is the number of blocks below the sync target
+	// of the block whose state is downloaded, so the block is finalised.
+	stateSyncTargetDepth = 256
+
+	// stateSyncTargetPeers is the number of peers which must respond with
+	// the header of the block whose state is downloaded, and agree on it.
+	stateSyncTargetPeers = 3
+
+	// stateSyncJustificationPeriod is the period of the blocks whose justification is
+	// kept by Substrate nodes, so the state sync target block is a multiple of it
+	// and its finality can be verified with its justification.
+	stateSyncJustificationPeriod = 512
+
+	// maxStateRequestAttempts is the maximum number of consecutive failed
+	// state requests before the state sync is aborted.
+	maxStateRequestAttempts = 10
+)
+
+// CreateStateResponse creates a state response message with the key-value entries
+// of the state of the requested block, starting after the requested start key. If
+// a proof is requested, the response only holds the storage proof of these entries.
+func (s *Service) CreateStateResponse(from peer.ID, req *messages.StateRequest) (
+	*messages.StateResponse, error) {
+	logger.Debugf("state request from %s: %s", from, req)
+
+	// child trie entries are always sent complete, so the start
+	// key can only be a key of the top trie.
+	if len(req.Start) > 1 {
+		return nil, fmt.Errorf("%w: %d start keys", errInvalidStateRequest, len(req.Start))
+	}
+
+	header, err := s.blockState.GetHeader(req.Block)
+	if err != nil {
+		return nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	trieState, err := s.storageState.TrieState(&header.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	var start []byte
+	if len(req.Start) == 1 {
+		start = req.Start[0]
+	}
+
+	response, err := newStateResponse(trieState, start, messages.MaxStateResponseEntriesSize)
+	if err != nil {
+		return nil, fmt.Errorf("creating state response: %w", err)
+	}
+
+	if req.NoProof {
+		return response, nil
+	}
+
+	proof, err := s.newStateProof(header.StateRoot, response)
+	if err != nil {
+		return nil, fmt.Errorf("creating state proof: %w", err)
+	}
+
+	return &messages.StateResponse{Proof: proof}, nil
+}
+
+// newStateProof returns the encoded storage proof of the key-value entries of the
+// given state response, made of the trie nodes of the top trie and of the child tries.
+// Note the proof nodes are not compacted as in the Substrate compact proof format.
+func (s *Service) newStateProof(stateRoot common.Hash, response *messages.StateResponse) ([]byte, error) {
+	var proofNodes [][]byte
+	seen := make(map[string]struct{})
+	for _, entry := range response.Entries {
+		root := stateRoot
+		if !entry.StateRoot.IsEmpty() {
+			root = entry.StateRoot
+		}
+
+		keys := make([][]byte, len(entry.StateEntries))
+		for i, stateEntry := range entry.StateEntries {
+			keys[i] = stateEntry.Key
+		}
+
+		nodes, err := s.storageState.GenerateTrieProof(root, keys)
+		if err != nil {
+			return nil, fmt.Errorf("generating proof of trie with root %s: %w", root, err)
+		}
+
+		for _, node := range nodes {
+			if _, ok := seen[string(node)]; ok {
+				continue
+			}
+			seen[string(node)] = struct{}{}
+			proofNodes = append(proofNodes, node)
+		}
+	}
+
+	return scale.Marshal(proofNodes)
+}
+
+// newStateResponse creates a state response with the key-value entries of the top
+// trie following the start key, and the key-value entries of the child tries found
+// in the top trie entries, up to the given maximum entries size.
+func newStateResponse(trieState *rtstorage.TrieState, start []byte, maxSize int) (
+	*messages.StateResponse, error) {
+	topEntry := messages.KeyValueStateEntry{}
+	var childEntries []messages.KeyValueStateEntry
+	size := 0
+
+	key := start
+	if key == nil && trieState.Has([]byte{}) {
+		key = []byte{}
+	} else {
+		key = trieState.NextKey(key)
+	}
+
+	for ; key != nil; key = trieState.NextKey(key) {
+		if size >= maxSize {
+			break
+		}
+
+		value := trieState.Get(key)
+		topEntry.StateEntries = append(topEntry.StateEntries, trie.Entry{Key: key, Value: value})
+		size += len(key) + len(value)
+
+		if !bytes.HasPrefix(key, inmemory.ChildStorageKeyPrefix) {
+			continue
+		}
+
+		childEntry, err := newChildStateEntry(trieState, key[len(inmemory.ChildStorageKeyPrefix):])
+		if err != nil {
+			return nil, fmt.Errorf("creating child state entry: %w", err)
+		}
+		childEntries = append(childEntries, childEntry)
+		for _, entry := range childEntry.StateEntries {
+			size += len(entry.Key) + len(entry.Value)
+		}
+	}
+	topEntry.Complete = key == nil
+
+	return &messages.StateResponse{
+		Entries: append([]messages.KeyValueStateEntry{topEntry}, childEntries...),
+	}, nil
+}
+
+func newChildStateEntry(trieState *rtstorage.TrieState, keyToChild []byte) (
+	entry messages.KeyValueStateEntry, err error) {
+	entry.StateRoot, err = trieState.GetChildRoot(keyToChild)
+	if err != nil {
+		return entry, fmt.Errorf("getting child root: %w", err)
+	}
+
+	keys, err := trieState.GetKeysWithPrefixFromChild(keyToChild, nil)
+	if err != nil {
+		return entry, fmt.Errorf("getting child keys: %w", err)
+	}
+
+	entry.StateEntries = make(trie.Entries, len(keys))
+	for i, key := range keys {
+		value, err := trieState.GetChildStorage(keyToChild, key)
+		if err != nil {
+			return entry, fmt.Errorf("getting child storage: %w", err)
+		}
+		entry.StateEntries[i] = trie.Entry{Key: key, Value: value}
+	}
+	entry.Complete = true
+
+	return entry, nil
+}
+
+// shouldStateSync returns true if the state sync is enabled and the node
+// has not imported any block yet, so the state can be downloaded instead.
+func (cs *chainSync) shouldStateSync() (bool, error) {
+	if !cs.stateSync {
+		return false, nil
+	}
+
+	bestBlockNumber, err := cs.blockState.BestBlockNumber()
+	if err != nil {
+		return false, fmt.Errorf("getting best block number: %w", err)
+	}
+
+	return bestBlockNumber == 0, nil
+}
+
+// syncState downloads the state of a finalised block from the peers, verifies it
+// against the block state root and stores it, so the blocks are then only executed
+// from this block instead of from genesis.
+func (cs *chainSync) syncState() error {
+	syncTarget := cs.peerViewSet.getTarget()
+	if syncTarget <= stateSyncTargetDepth+stateSyncJustificationPeriod {
+		logger.Infof("not syncing state since the sync target #%d is too low", syncTarget)
+		return nil
+	}
+
+	startTime := time.Now()
+
+	// the authority set changes from the highest finalised block, whose authority set
+	// is trusted, are proven by warp sync proofs. The warp sync target is the highest
+	// finalised block proven, whereas the state sync target is a block below it whose
+	// justification is verified against the authority set proven at this block.
+	header, authoritySetChanges, err := cs.getWarpSyncTarget()
+	if err != nil {
+		return fmt.Errorf("getting warp sync target: %w", err)
+	}
+
+	var justification []byte
+	if !cs.warpSync {
+		targetNumber := min(syncTarget-stateSyncTargetDepth, header.Number)
+		targetNumber -= targetNumber % stateSyncJustificationPeriod
+		if targetNumber == 0 {
+			logger.Infof("not syncing state since the finalised block #%d is too low", header.Number)
+			return nil
+		}
+
+		setID, authorities, changes, err := cs.authoritySetAt(targetNumber, authoritySetChanges)
+		if err != nil {
+			return fmt.Errorf("getting authority set of block #%d: %w", targetNumber, err)
+		}
+		// the changes following the state sync target are imported with the blocks
+		authoritySetChanges = changes
+
+		var justificationPeer peer.ID
+		header, justification, justificationPeer, err = cs.getStateSyncTarget(targetNumber)
+		if err != nil {
+			return fmt.Errorf("getting state sync target: %w", err)
+		}
+
+		err = grandpa.VerifyStateSyncJustification(header, justification, setID, authorities)
+		if err != nil {
+			cs.network.ReportPeer(peerset.ReputationChange{
+				Value:  peerset.BadJustificationValue,
+				Reason: peerset.BadJustificationReason,
+			}, justificationPeer)
+			return fmt.Errorf("verifying justification of block #%d: %w", header.Number, err)
+		}
+	}
+
+	headerHash := header.Hash()
+	logger.Infof("⬇️ syncing state of block #%d (%s)", header.Number, headerHash)
+
+	topEntries, childEntries, err := cs.downloadState(headerHash, header.Number)
+	if err != nil {
+		return fmt.Errorf("downloading state: %w", err)
+	}
+
+	stateVersion, err := stateVersionFromEntries(topEntries)
+	if err != nil {
+		return fmt.Errorf("getting state version: %w", err)
+	}
+
+	stateTrie, err := newStateSyncTrie(topEntries, childEntries, stateVersion)
+	if err != nil {
+		return fmt.Errorf("building state trie: %w", err)
+	}
+
+	stateRoot, err := stateTrie.Hash()
+	if err != nil {
+		return fmt.Errorf("hashing state trie: %w", err)
+	}
+
+	if stateRoot != header.StateRoot {
+		return fmt.Errorf("%w: expected %s and got %s", errStateRootMismatch, header.StateRoot, stateRoot)
+	}
+
+	trieState := rtstorage.NewTrieState(stateTrie)
+
+	cs.storageState.Lock()
+	defer cs.storageState.Unlock()
+
+	// the journal record is stored before the state, so an interrupted
+	// state sync can only leave unused journal records, which are pruned.
+	err = cs.storageState.StoreJournalRecord(trieState, header)
+	if err != nil {
+		return fmt.Errorf("storing journal record: %w", err)
+	}
+
+	err = cs.blockState.SetStateSyncedFinalisedHeader(header, justification, trieState)
+	if err != nil {
+		return fmt.Errorf("setting finalised header: %w", err)
+	}
+
+	err = cs.epochState.SetStateSyncedEpochData(trieState)
+	if err != nil {
+		return fmt.Errorf("setting epoch data: %w", err)
+	}
+
+	// the authority set changes are only stored once the state is synced,
+	// so they are not stored twice when falling back to the full sync.
+	for _, change := range authoritySetChanges {
+		err = cs.grandpaState.SetNextChange(change.Authorities, change.Number)
+		if err != nil {
+			return fmt.Errorf("setting authority set change at block #%d: %w", change.Number, err)
+		}
+
+		_, err = cs.grandpaState.IncrementSetID()
+		if err != nil {
+			return fmt.Errorf("incrementing set id: %w", err)
+		}
+	}
+
+	logger.Infof("⛓️ synced state of block #%d (%s) with %d keys, took: %.2f seconds",
+		header.Number, headerHash, len(topEntries), time.Since(startTime).Seconds())
+	return nil
+}
+
+// authoritySetAt returns the authority set of the block with the given number, from the
+// current authority set and the given authority set changes following it, along with
+// the authority set changes enacted up to this block.
+func (cs *chainSync) authoritySetAt(number uint, changes []grandpa.AuthoritySetChange) (
+	setID uint64, authorities []types.GrandpaVoter, enacted []grandpa.AuthoritySetChange, err error) {
+	setID, err = cs.grandpaState.GetCurrentSetID()
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("getting current set id: %w", err)
+	}
+
+	authorities, err = cs.grandpaState.GetAuthorities(setID)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("getting authorities: %w", err)
+	}
+
+	for i, change := range changes {
+		// the change number is the number of the last block of the previous set
+		if change.Number >= number {
+			return setID, authorities, changes[:i], nil
+		}
+		setID = change.SetID
+		authorities = change.Authorities
+	}
+
+	return setID, authorities, changes, nil
+}
+
+// getWarpSyncTarget requests warp sync proofs from the peers, starting from the
+// highest finalised block, until a finished proof is received. It returns the
+// header of the last finalised block proven, and the authority set changes
+// proven until this block.
+func (cs *chainSync) getWarpSyncTarget() (header *types.Header,
+	changes []grandpa.AuthoritySetChange, err error) {
+	setID, err := cs.grandpaState.GetCurrentSetID()
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting current set id: %w", err)
+	}
+
+	authorities, err := cs.grandpaState.GetAuthorities(setID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting authorities: %w", err)
+	}
+
+	header, err = cs.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	failedAttempts := 0
+	peerIndex := 0
+	for {
+		select {
+		case <-cs.stopCh:
+			return nil, nil, errStateSyncStopped
+		default:
+		}
+
+		peers := cs.stateSyncPeers(header.Number + 1)
+		if len(peers) == 0 {
+			return nil, nil, errNoPeers
+		}
+
+		if failedAttempts == maxStateRequestAttempts {
+			return nil, nil, fmt.Errorf("%w: %d failed warp sync requests", errNoPeers, failedAttempts)
+		}
+
+		peerID := peers[peerIndex%len(peers)]
+		request := &messages.WarpSyncRequest{Begin: header.Hash()}
+		proof := new(messages.WarpSyncProof)
+		var proofChanges []grandpa.AuthoritySetChange
+		err = cs.warpSyncRequestMaker.Do(peerID, request, proof)
+		if err == nil {
+			proofChanges, err = grandpa.VerifyWarpSyncProof(proof, setID, authorities)
+			if err != nil {
+				cs.network.ReportPeer(peerset.ReputationChange{
+					Value:  peerset.BadJustificationValue,
+					Reason: peerset.BadJustificationReason,
+				}, peerID)
+			}
+		}
+
+		if err != nil {
+			logger.Debugf("requesting warp sync proof from peer %s: %s", peerID, err)
+			failedAttempts++
+			peerIndex++
+			continue
+		}
+		failedAttempts = 0
+
+		changes = append(changes, proofChanges...)
+		if len(proofChanges) > 0 {
+			lastChange := proofChanges[len(proofChanges)-1]
+			setID = lastChange.SetID
+			authorities = lastChange.Authorities
+		}
+
+		lastFragment := proof.Fragments[len(proof.Fragments)-1]
+		header = &lastFragment.Header
+		logger.Infof("⏩ warp synced to finalised block #%d (%s) with set id %d",
+			header.Number, header.Hash(), setID)
+
+		if proof.IsFinished {
+			return header, changes, nil
+		}
+	}
+}
+
+// getStateSyncTarget requests the header and the justification of the block with the
+// given number from several peers, and returns them if enough peers respond and they
+// all agree on the header, along with the peer which sent the justification returned.
+func (cs *chainSync) getStateSyncTarget(blockNumber uint) (header *types.Header,
+	justification []byte, justificationPeer peer.ID, err error) {
+	request := messages.NewBlockRequest(*variadic.MustNewUint32OrHash(uint32(blockNumber)), 1,
+		messages.RequestedDataHeader+messages.RequestedDataJustification, messages.Ascending)
+
+	responses := 0
+	for _, peerID := range cs.stateSyncPeers(blockNumber) {
+		if responses == stateSyncTargetPeers {
+			break
+		}
+
+		response := new(messages.BlockResponseMessage)
+		err = cs.requestMaker.Do(peerID, request, response)
+		if err != nil {
+			logger.Debugf("requesting header #%d from peer %s: %s", blockNumber, peerID, err)
+			continue
+		}
+
+		if len(response.BlockData) != 1 || response.BlockData[0].Header == nil ||
+			response.BlockData[0].Header.Number != blockNumber {
+			cs.network.ReportPeer(peerset.ReputationChange{
+				Value:  peerset.BadMessageValue,
+				Reason: peerset.BadMessageReason,
+			}, peerID)
+			continue
+		}
+
+		responseHeader := response.BlockData[0].Header
+		if header != nil && header.Hash() != responseHeader.Hash() {
+			return nil, nil, "", fmt.Errorf("%w: block #%d has hashes %s and %s",
+				errStateSyncTargetMismatch, blockNumber, header.Hash(), responseHeader.Hash())
+		}
+
+		header = responseHeader
+		responses++
+
+		responseJustification := response.BlockData[0].Justification
+		if justification == nil && responseJustification != nil && len(*responseJustification) > 0 {
+			justification = *responseJustification
+			justificationPeer = peerID
+		}
+	}
+
+	if responses < stateSyncTargetPeers {
+		return nil, nil, "", fmt.Errorf("%w: %d of %d peers responded for header #%d",
+			errNoPeers, responses, stateSyncTargetPeers, blockNumber)
+	}
+
+	if justification == nil {
+		return nil, nil, "", fmt.Errorf("%w: block #%d", errNilJustificationInResponse, blockNumber)
+	}
+
+	return header, justification, justificationPeer, nil
+}
+
+// stateSyncPeers returns the peers whose best block number is greater
+// or equal to the given block number.
+func (cs *chainSync) stateSyncPeers(blockNumber uint) (peers []peer.ID) {
+	for _, view := range cs.peerViewSet.values() {
+		if view.number >= blockNumber {
+			peers = append(peers, view.who)
+		}
+	}
+	return peers
+}
+
+// downloadState requests the state of the given block from the peers, page by page,
+// and returns the key-value entries of the top trie and of each child trie by root.
+func (cs *chainSync) downloadState(blockHash common.Hash, blockNumber uint) (
+	topEntries trie.Entries, childEntries map[common.Hash]trie.Entries, err error) {
+	childEntries = make(map[common.Hash]trie.Entries)
+
+	var lastKey []byte
+	failedAttempts := 0
+	peerIndex := 0
+	for {
+		select {
+		case <-cs.stopCh:
+			return nil, nil, errStateSyncStopped
+		default:
+		}
+
+		peers := cs.stateSyncPeers(blockNumber)
+		if len(peers) == 0 {
+			return nil, nil, errNoPeers
+		}
+
+		if failedAttempts == maxStateRequestAttempts {
+			return nil, nil, fmt.Errorf("%w: %d failed state requests", errNoPeers, failedAttempts)
+		}
+
+		peerID := peers[peerIndex%len(peers)]
+		request := &messages.StateRequest{
+			Block:   blockHash,
+			NoProof: true,
+		}
+		if lastKey != nil {
+			request.Start = [][]byte{lastKey}
+		}
+
+		response := new(messages.StateResponse)
+		err = cs.stateRequestMaker.Do(peerID, request, response)
+		if err == nil {
+			err = validateStateResponse(response, lastKey)
+			if err != nil {
+				cs.network.ReportPeer(peerset.ReputationChange{
+					Value:  peerset.BadMessageValue,
+					Reason: peerset.BadMessageReason,
+				}, peerID)
+			}
+		}
+
+		if err != nil {
+			logger.Debugf("requesting state from peer %s: %s", peerID, err)
+			failedAttempts++
+			peerIndex++
+			continue
+		}
+		failedAttempts = 0
+
+		topEntry := response.Entries[0]
+		topEntries = append(topEntries, topEntry.StateEntries...)
+		for _, childEntry := range response.Entries[1:] {
+			childEntries[childEntry.StateRoot] = childEntry.StateEntries
+		}
+
+		if topEntry.Complete {
+			return topEntries, childEntries, nil
+		}
+
+		lastKey = topEntry.StateEntries[len(topEntry.StateEntries)-1].Key
+		logger.Debugf("downloaded %d state entries so far, last key 0x%x", len(topEntries), lastKey)
+	}
+}
+
+// validateStateResponse checks the state response contains the top trie entries
+// in ascending key order after the last key received, and complete child tries.
+func validateStateResponse(response *messages.StateResponse, lastKey []byte) error {
+	if len(response.Entries) == 0 {
+		return fmt.Errorf("%w: no entries", errInvalidStateResponse)
+	}
+
+	topEntry := response.Entries[0]
+	if !topEntry.StateRoot.IsEmpty() {
+		return fmt.Errorf("%w: top trie entry has state root %s", errInvalidStateResponse, topEntry.StateRoot)
+	}
+
+	if !topEntry.Complete && len(topEntry.StateEntries) == 0 {
+		return fmt.Errorf("%w: incomplete top trie entry is empty", errInvalidStateResponse)
+	}
+
+	previousKey := lastKey
+	for _, entry := range topEntry.StateEntries {
+		if previousKey != nil && bytes.Compare(entry.Key, previousKey) <= 0 {
+			return fmt.Errorf("%w: key 0x%x is not after key 0x%x",
+				errInvalidStateResponse, entry.Key, previousKey)
+		}
+		previousKey = entry.Key
+	}
+
+	for _, childEntry := range response.Entries[1:] {
+		if childEntry.StateRoot.IsEmpty() || !childEntry.Complete {
+			return fmt.Errorf("%w: child trie entry is incomplete", errInvalidStateResponse)
+		}
+	}
+
+	return nil
+}
+
+// newStateSyncTrie builds the state trie with the given state version from the given
+// top trie and child tries key-value entries. Each child trie root is verified against
+// the root stored in the top trie.
+func newStateSyncTrie(topEntries trie.Entries, childEntries map[common.Hash]trie.Entries,
+	stateVersion trie.TrieLayout) (stateTrie *inmemory.InMemoryTrie, err error) {
+	stateTrie = inmemory.NewEmptyTrie()
+	stateTrie.SetVersion(stateVersion)
+
+	for _, entry := range topEntries {
+		if !bytes.HasPrefix(entry.Key, inmemory.ChildStorageKeyPrefix) {
+			err = stateTrie.Put(entry.Key, entry.Value)
+			if err != nil {
+				return nil, fmt.Errorf("putting key 0x%x: %w", entry.Key, err)
+			}
+			continue
+		}
+
+		childRoot := common.BytesToHash(entry.Value)
+		entries, ok := childEntries[childRoot]
+		if !ok {
+			return nil, fmt.Errorf("%w: for key 0x%x", errMissingChildTrie, entry.Key)
+		}
+
+		childTrie := inmemory.NewEmptyTrie()
+		childTrie.SetVersion(stateVersion)
+		for _, childEntry := range entries {
+			err = childTrie.Put(childEntry.Key, childEntry.Value)
+			if err != nil {
+				return nil, fmt.Errorf("putting child key 0x%x: %w", childEntry.Key, err)
+			}
+		}
+
+		err = stateTrie.SetChild(entry.Key[len(inmemory.ChildStorageKeyPrefix):], childTrie)
+		if err != nil {
+			return nil, fmt.Errorf("setting child trie: %w", err)
+		}
+
+		if !bytes.Equal(stateTrie.Get(entry.Key), entry.Value) {
+			return nil, fmt.Errorf("%w: for child trie 0x%x", errStateRootMismatch, entry.Key)
+		}
+	}
+
+	return stateTrie, nil
+}
+
+// stateVersionFromEntries returns the state version of the runtime
+// code found in the given top trie key-value entries.
+func stateVersionFromEntries(entries trie.Entries) (stateVersion trie.TrieLayout, err error) {
+	for _, entry := range entries {
+		if !bytes.Equal(entry.Key, common.CodeKey) {
+			continue
+		}
+
+		version, err := wazero_runtime.GetRuntimeVersion(entry.Value)
+		if err != nil {
+			return stateVersion, fmt.Errorf("getting runtime version: %w", err)
+		}
+
+		stateVersion, err = trie.ParseVersion(version.StateVersion)
+		if err != nil {
+			return stateVersion, fmt.Errorf("parsing state version: %w", err)
+		}
+		return stateVersion, nil
+	}
+
+	return stateVersion, errMissingRuntimeCode
+}
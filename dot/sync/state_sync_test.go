@@ -0,0 +1,382 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestStateSyncTrie(t *testing.T) *inmemory.InMemoryTrie {
+	t.Helper()
+
+	stateTrie := inmemory.NewEmptyTrie()
+	for _, key := range []string{"a", "b", "c", "d"} {
+		err := stateTrie.Put([]byte(key), []byte(key+"_value"))
+		require.NoError(t, err)
+	}
+
+	childTrie := inmemory.NewEmptyTrie()
+	err := childTrie.Put([]byte("x"), []byte{1})
+	require.NoError(t, err)
+	err = childTrie.Put([]byte("y"), []byte{2})
+	require.NoError(t, err)
+	err = stateTrie.SetChild([]byte("child"), childTrie)
+	require.NoError(t, err)
+
+	return stateTrie
+}
+
+func Test_Service_CreateStateResponse(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	blockHash := common.Hash{1}
+	stateRoot := common.Hash{2}
+	trieState := rtstorage.NewTrieState(newTestStateSyncTrie(t))
+	childRoot, err := trieState.GetChildRoot([]byte("child"))
+	require.NoError(t, err)
+	childKey := append(append([]byte{}, inmemory.ChildStorageKeyPrefix...), []byte("child")...)
+
+	testCases := map[string]struct {
+		serviceBuilder func(ctrl *gomock.Controller) *Service
+		request        *messages.StateRequest
+		response       *messages.StateResponse
+		errWrapped     error
+		errMessage     string
+	}{
+		"proof_requested": {
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeader(blockHash).Return(&types.Header{StateRoot: stateRoot}, nil)
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().TrieState(&stateRoot).Return(trieState, nil)
+				storageState.EXPECT().GenerateTrieProof(stateRoot, [][]byte{
+					childKey, []byte("a"), []byte("b"), []byte("c"), []byte("d"),
+				}).Return([][]byte{{1}, {2}}, nil)
+				storageState.EXPECT().GenerateTrieProof(childRoot, [][]byte{[]byte("x"), []byte("y")}).
+					Return([][]byte{{2}, {3}}, nil)
+				return &Service{blockState: blockState, storageState: storageState}
+			},
+			request: &messages.StateRequest{Block: blockHash},
+			response: &messages.StateResponse{
+				Proof: scale.MustMarshal([][]byte{{1}, {2}, {3}}),
+			},
+		},
+		"generate_proof_error": {
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeader(blockHash).Return(&types.Header{StateRoot: stateRoot}, nil)
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().TrieState(&stateRoot).Return(trieState, nil)
+				storageState.EXPECT().GenerateTrieProof(stateRoot, gomock.Any()).Return(nil, errTest)
+				return &Service{blockState: blockState, storageState: storageState}
+			},
+			request:    &messages.StateRequest{Block: blockHash},
+			errWrapped: errTest,
+			errMessage: "creating state proof: generating proof of trie with root " +
+				"0x0200000000000000000000000000000000000000000000000000000000000000: test error",
+		},
+		"child_start_key": {
+			serviceBuilder: func(_ *gomock.Controller) *Service { return &Service{} },
+			request: &messages.StateRequest{
+				Block:   blockHash,
+				Start:   [][]byte{{1}, {2}},
+				NoProof: true,
+			},
+			errWrapped: errInvalidStateRequest,
+			errMessage: "invalid state request: 2 start keys",
+		},
+		"get_header_error": {
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeader(blockHash).Return(nil, errTest)
+				return &Service{blockState: blockState}
+			},
+			request:    &messages.StateRequest{Block: blockHash, NoProof: true},
+			errWrapped: errTest,
+			errMessage: "getting header: test error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			service := testCase.serviceBuilder(ctrl)
+
+			response, err := service.CreateStateResponse(peer.ID("peer"), testCase.request)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.response, response)
+		})
+	}
+}
+
+func Test_newStateResponse(t *testing.T) {
+	t.Parallel()
+
+	stateTrie := newTestStateSyncTrie(t)
+	trieState := rtstorage.NewTrieState(stateTrie)
+	childRoot, err := trieState.GetChildRoot([]byte("child"))
+	require.NoError(t, err)
+	childKey := append(append([]byte{}, inmemory.ChildStorageKeyPrefix...), []byte("child")...)
+
+	t.Run("first_page_with_child_trie", func(t *testing.T) {
+		t.Parallel()
+
+		response, err := newStateResponse(trieState, nil, 1)
+		require.NoError(t, err)
+
+		expected := &messages.StateResponse{
+			Entries: []messages.KeyValueStateEntry{{
+				StateEntries: trie.Entries{
+					{Key: childKey, Value: childRoot[:]},
+				},
+			}, {
+				StateRoot: childRoot,
+				StateEntries: trie.Entries{
+					{Key: []byte("x"), Value: []byte{1}},
+					{Key: []byte("y"), Value: []byte{2}},
+				},
+				Complete: true,
+			}},
+		}
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("last_page", func(t *testing.T) {
+		t.Parallel()
+
+		response, err := newStateResponse(trieState, []byte("b"), 1024)
+		require.NoError(t, err)
+
+		expected := &messages.StateResponse{
+			Entries: []messages.KeyValueStateEntry{{
+				StateEntries: trie.Entries{
+					{Key: []byte("c"), Value: []byte("c_value")},
+					{Key: []byte("d"), Value: []byte("d_value")},
+				},
+				Complete: true,
+			}},
+		}
+		assert.Equal(t, expected, response)
+	})
+}
+
+func Test_validateStateResponse(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		response   *messages.StateResponse
+		lastKey    []byte
+		errWrapped error
+		errMessage string
+	}{
+		"no_entries": {
+			response:   &messages.StateResponse{},
+			errWrapped: errInvalidStateResponse,
+			errMessage: "invalid state response: no entries",
+		},
+		"top_entry_with_state_root": {
+			response: &messages.StateResponse{
+				Entries: []messages.KeyValueStateEntry{{StateRoot: common.Hash{1}}},
+			},
+			errWrapped: errInvalidStateResponse,
+			errMessage: "invalid state response: top trie entry has state root " +
+				"0x0100000000000000000000000000000000000000000000000000000000000000",
+		},
+		"empty_incomplete_top_entry": {
+			response: &messages.StateResponse{
+				Entries: []messages.KeyValueStateEntry{{}},
+			},
+			errWrapped: errInvalidStateResponse,
+			errMessage: "invalid state response: incomplete top trie entry is empty",
+		},
+		"key_not_after_last_key": {
+			response: &messages.StateResponse{
+				Entries: []messages.KeyValueStateEntry{{
+					StateEntries: trie.Entries{{Key: []byte{1}}},
+				}},
+			},
+			lastKey:    []byte{1},
+			errWrapped: errInvalidStateResponse,
+			errMessage: "invalid state response: key 0x01 is not after key 0x01",
+		},
+		"incomplete_child_entry": {
+			response: &messages.StateResponse{
+				Entries: []messages.KeyValueStateEntry{
+					{Complete: true},
+					{StateRoot: common.Hash{1}},
+				},
+			},
+			errWrapped: errInvalidStateResponse,
+			errMessage: "invalid state response: child trie entry is incomplete",
+		},
+		"valid": {
+			response: &messages.StateResponse{
+				Entries: []messages.KeyValueStateEntry{
+					{StateEntries: trie.Entries{{Key: []byte{2}}, {Key: []byte{3}}}},
+					{StateRoot: common.Hash{1}, Complete: true},
+				},
+			},
+			lastKey: []byte{1},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateStateResponse(testCase.response, testCase.lastKey)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
+
+func Test_newStateSyncTrie(t *testing.T) {
+	t.Parallel()
+
+	expectedTrie := newTestStateSyncTrie(t)
+	expectedRoot := expectedTrie.MustHash()
+
+	trieState := rtstorage.NewTrieState(expectedTrie)
+	response, err := newStateResponse(trieState, nil, messages.MaxStateResponseEntriesSize)
+	require.NoError(t, err)
+
+	topEntries := response.Entries[0].StateEntries
+	childEntries := make(map[common.Hash]trie.Entries)
+	for _, entry := range response.Entries[1:] {
+		childEntries[entry.StateRoot] = entry.StateEntries
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		stateTrie, err := newStateSyncTrie(topEntries, childEntries, trie.V0)
+		require.NoError(t, err)
+		assert.Equal(t, expectedRoot, stateTrie.MustHash())
+	})
+
+	t.Run("missing_child_trie", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newStateSyncTrie(topEntries, nil, trie.V0)
+		assert.ErrorIs(t, err, errMissingChildTrie)
+	})
+}
+
+func Test_chainSync_getStateSyncTarget(t *testing.T) {
+	t.Parallel()
+
+	header := &types.Header{Number: 512}
+	otherHeader := &types.Header{Number: 512, StateRoot: common.Hash{1}}
+	justification := []byte{1, 2, 3}
+	peers := []peer.ID{"peer1", "peer2", "peer3"}
+
+	testCases := map[string]struct {
+		blockData         map[peer.ID]*types.BlockData
+		justification     []byte
+		justificationPeer peer.ID
+		errWrapped        error
+		errMessage        string
+	}{
+		"not_enough_peers": {
+			blockData: map[peer.ID]*types.BlockData{
+				"peer1": {Header: header, Justification: &justification},
+			},
+			errWrapped: errNoPeers,
+			errMessage: "no peers to sync with: 1 of 3 peers responded for header #512",
+		},
+		"peers_disagree": {
+			blockData: map[peer.ID]*types.BlockData{
+				"peer1": {Header: header, Justification: &justification},
+				"peer2": {Header: otherHeader},
+			},
+			errWrapped: errStateSyncTargetMismatch,
+			errMessage: "peers disagree on state sync target: block #512 has hashes " +
+				header.Hash().String() + " and " + otherHeader.Hash().String(),
+		},
+		"missing_justification": {
+			blockData: map[peer.ID]*types.BlockData{
+				"peer1": {Header: header},
+				"peer2": {Header: header},
+				"peer3": {Header: header},
+			},
+			errWrapped: errNilJustificationInResponse,
+			errMessage: "expected justification, received none: block #512",
+		},
+		"header_with_justification": {
+			blockData: map[peer.ID]*types.BlockData{
+				"peer1": {Header: header},
+				"peer2": {Header: header, Justification: &justification},
+				"peer3": {Header: header, Justification: &justification},
+			},
+			justification:     justification,
+			justificationPeer: peer.ID("peer2"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			requestMaker := NewMockRequestMaker(ctrl)
+			requestMaker.EXPECT().Do(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(peerID peer.ID, _ *messages.BlockRequestMessage,
+					response *messages.BlockResponseMessage) error {
+					blockData, ok := testCase.blockData[peerID]
+					if !ok {
+						return errors.New("no response")
+					}
+					response.BlockData = []*types.BlockData{blockData}
+					return nil
+				}).AnyTimes()
+
+			cs := &chainSync{
+				peerViewSet:  newPeerViewSet(len(peers)),
+				requestMaker: requestMaker,
+			}
+			for _, peerID := range peers {
+				cs.peerViewSet.update(peerID, common.Hash{1}, 1000)
+			}
+
+			targetHeader, targetJustification, justificationPeer, err := cs.getStateSyncTarget(512)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				assert.Nil(t, targetHeader)
+			} else {
+				assert.Equal(t, header, targetHeader)
+			}
+			assert.Equal(t, testCase.justification, targetJustification)
+			assert.Equal(t, testCase.justificationPeer, justificationPeer)
+		})
+	}
+}
@@ -23,9 +23,10 @@
 
 // Service deals with chain syncing by sending block request messages and watching for responses.
 type Service struct {
-	blockState BlockState
-	chainSync  ChainSync
-	network    Network
+	blockState   BlockState
+	storageState StorageState
+	chainSync    ChainSync
+	network      Network
 
 	seenBlockSyncRequests *lrucache.LRUCache[common.Hash, uint]
 }
@@ -50,6 +51,16 @@ type Config struct {
 	Telemetry          Telemetry
 	BadBlocks          []string
 	RequestMaker       network.RequestMaker
+	// StateSync enables downloading the state of a recent finalised
+	// block instead of executing every block from genesis.
+	StateSync         bool
+	StateRequestMaker network.RequestMaker
+	// WarpSync enables proving the state sync target block with
+	// the GRANDPA authority set changes, and requires StateSync.
+	WarpSync             bool
+	WarpSyncRequestMaker network.RequestMaker
+	GrandpaState         GrandpaState
+	EpochState           EpochState
 }
 
 // NewService returns a new *sync.Service
@@ -74,11 +85,19 @@ func NewService(cfg *Config) (*Service, error) {
 		badBlocks:          cfg.BadBlocks,
 		requestMaker:       cfg.RequestMaker,
 		waitPeersDuration:  100 * time.Millisecond,
+		stateSync:          cfg.StateSync,
+		stateRequestMaker:  cfg.StateRequestMaker,
+
+		warpSync:             cfg.WarpSync,
+		warpSyncRequestMaker: cfg.WarpSyncRequestMaker,
+		grandpaState:         cfg.GrandpaState,
+		epochState:           cfg.EpochState,
 	}
 	chainSync := newChainSync(csCfg)
 
 	return &Service{
 		blockState:            cfg.BlockState,
+		storageState:          cfg.StorageState,
 		chainSync:             chainSync,
 		network:               cfg.Network,
 		seenBlockSyncRequests: lrucache.NewLRUCache[common.Hash, uint](100),
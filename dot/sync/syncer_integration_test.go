@@ -7,12 +7,10 @@
 
 import (
 	"errors"
-	"path/filepath"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
-	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
@@ -70,13 +68,6 @@ func newTestSyncer(t *testing.T) *Service {
 		LogLvl:  log.Critical,
 	}
 
-	if stateSrvc != nil {
-		rtCfg.NodeStorage.BaseDB = stateSrvc.Base
-	} else {
-		rtCfg.NodeStorage.BaseDB, err = database.LoadDatabase(filepath.Join(testDatadirPath, "offline_storage"), false)
-		require.NoError(t, err)
-	}
-
 	rtCfg.CodeHash, err = cfg.StorageState.(*state.InmemoryStorageState).LoadCodeHash(nil)
 	require.NoError(t, err)
 
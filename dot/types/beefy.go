@@ -0,0 +1,112 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package types
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// BeefyAuthorityID is the compressed ECDSA public key of a BEEFY authority
+type BeefyAuthorityID [33]byte
+
+// BeefyValidatorSet is a set of BEEFY authorities with its id
+type BeefyValidatorSet struct {
+	Validators []BeefyAuthorityID
+	ID         uint64
+}
+
+func (v BeefyValidatorSet) String() string {
+	return fmt.Sprintf("BeefyValidatorSet{Validators=%d, ID=%d}", len(v.Validators), v.ID)
+}
+
+// BeefyOnDisabled represents a BEEFY authority being disabled
+type BeefyOnDisabled struct {
+	ID uint32
+}
+
+func (b BeefyOnDisabled) String() string {
+	return fmt.Sprintf("BeefyOnDisabled{ID=%d}", b.ID)
+}
+
+// BeefyMmrRoot represents the MMR root of the block, which is the BEEFY payload voted on
+type BeefyMmrRoot struct {
+	Root common.Hash
+}
+
+func (b BeefyMmrRoot) String() string {
+	return fmt.Sprintf("BeefyMmrRoot{Root=%s}", b.Root)
+}
+
+type BeefyConsensusDigestValues interface {
+	BeefyValidatorSet | BeefyOnDisabled | BeefyMmrRoot
+}
+
+type BeefyConsensusDigest struct {
+	inner any
+}
+
+func setBeefyConsensusDigest[Value BeefyConsensusDigestValues](mvdt *BeefyConsensusDigest, value Value) {
+	mvdt.inner = value
+}
+
+func (mvdt *BeefyConsensusDigest) SetValue(value any) (err error) {
+	switch value := value.(type) {
+	case BeefyValidatorSet:
+		setBeefyConsensusDigest(mvdt, value)
+		return
+
+	case BeefyOnDisabled:
+		setBeefyConsensusDigest(mvdt, value)
+		return
+
+	case BeefyMmrRoot:
+		setBeefyConsensusDigest(mvdt, value)
+		return
+
+	default:
+		return fmt.Errorf("unsupported type")
+	}
+}
+
+func (mvdt BeefyConsensusDigest) IndexValue() (index uint, value any, err error) {
+	switch mvdt.inner.(type) {
+	case BeefyValidatorSet:
+		return 1, mvdt.inner, nil
+
+	case BeefyOnDisabled:
+		return 2, mvdt.inner, nil
+
+	case BeefyMmrRoot:
+		return 3, mvdt.inner, nil
+
+	}
+	return 0, nil, scale.ErrUnsupportedVaryingDataTypeValue
+}
+
+func (mvdt BeefyConsensusDigest) Value() (value any, err error) {
+	_, value, err = mvdt.IndexValue()
+	return
+}
+func (mvdt BeefyConsensusDigest) ValueAt(index uint) (value any, err error) {
+	switch index {
+	case 1:
+		return *new(BeefyValidatorSet), nil
+
+	case 2:
+		return *new(BeefyOnDisabled), nil
+
+	case 3:
+		return *new(BeefyMmrRoot), nil
+
+	}
+	return nil, scale.ErrUnknownVaryingDataTypeValue
+}
+
+// NewBeefyConsensusDigest constructs a vdt representing a beefy consensus digest
+func NewBeefyConsensusDigest() BeefyConsensusDigest {
+	return BeefyConsensusDigest{}
+}
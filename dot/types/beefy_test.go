@@ -0,0 +1,60 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package types
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeefyConsensusDigest(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value   any
+		encoded []byte
+	}{
+		"authorities_change": {
+			value: BeefyValidatorSet{
+				Validators: []BeefyAuthorityID{{2, 1}},
+				ID:         7,
+			},
+			encoded: common.MustHexToBytes("0x0104020100000000000000000000000000000000000000000000000000000000000000" +
+				"0700000000000000"),
+		},
+		"on_disabled": {
+			value:   BeefyOnDisabled{ID: 3},
+			encoded: []byte{2, 3, 0, 0, 0},
+		},
+		"mmr_root": {
+			value:   BeefyMmrRoot{Root: common.Hash{1}},
+			encoded: append([]byte{3, 1}, make([]byte, 31)...),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			digest := NewBeefyConsensusDigest()
+			err := digest.SetValue(tt.value)
+			require.NoError(t, err)
+
+			encoded, err := scale.Marshal(digest)
+			require.NoError(t, err)
+			require.Equal(t, tt.encoded, encoded)
+
+			decoded := NewBeefyConsensusDigest()
+			err = scale.Unmarshal(encoded, &decoded)
+			require.NoError(t, err)
+			value, err := decoded.Value()
+			require.NoError(t, err)
+			require.Equal(t, tt.value, value)
+		})
+	}
+}
@@ -143,6 +143,9 @@ func (h ConsensusEngineID) String() string {
 // GrandpaEngineID is the hard-coded grandpa ID
 var GrandpaEngineID = ConsensusEngineID{'F', 'R', 'N', 'K'}
 
+// BeefyEngineID is the hard-coded beefy ID
+var BeefyEngineID = ConsensusEngineID{'B', 'E', 'E', 'F'}
+
 // PreRuntimeDigest contains messages from the consensus engine to the runtime.
 type PreRuntimeDigest digestItem
 
@@ -103,15 +103,17 @@ func NewGrandpaVotersFromAuthorities(ad []Authority) []GrandpaVoter {
 func NewGrandpaVotersFromAuthoritiesRaw(ad []GrandpaAuthoritiesRaw) ([]GrandpaVoter, error) {
 	v := make([]GrandpaVoter, len(ad))
 
-	for i, d := range ad {
-		key, err := ed25519.NewPublicKey(d.Key[:])
+	for i := range ad {
+		// the key is sliced from the slice element and not from a
+		// loop variable copy, so the voter keys do not share memory.
+		key, err := ed25519.NewPublicKey(ad[i].Key[:])
 		if err != nil {
 			return nil, err
 		}
 
 		v[i] = GrandpaVoter{
 			Key: *key,
-			ID:  d.ID,
+			ID:  ad[i].ID,
 		}
 	}
 
@@ -170,6 +172,9 @@ type FinalisationInfo struct {
 	Header Header
 	Round  uint64
 	SetID  uint64
+	// StaleHeaders are the headers of the blocks pruned from the block tree
+	// by the finalisation, since they are not descendants of the finalised block.
+	StaleHeaders []Header
 }
 
 // GrandpaSignedVote represents a signed precommit message for a finalised block
@@ -155,3 +155,21 @@ func TestGrandpaAuthoritiesRawToAuthorities(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, authority, authorities[1])
 }
+
+func TestNewGrandpaVotersFromAuthoritiesRaw(t *testing.T) {
+	t.Parallel()
+
+	auths := []GrandpaAuthoritiesRaw{
+		{Key: [32]byte{1}, ID: 0},
+		{Key: [32]byte{2}, ID: 1},
+	}
+
+	voters, err := NewGrandpaVotersFromAuthoritiesRaw(auths)
+	require.NoError(t, err)
+
+	require.Len(t, voters, 2)
+	for i, voter := range voters {
+		require.Equal(t, auths[i].Key[:], voter.Key.Encode())
+		require.Equal(t, auths[i].ID, voter.ID)
+	}
+}
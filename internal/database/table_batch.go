@@ -14,6 +14,15 @@ type tableBatch struct {
 	prefix []byte
 }
 
+// NewTableBatch returns a batch writing the keys prefixed with the given table prefix
+// in the given database batch, so the writes to several tables are flushed atomically.
+func NewTableBatch(batch Batch, prefix string) Batch {
+	return &tableBatch{
+		batch:  batch,
+		prefix: []byte(prefix),
+	}
+}
+
 func (tb *tableBatch) Put(key, value []byte) error {
 	tableItemKey := bytes.Join([][]byte{tb.prefix, key}, nil)
 	return tb.batch.Put(tableItemKey, value)
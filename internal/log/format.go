@@ -3,10 +3,43 @@
 
 package log
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 // Format is the format to use.
 type Format uint8
 
 const (
 	// FormatConsole is the default human readable console format.
 	FormatConsole Format = iota
+	// FormatJSON is the JSON format, writing one JSON object per line.
+	FormatJSON
 )
+
+func (format Format) String() (s string) {
+	switch format {
+	case FormatConsole:
+		return "console"
+	case FormatJSON:
+		return "json"
+	default:
+		return "???"
+	}
+}
+
+var ErrFormatNotRecognised = errors.New("format is not recognised")
+
+// ParseFormat parses a string into a format, and returns an
+// error if it fails. It accepts 'console' and 'json'.
+func ParseFormat(s string) (format Format, err error) {
+	switch strings.ToLower(s) {
+	case FormatConsole.String():
+		return FormatConsole, nil
+	case FormatJSON.String():
+		return FormatJSON, nil
+	}
+	return 0, fmt.Errorf("%w: %s", ErrFormatNotRecognised, s)
+}
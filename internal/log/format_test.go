@@ -0,0 +1,52 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package log
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseFormat(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		s      string
+		format Format
+		err    error
+	}{
+		"console": {
+			s:      "console",
+			format: FormatConsole,
+		},
+		"json": {
+			s:      "JSON",
+			format: FormatJSON,
+		},
+		"invalid": {
+			s:   "xml",
+			err: errors.New("format is not recognised: xml"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			format, err := ParseFormat(testCase.s)
+
+			if testCase.err != nil {
+				require.EqualError(t, err, testCase.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.format, format)
+		})
+	}
+}
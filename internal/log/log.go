@@ -4,6 +4,7 @@
 package log
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -16,7 +17,8 @@ func (l *Logger) log(logLevel Level, s string, args ...interface{}) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
 
-	if *l.settings.level < logLevel {
+	module := l.settings.module()
+	if l.settings.levelFor(module) < logLevel {
 		return
 	}
 
@@ -24,9 +26,22 @@ func (l *Logger) log(logLevel Level, s string, args ...interface{}) {
 		s = fmt.Sprintf(s, args...)
 	}
 
-	line := time.Now().Format(time.RFC3339) + " " + logLevel.format() + " " + s
-
 	callerString := getCallerString(l.settings.caller)
+
+	var line string
+	if l.settings.format != nil && *l.settings.format == FormatJSON {
+		line = l.jsonLine(logLevel, module, callerString, s)
+	} else {
+		line = l.consoleLine(logLevel, callerString, s)
+	}
+
+	line += "\n"
+	_, _ = io.WriteString(l.settings.writer, line)
+}
+
+func (l *Logger) consoleLine(logLevel Level, callerString, s string) (line string) {
+	line = time.Now().Format(time.RFC3339) + " " + logLevel.format() + " " + s
+
 	if callerString != "" {
 		line += "\t" + color.HiWhiteString(callerString)
 	}
@@ -41,8 +56,44 @@ func (l *Logger) log(logLevel Level, s string, args ...interface{}) {
 		line += "\t" + strings.Join(keyValues, " ")
 	}
 
-	line += "\n"
-	_, _ = io.WriteString(l.settings.writer, line)
+	return line
+}
+
+// jsonLogLine is the JSON object written for each log line
+// when using the JSON format.
+type jsonLogLine struct {
+	Timestamp string            `json:"timestamp"`
+	Level     string            `json:"level"`
+	Module    string            `json:"module,omitempty"`
+	Caller    string            `json:"caller,omitempty"`
+	Message   string            `json:"message"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
+func (l *Logger) jsonLine(logLevel Level, module, callerString, s string) (line string) {
+	logLine := jsonLogLine{
+		Timestamp: time.Now().Format(time.RFC3339Nano),
+		Level:     logLevel.String(),
+		Module:    module,
+		Caller:    callerString,
+		Message:   s,
+	}
+
+	for _, kvs := range l.settings.context {
+		if kvs.key == "pkg" {
+			continue
+		}
+		if logLine.Fields == nil {
+			logLine.Fields = make(map[string]string, len(l.settings.context))
+		}
+		logLine.Fields[kvs.key] = strings.Join(kvs.values, ",")
+	}
+
+	data, err := json.Marshal(logLine)
+	if err != nil { // only string values are encoded so this should never happen
+		return fmt.Sprintf(`{"level":"ERROR","message":"encoding log line: %s"}`, err)
+	}
+	return string(data)
 }
 
 // Trace logs with the trce level.
@@ -101,6 +101,69 @@ func Test_Logger_log(t *testing.T) {
 			s:           "some words",
 			outputRegex: timePrefixRegex + "TRACE    some words\tkey1=a,b key2=c,d\n$",
 		},
+		"module_level_override": {
+			logger: &Logger{
+				settings: settings{
+					level:  levelPtr(Info),
+					caller: newCallerSettings(false, false, false),
+					context: []contextKeyValues{
+						{key: "pkg", values: []string{"sync"}},
+					},
+					moduleLevels: map[string]Level{"sync": Trace, "": Error},
+				},
+				mutex: new(sync.Mutex),
+			},
+			level:       Trace,
+			s:           "some words",
+			outputRegex: timePrefixRegex + "TRACE    some words\tpkg=sync\n$",
+		},
+		"all_modules_level_override": {
+			logger: &Logger{
+				settings: settings{
+					level:  levelPtr(Trace),
+					caller: newCallerSettings(false, false, false),
+					context: []contextKeyValues{
+						{key: "pkg", values: []string{"core"}},
+					},
+					moduleLevels: map[string]Level{"sync": Trace, "": Error},
+				},
+				mutex: new(sync.Mutex),
+			},
+			level:       Info,
+			s:           "some words",
+			outputRegex: "^$",
+		},
+		"json": {
+			logger: &Logger{
+				settings: settings{
+					level:  levelPtr(Trace),
+					format: formatPtr(FormatJSON),
+					caller: newCallerSettings(false, false, false),
+				},
+				mutex: new(sync.Mutex),
+			},
+			level:       Info,
+			s:           "some \"words\"",
+			outputRegex: `^{"timestamp":"[^"]+","level":"INFO","message":"some \\"words\\""}\n$`,
+		},
+		"json_with_caller_and_context": {
+			logger: &Logger{
+				settings: settings{
+					level:  levelPtr(Trace),
+					format: formatPtr(FormatJSON),
+					caller: newCallerSettings(true, true, false),
+					context: []contextKeyValues{
+						{key: "pkg", values: []string{"sync"}},
+						{key: "key1", values: []string{"a", "b"}},
+					},
+				},
+				mutex: new(sync.Mutex),
+			},
+			level: Debug,
+			s:     "some words",
+			outputRegex: `^{"timestamp":"[^"]+","level":"DEBUG","module":"sync",` +
+				`"caller":"log_test.go:L[0-9]+","message":"some words","fields":{"key1":"a,b"}}\n$`,
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -22,6 +22,30 @@ func SetLevel(level Level) Option {
 	}
 }
 
+// SetModuleLevel overrides the level of the loggers of the given module,
+// which is the value of their "pkg" context key. An empty module overrides
+// the level of the loggers of all modules not overridden specifically.
+// Overrides take precedence over the level set with SetLevel.
+func SetModuleLevel(module string, level Level) Option {
+	return func(s *settings) {
+		if level == DoNotChange {
+			return
+		}
+		if s.moduleLevels == nil {
+			s.moduleLevels = make(map[string]Level, 1)
+		}
+		s.moduleLevels[module] = level
+	}
+}
+
+// ResetModuleLevels removes all the level overrides set with SetModuleLevel,
+// so loggers use again the level set with SetLevel.
+func ResetModuleLevels() Option {
+	return func(s *settings) {
+		s.moduleLevels = nil
+	}
+}
+
 // SetCallerFile enables or disables logging the caller file.
 // The default is disabled.
 func SetCallerFile(enabled bool) Option {
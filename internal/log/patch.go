@@ -19,6 +19,10 @@ func (l *Logger) Patch(options ...Option) {
 func (l *Logger) patchWithoutLocking(options ...Option) {
 	var updatedSettings settings
 	updatedSettings.mergeWith(l.settings)
-	updatedSettings.mergeWith(newSettings(options))
+	// options are applied on the copy of the settings instead of being
+	// merged, so they can also unset values such as the module levels.
+	for _, option := range options {
+		option(&updatedSettings)
+	}
 	l.settings = updatedSettings
 }
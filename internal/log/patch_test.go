@@ -160,6 +160,55 @@ func Test_Logger_patchWithoutLocking(t *testing.T) {
 				mutex: new(sync.Mutex),
 			},
 		},
+		"set_module_level": {
+			initialLogger: &Logger{
+				settings: settings{
+					writer:       os.Stdout,
+					level:        levelPtr(Info),
+					format:       formatPtr(FormatConsole),
+					caller:       newCallerSettings(false, false, false),
+					moduleLevels: map[string]Level{"sync": Debug},
+				},
+				mutex: new(sync.Mutex),
+			},
+			options: []Option{
+				SetModuleLevel("sync", Trace),
+				SetModuleLevel("core", Warn),
+				SetModuleLevel("babe", DoNotChange),
+			},
+			expectedLogger: &Logger{
+				settings: settings{
+					writer:       os.Stdout,
+					level:        levelPtr(Info),
+					format:       formatPtr(FormatConsole),
+					caller:       newCallerSettings(false, false, false),
+					moduleLevels: map[string]Level{"sync": Trace, "core": Warn},
+				},
+				mutex: new(sync.Mutex),
+			},
+		},
+		"reset_module_levels": {
+			initialLogger: &Logger{
+				settings: settings{
+					writer:       os.Stdout,
+					level:        levelPtr(Info),
+					format:       formatPtr(FormatConsole),
+					caller:       newCallerSettings(false, false, false),
+					moduleLevels: map[string]Level{"sync": Trace},
+				},
+				mutex: new(sync.Mutex),
+			},
+			options: []Option{ResetModuleLevels()},
+			expectedLogger: &Logger{
+				settings: settings{
+					writer: os.Stdout,
+					level:  levelPtr(Info),
+					format: formatPtr(FormatConsole),
+					caller: newCallerSettings(false, false, false),
+				},
+				mutex: new(sync.Mutex),
+			},
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -14,6 +14,10 @@ type settings struct {
 	format  *Format
 	caller  callerSettings
 	context []contextKeyValues
+	// moduleLevels overrides the level of the loggers
+	// with the module (pkg context) as key. The empty
+	// module key overrides the level of all loggers.
+	moduleLevels map[string]Level
 }
 
 type contextKeyValues struct {
@@ -66,6 +70,17 @@ func (s *settings) mergeWith(other settings) {
 
 	s.caller.mergeWith(other.caller)
 
+	if len(other.moduleLevels) > 0 {
+		moduleLevels := make(map[string]Level, len(s.moduleLevels)+len(other.moduleLevels))
+		for module, level := range s.moduleLevels {
+			moduleLevels[module] = level
+		}
+		for module, level := range other.moduleLevels {
+			moduleLevels[module] = level
+		}
+		s.moduleLevels = moduleLevels
+	}
+
 	existingKeyToIndex := make(map[string]int, len(s.context))
 	for i, kvs := range s.context {
 		existingKeyToIndex[kvs.key] = i
@@ -84,3 +99,26 @@ func (s *settings) mergeWith(other settings) {
 		s.context = append(s.context, kvsCopy)
 	}
 }
+
+// module returns the module of the logger, which is the
+// last value of its "pkg" context key, or the empty string.
+func (s *settings) module() string {
+	for _, kvs := range s.context {
+		if kvs.key == "pkg" && len(kvs.values) > 0 {
+			return kvs.values[len(kvs.values)-1]
+		}
+	}
+	return ""
+}
+
+// levelFor returns the level to use for the logger, taking into
+// account the level overrides for its module and for all modules.
+func (s *settings) levelFor(module string) Level {
+	if level, ok := s.moduleLevels[module]; ok {
+		return level
+	}
+	if level, ok := s.moduleLevels[""]; ok {
+		return level
+	}
+	return *s.level
+}
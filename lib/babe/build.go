@@ -170,9 +170,12 @@ func (b *BlockBuilder) buildBlockSeal(header *types.Header) (*types.SealDigest,
 
 // buildBlockExtrinsics applies extrinsics to the block. it returns an array of included extrinsics.
 // for each extrinsic in queue, add it to the block, until the slot ends or the block is full.
-// if any extrinsic fails, it returns an empty array and an error.
+// the queue only yields transactions once the transactions they depend on were popped, and
+// transactions depending on a transaction which is not included are not included either.
 func (b *BlockBuilder) buildBlockExtrinsics(slot Slot, rt ExtrinsicHandler) []*transaction.ValidTransaction {
-	var included []*transaction.ValidTransaction
+	var included, deferred []*transaction.ValidTransaction
+	// unavailableTags are the tags provided by the transactions not included in the block.
+	unavailableTags := make(map[string]struct{})
 
 	slotEnd := slot.start.Add(slot.duration * 2 / 3) // reserve last 1/3 of slot for block finalisation
 	timeout := slotEnd.Sub(slot.start)               // timeout relative to the slot start
@@ -186,11 +189,19 @@ func (b *BlockBuilder) buildBlockExtrinsics(slot Slot, rt ExtrinsicHandler) []*t
 		}
 
 		extrinsic := txn.Extrinsic
+		if requiresAnyTag(txn, unavailableTags) {
+			logger.Tracef("deferring extrinsic %s depending on a transaction not included", extrinsic)
+			deferred = append(deferred, txn)
+			addProvidedTags(txn, unavailableTags)
+			continue
+		}
+
 		logger.Tracef("build block, applying extrinsic %s", extrinsic)
 
 		ret, err := rt.ApplyExtrinsic(extrinsic)
 		if err != nil {
 			logger.Warnf("determining apply extrinsic call error: %s", err)
+			addProvidedTags(txn, unavailableTags)
 			continue
 		}
 
@@ -201,21 +212,24 @@ func (b *BlockBuilder) buildBlockExtrinsics(slot Slot, rt ExtrinsicHandler) []*t
 			// Failure of the module call dispatching doesn't invalidate the extrinsic.
 			// It is included in the block.
 			if _, ok := err.(*DispatchOutcomeError); !ok {
+				addProvidedTags(txn, unavailableTags)
 				continue
 			}
 
 			// don't drop transactions that may be valid in a later block ie.
-			// run out of gas for this block or have a nonce that may be valid in a later block
+			// run out of gas for this block or have a nonce that may be valid in a later block.
+			// They are pushed back to the queue once the block is built, so they are not
+			// popped again while building this block.
 			var e *TransactionValidityError
 			if !errors.As(err, &e) {
+				addProvidedTags(txn, unavailableTags)
 				continue
 			}
 
 			if errors.Is(e.msg, errExhaustsResources) || errors.Is(e.msg, errInvalidTransaction) {
-				hash, err := b.transactionState.Push(txn)
-				if err != nil {
-					logger.Debugf("failed to re-add transaction with hash %s to queue: %s", hash, err)
-				}
+				deferred = append(deferred, txn)
+				addProvidedTags(txn, unavailableTags)
+				continue
 			}
 		}
 
@@ -223,6 +237,7 @@ func (b *BlockBuilder) buildBlockExtrinsics(slot Slot, rt ExtrinsicHandler) []*t
 		included = append(included, txn)
 	}
 
+	b.addToQueue(deferred)
 	return included
 }
 
@@ -295,6 +310,29 @@ func buildBlockInherents(slot Slot, rt ExtrinsicHandler, parent *types.Header) (
 	return exts, nil
 }
 
+// requiresAnyTag returns true if the transaction requires any of the given tags.
+func requiresAnyTag(txn *transaction.ValidTransaction, tags map[string]struct{}) bool {
+	if txn.Validity == nil {
+		return false
+	}
+	for _, tag := range txn.Validity.Requires {
+		if _, ok := tags[string(tag)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// addProvidedTags adds the tags provided by the transaction to the given tags.
+func addProvidedTags(txn *transaction.ValidTransaction, tags map[string]struct{}) {
+	if txn.Validity == nil {
+		return
+	}
+	for _, tag := range txn.Validity.Provides {
+		tags[string(tag)] = struct{}{}
+	}
+}
+
 func (b *BlockBuilder) addToQueue(txs []*transaction.ValidTransaction) {
 	for _, t := range txs {
 		hash, err := b.transactionState.Push(t)
@@ -5,13 +5,11 @@
 
 import (
 	"bytes"
-	"path/filepath"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/core"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
-	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/babe/mocks"
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -117,17 +115,6 @@ func newTestCoreService(t *testing.T, cfg *core.Config, genesis genesis.Genesis,
 		rtCfg.CodeHash, err = cfg.StorageState.(*state.InmemoryStorageState).LoadCodeHash(nil)
 		require.NoError(t, err)
 
-		nodeStorage := runtime.NodeStorage{}
-
-		if stateSrvc != nil {
-			nodeStorage.BaseDB = stateSrvc.Base
-		} else {
-			nodeStorage.BaseDB, err = database.LoadDatabase(filepath.Join(testDatadirPath, "offline_storage"), false)
-			require.NoError(t, err)
-		}
-
-		rtCfg.NodeStorage = nodeStorage
-
 		cfg.Runtime, err = wazero_runtime.NewRuntimeFromGenesis(rtCfg)
 		require.NoError(t, err)
 	}
@@ -216,10 +203,6 @@ func createTestService(t *testing.T, cfg ServiceConfig, genesis genesis.Genesis,
 	rtCfg.CodeHash, err = storageState.LoadCodeHash(nil)
 	require.NoError(t, err)
 
-	nodeStorage := runtime.NodeStorage{}
-	nodeStorage.BaseDB = dbSrv.Base
-
-	rtCfg.NodeStorage = nodeStorage
 	rtCfg.Transaction = dbSrv.Transaction
 	runtime, err := wazero_runtime.NewRuntimeFromGenesis(rtCfg)
 	require.NoError(t, err)
@@ -0,0 +1,521 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beefy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "beefy"))
+
+// DefaultMinBlockDelta is the default minimum number of blocks between two voted blocks
+const DefaultMinBlockDelta = 8
+
+// Config is the configuration of the BEEFY service
+type Config struct {
+	LogLvl       log.Level
+	BlockState   BlockState
+	StorageState StorageState
+	Network      Network
+	Keystore     keystore.Keystore
+	Authority    bool
+	// MinBlockDelta is the minimum number of blocks between two voted blocks
+	MinBlockDelta uint
+}
+
+// Service is the BEEFY client. It votes for the MMR root of the blocks finalised by GRANDPA,
+// gossips the votes to the other validators, and aggregates the votes into justifications.
+type Service struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	blockState    BlockState
+	storageState  StorageState
+	network       Network
+	keystore      keystore.Keystore
+	authority     bool
+	minBlockDelta uint
+	finalisedCh   chan *types.FinalisationInfo
+
+	lock sync.Mutex
+	// rounds is nil until the first validator set is known
+	rounds *rounds
+	// keypair is the key of the validator set of the node, or nil if the node is not a validator
+	keypair *secp256k1.Keypair
+	// sessionStart is the number of the block where the current validator set started,
+	// which is the first block voted by the set
+	sessionStart uint
+	bestBeefy    uint
+	bestGrandpa  uint
+	lastVoted    uint
+}
+
+// NewService returns a new BEEFY service
+func NewService(cfg *Config) (*Service, error) {
+	if cfg.Keystore.Name() != keystore.BeefName || cfg.Keystore.Type() != crypto.Secp256k1Type {
+		return nil, ErrInvalidKeystoreType
+	}
+
+	logger.Patch(log.SetLevel(cfg.LogLvl))
+
+	minBlockDelta := cfg.MinBlockDelta
+	if minBlockDelta == 0 {
+		minBlockDelta = DefaultMinBlockDelta
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Service{
+		ctx:           ctx,
+		cancel:        cancel,
+		blockState:    cfg.BlockState,
+		storageState:  cfg.StorageState,
+		network:       cfg.Network,
+		keystore:      cfg.Keystore,
+		authority:     cfg.Authority,
+		minBlockDelta: minBlockDelta,
+	}
+
+	err := s.registerProtocol()
+	if err != nil {
+		return nil, fmt.Errorf("registering protocol: %w", err)
+	}
+
+	return s, nil
+}
+
+// Start starts the BEEFY service. The service stays idle if the runtime does not support BEEFY.
+func (s *Service) Start() error {
+	err := s.initialise()
+	if errors.Is(err, wazero_runtime.ErrExportFunctionNotFound) {
+		logger.Info("runtime does not support BEEFY, the BEEFY service is idle")
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("initialising: %w", err)
+	}
+
+	s.finalisedCh = s.blockState.GetFinalisedNotifierChannel()
+	s.wg.Add(1)
+	go s.handleFinalisedBlocks()
+	return nil
+}
+
+// Stop stops the BEEFY service
+func (s *Service) Stop() error {
+	s.cancel()
+	s.wg.Wait()
+	if s.finalisedCh != nil {
+		s.blockState.FreeFinalisedNotifierChannel(s.finalisedCh)
+	}
+	return nil
+}
+
+// initialise gets the current validator set from the runtime, on the state of the highest
+// finalised block, and finds the block where the set started and the highest block with a
+// BEEFY justification.
+func (s *Service) initialise() error {
+	finalisedHeader, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	trieState, err := s.storageState.TrieState(&finalisedHeader.StateRoot)
+	if err != nil {
+		return fmt.Errorf("getting trie state: %w", err)
+	}
+
+	rt, err := s.blockState.GetRuntime(finalisedHeader.Hash())
+	if err != nil {
+		return fmt.Errorf("getting runtime: %w", err)
+	}
+
+	rt.SetContextStorage(trieState)
+
+	encodedValidatorSet, err := rt.Exec(runtime.BeefyAPIValidatorSet, []byte{})
+	if err != nil {
+		return fmt.Errorf("getting validator set: %w", err)
+	}
+
+	var validatorSet *types.BeefyValidatorSet
+	err = scale.Unmarshal(encodedValidatorSet, &validatorSet)
+	if err != nil {
+		return fmt.Errorf("decoding validator set: %w", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.bestGrandpa = finalisedHeader.Number
+	if validatorSet == nil {
+		logger.Info("BEEFY is not active yet, waiting for the first validator set")
+		return nil
+	}
+
+	sessionStart, bestBeefy, err := s.findSessionStart(finalisedHeader.Number, validatorSet.ID)
+	if err != nil {
+		return fmt.Errorf("finding start of validator set id %d: %w", validatorSet.ID, err)
+	}
+
+	s.setValidatorSet(*validatorSet, sessionStart)
+	s.bestBeefy = bestBeefy
+	logger.Infof("starting BEEFY with validator set id %d started at block #%d and best BEEFY block #%d",
+		validatorSet.ID, sessionStart, bestBeefy)
+	return nil
+}
+
+// findSessionStart walks back the finalised chain from the given block number to find the
+// block which enacted the validator set with the given id, and the highest block with a
+// BEEFY justification after it.
+func (s *Service) findSessionStart(from uint, setID uint64) (sessionStart, bestBeefy uint, err error) {
+	for number := from; number > 0; number-- {
+		header, err := s.blockState.GetHeaderByNumber(number)
+		if errors.Is(err, database.ErrNotFound) {
+			// the blocks below a warp or state synced block are not stored
+			return number + 1, bestBeefy, nil
+		} else if err != nil {
+			return 0, 0, fmt.Errorf("getting header of block #%d: %w", number, err)
+		}
+
+		if bestBeefy == 0 {
+			has, err := s.blockState.HasBeefyJustification(header.Hash())
+			if err != nil {
+				return 0, 0, fmt.Errorf("checking BEEFY justification of block #%d: %w", number, err)
+			} else if has {
+				bestBeefy = number
+			}
+		}
+
+		digests, err := findDigests(header)
+		if err != nil {
+			return 0, 0, fmt.Errorf("finding digests of block #%d: %w", number, err)
+		}
+
+		if digests.validatorSet != nil && digests.validatorSet.ID == setID {
+			return number, bestBeefy, nil
+		}
+	}
+
+	return 1, bestBeefy, nil
+}
+
+// setValidatorSet sets the current validator set, and the key of the node in the set if any.
+func (s *Service) setValidatorSet(validatorSet types.BeefyValidatorSet, sessionStart uint) {
+	s.rounds = newRounds(validatorSet)
+	s.sessionStart = sessionStart
+	s.keypair = nil
+
+	if !s.authority {
+		return
+	}
+
+	for _, kp := range s.keystore.Keypairs() {
+		keypair, ok := kp.(*secp256k1.Keypair)
+		if !ok {
+			continue
+		}
+
+		var authorityID types.BeefyAuthorityID
+		copy(authorityID[:], keypair.Public().Encode())
+		if s.rounds.validatorIndex(authorityID) >= 0 {
+			s.keypair = keypair
+			return
+		}
+	}
+}
+
+func (s *Service) handleFinalisedBlocks() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case info, ok := <-s.finalisedCh:
+			if !ok {
+				return
+			}
+
+			err := s.handleFinalisedBlock(&info.Header)
+			if err != nil {
+				logger.Errorf("handling finalised block #%d: %s", info.Header.Number, err)
+			}
+		}
+	}
+}
+
+// handleFinalisedBlock enacts the validator set changes of the blocks finalised up to the
+// given header, including the blocks whose finalisation was not notified, and votes.
+func (s *Service) handleFinalisedBlock(finalisedHeader *types.Header) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for number := s.bestGrandpa + 1; number <= finalisedHeader.Number; number++ {
+		header := finalisedHeader
+		if number != finalisedHeader.Number {
+			var err error
+			header, err = s.blockState.GetHeaderByNumber(number)
+			if err != nil {
+				return fmt.Errorf("getting header of block #%d: %w", number, err)
+			}
+		}
+
+		digests, err := findDigests(header)
+		if err != nil {
+			return fmt.Errorf("finding digests of block #%d: %w", number, err)
+		}
+
+		if digests.validatorSet != nil {
+			logger.Infof("new BEEFY validator set id %d with %d validators at block #%d",
+				digests.validatorSet.ID, len(digests.validatorSet.Validators), number)
+			s.setValidatorSet(*digests.validatorSet, number)
+		}
+		s.bestGrandpa = number
+	}
+
+	return s.vote()
+}
+
+// voteTarget returns the number of the block to vote for, or false if there is no block to vote
+// for. The first block of the session is voted until it is justified, since it enacts the validator
+// set. Then the target is the next power of two of half the distance between the best BEEFY block
+// and the best GRANDPA block, and at least the minimum block delta, after the best BEEFY block.
+func voteTarget(bestGrandpa, bestBeefy, sessionStart, minBlockDelta uint) (target uint, ok bool) {
+	if bestBeefy < sessionStart {
+		target = sessionStart
+	} else {
+		var diff uint
+		if bestGrandpa > bestBeefy {
+			diff = bestGrandpa - bestBeefy
+		}
+		delta := nextPowerOfTwo((diff + 1) / 2)
+		if delta < minBlockDelta {
+			delta = minBlockDelta
+		}
+		target = bestBeefy + delta
+	}
+
+	if target > bestGrandpa {
+		return 0, false
+	}
+	return target, true
+}
+
+func nextPowerOfTwo(n uint) uint {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << bits.Len(n-1)
+}
+
+// vote signs and gossips a vote for the MMR root of the vote target, if the node is a validator.
+func (s *Service) vote() error {
+	if s.keypair == nil {
+		return nil
+	}
+
+	target, ok := voteTarget(s.bestGrandpa, s.bestBeefy, s.sessionStart, s.minBlockDelta)
+	if !ok || target <= s.lastVoted {
+		return nil
+	}
+
+	header, err := s.blockState.GetHeaderByNumber(target)
+	if err != nil {
+		return fmt.Errorf("getting header of block #%d: %w", target, err)
+	}
+
+	digests, err := findDigests(header)
+	if err != nil {
+		return fmt.Errorf("finding digests of block #%d: %w", target, err)
+	}
+
+	if digests.mmrRoot == nil {
+		logger.Debugf("no MMR root digest in block #%d, not voting", target)
+		return nil
+	}
+
+	commitment := Commitment{
+		Payload:        NewMmrRootPayload(*digests.mmrRoot),
+		BlockNumber:    uint32(target),
+		ValidatorSetID: s.rounds.validatorSet.ID,
+	}
+
+	commitmentHash, err := commitment.Hash()
+	if err != nil {
+		return fmt.Errorf("hashing commitment: %w", err)
+	}
+
+	signature, err := s.keypair.Sign(commitmentHash[:])
+	if err != nil {
+		return fmt.Errorf("signing commitment: %w", err)
+	}
+
+	vote := VoteMessage{Commitment: commitment}
+	copy(vote.ID[:], s.keypair.Public().Encode())
+	copy(vote.Signature[:], signature)
+
+	s.lastVoted = target
+	logger.Debugf("voting for block #%d with validator set id %d", target, commitment.ValidatorSetID)
+
+	_, err = s.addVote(&vote, commitmentHash)
+	if err != nil {
+		return fmt.Errorf("adding own vote: %w", err)
+	}
+
+	return s.gossip(vote)
+}
+
+// handleVote verifies and adds a vote received from the network, and returns true if the vote
+// should be propagated.
+func (s *Service) handleVote(vote *VoteMessage) (propagate bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.rounds == nil {
+		return false, nil
+	}
+
+	// only the votes of the current validator set for finalised blocks which are not justified are kept
+	blockNumber := uint(vote.Commitment.BlockNumber)
+	if vote.Commitment.ValidatorSetID != s.rounds.validatorSet.ID ||
+		blockNumber <= s.bestBeefy || blockNumber < s.sessionStart || blockNumber > s.bestGrandpa {
+		return false, nil
+	}
+
+	if s.rounds.validatorIndex(vote.ID) < 0 {
+		return false, fmt.Errorf("%w: 0x%x", errUnknownAuthority, vote.ID)
+	}
+
+	commitmentHash, err := vote.Commitment.Hash()
+	if err != nil {
+		return false, fmt.Errorf("hashing commitment: %w", err)
+	}
+
+	err = verifySignature(commitmentHash, vote.ID, vote.Signature)
+	if err != nil {
+		return false, fmt.Errorf("verifying vote: %w", err)
+	}
+
+	return s.addVote(vote, commitmentHash)
+}
+
+// addVote adds the verified vote to the rounds, and finalises the block of the vote if
+// the vote completes the threshold of its commitment.
+func (s *Service) addVote(vote *VoteMessage, commitmentHash common.Hash) (added bool, err error) {
+	added, signedCommitment := s.rounds.addVote(vote, commitmentHash)
+	if signedCommitment == nil {
+		return added, nil
+	}
+
+	err = s.finalise(*signedCommitment)
+	if err != nil {
+		return false, fmt.Errorf("finalising block #%d: %w", signedCommitment.Commitment.BlockNumber, err)
+	}
+
+	err = s.gossip(NewVersionedFinalityProof(*signedCommitment))
+	if err != nil {
+		return false, fmt.Errorf("gossiping finality proof: %w", err)
+	}
+	return added, nil
+}
+
+// handleFinalityProof verifies and stores a finality proof received from the network, and
+// returns true if the finality proof should be propagated.
+func (s *Service) handleFinalityProof(signedCommitment SignedCommitment) (propagate bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.rounds == nil {
+		return false, nil
+	}
+
+	blockNumber := uint(signedCommitment.Commitment.BlockNumber)
+	if signedCommitment.Commitment.ValidatorSetID != s.rounds.validatorSet.ID ||
+		blockNumber <= s.bestBeefy || blockNumber > s.bestGrandpa {
+		return false, nil
+	}
+
+	err = s.verifySignedCommitment(signedCommitment)
+	if err != nil {
+		return false, fmt.Errorf("verifying finality proof: %w", err)
+	}
+
+	err = s.finalise(signedCommitment)
+	if err != nil {
+		return false, fmt.Errorf("finalising block #%d: %w", blockNumber, err)
+	}
+	return true, nil
+}
+
+// verifySignedCommitment returns an error if the signed commitment is not signed by more than
+// two thirds of the current validator set.
+func (s *Service) verifySignedCommitment(signedCommitment SignedCommitment) error {
+	validators := s.rounds.validatorSet.Validators
+	if len(signedCommitment.Signatures) != len(validators) {
+		return fmt.Errorf("%w: %d signatures for %d validators",
+			errInvalidSignedCommitment, len(signedCommitment.Signatures), len(validators))
+	}
+
+	commitmentHash, err := signedCommitment.Commitment.Hash()
+	if err != nil {
+		return fmt.Errorf("hashing commitment: %w", err)
+	}
+
+	var signatures int
+	for i, signature := range signedCommitment.Signatures {
+		if signature == nil {
+			continue
+		}
+
+		err = verifySignature(commitmentHash, validators[i], *signature)
+		if err != nil {
+			return err
+		}
+		signatures++
+	}
+
+	if signatures < threshold(len(validators)) {
+		return fmt.Errorf("%w: %d signatures for %d validators",
+			errNotEnoughSignatures, signatures, len(validators))
+	}
+	return nil
+}
+
+// finalise stores the signed commitment as the BEEFY justification of its block.
+func (s *Service) finalise(signedCommitment SignedCommitment) error {
+	blockNumber := uint(signedCommitment.Commitment.BlockNumber)
+	header, err := s.blockState.GetHeaderByNumber(blockNumber)
+	if err != nil {
+		return fmt.Errorf("getting header: %w", err)
+	}
+
+	justification, err := scale.Marshal(NewVersionedFinalityProof(signedCommitment))
+	if err != nil {
+		return fmt.Errorf("encoding justification: %w", err)
+	}
+
+	err = s.blockState.SetBeefyJustification(header.Hash(), justification)
+	if err != nil {
+		return fmt.Errorf("setting justification: %w", err)
+	}
+
+	s.bestBeefy = blockNumber
+	s.rounds.conclude(signedCommitment.Commitment.BlockNumber)
+	logger.Infof("BEEFY finalised block #%d (%s)", blockNumber, header.Hash())
+	return nil
+}
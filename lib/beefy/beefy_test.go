@@ -0,0 +1,554 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beefy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_voteTarget(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		bestGrandpa   uint
+		bestBeefy     uint
+		sessionStart  uint
+		minBlockDelta uint
+		target        uint
+		ok            bool
+	}{
+		"min_delta_not_finalised": {
+			bestGrandpa:   4,
+			bestBeefy:     2,
+			sessionStart:  1,
+			minBlockDelta: 4,
+		},
+		"min_delta": {
+			bestGrandpa:   6,
+			bestBeefy:     2,
+			sessionStart:  1,
+			minBlockDelta: 4,
+			target:        6,
+			ok:            true,
+		},
+		"min_delta_above_power_of_two": {
+			bestGrandpa:   18,
+			bestBeefy:     10,
+			sessionStart:  1,
+			minBlockDelta: 8,
+			target:        18,
+			ok:            true,
+		},
+		"power_of_two": {
+			bestGrandpa:   1016,
+			bestBeefy:     1000,
+			sessionStart:  1,
+			minBlockDelta: 4,
+			target:        1008,
+			ok:            true,
+		},
+		"next_power_of_two": {
+			bestGrandpa:   2000,
+			bestBeefy:     1000,
+			sessionStart:  1,
+			minBlockDelta: 4,
+			target:        1512,
+			ok:            true,
+		},
+		"session_start_not_justified": {
+			bestGrandpa:   1008,
+			bestBeefy:     1000,
+			sessionStart:  1001,
+			minBlockDelta: 4,
+			target:        1001,
+			ok:            true,
+		},
+		"session_start_not_finalised": {
+			bestGrandpa:   1000,
+			bestBeefy:     900,
+			sessionStart:  1001,
+			minBlockDelta: 4,
+		},
+		"best_beefy_above_best_grandpa": {
+			bestGrandpa:   10,
+			bestBeefy:     12,
+			sessionStart:  1,
+			minBlockDelta: 1,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			target, ok := voteTarget(tt.bestGrandpa, tt.bestBeefy, tt.sessionStart, tt.minBlockDelta)
+			assert.Equal(t, tt.target, target)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestNewService_invalidKeystore(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewService(&Config{
+		Keystore: keystore.NewBasicKeystore(keystore.GranName, crypto.Ed25519Type),
+	})
+	assert.ErrorIs(t, err, ErrInvalidKeystoreType)
+}
+
+// newTestValidators returns the keypairs and the validator set of the given number of validators.
+func newTestValidators(t *testing.T, count int) ([]*secp256k1.Keypair, types.BeefyValidatorSet) {
+	t.Helper()
+	keypairs := make([]*secp256k1.Keypair, count)
+	validatorSet := types.BeefyValidatorSet{ID: 1}
+	for i := range keypairs {
+		keypair, err := secp256k1.GenerateKeypair()
+		require.NoError(t, err)
+		keypairs[i] = keypair
+
+		var authorityID types.BeefyAuthorityID
+		copy(authorityID[:], keypair.Public().Encode())
+		validatorSet.Validators = append(validatorSet.Validators, authorityID)
+	}
+	return keypairs, validatorSet
+}
+
+func newTestVote(t *testing.T, keypair *secp256k1.Keypair, commitment Commitment) *VoteMessage {
+	t.Helper()
+	commitmentHash, err := commitment.Hash()
+	require.NoError(t, err)
+	signature, err := keypair.Sign(commitmentHash[:])
+	require.NoError(t, err)
+
+	vote := &VoteMessage{Commitment: commitment}
+	copy(vote.ID[:], keypair.Public().Encode())
+	copy(vote.Signature[:], signature)
+	return vote
+}
+
+// decodeGossipedValue returns the vote or finality proof of the gossiped BEEFY message.
+func decodeGossipedValue(t *testing.T, msg network.NotificationsMessage) any {
+	t.Helper()
+	beefyMessage, ok := msg.(*network.BeefyMessage)
+	require.True(t, ok)
+
+	gossipMsg := newGossipMessage()
+	err := scale.Unmarshal(beefyMessage.Data, &gossipMsg)
+	require.NoError(t, err)
+	value, err := gossipMsg.Value()
+	require.NoError(t, err)
+	return value
+}
+
+func TestService_vote(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	keypairs, validatorSet := newTestValidators(t, 4)
+	ks := keystore.NewBasicKeystore(keystore.BeefName, crypto.Secp256k1Type)
+	err := ks.Insert(keypairs[0])
+	require.NoError(t, err)
+
+	mmrRoot := common.Hash{9}
+	digest := types.NewDigest()
+	err = digest.Add(types.ConsensusDigest{
+		ConsensusEngineID: types.BeefyEngineID,
+		Data:              scale.MustMarshal(newTestBeefyDigest(t, types.BeefyMmrRoot{Root: mmrRoot})),
+	})
+	require.NoError(t, err)
+	header := types.NewHeader(common.Hash{1}, common.Hash{}, common.Hash{}, 8, digest)
+
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().GetHeaderByNumber(uint(8)).Return(header, nil)
+	mockNetwork := NewMockNetwork(ctrl)
+	var gossiped network.NotificationsMessage
+	mockNetwork.EXPECT().GossipMessage(gomock.Any()).Do(func(msg network.NotificationsMessage) {
+		gossiped = msg
+	})
+
+	s := &Service{
+		blockState:    blockState,
+		network:       mockNetwork,
+		keystore:      ks,
+		authority:     true,
+		minBlockDelta: DefaultMinBlockDelta,
+		bestGrandpa:   8,
+		bestBeefy:     0,
+	}
+	s.setValidatorSet(validatorSet, 0)
+	require.Equal(t, keypairs[0], s.keypair)
+
+	err = s.vote()
+	require.NoError(t, err)
+	assert.Equal(t, uint(8), s.lastVoted)
+
+	vote, ok := decodeGossipedValue(t, gossiped).(VoteMessage)
+	require.True(t, ok)
+	assert.Equal(t, Commitment{
+		Payload:        NewMmrRootPayload(mmrRoot),
+		BlockNumber:    8,
+		ValidatorSetID: validatorSet.ID,
+	}, vote.Commitment)
+	assert.Equal(t, validatorSet.Validators[0], vote.ID)
+
+	commitmentHash, err := vote.Commitment.Hash()
+	require.NoError(t, err)
+	err = verifySignature(commitmentHash, vote.ID, vote.Signature)
+	require.NoError(t, err)
+
+	// the block is not voted twice
+	err = s.vote()
+	require.NoError(t, err)
+}
+
+func newTestBeefyDigest(t *testing.T, value any) types.BeefyConsensusDigest {
+	t.Helper()
+	beefyDigest := types.NewBeefyConsensusDigest()
+	err := beefyDigest.SetValue(value)
+	require.NoError(t, err)
+	return beefyDigest
+}
+
+func TestService_handleVote(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	keypairs, validatorSet := newTestValidators(t, 4)
+	commitment := Commitment{
+		Payload:        NewMmrRootPayload(common.Hash{9}),
+		BlockNumber:    5,
+		ValidatorSetID: validatorSet.ID,
+	}
+	header := types.NewHeader(common.Hash{1}, common.Hash{}, common.Hash{}, 5, types.NewDigest())
+
+	blockState := NewMockBlockState(ctrl)
+	mockNetwork := NewMockNetwork(ctrl)
+	s := &Service{
+		blockState:  blockState,
+		network:     mockNetwork,
+		bestGrandpa: 6,
+	}
+	s.setValidatorSet(validatorSet, 1)
+
+	// votes of another validator set and for not finalised blocks are ignored
+	otherSetCommitment := commitment
+	otherSetCommitment.ValidatorSetID++
+	propagate, err := s.handleVote(newTestVote(t, keypairs[0], otherSetCommitment))
+	require.NoError(t, err)
+	assert.False(t, propagate)
+
+	futureCommitment := commitment
+	futureCommitment.BlockNumber = 7
+	propagate, err = s.handleVote(newTestVote(t, keypairs[0], futureCommitment))
+	require.NoError(t, err)
+	assert.False(t, propagate)
+
+	// a vote with an invalid signature is rejected
+	invalidVote := newTestVote(t, keypairs[0], commitment)
+	invalidVote.ID = validatorSet.Validators[1]
+	_, err = s.handleVote(invalidVote)
+	assert.ErrorIs(t, err, errInvalidSignature)
+
+	propagate, err = s.handleVote(newTestVote(t, keypairs[0], commitment))
+	require.NoError(t, err)
+	assert.True(t, propagate)
+
+	// a duplicate vote is not propagated
+	propagate, err = s.handleVote(newTestVote(t, keypairs[0], commitment))
+	require.NoError(t, err)
+	assert.False(t, propagate)
+
+	propagate, err = s.handleVote(newTestVote(t, keypairs[1], commitment))
+	require.NoError(t, err)
+	assert.True(t, propagate)
+
+	// the third vote of the four validators reaches the threshold
+	var justification []byte
+	blockState.EXPECT().GetHeaderByNumber(uint(5)).Return(header, nil)
+	blockState.EXPECT().SetBeefyJustification(header.Hash(), gomock.Any()).
+		DoAndReturn(func(_ common.Hash, data []byte) error {
+			justification = data
+			return nil
+		})
+	var gossiped network.NotificationsMessage
+	mockNetwork.EXPECT().GossipMessage(gomock.Any()).Do(func(msg network.NotificationsMessage) {
+		gossiped = msg
+	})
+
+	propagate, err = s.handleVote(newTestVote(t, keypairs[3], commitment))
+	require.NoError(t, err)
+	assert.True(t, propagate)
+	assert.Equal(t, uint(5), s.bestBeefy)
+
+	finalityProof, ok := decodeGossipedValue(t, gossiped).(VersionedFinalityProof)
+	require.True(t, ok)
+	assert.Equal(t, scale.MustMarshal(finalityProof), justification)
+
+	signedCommitment, err := finalityProof.SignedCommitment()
+	require.NoError(t, err)
+	assert.Equal(t, commitment, signedCommitment.Commitment)
+	assert.Nil(t, signedCommitment.Signatures[2])
+	err = s.verifySignedCommitment(signedCommitment)
+	require.NoError(t, err)
+
+	// votes for the justified block are ignored
+	propagate, err = s.handleVote(newTestVote(t, keypairs[2], commitment))
+	require.NoError(t, err)
+	assert.False(t, propagate)
+}
+
+func TestService_handleFinalityProof(t *testing.T) {
+	t.Parallel()
+
+	keypairs, validatorSet := newTestValidators(t, 4)
+	commitment := Commitment{
+		Payload:        NewMmrRootPayload(common.Hash{9}),
+		BlockNumber:    5,
+		ValidatorSetID: validatorSet.ID,
+	}
+	header := types.NewHeader(common.Hash{1}, common.Hash{}, common.Hash{}, 5, types.NewDigest())
+
+	newSignedCommitment := func(t *testing.T, signers ...int) SignedCommitment {
+		t.Helper()
+		signedCommitment := SignedCommitment{
+			Commitment: commitment,
+			Signatures: make([]*Signature, len(keypairs)),
+		}
+		for _, i := range signers {
+			vote := newTestVote(t, keypairs[i], commitment)
+			signedCommitment.Signatures[i] = &vote.Signature
+		}
+		return signedCommitment
+	}
+
+	tests := map[string]struct {
+		signedCommitment func(t *testing.T) SignedCommitment
+		blockState       func(ctrl *gomock.Controller) BlockState
+		propagate        bool
+		errWrapped       error
+		bestBeefy        uint
+	}{
+		"valid": {
+			signedCommitment: func(t *testing.T) SignedCommitment {
+				return newSignedCommitment(t, 0, 1, 3)
+			},
+			blockState: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeaderByNumber(uint(5)).Return(header, nil)
+				blockState.EXPECT().SetBeefyJustification(header.Hash(), gomock.Any()).Return(nil)
+				return blockState
+			},
+			propagate: true,
+			bestBeefy: 5,
+		},
+		"not_enough_signatures": {
+			signedCommitment: func(t *testing.T) SignedCommitment {
+				return newSignedCommitment(t, 0, 1)
+			},
+			blockState: func(ctrl *gomock.Controller) BlockState {
+				return NewMockBlockState(ctrl)
+			},
+			errWrapped: errNotEnoughSignatures,
+		},
+		"invalid_signature": {
+			signedCommitment: func(t *testing.T) SignedCommitment {
+				signedCommitment := newSignedCommitment(t, 0, 1, 3)
+				signedCommitment.Signatures[2] = signedCommitment.Signatures[3]
+				return signedCommitment
+			},
+			blockState: func(ctrl *gomock.Controller) BlockState {
+				return NewMockBlockState(ctrl)
+			},
+			errWrapped: errInvalidSignature,
+		},
+		"invalid_validator_set_length": {
+			signedCommitment: func(t *testing.T) SignedCommitment {
+				signedCommitment := newSignedCommitment(t, 0, 1, 3)
+				signedCommitment.Signatures = signedCommitment.Signatures[:3]
+				return signedCommitment
+			},
+			blockState: func(ctrl *gomock.Controller) BlockState {
+				return NewMockBlockState(ctrl)
+			},
+			errWrapped: errInvalidSignedCommitment,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			s := &Service{
+				blockState:  tt.blockState(ctrl),
+				bestGrandpa: 6,
+			}
+			s.setValidatorSet(validatorSet, 1)
+
+			propagate, err := s.handleFinalityProof(tt.signedCommitment(t))
+			assert.ErrorIs(t, err, tt.errWrapped)
+			assert.Equal(t, tt.propagate, propagate)
+			assert.Equal(t, tt.bestBeefy, s.bestBeefy)
+		})
+	}
+}
+
+func TestService_handleFinalisedBlock_validatorSetChange(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	_, validatorSet := newTestValidators(t, 2)
+	digest := types.NewDigest()
+	err := digest.Add(types.ConsensusDigest{
+		ConsensusEngineID: types.BeefyEngineID,
+		Data:              scale.MustMarshal(newTestBeefyDigest(t, validatorSet)),
+	})
+	require.NoError(t, err)
+	header3 := types.NewHeader(common.Hash{2}, common.Hash{}, common.Hash{}, 3, digest)
+	header4 := types.NewHeader(header3.Hash(), common.Hash{}, common.Hash{}, 4, types.NewDigest())
+
+	// the set change of the block #3 is enacted although only the block #4 is notified
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().GetHeaderByNumber(uint(3)).Return(header3, nil)
+
+	s := &Service{
+		blockState:  blockState,
+		bestGrandpa: 2,
+	}
+
+	err = s.handleFinalisedBlock(header4)
+	require.NoError(t, err)
+	require.NotNil(t, s.rounds)
+	assert.Equal(t, validatorSet, s.rounds.validatorSet)
+	assert.Equal(t, uint(3), s.sessionStart)
+	assert.Equal(t, uint(4), s.bestGrandpa)
+}
+
+func TestService_Start(t *testing.T) {
+	t.Parallel()
+
+	_, validatorSet := newTestValidators(t, 2)
+	setChangeDigest := types.NewDigest()
+	err := setChangeDigest.Add(types.ConsensusDigest{
+		ConsensusEngineID: types.BeefyEngineID,
+		Data:              scale.MustMarshal(newTestBeefyDigest(t, validatorSet)),
+	})
+	require.NoError(t, err)
+	header1 := types.NewHeader(common.Hash{}, common.Hash{}, common.Hash{}, 1, setChangeDigest)
+	header2 := types.NewHeader(header1.Hash(), common.Hash{2}, common.Hash{}, 2, types.NewDigest())
+	trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+
+	t.Run("runtime_without_beefy", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		storageState := NewMockStorageState(ctrl)
+		storageState.EXPECT().TrieState(&header2.StateRoot).Return(trieState, nil)
+		rt := NewMockInstance(ctrl)
+		rt.EXPECT().SetContextStorage(trieState)
+		rt.EXPECT().Exec(runtime.BeefyAPIValidatorSet, []byte{}).
+			Return(nil, fmt.Errorf("%w: %s", wazero_runtime.ErrExportFunctionNotFound, runtime.BeefyAPIValidatorSet))
+		blockState := NewMockBlockState(ctrl)
+		blockState.EXPECT().GetHighestFinalisedHeader().Return(header2, nil)
+		blockState.EXPECT().GetRuntime(header2.Hash()).Return(rt, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		s := &Service{
+			ctx:          ctx,
+			cancel:       cancel,
+			blockState:   blockState,
+			storageState: storageState,
+		}
+		err := s.Start()
+		require.NoError(t, err)
+		assert.Nil(t, s.rounds)
+		assert.Nil(t, s.finalisedCh)
+
+		err = s.Stop()
+		require.NoError(t, err)
+	})
+
+	t.Run("initialise_from_validator_set", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		storageState := NewMockStorageState(ctrl)
+		storageState.EXPECT().TrieState(&header2.StateRoot).Return(trieState, nil)
+		rt := NewMockInstance(ctrl)
+		rt.EXPECT().SetContextStorage(trieState)
+		rt.EXPECT().Exec(runtime.BeefyAPIValidatorSet, []byte{}).
+			Return(scale.MustMarshal(&validatorSet), nil)
+		finalisedCh := make(chan *types.FinalisationInfo)
+		blockState := NewMockBlockState(ctrl)
+		blockState.EXPECT().GetHighestFinalisedHeader().Return(header2, nil)
+		blockState.EXPECT().GetRuntime(header2.Hash()).Return(rt, nil)
+		blockState.EXPECT().GetHeaderByNumber(uint(2)).Return(header2, nil)
+		blockState.EXPECT().HasBeefyJustification(header2.Hash()).Return(false, nil)
+		blockState.EXPECT().GetHeaderByNumber(uint(1)).Return(header1, nil)
+		blockState.EXPECT().HasBeefyJustification(header1.Hash()).Return(true, nil)
+		blockState.EXPECT().GetFinalisedNotifierChannel().Return(finalisedCh)
+		blockState.EXPECT().FreeFinalisedNotifierChannel(finalisedCh)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		s := &Service{
+			ctx:          ctx,
+			cancel:       cancel,
+			blockState:   blockState,
+			storageState: storageState,
+		}
+		err := s.Start()
+		require.NoError(t, err)
+
+		s.lock.Lock()
+		assert.Equal(t, validatorSet, s.rounds.validatorSet)
+		assert.Equal(t, uint(1), s.sessionStart)
+		assert.Equal(t, uint(1), s.bestBeefy)
+		assert.Equal(t, uint(2), s.bestGrandpa)
+		s.lock.Unlock()
+
+		err = s.Stop()
+		require.NoError(t, err)
+	})
+}
+
+func Test_Service_registerProtocol(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	const genesisHex = "aabb000000000000000000000000000000000000000000000000000000000000"
+
+	// the names are built by the network protocol names helper
+	mockNetwork := NewMockNetwork(ctrl)
+	mockNetwork.EXPECT().ProtocolNames(protocol.ID(beefyID2)).
+		DoAndReturn(func(subprotocol protocol.ID) []protocol.ID {
+			return network.ProtocolNames(common.Hash{0xaa, 0xbb}, "fork", "/dot", subprotocol)
+		})
+	mockNetwork.EXPECT().RegisterNotificationsProtocol(protocol.ID("/"+genesisHex+"/fork/beefy/2"),
+		network.BeefyMsgType, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), nil,
+		network.MaxBeefyNotificationSize, protocol.ID("/dot/beefy/2"), protocol.ID("/paritytech/beefy/2"))
+
+	s := &Service{network: mockNetwork}
+	err := s.registerProtocol()
+	require.NoError(t, err)
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beefy
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// headerDigests contains the BEEFY consensus digests of a header
+type headerDigests struct {
+	// validatorSet is the new validator set, or nil if the set does not change at the header
+	validatorSet *types.BeefyValidatorSet
+	// mmrRoot is the MMR root of the header, or nil if the header has no MMR root digest
+	mmrRoot *common.Hash
+}
+
+// findDigests returns the BEEFY consensus digests found in the header digest.
+func findDigests(header *types.Header) (digests headerDigests, err error) {
+	for _, item := range header.Digest {
+		value, err := item.Value()
+		if err != nil {
+			return digests, fmt.Errorf("getting digest item value: %w", err)
+		}
+
+		consensusDigest, ok := value.(types.ConsensusDigest)
+		if !ok || consensusDigest.ConsensusEngineID != types.BeefyEngineID {
+			continue
+		}
+
+		beefyDigest := types.NewBeefyConsensusDigest()
+		err = scale.Unmarshal(consensusDigest.Data, &beefyDigest)
+		if err != nil {
+			return digests, fmt.Errorf("decoding beefy consensus digest: %w", err)
+		}
+
+		beefyDigestValue, err := beefyDigest.Value()
+		if err != nil {
+			return digests, fmt.Errorf("getting beefy consensus digest value: %w", err)
+		}
+
+		switch beefyDigestValue := beefyDigestValue.(type) {
+		case types.BeefyValidatorSet:
+			digests.validatorSet = &beefyDigestValue
+		case types.BeefyMmrRoot:
+			digests.mmrRoot = &beefyDigestValue.Root
+		}
+	}
+
+	return digests, nil
+}
@@ -0,0 +1,18 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beefy
+
+import "errors"
+
+var (
+	// ErrInvalidKeystoreType is returned when the keystore is not the secp256k1 beefy keystore
+	ErrInvalidKeystoreType = errors.New("invalid keystore type")
+	// ErrInvalidMessageType is returned when a network message is not a BEEFY message
+	ErrInvalidMessageType = errors.New("invalid message type")
+
+	errInvalidSignature        = errors.New("invalid signature")
+	errInvalidSignedCommitment = errors.New("invalid signed commitment")
+	errNotEnoughSignatures     = errors.New("not enough valid signatures")
+	errUnknownAuthority        = errors.New("authority is not in the validator set")
+)
@@ -0,0 +1,47 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beefy
+
+import (
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// BlockState is the interface required by BEEFY into the block state
+type BlockState interface {
+	GenesisHash() common.Hash
+	GetHeaderByNumber(num uint) (*types.Header, error)
+	GetHighestFinalisedHeader() (*types.Header, error)
+	GetFinalisedNotifierChannel() chan *types.FinalisationInfo
+	FreeFinalisedNotifierChannel(ch chan *types.FinalisationInfo)
+	GetRuntime(blockHash common.Hash) (instance runtime.Instance, err error)
+	HasBeefyJustification(hash common.Hash) (bool, error)
+	SetBeefyJustification(hash common.Hash, data []byte) error
+}
+
+// StorageState is the interface required by BEEFY into the storage state
+type StorageState interface {
+	TrieState(root *common.Hash) (*rtstorage.TrieState, error)
+}
+
+// Network is the interface required by BEEFY for the network
+type Network interface {
+	GossipMessage(msg network.NotificationsMessage)
+	ProtocolNames(subprotocol protocol.ID) []protocol.ID
+	RegisterNotificationsProtocol(sub protocol.ID,
+		messageID network.MessageType,
+		handshakeGetter network.HandshakeGetter,
+		handshakeDecoder network.HandshakeDecoder,
+		handshakeValidator network.HandshakeValidator,
+		messageDecoder network.MessageDecoder,
+		messageHandler network.NotificationsMessageHandler,
+		batchHandler network.NotificationsMessageBatchHandler,
+		maxSize uint64,
+		fallbackProtocolIDs ...protocol.ID,
+	) error
+}
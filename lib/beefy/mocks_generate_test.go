@@ -0,0 +1,7 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beefy
+
+//go:generate mockgen -destination=mocks_test.go -package $GOPACKAGE . BlockState,StorageState,Network
+//go:generate mockgen -destination=mock_runtime_instance_test.go -package $GOPACKAGE github.com/ChainSafe/gossamer/lib/runtime Instance
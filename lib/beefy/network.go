@@ -0,0 +1,148 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beefy
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const beefyID2 = "/beefy/2"
+
+// legacyBeefyProtocolID is the legacy name of the BEEFY protocol, used with the peers
+// which don't support the genesis hash based name
+const legacyBeefyProtocolID = "/paritytech/beefy/2"
+
+// Handshake is exchanged by nodes that are beginning the BEEFY protocol
+type Handshake struct {
+	Role common.NetworkRole
+}
+
+// String formats a Handshake as a string
+func (hs *Handshake) String() string {
+	return fmt.Sprintf("BeefyHandshake NetworkRole=%d", hs.Role)
+}
+
+// Encode encodes a Handshake message using SCALE
+func (hs *Handshake) Encode() ([]byte, error) {
+	return scale.Marshal(*hs)
+}
+
+// Decode the message into a Handshake
+func (hs *Handshake) Decode(in []byte) error {
+	return scale.Unmarshal(in, hs)
+}
+
+// IsValid return if it is a valid handshake.
+func (hs *Handshake) IsValid() bool {
+	switch hs.Role {
+	case common.AuthorityRole, common.FullNodeRole:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Service) registerProtocol() error {
+	names := s.network.ProtocolNames(beefyID2)
+	fallbackNames := append(names[1:], legacyBeefyProtocolID)
+
+	return s.network.RegisterNotificationsProtocol(
+		names[0],
+		network.BeefyMsgType,
+		s.getHandshake,
+		decodeHandshake,
+		validateHandshake,
+		decodeNetworkMessage,
+		s.handleNetworkMessage,
+		nil,
+		network.MaxBeefyNotificationSize,
+		fallbackNames...,
+	)
+}
+
+func (s *Service) getHandshake() (network.Handshake, error) {
+	role := common.FullNodeRole
+	if s.authority {
+		role = common.AuthorityRole
+	}
+
+	return &Handshake{
+		Role: role,
+	}, nil
+}
+
+func decodeHandshake(in []byte) (network.Handshake, error) {
+	hs := new(Handshake)
+	err := hs.Decode(in)
+	return hs, err
+}
+
+func validateHandshake(_ peer.ID, _ network.Handshake) error {
+	return nil
+}
+
+func decodeNetworkMessage(in []byte) (network.NotificationsMessage, error) {
+	msg := new(network.BeefyMessage)
+	err := msg.Decode(in)
+	return msg, err
+}
+
+// handleNetworkMessage handles a BEEFY gossip message, and returns true if the message
+// should be propagated to the other peers.
+func (s *Service) handleNetworkMessage(from peer.ID, msg network.NotificationsMessage) (bool, error) {
+	bm, ok := msg.(*network.BeefyMessage)
+	if !ok {
+		return false, ErrInvalidMessageType
+	}
+
+	gossipMsg := newGossipMessage()
+	err := scale.Unmarshal(bm.Data, &gossipMsg)
+	if err != nil {
+		return false, fmt.Errorf("decoding message: %w", err)
+	}
+
+	value, err := gossipMsg.Value()
+	if err != nil {
+		return false, fmt.Errorf("getting message value: %w", err)
+	}
+
+	switch value := value.(type) {
+	case VoteMessage:
+		logger.Tracef("received vote for block #%d from peer %s",
+			value.Commitment.BlockNumber, from)
+		return s.handleVote(&value)
+	case VersionedFinalityProof:
+		signedCommitment, err := value.SignedCommitment()
+		if err != nil {
+			return false, fmt.Errorf("getting signed commitment: %w", err)
+		}
+		logger.Tracef("received finality proof for block #%d from peer %s",
+			signedCommitment.Commitment.BlockNumber, from)
+		return s.handleFinalityProof(signedCommitment)
+	default:
+		return false, fmt.Errorf("%w: %T", ErrInvalidMessageType, value)
+	}
+}
+
+// gossip encodes and gossips the given vote or finality proof
+func (s *Service) gossip(value any) error {
+	gossipMsg := newGossipMessage()
+	err := gossipMsg.SetValue(value)
+	if err != nil {
+		return fmt.Errorf("setting gossip message value: %w", err)
+	}
+
+	encoded, err := scale.Marshal(gossipMsg)
+	if err != nil {
+		return fmt.Errorf("encoding gossip message: %w", err)
+	}
+
+	s.network.GossipMessage(&network.BeefyMessage{Data: encoded})
+	return nil
+}
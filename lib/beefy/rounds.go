@@ -0,0 +1,109 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beefy
+
+import (
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// commitmentVotes are the signatures of the validators for a commitment
+type commitmentVotes struct {
+	commitment Commitment
+	// signatures maps the index of a validator in the set to its signature
+	signatures map[int]Signature
+}
+
+// rounds collects the votes of the validators of a validator set, per block number.
+type rounds struct {
+	validatorSet types.BeefyValidatorSet
+	// votes maps a block number to the votes for each commitment hash
+	votes map[uint32]map[common.Hash]*commitmentVotes
+	// voted maps a block number to the indexes of the validators which voted for the block
+	voted map[uint32]map[int]struct{}
+}
+
+func newRounds(validatorSet types.BeefyValidatorSet) *rounds {
+	return &rounds{
+		validatorSet: validatorSet,
+		votes:        make(map[uint32]map[common.Hash]*commitmentVotes),
+		voted:        make(map[uint32]map[int]struct{}),
+	}
+}
+
+// threshold returns the number of signatures required to justify a commitment,
+// which is more than two thirds of the validators.
+func threshold(validators int) int {
+	if validators == 0 {
+		return 0
+	}
+	return validators - (validators-1)/3
+}
+
+// validatorIndex returns the index of the authority in the validator set,
+// or -1 if the authority is not in the set.
+func (r *rounds) validatorIndex(authorityID types.BeefyAuthorityID) int {
+	for i, validator := range r.validatorSet.Validators {
+		if validator == authorityID {
+			return i
+		}
+	}
+	return -1
+}
+
+// addVote adds the vote, whose signature was verified, for the commitment with the given hash.
+// It returns false if the validator already voted for the block of the commitment, and the
+// signed commitment if the vote completes the threshold of the commitment.
+func (r *rounds) addVote(vote *VoteMessage, commitmentHash common.Hash) (
+	added bool, signedCommitment *SignedCommitment) {
+	index := r.validatorIndex(vote.ID)
+	if index < 0 {
+		return false, nil
+	}
+
+	blockNumber := vote.Commitment.BlockNumber
+	if _, ok := r.voted[blockNumber][index]; ok {
+		return false, nil
+	}
+
+	if r.voted[blockNumber] == nil {
+		r.voted[blockNumber] = make(map[int]struct{})
+		r.votes[blockNumber] = make(map[common.Hash]*commitmentVotes)
+	}
+	r.voted[blockNumber][index] = struct{}{}
+
+	votes, ok := r.votes[blockNumber][commitmentHash]
+	if !ok {
+		votes = &commitmentVotes{
+			commitment: vote.Commitment,
+			signatures: make(map[int]Signature),
+		}
+		r.votes[blockNumber][commitmentHash] = votes
+	}
+	votes.signatures[index] = vote.Signature
+
+	if len(votes.signatures) != threshold(len(r.validatorSet.Validators)) {
+		return true, nil
+	}
+
+	signedCommitment = &SignedCommitment{
+		Commitment: votes.commitment,
+		Signatures: make([]*Signature, len(r.validatorSet.Validators)),
+	}
+	for i, signature := range votes.signatures {
+		signature := signature
+		signedCommitment.Signatures[i] = &signature
+	}
+	return true, signedCommitment
+}
+
+// conclude drops the votes for the blocks up to the given block number.
+func (r *rounds) conclude(blockNumber uint32) {
+	for number := range r.votes {
+		if number <= blockNumber {
+			delete(r.votes, number)
+			delete(r.voted, number)
+		}
+	}
+}
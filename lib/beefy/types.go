@@ -0,0 +1,291 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beefy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// signatureLength is the length of a recoverable ECDSA signature
+const signatureLength = 65
+
+// bitfieldContainerSize is the number of bits of each byte of the signatures bitfield
+const bitfieldContainerSize = 8
+
+// MmrRootID is the payload id of the MMR root hash
+var MmrRootID = PayloadID{'m', 'h'}
+
+// PayloadID is the 2 bytes identifier of a payload item
+type PayloadID [2]byte
+
+// PayloadItem is a SCALE encoded item of a commitment payload
+type PayloadItem struct {
+	ID   PayloadID
+	Data []byte
+}
+
+// Payload is the data signed by the BEEFY validators, as a list of payload items sorted by id
+type Payload []PayloadItem
+
+// Get returns the data of the payload item with the given id, or nil if the payload
+// has no such item.
+func (p Payload) Get(id PayloadID) []byte {
+	for _, item := range p {
+		if item.ID == id {
+			return item.Data
+		}
+	}
+	return nil
+}
+
+// NewMmrRootPayload returns the payload containing only the given MMR root hash
+func NewMmrRootPayload(mmrRoot common.Hash) Payload {
+	return Payload{{ID: MmrRootID, Data: scale.MustMarshal(mmrRoot)}}
+}
+
+// Commitment is the payload of a block signed by a BEEFY validator set
+type Commitment struct {
+	Payload        Payload
+	BlockNumber    uint32
+	ValidatorSetID uint64
+}
+
+// Hash returns the keccak256 hash of the SCALE encoded commitment, which is the
+// message signed by the validators.
+func (c Commitment) Hash() (common.Hash, error) {
+	encoded, err := scale.Marshal(c)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("encoding commitment: %w", err)
+	}
+	return common.Keccak256(encoded)
+}
+
+// Signature is a recoverable ECDSA signature of a commitment
+type Signature [signatureLength]byte
+
+// VoteMessage is the vote of a validator for a commitment
+type VoteMessage struct {
+	Commitment Commitment
+	ID         types.BeefyAuthorityID
+	Signature  Signature
+}
+
+// verifySignature returns an error if the signature of the commitment hash was not made by the authority.
+func verifySignature(hash common.Hash, authorityID types.BeefyAuthorityID, signature Signature) error {
+	// RecoverPublicKeyCompressed updates the recovery byte of the signature in place
+	sig := signature
+	publicKey, err := secp256k1.RecoverPublicKeyCompressed(hash[:], sig[:])
+	if err != nil {
+		return fmt.Errorf("recovering public key: %w", err)
+	}
+
+	if !bytes.Equal(publicKey, authorityID[:]) {
+		return fmt.Errorf("%w: signature of 0x%x", errInvalidSignature, authorityID)
+	}
+	return nil
+}
+
+// SignedCommitment is a commitment with the signatures of the validator set, where the
+// signature of each validator is at the index of the validator in the set, or nil if the
+// validator did not sign the commitment.
+type SignedCommitment struct {
+	Commitment Commitment
+	Signatures []*Signature
+}
+
+// compactSignedCommitment is the encoding of a SignedCommitment where the missing signatures
+// are represented by the bits of a bitfield instead of None options.
+type compactSignedCommitment struct {
+	Commitment        Commitment
+	SignaturesFrom    []byte
+	ValidatorSetLen   uint32
+	SignaturesCompact []Signature
+}
+
+// MarshalSCALE encodes the signed commitment with its compact encoding
+func (sc SignedCommitment) MarshalSCALE() ([]byte, error) {
+	compact := compactSignedCommitment{
+		Commitment:        sc.Commitment,
+		ValidatorSetLen:   uint32(len(sc.Signatures)),
+		SignaturesCompact: []Signature{},
+	}
+
+	// the bits are followed by excess bits so the bitfield fills whole bytes
+	excessBits := bitfieldContainerSize - len(sc.Signatures)%bitfieldContainerSize
+	bits := make([]byte, len(sc.Signatures), len(sc.Signatures)+excessBits)
+	for i, signature := range sc.Signatures {
+		if signature != nil {
+			bits[i] = 1
+			compact.SignaturesCompact = append(compact.SignaturesCompact, *signature)
+		}
+	}
+	bits = bits[:len(bits)+excessBits]
+
+	compact.SignaturesFrom = make([]byte, 0, len(bits)/bitfieldContainerSize)
+	for i := 0; i < len(bits); i += bitfieldContainerSize {
+		var container byte
+		for _, bit := range bits[i : i+bitfieldContainerSize] {
+			container = container<<1 | bit
+		}
+		compact.SignaturesFrom = append(compact.SignaturesFrom, container)
+	}
+
+	return scale.Marshal(compact)
+}
+
+// UnmarshalSCALE decodes the signed commitment from its compact encoding
+func (sc *SignedCommitment) UnmarshalSCALE(reader io.Reader) error {
+	var compact compactSignedCommitment
+	err := scale.NewDecoder(reader).Decode(&compact)
+	if err != nil {
+		return err
+	}
+
+	if uint64(compact.ValidatorSetLen) > uint64(len(compact.SignaturesFrom))*bitfieldContainerSize {
+		return fmt.Errorf("%w: %d validators for a bitfield of %d bytes",
+			errInvalidSignedCommitment, compact.ValidatorSetLen, len(compact.SignaturesFrom))
+	}
+
+	signatures := make([]*Signature, compact.ValidatorSetLen)
+	next := 0
+	for i := range signatures {
+		container := compact.SignaturesFrom[i/bitfieldContainerSize]
+		if (container>>(bitfieldContainerSize-1-i%bitfieldContainerSize))&1 == 0 {
+			continue
+		}
+
+		if next >= len(compact.SignaturesCompact) {
+			return fmt.Errorf("%w: missing signatures", errInvalidSignedCommitment)
+		}
+		signature := compact.SignaturesCompact[next]
+		signatures[i] = &signature
+		next++
+	}
+
+	sc.Commitment = compact.Commitment
+	sc.Signatures = signatures
+	return nil
+}
+
+// VersionedFinalityProof is the BEEFY justification of a block
+type VersionedFinalityProof struct {
+	inner any
+}
+
+// SetValue sets the value of the versioned finality proof
+func (vfp *VersionedFinalityProof) SetValue(value any) (err error) {
+	switch value := value.(type) {
+	case SignedCommitment:
+		vfp.inner = value
+		return
+	default:
+		return fmt.Errorf("unsupported type")
+	}
+}
+
+// IndexValue returns the index and the value of the versioned finality proof
+func (vfp VersionedFinalityProof) IndexValue() (index uint, value any, err error) {
+	switch vfp.inner.(type) {
+	case SignedCommitment:
+		return 1, vfp.inner, nil
+	}
+	return 0, nil, scale.ErrUnsupportedVaryingDataTypeValue
+}
+
+// Value returns the value of the versioned finality proof
+func (vfp VersionedFinalityProof) Value() (value any, err error) {
+	_, value, err = vfp.IndexValue()
+	return
+}
+
+// ValueAt returns a zero value of the type at the given index
+func (VersionedFinalityProof) ValueAt(index uint) (value any, err error) {
+	switch index {
+	case 1:
+		return *new(SignedCommitment), nil
+	}
+	return nil, scale.ErrUnknownVaryingDataTypeValue
+}
+
+// NewVersionedFinalityProof returns the versioned finality proof of the given signed commitment
+func NewVersionedFinalityProof(signedCommitment SignedCommitment) VersionedFinalityProof {
+	return VersionedFinalityProof{inner: signedCommitment}
+}
+
+// SignedCommitment returns the signed commitment of the finality proof
+func (vfp VersionedFinalityProof) SignedCommitment() (SignedCommitment, error) {
+	value, err := vfp.Value()
+	if err != nil {
+		return SignedCommitment{}, err
+	}
+	return value.(SignedCommitment), nil
+}
+
+type gossipMessageValues interface {
+	VoteMessage | VersionedFinalityProof
+}
+
+// gossipMessage is a message gossiped over the BEEFY notifications protocol
+type gossipMessage struct {
+	inner any
+}
+
+func setGossipMessage[Value gossipMessageValues](mvdt *gossipMessage, value Value) {
+	mvdt.inner = value
+}
+
+func (mvdt *gossipMessage) SetValue(value any) (err error) {
+	switch value := value.(type) {
+	case VoteMessage:
+		setGossipMessage(mvdt, value)
+		return
+
+	case VersionedFinalityProof:
+		setGossipMessage(mvdt, value)
+		return
+
+	default:
+		return fmt.Errorf("unsupported type")
+	}
+}
+
+func (mvdt gossipMessage) IndexValue() (index uint, value any, err error) {
+	switch mvdt.inner.(type) {
+	case VoteMessage:
+		return 0, mvdt.inner, nil
+
+	case VersionedFinalityProof:
+		return 1, mvdt.inner, nil
+
+	}
+	return 0, nil, scale.ErrUnsupportedVaryingDataTypeValue
+}
+
+func (mvdt gossipMessage) Value() (value any, err error) {
+	_, value, err = mvdt.IndexValue()
+	return
+}
+
+func (mvdt gossipMessage) ValueAt(index uint) (value any, err error) {
+	switch index {
+	case 0:
+		return *new(VoteMessage), nil
+
+	case 1:
+		return *new(VersionedFinalityProof), nil
+
+	}
+	return nil, scale.ErrUnknownVaryingDataTypeValue
+}
+
+func newGossipMessage() gossipMessage {
+	return gossipMessage{}
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package beefy
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignedCommitment_encoding(t *testing.T) {
+	t.Parallel()
+
+	signature1 := Signature{1, 2}
+	signature2 := Signature{3, 4}
+	commitment := Commitment{
+		Payload:        Payload{{ID: MmrRootID, Data: scale.MustMarshal("Hello World!")}},
+		BlockNumber:    5,
+		ValidatorSetID: 0,
+	}
+
+	tests := map[string]struct {
+		signedCommitment SignedCommitment
+		encoded          []byte
+	}{
+		"partial_signatures": {
+			signedCommitment: SignedCommitment{
+				Commitment: commitment,
+				Signatures: []*Signature{nil, nil, &signature1, &signature2},
+			},
+			encoded: concatBytes(
+				common.MustHexToBytes("0x046d68343048656c6c6f20576f726c6421"+ // payload
+					"05000000"+ // block number
+					"0000000000000000"+ // validator set id
+					"0430"+ // signatures bitfield
+					"04000000"+ // validator set length
+					"08"), // signatures length
+				signature1[:], signature2[:]),
+		},
+		"full_bitfield_byte": {
+			signedCommitment: SignedCommitment{
+				Commitment: commitment,
+				Signatures: []*Signature{&signature1, nil, nil, nil, nil, nil, nil, &signature2},
+			},
+			encoded: concatBytes(
+				common.MustHexToBytes("0x046d68343048656c6c6f20576f726c6421"+
+					"05000000"+
+					"0000000000000000"+
+					"088100"+ // the excess bits fill a whole byte
+					"08000000"+
+					"08"),
+				signature1[:], signature2[:]),
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := scale.Marshal(tt.signedCommitment)
+			require.NoError(t, err)
+			assert.Equal(t, tt.encoded, encoded)
+
+			var decoded SignedCommitment
+			err = scale.Unmarshal(encoded, &decoded)
+			require.NoError(t, err)
+			assert.Equal(t, tt.signedCommitment, decoded)
+		})
+	}
+}
+
+func TestSignedCommitment_UnmarshalSCALE_missingSignatures(t *testing.T) {
+	t.Parallel()
+
+	encoded := common.MustHexToBytes("0x00" + // empty payload
+		"05000000" +
+		"0000000000000000" +
+		"04c0" + // two signatures in the bitfield
+		"02000000" +
+		"00") // no signature
+
+	var decoded SignedCommitment
+	err := scale.Unmarshal(encoded, &decoded)
+	assert.ErrorIs(t, err, errInvalidSignedCommitment)
+}
+
+func TestGossipMessage_finalityProof(t *testing.T) {
+	t.Parallel()
+
+	signature := Signature{1}
+	signedCommitment := SignedCommitment{
+		Commitment: Commitment{
+			Payload:        NewMmrRootPayload(common.Hash{2}),
+			BlockNumber:    3,
+			ValidatorSetID: 4,
+		},
+		Signatures: []*Signature{&signature, nil},
+	}
+
+	msg := newGossipMessage()
+	err := msg.SetValue(NewVersionedFinalityProof(signedCommitment))
+	require.NoError(t, err)
+
+	encoded, err := scale.Marshal(msg)
+	require.NoError(t, err)
+	// the finality proof message index is followed by the version 1 index
+	assert.Equal(t, []byte{1, 1}, encoded[:2])
+
+	decoded := newGossipMessage()
+	err = scale.Unmarshal(encoded, &decoded)
+	require.NoError(t, err)
+
+	value, err := decoded.Value()
+	require.NoError(t, err)
+	finalityProof, ok := value.(VersionedFinalityProof)
+	require.True(t, ok)
+	decodedSignedCommitment, err := finalityProof.SignedCommitment()
+	require.NoError(t, err)
+	assert.Equal(t, signedCommitment, decodedSignedCommitment)
+	assert.Equal(t, scale.MustMarshal(common.Hash{2}), decodedSignedCommitment.Commitment.Payload.Get(MmrRootID))
+}
+
+func concatBytes(slices ...[]byte) (concatenated []byte) {
+	for _, slice := range slices {
+		concatenated = append(concatenated, slice...)
+	}
+	return concatenated
+}
@@ -22,6 +22,8 @@
 	NodeNameKey = []byte("node_name")
 	// PruningKey is the storage key to store the current pruning configuration.
 	PruningKey = []byte("prune")
+	// StorageBackendKey is the storage key to store the storage state backend used with the database.
+	StorageBackendKey = []byte("storage_backend")
 	// CodeSubstitutedBlock is the storage key to store block hash of substituted (if there is currently code substituted)
 	CodeSubstitutedBlock = []byte("code_substituted_block")
 )
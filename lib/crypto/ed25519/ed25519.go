@@ -13,6 +13,7 @@
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 
 	"github.com/ChainSafe/go-schnorrkel"
 )
@@ -132,6 +133,40 @@ func NewKeypairFromMnenomic(mnemonic, password string) (*Keypair, error) {
 	return NewKeypairFromSeed(seed[:32])
 }
 
+// NewKeypairFromSecretURI returns a new Keypair derived from the given secret URI, which only
+// supports hard derivation junctions.
+func NewKeypairFromSecretURI(suri string) (*Keypair, error) {
+	parsed, err := crypto.ParseSecretURI(suri)
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := parsed.Seed()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, junction := range parsed.Junctions {
+		if !junction.Hard {
+			return nil, fmt.Errorf("ed25519: %w", crypto.ErrSoftDerivationNotSupported)
+		}
+		seed = deriveHardJunction(seed, junction.ChainCode)
+	}
+
+	return NewKeypairFromSeed(seed)
+}
+
+// deriveHardJunction returns the seed derived from the given seed and chain code
+func deriveHardJunction(seed []byte, chainCode [crypto.ChainCodeLength]byte) []byte {
+	encoded := scale.MustMarshal(struct {
+		ID        string
+		Seed      [crypto.SeedLength]byte
+		ChainCode [crypto.ChainCodeLength]byte
+	}{"Ed25519HDKD", [crypto.SeedLength]byte(seed), chainCode})
+	derived := common.MustBlake2bHash(encoded)
+	return derived[:]
+}
+
 // GenerateKeypair returns a new ed25519 keypair
 func GenerateKeypair() (*Keypair, error) {
 	buf := make([]byte, SeedLength)
@@ -178,3 +178,42 @@ func TestPublicKeyFromPrivate(t *testing.T) {
 	addr := crypto.PublicKeyToAddress(kp.Public())
 	require.Equal(t, "5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu", string(addr))
 }
+
+func TestNewKeypairFromSecretURI(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		suri      string
+		publicKey string
+		errMsg    string
+	}{
+		"dev_phrase_hard_junction": {
+			// public key of `subkey inspect --scheme ed25519 //Alice`
+			suri:      "//Alice",
+			publicKey: "0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee",
+		},
+		"hex_seed": {
+			suri:      "0xabf8e5bdbe30c65656c0a3cbd181ff8a56294a69dfedd27982aace4a76909115",
+			publicKey: "0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee",
+		},
+		"soft_junction": {
+			suri:   "//Alice/soft",
+			errMsg: "ed25519: soft derivation not supported",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			kp, err := NewKeypairFromSecretURI(testCase.suri)
+			if testCase.errMsg != "" {
+				require.EqualError(t, err, testCase.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.publicKey, kp.Public().Hex())
+		})
+	}
+}
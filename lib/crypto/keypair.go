@@ -4,13 +4,17 @@
 package crypto
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ss58"
 
-	"github.com/btcsuite/btcutil/base58"
 	bip39 "github.com/cosmos/go-bip39"
-	"golang.org/x/crypto/blake2b"
 )
 
+var ErrInvalidWordCount = errors.New("invalid mnemonic word count")
+
 // KeyType str
 type KeyType = string
 
@@ -44,41 +48,63 @@ type PrivateKey interface {
 	Hex() string
 }
 
-var ss58Prefix = []byte("SS58PRE")
-
-// PublicKeyToAddress returns an ss58 address given a PublicKey
+// PublicKeyToAddress returns an ss58 address given a PublicKey, using the generic substrate address type
 // see: https://github.com/paritytech/substrate/wiki/External-Address-Format-(SS58)
-// also see: https://github.com/paritytech/substrate/blob/master/primitives/core/src/crypto.rs#L275
 func PublicKeyToAddress(pub PublicKey) common.Address {
-	enc := append([]byte{42}, pub.Encode()...)
-	return publicKeyBytesToAddress(enc)
+	return PublicKeyToAddressWithPrefix(pub, ss58.GenericPrefix)
 }
 
-func publicKeyBytesToAddress(b []byte) common.Address {
-	hasher, err := blake2b.New(64, nil)
+// PublicKeyToAddressWithPrefix returns an ss58 address given a PublicKey and the address type of the network
+func PublicKeyToAddressWithPrefix(pub PublicKey, prefix uint16) common.Address {
+	address, err := ss58.Encode(pub.Encode(), prefix)
 	if err != nil {
 		return ""
 	}
-	_, err = hasher.Write(append(ss58Prefix, b...))
-	if err != nil {
-		return ""
-	}
-	checksum := hasher.Sum(nil)
-	return common.Address(base58.Encode(append(b, checksum[:2]...)))
+	return common.Address(address)
 }
 
-// PublicAddressToByteArray returns []byte address for given PublicKey Address
+// PublicAddressToByteArray returns []byte address for given PublicKey Address, or nil if the address
+// is not a valid ss58 address
 func PublicAddressToByteArray(add common.Address) []byte {
 	if add == "" {
 		return nil
 	}
-	k := base58.Decode(string(add))
-	return k[1:33]
+
+	pub, _, err := ss58.Decode(string(add))
+	if err != nil {
+		return nil
+	}
+	return pub
+}
+
+// PublicKeyToAccountID returns the account id of a PublicKey, which is the public key itself for the 32 bytes
+// sr25519 and ed25519 public keys, and the blake2b hash of the compressed public key for the secp256k1 public keys
+func PublicKeyToAccountID(pub PublicKey) []byte {
+	encoded := pub.Encode()
+	if len(encoded) == common.HashLength {
+		return encoded
+	}
+
+	hash := common.MustBlake2bHash(encoded)
+	return hash[:]
 }
 
-// NewBIP39Mnemonic returns a new BIP39-compatible mnemonic
+// NewBIP39Mnemonic returns a new BIP39-compatible mnemonic of 12 words
 func NewBIP39Mnemonic() (string, error) {
-	entropy, err := bip39.NewEntropy(128)
+	return NewBIP39MnemonicWithWordCount(12)
+}
+
+// NewBIP39MnemonicWithWordCount returns a new BIP39-compatible mnemonic with the given number of words,
+// which is either 12, 15, 18, 21 or 24
+func NewBIP39MnemonicWithWordCount(words int) (string, error) {
+	switch words {
+	case 12, 15, 18, 21, 24:
+	default:
+		return "", fmt.Errorf("%w: %d", ErrInvalidWordCount, words)
+	}
+
+	// every word encodes 11 bits, and every 3 words hold 1 bit of checksum
+	entropy, err := bip39.NewEntropy(words * 32 / 3)
 	if err != nil {
 		return "", err
 	}
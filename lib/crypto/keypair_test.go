@@ -4,9 +4,12 @@
 package crypto_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 
 	"github.com/stretchr/testify/require"
@@ -22,3 +25,29 @@ func TestPubkeyToAddress(t *testing.T) {
 	a := pk.Address()
 	require.Equal(t, addr, string(a))
 }
+
+func TestPublicKeyToAccountID(t *testing.T) {
+	srKp, err := sr25519.NewKeypairFromSecretURI("//Alice")
+	require.NoError(t, err)
+	require.Equal(t, srKp.Public().Encode(), crypto.PublicKeyToAccountID(srKp.Public()))
+
+	ecdsaKp, err := secp256k1.NewKeypairFromSecretURI("//Alice")
+	require.NoError(t, err)
+	hash := common.MustBlake2bHash(ecdsaKp.Public().Encode())
+	require.Equal(t, hash[:], crypto.PublicKeyToAccountID(ecdsaKp.Public()))
+}
+
+func TestNewBIP39MnemonicWithWordCount(t *testing.T) {
+	for _, words := range []int{12, 15, 18, 21, 24} {
+		mnemonic, err := crypto.NewBIP39MnemonicWithWordCount(words)
+		require.NoError(t, err)
+		require.Len(t, strings.Fields(mnemonic), words)
+
+		_, err = sr25519.NewKeypairFromMnenomic(mnemonic, "")
+		require.NoError(t, err)
+	}
+
+	_, err := crypto.NewBIP39MnemonicWithWordCount(13)
+	require.ErrorIs(t, err, crypto.ErrInvalidWordCount)
+	require.EqualError(t, err, "invalid mnemonic word count: 13")
+}
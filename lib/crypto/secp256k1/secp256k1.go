@@ -9,14 +9,19 @@
 	"errors"
 	"fmt"
 
+	"github.com/ChainSafe/go-schnorrkel"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	secp256k1 "github.com/ethereum/go-ethereum/crypto"
 )
 
 // PrivateKeyLength is the fixed Private Key Length
 const PrivateKeyLength = 32
 
+// PublicKeyLength is the length of a compressed public key
+const PublicKeyLength = 33
+
 // SignatureLength is the fixed Signature Length
 const SignatureLength = 64
 
@@ -112,6 +117,26 @@ func NewPrivateKey(in []byte) (*PrivateKey, error) {
 	return priv, err
 }
 
+// NewKeypairFromSeed returns a Keypair given a 32 byte seed, which is used as the private key
+func NewKeypairFromSeed(seed []byte) (*Keypair, error) {
+	priv, err := NewPrivateKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeypairFromPrivate(priv)
+}
+
+// NewKeypairFromMnenomic returns a new Keypair using the first 32 bytes
+// of the bip39 seed of the given mnemonic as its private key
+func NewKeypairFromMnenomic(mnemonic, password string) (*Keypair, error) {
+	seed, err := schnorrkel.SeedFromMnemonic(mnemonic, password)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeypairFromSeed(seed[:PrivateKeyLength])
+}
+
 // NewKeypairFromPrivateKeyString returns a Keypair given a 0x prefixed private key string
 func NewKeypairFromPrivateKeyString(in string) (*Keypair, error) {
 	privBytes, err := common.HexToBytes(in)
@@ -127,6 +152,40 @@ func NewKeypairFromPrivateKeyString(in string) (*Keypair, error) {
 	return NewKeypairFromPrivate(priv)
 }
 
+// NewKeypairFromSecretURI returns a new Keypair derived from the given secret URI, which only
+// supports hard derivation junctions.
+func NewKeypairFromSecretURI(suri string) (*Keypair, error) {
+	parsed, err := crypto.ParseSecretURI(suri)
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := parsed.Seed()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, junction := range parsed.Junctions {
+		if !junction.Hard {
+			return nil, fmt.Errorf("secp256k1: %w", crypto.ErrSoftDerivationNotSupported)
+		}
+		seed = deriveHardJunction(seed, junction.ChainCode)
+	}
+
+	return NewKeypairFromSeed(seed)
+}
+
+// deriveHardJunction returns the seed derived from the given seed and chain code
+func deriveHardJunction(seed []byte, chainCode [crypto.ChainCodeLength]byte) []byte {
+	encoded := scale.MustMarshal(struct {
+		ID        string
+		Seed      [crypto.SeedLength]byte
+		ChainCode [crypto.ChainCodeLength]byte
+	}{"Secp256k1HDKD", [crypto.SeedLength]byte(seed), chainCode})
+	derived := common.MustBlake2bHash(encoded)
+	return derived[:]
+}
+
 // GenerateKeypair will generate a Keypair
 func GenerateKeypair() (*Keypair, error) {
 	priv, err := secp256k1.GenerateKey()
@@ -174,6 +233,20 @@ func (k *PublicKey) Verify(msg, sig []byte) (bool, error) {
 	return secp256k1.VerifySignature(k.Encode(), msg, sig), nil
 }
 
+// NewPublicKey returns a secp256k1 public key from its 33 byte compressed encoding
+func NewPublicKey(in []byte) (*PublicKey, error) {
+	if len(in) != PublicKeyLength {
+		return nil, fmt.Errorf("cannot create public key: input is not %d bytes", PublicKeyLength)
+	}
+
+	pub := new(PublicKey)
+	err := pub.Decode(in)
+	if err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
 // UnmarshalPubkey converts [65]byte to a secp256k1 public key.
 func (k *PublicKey) UnmarshalPubkey(pub []byte) error {
 	pubKey, err := secp256k1.UnmarshalPubkey(pub)
@@ -201,3 +201,67 @@ func TestVerifySignature(t *testing.T) {
 	}
 
 }
+
+func TestNewKeypairFromMnenomic(t *testing.T) {
+	mnemonic := "twist sausage october vivid neglect swear crumble hawk beauty fabric egg fragile"
+	kp, err := NewKeypairFromMnenomic(mnemonic, "")
+	require.NoError(t, err)
+
+	again, err := NewKeypairFromMnenomic(mnemonic, "")
+	require.NoError(t, err)
+	require.Equal(t, kp.Public().Encode(), again.Public().Encode())
+
+	other, err := NewKeypairFromMnenomic(mnemonic, "password")
+	require.NoError(t, err)
+	require.NotEqual(t, kp.Public().Encode(), other.Public().Encode())
+
+	_, err = NewKeypairFromMnenomic("not a mnemonic", "")
+	require.Error(t, err)
+}
+
+func TestNewPublicKey(t *testing.T) {
+	kp, err := GenerateKeypair()
+	require.NoError(t, err)
+
+	pub, err := NewPublicKey(kp.Public().Encode())
+	require.NoError(t, err)
+	require.Equal(t, kp.Public(), pub)
+
+	_, err = NewPublicKey(kp.Public().Encode()[1:])
+	require.EqualError(t, err, "cannot create public key: input is not 33 bytes")
+}
+
+func TestNewKeypairFromSecretURI(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		suri      string
+		publicKey string
+		errMsg    string
+	}{
+		"dev_phrase_hard_junction": {
+			// public key of `subkey inspect --scheme ecdsa //Alice`
+			suri:      "//Alice",
+			publicKey: "0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1",
+		},
+		"soft_junction": {
+			suri:   "//Alice/soft",
+			errMsg: "secp256k1: soft derivation not supported",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			kp, err := NewKeypairFromSecretURI(testCase.suri)
+			if testCase.errMsg != "" {
+				require.EqualError(t, err, testCase.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.publicKey, kp.Public().Hex())
+		})
+	}
+}
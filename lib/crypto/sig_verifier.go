@@ -5,8 +5,9 @@
 
 import (
 	"errors"
+	"runtime"
 	"sync"
-	"time"
+	"sync/atomic"
 )
 
 var ErrSignatureVerificationFailed = errors.New("failed to verify signature")
@@ -14,7 +15,8 @@
 // SigVerifyFunc verifies a signature given a public key and a message
 type SigVerifyFunc func(pubkey, sig, msg []byte) (err error)
 
-// SignatureInfo ...
+// SignatureInfo holds a signature to verify, together with its public key,
+// its message and the function to verify it.
 type SignatureInfo struct {
 	PubKey     []byte
 	Sign       []byte
@@ -22,16 +24,23 @@ type SignatureInfo struct {
 	VerifyFunc SigVerifyFunc
 }
 
-// SignatureVerifier ...
+// SignatureVerifier verifies batches of signatures in the background,
+// using a pool of workers.
 type SignatureVerifier struct {
-	batch   []*SignatureInfo
-	init    bool // Indicates whether the batch processing is started.
-	invalid bool // Set to true if any signature verification fails.
 	logger  Erroer
-	closeCh chan struct{}
+	workers int
+
+	// batch holds the signatures added before the verification is started.
+	batch []*SignatureInfo
+	// init indicates whether the batch verification is started.
+	init bool
+	// signatures is the channel feeding the workers, and is nil if
+	// the batch verification is not started.
+	signatures chan *SignatureInfo
+	// invalid is set to true if any signature verification fails.
+	invalid atomic.Bool
+	wg      sync.WaitGroup
 	sync.RWMutex
-	sync.Once
-	sync.WaitGroup
 }
 
 // NewSignatureVerifier initialises SignatureVerifier which does background verification of signatures.
@@ -40,68 +49,75 @@ type SignatureVerifier struct {
 // Signatures can be added to the batch using Add().
 func NewSignatureVerifier(logger Erroer) *SignatureVerifier {
 	return &SignatureVerifier{
-		batch:   make([]*SignatureInfo, 0),
-		init:    false,
-		invalid: false,
 		logger:  logger,
-		RWMutex: sync.RWMutex{},
-		closeCh: make(chan struct{}),
+		workers: runtime.NumCPU(),
+		batch:   make([]*SignatureInfo, 0),
 	}
 }
 
-// Start signature verification in batch.
+// Start starts the batch signature verification, spawning the verification workers.
+// Signatures added before the start are verified first. If the verification is
+// already started, the current batch is discarded.
 func (sv *SignatureVerifier) Start() {
-	// Update the init state.
 	sv.Lock()
+	defer sv.Unlock()
+
+	if sv.init {
+		sv.stop()
+		sv.reset()
+	}
+
 	sv.init = true
-	sv.Unlock()
-
-	sv.WaitGroup.Add(1)
-
-	go func() {
-		defer sv.Done()
-		for {
-			select {
-			case <-sv.closeCh:
-				return
-			default:
-				signature := sv.Remove()
-				if signature == nil {
-					continue
-				}
-				err := signature.VerifyFunc(signature.PubKey, signature.Sign, signature.Msg)
-				if err != nil {
-					sv.logger.Errorf("[ext_crypto_start_batch_verify_version_1]: %s", err)
-					sv.Invalid()
-					return
-				}
-			}
+	sv.signatures = make(chan *SignatureInfo, sv.workers)
+	for i := 0; i < sv.workers; i++ {
+		sv.wg.Add(1)
+		go sv.verify(sv.signatures)
+	}
+
+	batch := sv.batch
+	sv.batch = make([]*SignatureInfo, 0)
+	for _, signature := range batch {
+		sv.signatures <- signature
+	}
+}
+
+// verify verifies the signatures received on the given channel,
+// until the channel is closed. Once a signature is invalid, the remaining
+// signatures are drained without being verified.
+func (sv *SignatureVerifier) verify(signatures <-chan *SignatureInfo) {
+	defer sv.wg.Done()
+	for signature := range signatures {
+		if sv.invalid.Load() {
+			continue
+		}
+
+		err := signature.VerifyFunc(signature.PubKey, signature.Sign, signature.Msg)
+		if err != nil {
+			sv.logger.Errorf("[ext_crypto_start_batch_verify_version_1]: %s", err)
+			sv.invalid.Store(true)
 		}
-	}()
+	}
 }
 
-// IsStarted ...
+// IsStarted returns true if the batch signature verification is started.
 func (sv *SignatureVerifier) IsStarted() bool {
 	sv.RLock()
 	defer sv.RUnlock()
 	return sv.init
 }
 
-// IsInvalid ...
+// IsInvalid returns true if any signature of the batch failed verification.
 func (sv *SignatureVerifier) IsInvalid() bool {
-	sv.RLock()
-	defer sv.RUnlock()
-	return sv.invalid
+	return sv.invalid.Load()
 }
 
-// Invalid ...
+// Invalid marks the batch as invalid.
 func (sv *SignatureVerifier) Invalid() {
-	sv.RLock()
-	defer sv.RUnlock()
-	sv.invalid = true
+	sv.invalid.Store(true)
 }
 
-// Add ...
+// Add adds a signature to the batch. If the batch verification is started,
+// the signature is verified in the background.
 func (sv *SignatureVerifier) Add(s *SignatureInfo) {
 	if sv.IsInvalid() {
 		return
@@ -109,10 +125,15 @@ func (sv *SignatureVerifier) Add(s *SignatureInfo) {
 
 	sv.Lock()
 	defer sv.Unlock()
+	if sv.signatures != nil {
+		sv.signatures <- s
+		return
+	}
 	sv.batch = append(sv.batch, s)
 }
 
-// Remove returns the first signature from the batch. Returns nil if batch is empty.
+// Remove returns the first signature from the batch of signatures
+// not yet sent to the workers. Returns nil if this batch is empty.
 func (sv *SignatureVerifier) Remove() *SignatureInfo {
 	sv.Lock()
 	defer sv.Unlock()
@@ -120,35 +141,57 @@ func (sv *SignatureVerifier) Remove() *SignatureInfo {
 		return nil
 	}
 	sign := sv.batch[0]
-	sv.batch = sv.batch[1:len(sv.batch)]
+	sv.batch = sv.batch[1:]
 	return sign
 }
 
-// Reset reset the signature verifier for reuse.
+// Reset stops the verification workers and resets the signature verifier for reuse.
 func (sv *SignatureVerifier) Reset() {
 	sv.Lock()
 	defer sv.Unlock()
-	sv.init = false
-	sv.batch = make([]*SignatureInfo, 0)
-	sv.invalid = false
-	sv.closeCh = make(chan struct{})
+	sv.stop()
+	sv.reset()
 }
 
-// Finish waits till batch is finished. Returns true if all the signatures are valid, Otherwise returns false.
+// Finish waits for all the signatures of the batch to be verified and resets the
+// signature verifier. Returns true if all the signatures are valid, otherwise returns false.
 func (sv *SignatureVerifier) Finish() bool {
-	for {
-		time.Sleep(100 * time.Millisecond)
-		sv.Lock()
-		if sv.invalid || len(sv.batch) == 0 {
-			close(sv.closeCh)
-			sv.Unlock()
+	sv.Lock()
+	defer sv.Unlock()
+
+	sv.stop()
+	// verify the signatures added without starting the verification
+	for _, signature := range sv.batch {
+		if sv.invalid.Load() {
 			break
 		}
-		sv.RUnlock()
+		err := signature.VerifyFunc(signature.PubKey, signature.Sign, signature.Msg)
+		if err != nil {
+			sv.logger.Errorf("[ext_crypto_finish_batch_verify_version_1]: %s", err)
+			sv.invalid.Store(true)
+		}
 	}
-	// Wait till start function to finish and then reset it.
-	sv.Wait()
-	isInvalid := sv.IsInvalid()
-	sv.Reset()
-	return !isInvalid
+
+	valid := !sv.invalid.Load()
+	sv.reset()
+	return valid
+}
+
+// stop closes the signatures channel and waits for the workers to return.
+// It must be called with the lock held.
+func (sv *SignatureVerifier) stop() {
+	if sv.signatures == nil {
+		return
+	}
+	close(sv.signatures)
+	sv.signatures = nil
+	sv.wg.Wait()
+}
+
+// reset resets the batch state. It must be called with the lock held,
+// after the workers are stopped.
+func (sv *SignatureVerifier) reset() {
+	sv.init = false
+	sv.batch = make([]*SignatureInfo, 0)
+	sv.invalid.Store(false)
 }
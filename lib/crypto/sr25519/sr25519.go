@@ -159,6 +159,50 @@ func NewKeypairFromMnenomic(mnemonic, password string) (*Keypair, error) {
 	}, nil
 }
 
+// NewKeypairFromSecretURI returns a new Keypair derived from the given secret URI, supporting
+// both hard and soft derivation junctions.
+func NewKeypairFromSecretURI(suri string) (*Keypair, error) {
+	parsed, err := crypto.ParseSecretURI(suri)
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := parsed.Seed()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := [SeedLength]byte{}
+	copy(buf[:], seed)
+	msc, err := sr25519.NewMiniSecretKeyFromRaw(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	priv := msc.ExpandEd25519()
+	for _, junction := range parsed.Junctions {
+		if junction.Hard {
+			derived, _, err := priv.HardDeriveMiniSecretKey([]byte{}, junction.ChainCode)
+			if err != nil {
+				return nil, fmt.Errorf("hard deriving key: %w", err)
+			}
+			priv = derived.ExpandEd25519()
+			continue
+		}
+
+		derived, err := sr25519.DeriveKeySimple(priv, []byte{}, junction.ChainCode)
+		if err != nil {
+			return nil, fmt.Errorf("soft deriving key: %w", err)
+		}
+		priv, err = derived.Secret()
+		if err != nil {
+			return nil, fmt.Errorf("getting soft derived key: %w", err)
+		}
+	}
+
+	return NewKeypair(priv)
+}
+
 // NewPrivateKey creates a new private key using the input bytes
 func NewPrivateKey(in []byte) (*PrivateKey, error) {
 	if len(in) != PrivateKeyLength {
@@ -9,6 +9,7 @@
 	"fmt"
 	"testing"
 
+	sr25519 "github.com/ChainSafe/go-schnorrkel"
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	bip39 "github.com/cosmos/go-bip39"
 	"github.com/gtank/merlin"
@@ -182,3 +183,78 @@ func TestVerifySignature(t *testing.T) {
 	}
 
 }
+
+func TestNewKeypairFromSecretURI(t *testing.T) {
+	t.Parallel()
+
+	// public key of `subkey inspect //Alice`
+	const alicePublicKey = "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"
+
+	testCases := map[string]struct {
+		suri      string
+		publicKey string
+		errMsg    string
+	}{
+		"dev_phrase_hard_junction": {
+			suri:      "//Alice",
+			publicKey: alicePublicKey,
+		},
+		"explicit_dev_phrase": {
+			suri:      crypto.DevPhrase + "//Alice",
+			publicKey: alicePublicKey,
+		},
+		"hex_seed": {
+			suri:      "0xe5be9a5092b81bca64be81d212e7f2f9eba183bb7a90954f7b76361f6edb5c0a",
+			publicKey: alicePublicKey,
+		},
+		"invalid_mnemonic": {
+			suri:   "invalid mnemonic//Alice",
+			errMsg: "getting seed from mnemonic: Invalid mnemonic",
+		},
+		"invalid_hex_seed_length": {
+			suri:   "0x0102",
+			errMsg: "invalid secret URI: seed is 2 bytes long instead of 32",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			kp, err := NewKeypairFromSecretURI(testCase.suri)
+			if testCase.errMsg != "" {
+				require.EqualError(t, err, testCase.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.publicKey, kp.Public().Hex())
+		})
+	}
+}
+
+func TestNewKeypairFromSecretURI_softJunction(t *testing.T) {
+	t.Parallel()
+
+	parent, err := NewKeypairFromSecretURI("//Alice")
+	require.NoError(t, err)
+
+	kp, err := NewKeypairFromSecretURI("//Alice/soft")
+	require.NoError(t, err)
+
+	// the public key of a soft derived key can be derived from the parent public key
+	chainCode := crypto.NewDeriveJunction("soft", false).ChainCode
+	derived, err := sr25519.DeriveKeySimple(parent.public.key, []byte{}, chainCode)
+	require.NoError(t, err)
+	expected, err := derived.Public()
+	require.NoError(t, err)
+	require.Equal(t, expected.Encode(), kp.public.key.Encode())
+
+	// the soft derived key signs messages verifiable with its public key
+	msg := []byte("helloworld")
+	sig, err := kp.Sign(msg)
+	require.NoError(t, err)
+	ok, err := kp.Public().Verify(msg, sig)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
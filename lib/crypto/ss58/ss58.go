@@ -0,0 +1,167 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package ss58 implements the SS58 address format of the Substrate based chains.
+// see: https://docs.substrate.io/reference/address-formats/
+package ss58
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Address type prefixes of the well known networks
+const (
+	// PolkadotPrefix is the address type of the Polkadot relay chain
+	PolkadotPrefix uint16 = 0
+	// KusamaPrefix is the address type of the Kusama relay chain
+	KusamaPrefix uint16 = 2
+	// GenericPrefix is the address type of the generic Substrate chains
+	GenericPrefix uint16 = 42
+)
+
+const (
+	// maxSimplePrefix is the highest address type encoded in the one byte simple format
+	maxSimplePrefix = 63
+	// maxPrefix is the highest address type encoded in the two bytes full format
+	maxPrefix = 16383
+)
+
+var (
+	ErrInvalidPrefix   = errors.New("invalid address type")
+	ErrReservedPrefix  = errors.New("reserved address type")
+	ErrInvalidFormat   = errors.New("invalid base58 format")
+	ErrInvalidLength   = errors.New("invalid address length")
+	ErrInvalidChecksum = errors.New("invalid checksum")
+)
+
+var checksumPrefix = []byte("SS58PRE")
+
+// checksumLength returns the length of the checksum of the payload with the given length
+func checksumLength(payloadLength int) (int, error) {
+	switch payloadLength {
+	case 1, 2, 4, 8:
+		return 1, nil
+	case 32, 33:
+		return 2, nil
+	default:
+		return 0, fmt.Errorf("%w: payload of %d bytes", ErrInvalidLength, payloadLength)
+	}
+}
+
+// encodePrefix returns the address type prefix in its simple format if it is lower than 64,
+// and in its full format otherwise.
+func encodePrefix(prefix uint16) ([]byte, error) {
+	switch {
+	case prefix == 46 || prefix == 47:
+		return nil, fmt.Errorf("%w: %d", ErrReservedPrefix, prefix)
+	case prefix <= maxSimplePrefix:
+		return []byte{byte(prefix)}, nil
+	case prefix <= maxPrefix:
+		// the lowest 6 bits of the first byte are the bits 2 to 7 of the prefix, and the second
+		// byte holds the bits 0 and 1 of the prefix followed by the bits 8 to 13 of the prefix
+		first := byte((prefix&0b0000_0000_1111_1100)>>2) | 0b0100_0000
+		second := byte(prefix>>8) | byte((prefix&0b0000_0000_0000_0011)<<6)
+		return []byte{first, second}, nil
+	default:
+		return nil, fmt.Errorf("%w: %d is higher than %d", ErrInvalidPrefix, prefix, maxPrefix)
+	}
+}
+
+// decodePrefix returns the address type prefix at the start of the given data, and the length of
+// its encoding.
+func decodePrefix(data []byte) (prefix uint16, length int, err error) {
+	switch {
+	case len(data) == 0:
+		return 0, 0, fmt.Errorf("%w: empty address", ErrInvalidLength)
+	case data[0] <= maxSimplePrefix:
+		return uint16(data[0]), 1, nil
+	case data[0] < 0b1000_0000:
+		if len(data) < 2 {
+			return 0, 0, fmt.Errorf("%w: missing second prefix byte", ErrInvalidLength)
+		}
+		lower := data[0]<<2 | data[1]>>6
+		upper := data[1] & 0b0011_1111
+		return uint16(lower) | uint16(upper)<<8, 2, nil
+	default:
+		return 0, 0, fmt.Errorf("%w: first byte 0x%x", ErrInvalidPrefix, data[0])
+	}
+}
+
+func checksum(data []byte) []byte {
+	hash := blake2b.Sum512(append(append([]byte{}, checksumPrefix...), data...))
+	return hash[:]
+}
+
+// Encode returns the SS58 address of the given public key or account id with the given address type
+func Encode(payload []byte, prefix uint16) (string, error) {
+	checksumLen, err := checksumLength(len(payload))
+	if err != nil {
+		return "", err
+	}
+
+	data, err := encodePrefix(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	data = append(data, payload...)
+	data = append(data, checksum(data)[:checksumLen]...)
+	return base58.Encode(data), nil
+}
+
+// Decode returns the public key or account id of the given SS58 address, and the address type
+// of the address. It returns an error if the checksum of the address is invalid.
+func Decode(address string) (payload []byte, prefix uint16, err error) {
+	data := base58.Decode(address)
+	if len(data) == 0 {
+		return nil, 0, fmt.Errorf("%w: %q", ErrInvalidFormat, address)
+	}
+
+	prefix, prefixLen, err := decodePrefix(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// the payload lengths are either lower than or equal to 8, and use a 1 byte checksum, or
+	// higher than or equal to 32, and use a 2 bytes checksum
+	checksumLen := 1
+	if len(data)-prefixLen > 8+1 {
+		checksumLen = 2
+	}
+
+	payloadLen := len(data) - prefixLen - checksumLen
+	expectedChecksumLen, err := checksumLength(payloadLen)
+	if err != nil {
+		return nil, 0, err
+	} else if expectedChecksumLen != checksumLen {
+		return nil, 0, fmt.Errorf("%w: payload of %d bytes", ErrInvalidLength, payloadLen)
+	}
+
+	body := data[:len(data)-checksumLen]
+	expected := checksum(body)[:checksumLen]
+	actual := data[len(data)-checksumLen:]
+	if !bytes.Equal(expected, actual) {
+		return nil, 0, fmt.Errorf("%w: expected 0x%x and got 0x%x", ErrInvalidChecksum, expected, actual)
+	}
+
+	return body[prefixLen:], prefix, nil
+}
+
+// DecodeWithPrefix returns the public key or account id of the given SS58 address, and returns an
+// error if the address type of the address is not the given address type.
+func DecodeWithPrefix(address string, prefix uint16) ([]byte, error) {
+	payload, addressPrefix, err := Decode(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if addressPrefix != prefix {
+		return nil, fmt.Errorf("%w: expected %d and got %d", ErrInvalidPrefix, prefix, addressPrefix)
+	}
+	return payload, nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package ss58
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// alicePublicKey is the sr25519 public key of //Alice
+var alicePublicKey = common.MustHexToBytes("0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+
+func Test_Encode(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		payload    []byte
+		prefix     uint16
+		address    string
+		errWrapped error
+		errMessage string
+	}{
+		"polkadot": {
+			payload: alicePublicKey,
+			prefix:  PolkadotPrefix,
+			address: "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5",
+		},
+		"kusama": {
+			payload: alicePublicKey,
+			prefix:  KusamaPrefix,
+			address: "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F",
+		},
+		"generic": {
+			payload: alicePublicKey,
+			prefix:  GenericPrefix,
+			address: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+		},
+		"reserved_prefix": {
+			payload:    alicePublicKey,
+			prefix:     46,
+			errWrapped: ErrReservedPrefix,
+			errMessage: "reserved address type: 46",
+		},
+		"prefix_too_high": {
+			payload:    alicePublicKey,
+			prefix:     16384,
+			errWrapped: ErrInvalidPrefix,
+			errMessage: "invalid address type: 16384 is higher than 16383",
+		},
+		"invalid_payload_length": {
+			payload:    []byte{1, 2, 3},
+			prefix:     GenericPrefix,
+			errWrapped: ErrInvalidLength,
+			errMessage: "invalid address length: payload of 3 bytes",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			address, err := Encode(testCase.payload, testCase.prefix)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.address, address)
+		})
+	}
+}
+
+func Test_Encode_fullFormatPrefix(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		prefix      uint16
+		prefixBytes []byte
+	}{
+		"lowest":  {prefix: 64, prefixBytes: []byte{0b0101_0000, 0b0000_0000}},
+		"255":     {prefix: 255, prefixBytes: []byte{0b0111_1111, 0b1100_0000}},
+		"256":     {prefix: 256, prefixBytes: []byte{0b0100_0000, 0b0000_0001}},
+		"highest": {prefix: 16383, prefixBytes: []byte{0b0111_1111, 0b1111_1111}},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			address, err := Encode(alicePublicKey, testCase.prefix)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.prefixBytes, base58.Decode(address)[:2])
+
+			payload, prefix, err := Decode(address)
+			require.NoError(t, err)
+			assert.Equal(t, alicePublicKey, payload)
+			assert.Equal(t, testCase.prefix, prefix)
+		})
+	}
+}
+
+func Test_Decode(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		address    string
+		payload    []byte
+		prefix     uint16
+		errWrapped error
+		errMessage string
+	}{
+		"polkadot": {
+			address: "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5",
+			payload: alicePublicKey,
+			prefix:  PolkadotPrefix,
+		},
+		"generic": {
+			address: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+			payload: alicePublicKey,
+			prefix:  GenericPrefix,
+		},
+		"short_payload": {
+			address: base58.Encode([]byte{42, 1, 2, 3, 4, checksum([]byte{42, 1, 2, 3, 4})[0]}),
+			payload: []byte{1, 2, 3, 4},
+			prefix:  GenericPrefix,
+		},
+		"invalid_base58": {
+			address:    "0OIl",
+			errWrapped: ErrInvalidFormat,
+			errMessage: `invalid base58 format: "0OIl"`,
+		},
+		"invalid_checksum": {
+			address:    "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQZ",
+			errWrapped: ErrInvalidChecksum,
+			errMessage: "invalid checksum: expected 0x1d21 and got 0x1d22",
+		},
+		"invalid_prefix": {
+			address:    base58.Encode(append([]byte{0b1000_0000}, alicePublicKey...)),
+			errWrapped: ErrInvalidPrefix,
+			errMessage: "invalid address type: first byte 0x80",
+		},
+		"invalid_length": {
+			address:    base58.Encode(append([]byte{42}, alicePublicKey[:20]...)),
+			errWrapped: ErrInvalidLength,
+			errMessage: "invalid address length: payload of 18 bytes",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			payload, prefix, err := Decode(testCase.address)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.payload, payload)
+			assert.Equal(t, testCase.prefix, prefix)
+		})
+	}
+}
+
+func Test_DecodeWithPrefix(t *testing.T) {
+	t.Parallel()
+
+	payload, err := DecodeWithPrefix("HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F", KusamaPrefix)
+	require.NoError(t, err)
+	assert.Equal(t, alicePublicKey, payload)
+
+	_, err = DecodeWithPrefix("HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F", PolkadotPrefix)
+	assert.ErrorIs(t, err, ErrInvalidPrefix)
+	assert.EqualError(t, err, "invalid address type: expected 0 and got 2")
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ChainSafe/go-schnorrkel"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// DevPhrase is the mnemonic of the development accounts, used by the secret URIs without phrase
+const DevPhrase = "bottom drive obey lake curtain smoke basket hold race lonely fit walk"
+
+const (
+	// ChainCodeLength is the length of the chain code of a derivation junction
+	ChainCodeLength = 32
+	// SeedLength is the length of the seed of a secret URI
+	SeedLength = 32
+)
+
+var (
+	ErrInvalidSecretURI           = errors.New("invalid secret URI")
+	ErrSoftDerivationNotSupported = errors.New("soft derivation not supported")
+)
+
+var (
+	secretURIRegex = regexp.MustCompile(`^(?P<phrase>[\d\w ]+)?(?P<path>(//?[^/]+)*)(///(?P<password>.*))?$`)
+	junctionRegex  = regexp.MustCompile(`/(/?[^/]+)`)
+)
+
+// DeriveJunction is a step of a key derivation path
+type DeriveJunction struct {
+	ChainCode [ChainCodeLength]byte
+	Hard      bool
+}
+
+// NewDeriveJunction returns the derivation junction of the given junction string. The chain code
+// is the SCALE encoding of the junction as an integer if it is a number, or as a string otherwise,
+// hashed if it is longer than the chain code.
+func NewDeriveJunction(junction string, hard bool) DeriveJunction {
+	var encoded []byte
+	number, err := strconv.ParseUint(junction, 10, 64)
+	if err == nil {
+		encoded = scale.MustMarshal(number)
+	} else {
+		encoded = scale.MustMarshal(junction)
+	}
+
+	dj := DeriveJunction{Hard: hard}
+	if len(encoded) > ChainCodeLength {
+		dj.ChainCode = common.MustBlake2bHash(encoded)
+	} else {
+		copy(dj.ChainCode[:], encoded)
+	}
+	return dj
+}
+
+// SecretURI is a parsed secret URI of the form `phrase//hard/soft///password`, where the phrase
+// is either a BIP39 mnemonic or a 0x prefixed hex seed, followed by the hard and soft derivation
+// junctions and the optional password of the mnemonic.
+type SecretURI struct {
+	Phrase    string
+	Junctions []DeriveJunction
+	Password  string
+}
+
+// ParseSecretURI parses the given secret URI. The phrase defaults to the DevPhrase
+// if the URI starts with the derivation path.
+func ParseSecretURI(suri string) (*SecretURI, error) {
+	matches := secretURIRegex.FindStringSubmatch(suri)
+	if matches == nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSecretURI, suri)
+	}
+
+	parsed := &SecretURI{
+		Phrase:   strings.TrimSpace(matches[secretURIRegex.SubexpIndex("phrase")]),
+		Password: matches[secretURIRegex.SubexpIndex("password")],
+	}
+	if parsed.Phrase == "" {
+		parsed.Phrase = DevPhrase
+	}
+
+	path := matches[secretURIRegex.SubexpIndex("path")]
+	for _, junction := range junctionRegex.FindAllStringSubmatch(path, -1) {
+		code, hard := strings.CutPrefix(junction[1], "/")
+		parsed.Junctions = append(parsed.Junctions, NewDeriveJunction(code, hard))
+	}
+
+	return parsed, nil
+}
+
+// Seed returns the 32 bytes seed of the secret URI, which is either the hex seed of the URI or the
+// seed of the mnemonic and password of the URI.
+func (s *SecretURI) Seed() ([]byte, error) {
+	if strings.HasPrefix(s.Phrase, "0x") {
+		seed, err := common.HexToBytes(s.Phrase)
+		if err != nil {
+			return nil, fmt.Errorf("decoding hex seed: %w", err)
+		} else if len(seed) != SeedLength {
+			return nil, fmt.Errorf("%w: seed is %d bytes long instead of %d", ErrInvalidSecretURI, len(seed), SeedLength)
+		}
+		return seed, nil
+	}
+
+	seed, err := schnorrkel.SeedFromMnemonic(s.Phrase, s.Password)
+	if err != nil {
+		return nil, fmt.Errorf("getting seed from mnemonic: %w", err)
+	}
+	return seed[:SeedLength], nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeriveJunction(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		junction  string
+		chainCode [crypto.ChainCodeLength]byte
+	}{
+		"string": {
+			junction:  "Alice",
+			chainCode: [crypto.ChainCodeLength]byte{0x14, 'A', 'l', 'i', 'c', 'e'},
+		},
+		"number": {
+			junction:  "1",
+			chainCode: [crypto.ChainCodeLength]byte{1},
+		},
+		"long_string": {
+			junction: "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyz",
+			chainCode: common.MustBlake2bHash(append([]byte{0xd0},
+				"abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyz"...)),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			junction := crypto.NewDeriveJunction(testCase.junction, true)
+			assert.Equal(t, testCase.chainCode, junction.ChainCode)
+			assert.True(t, junction.Hard)
+		})
+	}
+}
+
+func TestParseSecretURI(t *testing.T) {
+	t.Parallel()
+
+	const phrase = "legal winner thank year wave sausage worth useful legal winner thank yellow"
+
+	testCases := map[string]struct {
+		suri   string
+		parsed *crypto.SecretURI
+		errMsg string
+	}{
+		"phrase_only": {
+			suri:   phrase,
+			parsed: &crypto.SecretURI{Phrase: phrase},
+		},
+		"dev_phrase_path": {
+			suri: "//Alice/stash",
+			parsed: &crypto.SecretURI{
+				Phrase: crypto.DevPhrase,
+				Junctions: []crypto.DeriveJunction{
+					crypto.NewDeriveJunction("Alice", true),
+					crypto.NewDeriveJunction("stash", false),
+				},
+			},
+		},
+		"phrase_path_and_password": {
+			suri: phrase + "/1//polkadot///secret/password",
+			parsed: &crypto.SecretURI{
+				Phrase: phrase,
+				Junctions: []crypto.DeriveJunction{
+					crypto.NewDeriveJunction("1", false),
+					crypto.NewDeriveJunction("polkadot", true),
+				},
+				Password: "secret/password",
+			},
+		},
+		"hex_seed_password": {
+			suri: "0x0102///password",
+			parsed: &crypto.SecretURI{
+				Phrase:   "0x0102",
+				Password: "password",
+			},
+		},
+		"invalid_phrase": {
+			suri:   "invalid-phrase",
+			errMsg: `invalid secret URI: "invalid-phrase"`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			parsed, err := crypto.ParseSecretURI(testCase.suri)
+			if testCase.errMsg != "" {
+				require.EqualError(t, err, testCase.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.parsed, parsed)
+		})
+	}
+}
@@ -5,6 +5,8 @@
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -18,6 +20,7 @@ type Genesis struct {
 	Bootnodes          []string               `json:"bootNodes"`
 	TelemetryEndpoints []interface{}          `json:"telemetryEndpoints"`
 	ProtocolID         string                 `json:"protocolId"`
+	ForkID             string                 `json:"forkId,omitempty"`
 	Genesis            Fields                 `json:"genesis"`
 	Properties         map[string]interface{} `json:"properties"`
 	ForkBlocks         []string               `json:"forkBlocks"`
@@ -47,10 +50,109 @@ type TelemetryEndpoint struct {
 	Verbosity int    `mapstructure:",squash"`
 }
 
+// ErrRuntimeGenesis is returned when converting to raw a genesis holding a runtime genesis,
+// which can only be built by executing its runtime code.
+var ErrRuntimeGenesis = errors.New("runtime genesis must be built by its runtime")
+
 // Fields stores genesis raw data, and human readable runtime data
 type Fields struct {
-	Raw     map[string]map[string]string `json:"raw,omitempty"`
-	Runtime *Runtime                     `json:"runtime,omitempty"`
+	Raw map[string]map[string]string `json:"raw,omitempty"`
+	// ChildrenDefault holds the raw key-values of the default child tries, keyed by the hex encoded
+	// child storage key without the :child_storage:default: prefix. It is encoded as the
+	// childrenDefault field of the raw genesis.
+	ChildrenDefault map[string]map[string]string `json:"-"`
+	Runtime         *Runtime                     `json:"runtime,omitempty"`
+	RuntimeGenesis  *RuntimeGenesis              `json:"runtimeGenesis,omitempty"`
+}
+
+const rawChildrenDefaultKey = "childrenDefault"
+
+type fieldsJSON struct {
+	Raw            map[string]json.RawMessage `json:"raw,omitempty"`
+	Runtime        *Runtime                   `json:"runtime,omitempty"`
+	RuntimeGenesis *RuntimeGenesis            `json:"runtimeGenesis,omitempty"`
+}
+
+// MarshalJSON encodes the fields, with the default child tries in the childrenDefault field of the raw genesis.
+func (f Fields) MarshalJSON() ([]byte, error) {
+	encoded := fieldsJSON{
+		Runtime:        f.Runtime,
+		RuntimeGenesis: f.RuntimeGenesis,
+	}
+
+	if f.Raw != nil {
+		childrenDefault := f.ChildrenDefault
+		if childrenDefault == nil {
+			childrenDefault = make(map[string]map[string]string)
+		}
+
+		encoded.Raw = make(map[string]json.RawMessage, len(f.Raw)+1)
+		for key, keyValues := range f.Raw {
+			buf, err := json.Marshal(keyValues)
+			if err != nil {
+				return nil, err
+			}
+			encoded.Raw[key] = buf
+		}
+
+		buf, err := json.Marshal(childrenDefault)
+		if err != nil {
+			return nil, err
+		}
+		encoded.Raw[rawChildrenDefaultKey] = buf
+	}
+
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes the fields, decoding the childrenDefault field of the raw genesis
+// into the default child tries.
+func (f *Fields) UnmarshalJSON(buf []byte) error {
+	var decoded fieldsJSON
+	err := json.Unmarshal(buf, &decoded)
+	if err != nil {
+		return err
+	}
+
+	*f = Fields{
+		Runtime:        decoded.Runtime,
+		RuntimeGenesis: decoded.RuntimeGenesis,
+	}
+	if decoded.Raw == nil {
+		return nil
+	}
+
+	f.Raw = make(map[string]map[string]string, len(decoded.Raw))
+	for key, value := range decoded.Raw {
+		if key == rawChildrenDefaultKey {
+			var childrenDefault map[string]map[string]string
+			err = json.Unmarshal(value, &childrenDefault)
+			if err != nil {
+				return fmt.Errorf("decoding raw %s: %w", rawChildrenDefaultKey, err)
+			}
+			if len(childrenDefault) > 0 {
+				f.ChildrenDefault = childrenDefault
+			}
+			continue
+		}
+
+		var keyValues map[string]string
+		err = json.Unmarshal(value, &keyValues)
+		if err != nil {
+			return fmt.Errorf("decoding raw %s: %w", key, err)
+		}
+		f.Raw[key] = keyValues
+	}
+	return nil
+}
+
+// RuntimeGenesis is the structure of the genesis runtimeGenesis field, holding the runtime code
+// and either its full genesis config, or a patch applied to its default genesis config. The raw
+// storage is built by the GenesisBuilder API of the runtime.
+type RuntimeGenesis struct {
+	Code   string          `json:"code"`
+	Config json.RawMessage `json:"config,omitempty"`
+	Patch  json.RawMessage `json:"patch,omitempty"`
 }
 
 // Runtime is the structure of the genesis runtime field.
@@ -226,7 +328,7 @@ func (g *Genesis) GenesisFields() Fields {
 
 // IsRaw returns whether the genesis is raw or not
 func (g *Genesis) IsRaw() bool {
-	return g.Genesis.Raw != nil || g.Genesis.Runtime == nil
+	return g.Genesis.Raw != nil || (g.Genesis.Runtime == nil && g.Genesis.RuntimeGenesis == nil)
 }
 
 // ToRaw converts a non-raw genesis to a raw genesis
@@ -235,6 +337,10 @@ func (g *Genesis) ToRaw() error {
 		return nil
 	}
 
+	if g.Genesis.RuntimeGenesis != nil {
+		return ErrRuntimeGenesis
+	}
+
 	grt := g.Genesis.Runtime
 	res, err := buildRawMap(*grt)
 	if err != nil {
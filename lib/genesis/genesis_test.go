@@ -4,6 +4,7 @@
 package genesis
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -99,3 +100,47 @@ func TestBalancesFieldsUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestGenesis_RuntimeGenesis(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{"name":"test","genesis":{"runtimeGenesis":{"code":"0x0102","patch":{"balances":{}}}}}`)
+	gen := new(Genesis)
+	err := json.Unmarshal(data, gen)
+	require.NoError(t, err)
+
+	expected := &RuntimeGenesis{
+		Code:  "0x0102",
+		Patch: json.RawMessage(`{"balances":{}}`),
+	}
+	require.Equal(t, expected, gen.Genesis.RuntimeGenesis)
+	require.False(t, gen.IsRaw())
+
+	err = gen.ToRaw()
+	require.ErrorIs(t, err, ErrRuntimeGenesis)
+}
+
+func TestFields_ChildrenDefault(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{"raw":{"top":{"0x01":"0x02"},"childrenDefault":{"0x6368696c64":{"0x03":"0x04"}}}}`)
+	fields := Fields{}
+	err := json.Unmarshal(data, &fields)
+	require.NoError(t, err)
+
+	expected := Fields{
+		Raw: map[string]map[string]string{"top": {"0x01": "0x02"}},
+		ChildrenDefault: map[string]map[string]string{
+			"0x6368696c64": {"0x03": "0x04"},
+		},
+	}
+	require.Equal(t, expected, fields)
+
+	encoded, err := json.Marshal(fields)
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(encoded))
+
+	encoded, err = json.Marshal(Fields{Raw: map[string]map[string]string{"top": {}}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"raw":{"top":{},"childrenDefault":{}}}`, string(encoded))
+}
@@ -0,0 +1,898 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	finality "github.com/ChainSafe/gossamer/pkg/finality-grandpa"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/exp/rand"
+)
+
+// incomingVotesCapacity is the number of signed votes buffered for a round
+// before the votes received from the network are dropped.
+const incomingVotesCapacity = 1024
+
+// maxPendingRoundsAhead is the number of rounds ahead of the best round
+// for which votes received from the network are kept until the round starts.
+const maxPendingRoundsAhead = 1
+
+type (
+	// voterHash is the block hash type of the finality-grandpa voter, which
+	// needs an ordered type, holding the bytes of a common.Hash.
+	voterHash string
+	// voterID is the authority ID type of the finality-grandpa voter,
+	// holding the bytes of an ed25519 public key.
+	voterID string
+	// voterSignature is the ed25519 signature type of the finality-grandpa voter.
+	voterSignature = [64]byte
+)
+
+type (
+	voterMessage            = finality.Message[voterHash, uint32]
+	voterSignedMessage      = finality.SignedMessage[voterHash, uint32, voterSignature, voterID]
+	voterSignedMessageError = finality.SignedMessageError[voterHash, uint32, voterSignature, voterID]
+	voterInput              = finality.Input[voterHash, uint32, voterSignature, voterID]
+	voterCommit             = finality.Commit[voterHash, uint32, voterSignature, voterID]
+	voterHistoricalVotes    = finality.HistoricalVotes[voterHash, uint32, voterSignature, voterID]
+	voterRoundState         = finality.RoundState[voterHash, uint32]
+	voterHashNumber         = finality.HashNumber[voterHash, uint32]
+)
+
+func newVoterHash(hash common.Hash) voterHash {
+	return voterHash(hash[:])
+}
+
+func (h voterHash) toHash() common.Hash {
+	return common.BytesToHash([]byte(h))
+}
+
+func newVoterID(key ed25519.PublicKeyBytes) voterID {
+	return voterID(key[:])
+}
+
+func (id voterID) toPublicKeyBytes() (key ed25519.PublicKeyBytes) {
+	copy(key[:], id)
+	return key
+}
+
+// newVoterSet returns the finality-grandpa voter set for the given authorities.
+func newVoterSet(voters []Voter) (*finality.VoterSet[voterID], error) {
+	weights := make([]finality.IDWeight[voterID], len(voters))
+	for i, voter := range voters {
+		weights[i] = finality.IDWeight[voterID]{
+			ID:     newVoterID(voter.PublicKeyBytes()),
+			Weight: voter.ID,
+		}
+	}
+
+	voterSet := finality.NewVoterSet(weights)
+	if voterSet == nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidVoterSet, Voters(voters))
+	}
+	return voterSet, nil
+}
+
+// newVoterMessage returns the finality-grandpa message for the given subround and vote.
+func newVoterMessage(stage Subround, vote Vote) (voterMessage, error) {
+	hash := newVoterHash(vote.Hash)
+	switch stage {
+	case prevote:
+		return finality.NewMessage[voterHash, uint32](
+			finality.Prevote[voterHash, uint32]{TargetHash: hash, TargetNumber: vote.Number}), nil
+	case precommit:
+		return finality.NewMessage[voterHash, uint32](
+			finality.Precommit[voterHash, uint32]{TargetHash: hash, TargetNumber: vote.Number}), nil
+	case primaryProposal:
+		return finality.NewMessage[voterHash, uint32](
+			finality.PrimaryPropose[voterHash, uint32]{TargetHash: hash, TargetNumber: vote.Number}), nil
+	default:
+		return voterMessage{}, fmt.Errorf("%w: %s", ErrUnsupportedSubround, stage)
+	}
+}
+
+// voterMessageSubround returns the subround of the given finality-grandpa message.
+func voterMessageSubround(message voterMessage) (Subround, error) {
+	value, err := message.Value()
+	if err != nil {
+		return 0, fmt.Errorf("getting message value: %w", err)
+	}
+
+	switch value.(type) {
+	case finality.Prevote[voterHash, uint32]:
+		return prevote, nil
+	case finality.Precommit[voterHash, uint32]:
+		return precommit, nil
+	case finality.PrimaryPropose[voterHash, uint32]:
+		return primaryProposal, nil
+	default:
+		return 0, fmt.Errorf("%w: %T", ErrUnsupportedSubround, value)
+	}
+}
+
+// newSignedVote returns the signed vote of a voter signed message.
+func newSignedVote(message voterSignedMessage) SignedVote {
+	target := message.Message.Target()
+	return SignedVote{
+		Vote:        *NewVote(target.Hash.toHash(), target.Number),
+		Signature:   message.Signature,
+		AuthorityID: message.ID.toPublicKeyBytes(),
+	}
+}
+
+// roundComms are the channels of a round of the finality-grandpa voter.
+type roundComms struct {
+	incoming voterInput
+	done     chan struct{}
+}
+
+// environment implements the finality-grandpa voter environment for
+// an authority set, using the block and grandpa states, the network
+// gossip and the GRANDPA keypair of the service.
+type environment struct {
+	ctx     context.Context
+	service *Service
+	setID   uint64
+	voters  []Voter
+	localID *voterID
+
+	globalIn chan finality.GlobalInItem
+
+	mtx sync.Mutex
+	// rounds maps round numbers to the communication channels of the round.
+	// There can be more than one voting round for the same number, since
+	// the voter restarts the last completed round when catching up.
+	rounds    map[uint64][]*roundComms
+	bestRound uint64
+	// pending contains votes received for rounds which have not started yet.
+	pending map[uint64][]voterSignedMessageError
+	// catchUpRound is the last round a catch up was requested for.
+	catchUpRound uint64
+}
+
+func newEnvironment(ctx context.Context, service *Service, setID uint64, voters []Voter) *environment {
+	env := &environment{
+		ctx:      ctx,
+		service:  service,
+		setID:    setID,
+		voters:   voters,
+		globalIn: make(chan finality.GlobalInItem, incomingVotesCapacity),
+		rounds:   make(map[uint64][]*roundComms),
+		pending:  make(map[uint64][]voterSignedMessageError),
+	}
+
+	if service.authority {
+		publicKeyBytes := service.publicKeyBytes()
+		for _, voter := range voters {
+			if voter.PublicKeyBytes() == publicKeyBytes {
+				id := newVoterID(publicKeyBytes)
+				env.localID = &id
+				break
+			}
+		}
+	}
+
+	return env
+}
+
+// Ancestry returns the ancestry of a block up to but not including the base hash,
+// in reverse order from the block's parent.
+func (e *environment) Ancestry(base, block voterHash) (ancestors []voterHash, err error) {
+	if base == block {
+		return nil, fmt.Errorf("%w: %s", errNotDescendant, base.toHash())
+	}
+
+	baseHeader, err := e.service.blockState.GetHeader(base.toHash())
+	if err != nil {
+		return nil, fmt.Errorf("getting base header: %w", err)
+	}
+
+	header, err := e.service.blockState.GetHeader(block.toHash())
+	if err != nil {
+		return nil, fmt.Errorf("getting block header: %w", err)
+	}
+
+	for {
+		if header.Number <= baseHeader.Number {
+			return nil, fmt.Errorf("%w: block %s of base %s",
+				errNotDescendant, block.toHash(), base.toHash())
+		}
+
+		if header.ParentHash == baseHeader.Hash() {
+			return ancestors, nil
+		}
+
+		ancestors = append(ancestors, newVoterHash(header.ParentHash))
+		header, err = e.service.blockState.GetHeader(header.ParentHash)
+		if err != nil {
+			return nil, fmt.Errorf("getting parent header: %w", err)
+		}
+	}
+}
+
+// IsEqualOrDescendantOf returns true if the block is the base or a descendant of the base.
+func (e *environment) IsEqualOrDescendantOf(base, block voterHash) bool {
+	if base == block {
+		return true
+	}
+
+	isDescendant, err := e.service.blockState.IsDescendantOf(base.toHash(), block.toHash())
+	if err != nil {
+		logger.Debugf("checking if block %s is descendant of %s: %s", block.toHash(), base.toHash(), err)
+		return false
+	}
+	return isDescendant
+}
+
+// BestChainContaining returns a channel producing the block to vote on in a chain
+// containing the given base block. It is the best block if it descends from the base,
+// or the base otherwise, and it is limited to the next authority set change block.
+func (e *environment) BestChainContaining(base voterHash) finality.BestChain[voterHash, uint32] {
+	bestChain := make(chan finality.BestChainOutput[voterHash, uint32], 1)
+	target, err := e.bestChainContaining(base.toHash())
+	if err != nil {
+		logger.Debugf("getting best chain containing %s: %s", base.toHash(), err)
+		target = nil
+	}
+
+	var value *voterHashNumber
+	if target != nil {
+		value = &voterHashNumber{
+			Hash:   newVoterHash(target.Hash()),
+			Number: uint32(target.Number),
+		}
+	}
+	bestChain <- finality.BestChainOutput[voterHash, uint32]{Value: value}
+	return bestChain
+}
+
+func (e *environment) bestChainContaining(base common.Hash) (target *types.Header, err error) {
+	blockState := e.service.blockState
+	has, err := blockState.HasHeader(base)
+	if err != nil {
+		return nil, fmt.Errorf("checking base header exists: %w", err)
+	} else if !has {
+		return nil, nil
+	}
+
+	bestHeader, err := blockState.BestBlockHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting best block header: %w", err)
+	}
+
+	target = bestHeader
+	if base != bestHeader.Hash() {
+		isDescendant, err := blockState.IsDescendantOf(base, bestHeader.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("checking best block descends from base: %w", err)
+		}
+
+		if !isDescendant {
+			return blockState.GetHeader(base)
+		}
+	}
+
+	// do not vote past the next authority set change
+	changeNumber, err := e.service.grandpaState.NextGrandpaAuthorityChange(bestHeader.Hash(), bestHeader.Number)
+	if err != nil {
+		return target, nil //nolint:nilerr
+	}
+
+	for target.Number > changeNumber {
+		if target.Hash() == base {
+			break
+		}
+
+		target, err = blockState.GetHeader(target.ParentHash)
+		if err != nil {
+			return nil, fmt.Errorf("getting parent header: %w", err)
+		}
+	}
+
+	return target, nil
+}
+
+// RoundData returns the data to take part in the given round. The messages sent by
+// the voter on the outgoing channel are signed, gossiped and sent back to the voter.
+func (e *environment) RoundData(
+	round uint64,
+	outgoing finality.Output[voterHash, uint32],
+) finality.RoundData[voterHash, uint32, voterSignature, voterID] {
+	comms := &roundComms{
+		incoming: make(voterInput, incomingVotesCapacity),
+		done:     make(chan struct{}),
+	}
+
+	e.mtx.Lock()
+	e.rounds[round] = append(e.rounds[round], comms)
+	if round > e.bestRound {
+		e.bestRound = round
+		e.service.state.round = round
+		roundGauge.Set(float64(round))
+	}
+	for _, vote := range e.pending[round] {
+		comms.incoming <- vote
+	}
+	delete(e.pending, round)
+	e.mtx.Unlock()
+
+	go e.handleOutgoing(round, outgoing, comms.done)
+
+	interval := e.service.interval
+	return finality.RoundData[voterHash, uint32, voterSignature, voterID]{
+		VoterID:        e.localID,
+		PrevoteTimer:   finality.NewTimer(time.After(2 * interval)),
+		PrecommitTimer: finality.NewTimer(time.After(4 * interval)),
+		Incoming:       comms.incoming,
+	}
+}
+
+// handleOutgoing signs and gossips the messages of the voter for the round,
+// until the round is concluded or the voter is stopped.
+func (e *environment) handleOutgoing(round uint64, outgoing finality.Output[voterHash, uint32],
+	done <-chan struct{}) {
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case <-done:
+			return
+		case message := <-outgoing:
+			if e.localID == nil {
+				continue
+			}
+
+			err := e.sendMessage(round, message)
+			if err != nil {
+				logger.Errorf("sending message for round %d: %s", round, err)
+			}
+		}
+	}
+}
+
+// sendMessage signs the message, gossips it and imports it in the voter.
+func (e *environment) sendMessage(round uint64, message voterMessage) error {
+	stage, err := voterMessageSubround(message)
+	if err != nil {
+		return err
+	}
+
+	target := message.Target()
+	vote := NewVote(target.Hash.toHash(), target.Number)
+	encodedFullVote, err := scale.Marshal(FullVote{
+		Stage: stage,
+		Vote:  *vote,
+		Round: round,
+		SetID: e.setID,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding full vote: %w", err)
+	}
+
+	signature, err := e.service.keypair.Sign(encodedFullVote)
+	if err != nil {
+		return fmt.Errorf("signing vote: %w", err)
+	}
+
+	voteMessage := &VoteMessage{
+		Round: round,
+		SetID: e.setID,
+		Message: SignedMessage{
+			Stage:       stage,
+			BlockHash:   vote.Hash,
+			Number:      vote.Number,
+			Signature:   ed25519.NewSignatureBytes(signature),
+			AuthorityID: e.service.publicKeyBytes(),
+		},
+	}
+
+	consensusMessage, err := voteMessage.ToConsensusMessage()
+	if err != nil {
+		return fmt.Errorf("encoding vote message: %w", err)
+	}
+	e.service.network.GossipMessage(consensusMessage)
+
+	e.importVote(round, voterSignedMessage{
+		Message:   message,
+		Signature: voteMessage.Message.Signature,
+		ID:        *e.localID,
+	})
+	return nil
+}
+
+// importVote sends the signed vote to the voting rounds with the given number,
+// or keeps it until the round starts if it is for a future round.
+func (e *environment) importVote(round uint64, vote voterSignedMessage) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	item := voterSignedMessageError{SignedMessage: vote}
+	comms, ok := e.rounds[round]
+	if !ok {
+		if round > e.bestRound && round <= e.bestRound+maxPendingRoundsAhead &&
+			len(e.pending[round]) < incomingVotesCapacity {
+			e.pending[round] = append(e.pending[round], item)
+		}
+		return
+	}
+
+	for _, c := range comms {
+		select {
+		case c.incoming <- item:
+		default:
+			logger.Warnf("dropping vote for round %d from %s: incoming votes buffer is full",
+				round, vote.ID.toPublicKeyBytes())
+		}
+	}
+}
+
+// RoundCommitTimer returns a timer elapsing after a random duration between
+// zero and one second, to delay the broadcast of a commit message.
+func (*environment) RoundCommitTimer() finality.Timer {
+	delay := time.Duration(rand.Int63n(int64(time.Second)))
+	return finality.NewTimer(time.After(delay))
+}
+
+// Proposed is called when the voter made a primary proposal in the round.
+func (*environment) Proposed(uint64, finality.PrimaryPropose[voterHash, uint32]) error {
+	return nil
+}
+
+// Prevoted is called when the voter prevoted in the round.
+func (e *environment) Prevoted(round uint64, _ finality.Prevote[voterHash, uint32]) error {
+	return e.service.grandpaState.SetLatestRound(round)
+}
+
+// Precommitted is called when the voter precommitted in the round.
+func (*environment) Precommitted(uint64, finality.Precommit[voterHash, uint32]) error {
+	return nil
+}
+
+// Completed stores the votes of the round once it is completed,
+// so the round can be resumed and catch up requests can be answered.
+func (e *environment) Completed(round uint64, _ voterRoundState, _ voterHashNumber,
+	votes voterHistoricalVotes) error {
+	logger.Debugf("completed round %d of set id %d", round, e.setID)
+
+	err := e.storeVotes(round, votes)
+	if err != nil {
+		return err
+	}
+
+	return e.service.grandpaState.SetLatestRound(round)
+}
+
+// Concluded stores the votes of the round once its estimate is finalised,
+// and stops handling the messages of the round and of the previous rounds.
+func (e *environment) Concluded(round uint64, _ voterRoundState, _ voterHashNumber,
+	votes voterHistoricalVotes) error {
+	logger.Debugf("concluded round %d of set id %d", round, e.setID)
+
+	e.mtx.Lock()
+	for number, comms := range e.rounds {
+		if number > round {
+			continue
+		}
+		for _, c := range comms {
+			close(c.done)
+		}
+		delete(e.rounds, number)
+	}
+	e.mtx.Unlock()
+
+	return e.storeVotes(round, votes)
+}
+
+func (e *environment) storeVotes(round uint64, votes voterHistoricalVotes) error {
+	var prevotes, precommits []SignedVote
+	for _, vote := range votes.Seen() {
+		stage, err := voterMessageSubround(vote.Message)
+		if err != nil {
+			return err
+		}
+
+		switch stage {
+		case prevote:
+			prevotes = append(prevotes, newSignedVote(vote))
+		case precommit:
+			precommits = append(precommits, newSignedVote(vote))
+		}
+	}
+
+	err := e.service.grandpaState.SetPrevotes(round, e.setID, prevotes)
+	if err != nil {
+		return fmt.Errorf("storing prevotes: %w", err)
+	}
+
+	err = e.service.grandpaState.SetPrecommits(round, e.setID, precommits)
+	if err != nil {
+		return fmt.Errorf("storing precommits: %w", err)
+	}
+
+	return nil
+}
+
+// FinalizeBlock finalises the block with the commit precommits as justification.
+func (e *environment) FinalizeBlock(hash voterHash, number uint32, round uint64, commit voterCommit) error {
+	blockHash := hash.toHash()
+	highestFinalised, err := e.service.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	// the block may have been finalised with a justification received by the sync service
+	if uint(number) <= highestFinalised.Number {
+		return nil
+	}
+
+	precommits := make([]SignedVote, len(commit.Precommits))
+	for i, signedPrecommit := range commit.Precommits {
+		precommits[i] = SignedVote{
+			Vote:        *NewVote(signedPrecommit.Precommit.TargetHash.toHash(), signedPrecommit.Precommit.TargetNumber),
+			Signature:   signedPrecommit.Signature,
+			AuthorityID: signedPrecommit.ID.toPublicKeyBytes(),
+		}
+	}
+
+	justification, err := scale.Marshal(*newJustification(round, blockHash, number, precommits))
+	if err != nil {
+		return fmt.Errorf("encoding justification: %w", err)
+	}
+
+	err = e.service.blockState.SetJustification(blockHash, justification)
+	if err != nil {
+		return fmt.Errorf("setting justification: %w", err)
+	}
+
+	err = e.service.grandpaState.SetPrecommits(round, e.setID, precommits)
+	if err != nil {
+		return fmt.Errorf("storing precommits: %w", err)
+	}
+
+	err = e.service.blockState.SetFinalisedHash(blockHash, round, e.setID)
+	if err != nil {
+		return fmt.Errorf("setting finalised hash: %w", err)
+	}
+
+	e.service.head, err = e.service.blockState.GetHeader(blockHash)
+	if err != nil {
+		return fmt.Errorf("getting finalised header: %w", err)
+	}
+
+	logger.Infof("finalised block #%d (%s) in round %d of set id %d", number, blockHash, round, e.setID)
+	return nil
+}
+
+// PrevoteEquivocation reports the prevote equivocation to the runtime.
+func (e *environment) PrevoteEquivocation(round uint64,
+	equivocation finality.Equivocation[voterID, finality.Prevote[voterHash, uint32], voterSignature]) {
+	first := &SignedVote{
+		Vote:        *NewVote(equivocation.First.Vote.TargetHash.toHash(), equivocation.First.Vote.TargetNumber),
+		Signature:   equivocation.First.Signature,
+		AuthorityID: equivocation.Identity.toPublicKeyBytes(),
+	}
+	second := &SignedVote{
+		Vote:        *NewVote(equivocation.Second.Vote.TargetHash.toHash(), equivocation.Second.Vote.TargetNumber),
+		Signature:   equivocation.Second.Signature,
+		AuthorityID: equivocation.Identity.toPublicKeyBytes(),
+	}
+
+	err := e.service.submitEquivocationReport(e.setID, round, prevote, first, second)
+	if err != nil {
+		logger.Errorf("reporting prevote equivocation in round %d: %s", round, err)
+	}
+}
+
+// PrecommitEquivocation reports the precommit equivocation to the runtime.
+func (e *environment) PrecommitEquivocation(round uint64,
+	equivocation finality.Equivocation[voterID, finality.Precommit[voterHash, uint32], voterSignature]) {
+	first := &SignedVote{
+		Vote:        *NewVote(equivocation.First.Vote.TargetHash.toHash(), equivocation.First.Vote.TargetNumber),
+		Signature:   equivocation.First.Signature,
+		AuthorityID: equivocation.Identity.toPublicKeyBytes(),
+	}
+	second := &SignedVote{
+		Vote:        *NewVote(equivocation.Second.Vote.TargetHash.toHash(), equivocation.Second.Vote.TargetNumber),
+		Signature:   equivocation.Second.Signature,
+		AuthorityID: equivocation.Identity.toPublicKeyBytes(),
+	}
+
+	err := e.service.submitEquivocationReport(e.setID, round, precommit, first, second)
+	if err != nil {
+		logger.Errorf("reporting precommit equivocation in round %d: %s", round, err)
+	}
+}
+
+// handleMessage handles the network messages used by the voter, and returns
+// true if the message does not need to be handled by the message handler.
+func (e *environment) handleMessage(from peer.ID, m GrandpaMessage) (handled bool, err error) {
+	switch msg := m.(type) {
+	case *VoteMessage:
+		return true, e.handleVoteMessage(msg)
+	case *CommitMessage:
+		return true, e.handleCommitMessage(msg)
+	case *CatchUpResponse:
+		return true, e.handleCatchUpResponse(msg)
+	case *NeighbourPacketV1:
+		e.requestCatchUp(from, msg)
+		return false, nil
+	default:
+		return false, nil
+	}
+}
+
+// verifySignedVote verifies the signed vote is for a known block and is signed
+// by an authority of the set, and returns the voter signed message.
+func (e *environment) verifySignedVote(round uint64, stage Subround, vote SignedVote) (
+	signedMessage voterSignedMessage, err error) {
+	publicKey, err := ed25519.NewPublicKey(vote.AuthorityID[:])
+	if err != nil {
+		return signedMessage, fmt.Errorf("creating public key: %w", err)
+	}
+
+	if !isInAuthSet(publicKey, e.voters) {
+		return signedMessage, fmt.Errorf("%w: %s", ErrVoterNotFound, vote.AuthorityID)
+	}
+
+	err = validateMessageSignature(publicKey, &VoteMessage{
+		Round: round,
+		SetID: e.setID,
+		Message: SignedMessage{
+			Stage:       stage,
+			BlockHash:   vote.Vote.Hash,
+			Number:      vote.Vote.Number,
+			Signature:   vote.Signature,
+			AuthorityID: vote.AuthorityID,
+		},
+	})
+	if err != nil {
+		return signedMessage, fmt.Errorf("validating message signature: %w", err)
+	}
+
+	// the voter must only receive votes for known blocks
+	has, err := e.service.blockState.HasHeader(vote.Vote.Hash)
+	if err != nil {
+		return signedMessage, fmt.Errorf("checking block header exists: %w", err)
+	} else if !has {
+		return signedMessage, fmt.Errorf("%w: %s", ErrBlockDoesNotExist, vote.Vote.Hash)
+	}
+
+	message, err := newVoterMessage(stage, vote.Vote)
+	if err != nil {
+		return signedMessage, err
+	}
+
+	return voterSignedMessage{
+		Message:   message,
+		Signature: vote.Signature,
+		ID:        newVoterID(vote.AuthorityID),
+	}, nil
+}
+
+func (e *environment) handleVoteMessage(msg *VoteMessage) error {
+	if msg.SetID != e.setID {
+		return fmt.Errorf("%w: received %d and expected %d", ErrSetIDMismatch, msg.SetID, e.setID)
+	}
+
+	e.service.sendTelemetryVoteMessage(msg)
+	signedMessage, err := e.verifySignedVote(msg.Round, msg.Message.Stage, SignedVote{
+		Vote:        *NewVote(msg.Message.BlockHash, msg.Message.Number),
+		Signature:   msg.Message.Signature,
+		AuthorityID: msg.Message.AuthorityID,
+	})
+	if err != nil {
+		return fmt.Errorf("verifying vote: %w", err)
+	}
+
+	e.importVote(msg.Round, signedMessage)
+	return nil
+}
+
+func (e *environment) handleCommitMessage(msg *CommitMessage) error {
+	if msg.SetID != e.setID {
+		return fmt.Errorf("%w: received %d and expected %d", ErrSetIDMismatch, msg.SetID, e.setID)
+	}
+
+	if len(msg.Precommits) != len(msg.AuthData) {
+		return fmt.Errorf("%w: %d precommits and %d signatures",
+			ErrPrecommitSignatureMismatch, len(msg.Precommits), len(msg.AuthData))
+	}
+
+	compactCommit := finality.CompactCommit[voterHash, uint32, voterSignature, voterID]{
+		TargetHash:   newVoterHash(msg.Vote.Hash),
+		TargetNumber: msg.Vote.Number,
+		Precommits:   make([]finality.Precommit[voterHash, uint32], len(msg.Precommits)),
+		AuthData:     make(finality.MultiAuthData[voterSignature, voterID], len(msg.AuthData)),
+	}
+	for i, precommitVote := range msg.Precommits {
+		signedMessage, err := e.verifySignedVote(msg.Round, precommit, SignedVote{
+			Vote:        precommitVote,
+			Signature:   msg.AuthData[i].Signature,
+			AuthorityID: msg.AuthData[i].AuthorityID,
+		})
+		if err != nil {
+			return fmt.Errorf("verifying precommit: %w", err)
+		}
+
+		compactCommit.Precommits[i] = finality.Precommit[voterHash, uint32]{
+			TargetHash:   newVoterHash(precommitVote.Hash),
+			TargetNumber: precommitVote.Number,
+		}
+		compactCommit.AuthData[i].Signature = signedMessage.Signature
+		compactCommit.AuthData[i].ID = signedMessage.ID
+	}
+
+	communication := finality.NewCommunicationIn[voterHash, uint32, voterSignature, voterID](
+		finality.CommunicationInCommit[voterHash, uint32, voterSignature, voterID]{
+			Number:        msg.Round,
+			CompactCommit: compactCommit,
+		})
+	return e.sendGlobalIn(communication)
+}
+
+func (e *environment) handleCatchUpResponse(msg *CatchUpResponse) error {
+	if msg.SetID != e.setID {
+		return fmt.Errorf("%w: received %d and expected %d", ErrSetIDMismatch, msg.SetID, e.setID)
+	}
+
+	catchUp := finality.CatchUp[voterHash, uint32, voterSignature, voterID]{
+		RoundNumber: msg.Round,
+		Prevotes:    make([]finality.SignedPrevote[voterHash, uint32, voterSignature, voterID], len(msg.PreVoteJustification)),
+		Precommits: make([]finality.SignedPrecommit[voterHash, uint32, voterSignature, voterID],
+			len(msg.PreCommitJustification)),
+		BaseHash:   newVoterHash(msg.Hash),
+		BaseNumber: msg.Number,
+	}
+
+	for i, vote := range msg.PreVoteJustification {
+		signedMessage, err := e.verifySignedVote(msg.Round, prevote, vote)
+		if err != nil {
+			return fmt.Errorf("verifying prevote: %w", err)
+		}
+		catchUp.Prevotes[i] = finality.SignedPrevote[voterHash, uint32, voterSignature, voterID]{
+			Prevote: finality.Prevote[voterHash, uint32]{
+				TargetHash:   newVoterHash(vote.Vote.Hash),
+				TargetNumber: vote.Vote.Number,
+			},
+			Signature: signedMessage.Signature,
+			ID:        signedMessage.ID,
+		}
+	}
+
+	for i, vote := range msg.PreCommitJustification {
+		signedMessage, err := e.verifySignedVote(msg.Round, precommit, vote)
+		if err != nil {
+			return fmt.Errorf("verifying precommit: %w", err)
+		}
+		catchUp.Precommits[i] = finality.SignedPrecommit[voterHash, uint32, voterSignature, voterID]{
+			Precommit: finality.Precommit[voterHash, uint32]{
+				TargetHash:   newVoterHash(vote.Vote.Hash),
+				TargetNumber: vote.Vote.Number,
+			},
+			Signature: signedMessage.Signature,
+			ID:        signedMessage.ID,
+		}
+	}
+
+	communication := finality.NewCommunicationIn[voterHash, uint32, voterSignature, voterID](
+		finality.CommunicationInCatchUp[voterHash, uint32, voterSignature, voterID]{
+			CatchUp: catchUp,
+		})
+	return e.sendGlobalIn(communication)
+}
+
+func (e *environment) sendGlobalIn(communication finality.CommunicationIn) error {
+	select {
+	case e.globalIn <- finality.GlobalInItem{CommunicationIn: communication}:
+		return nil
+	case <-e.ctx.Done():
+		return e.ctx.Err()
+	}
+}
+
+// requestCatchUp sends a catch up request to the peer if it is
+// more than one round ahead of us in the same authority set.
+func (e *environment) requestCatchUp(from peer.ID, msg *NeighbourPacketV1) {
+	if msg.SetID != e.setID {
+		return
+	}
+
+	e.mtx.Lock()
+	if msg.Round <= e.bestRound+1 || msg.Round-1 <= e.catchUpRound {
+		e.mtx.Unlock()
+		return
+	}
+	e.catchUpRound = msg.Round - 1
+	e.mtx.Unlock()
+
+	logger.Debugf("requesting catch up for round %d of set id %d from peer %s", msg.Round-1, e.setID, from)
+	request := newCatchUpRequest(msg.Round-1, e.setID)
+	consensusMessage, err := request.ToConsensusMessage()
+	if err != nil {
+		logger.Errorf("encoding catch up request: %s", err)
+		return
+	}
+
+	err = e.service.network.SendMessage(from, consensusMessage)
+	if err != nil {
+		logger.Debugf("sending catch up request to peer %s: %s", from, err)
+	}
+}
+
+// handleGlobalOutgoing gossips the commit messages of the voter, until
+// the voter is stopped and closes the channel.
+func (e *environment) handleGlobalOutgoing(globalOut <-chan finality.CommunicationOut) {
+	for communication := range globalOut {
+		commit, ok := communication.Variant().(finality.CommunicationOutCommit[
+			voterHash, uint32, voterSignature, voterID])
+		if !ok {
+			logger.Warnf("unexpected voter communication %T", communication.Variant())
+			continue
+		}
+
+		commitMessage := &CommitMessage{
+			Round:      commit.Number,
+			SetID:      e.setID,
+			Vote:       *NewVote(commit.Commit.TargetHash.toHash(), commit.Commit.TargetNumber),
+			Precommits: make([]Vote, len(commit.Commit.Precommits)),
+			AuthData:   make([]AuthData, len(commit.Commit.Precommits)),
+		}
+		for i, signedPrecommit := range commit.Commit.Precommits {
+			commitMessage.Precommits[i] = *NewVote(signedPrecommit.Precommit.TargetHash.toHash(),
+				signedPrecommit.Precommit.TargetNumber)
+			commitMessage.AuthData[i] = AuthData{
+				Signature:   signedPrecommit.Signature,
+				AuthorityID: signedPrecommit.ID.toPublicKeyBytes(),
+			}
+		}
+
+		consensusMessage, err := commitMessage.ToConsensusMessage()
+		if err != nil {
+			logger.Errorf("encoding commit message: %s", err)
+			continue
+		}
+
+		logger.Debugf("gossiping commit message for round %d", commit.Number)
+		e.service.network.GossipMessage(consensusMessage)
+	}
+}
+
+// lastRoundVotes returns the stored votes of the given round, or nil if there are none.
+func (e *environment) lastRoundVotes(round uint64) []voterSignedMessage {
+	var votes []voterSignedMessage
+	for _, stage := range []Subround{prevote, precommit} {
+		var signedVotes []SignedVote
+		var err error
+		if stage == prevote {
+			signedVotes, err = e.service.grandpaState.GetPrevotes(round, e.setID)
+		} else {
+			signedVotes, err = e.service.grandpaState.GetPrecommits(round, e.setID)
+		}
+		if err != nil {
+			logger.Debugf("getting %s votes of round %d: %s", stage, round, err)
+			continue
+		}
+
+		for _, signedVote := range signedVotes {
+			message, err := newVoterMessage(stage, signedVote.Vote)
+			if err != nil {
+				continue
+			}
+			votes = append(votes, voterSignedMessage{
+				Message:   message,
+				Signature: signedVote.Signature,
+				ID:        newVoterID(signedVote.AuthorityID),
+			})
+		}
+	}
+	return votes
+}
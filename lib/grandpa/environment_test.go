@@ -0,0 +1,274 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	finality "github.com/ChainSafe/gossamer/pkg/finality-grandpa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_newVoterMessage(t *testing.T) {
+	t.Parallel()
+
+	vote := Vote{Hash: common.Hash{1}, Number: 2}
+	testCases := map[string]struct {
+		stage       Subround
+		expected    voterMessage
+		errSentinel error
+	}{
+		"prevote": {
+			stage: prevote,
+			expected: finality.NewMessage[voterHash, uint32](finality.Prevote[voterHash, uint32]{
+				TargetHash: newVoterHash(common.Hash{1}), TargetNumber: 2}),
+		},
+		"precommit": {
+			stage: precommit,
+			expected: finality.NewMessage[voterHash, uint32](finality.Precommit[voterHash, uint32]{
+				TargetHash: newVoterHash(common.Hash{1}), TargetNumber: 2}),
+		},
+		"primary_proposal": {
+			stage: primaryProposal,
+			expected: finality.NewMessage[voterHash, uint32](finality.PrimaryPropose[voterHash, uint32]{
+				TargetHash: newVoterHash(common.Hash{1}), TargetNumber: 2}),
+		},
+		"unsupported_subround": {
+			stage:       Subround(3),
+			errSentinel: ErrUnsupportedSubround,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			message, err := newVoterMessage(testCase.stage, vote)
+			assert.ErrorIs(t, err, testCase.errSentinel)
+			assert.Equal(t, testCase.expected, message)
+			if testCase.errSentinel != nil {
+				return
+			}
+
+			stage, err := voterMessageSubround(message)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.stage, stage)
+			assert.Equal(t, vote.Hash, message.Target().Hash.toHash())
+		})
+	}
+}
+
+func Test_environment_Ancestry(t *testing.T) {
+	t.Parallel()
+
+	genesis := types.NewHeader(common.Hash{}, common.Hash{1}, common.Hash{}, 0, types.NewDigest())
+	headers := []*types.Header{genesis}
+	for number := uint(1); number <= 3; number++ {
+		headers = append(headers, types.NewHeader(headers[number-1].Hash(), common.Hash{1},
+			common.Hash{}, number, types.NewDigest()))
+	}
+
+	newBlockState := func(ctrl *gomock.Controller) BlockState {
+		blockState := NewMockBlockState(ctrl)
+		blockState.EXPECT().GetHeader(gomock.Any()).DoAndReturn(func(hash common.Hash) (*types.Header, error) {
+			for _, header := range headers {
+				if header.Hash() == hash {
+					return header, nil
+				}
+			}
+			return nil, errTest
+		}).AnyTimes()
+		return blockState
+	}
+
+	testCases := map[string]struct {
+		base, block common.Hash
+		ancestors   []voterHash
+		errSentinel error
+	}{
+		"parent_is_base": {
+			base:  headers[2].Hash(),
+			block: headers[3].Hash(),
+		},
+		"ancestors_exclude_base": {
+			base:      headers[0].Hash(),
+			block:     headers[3].Hash(),
+			ancestors: []voterHash{newVoterHash(headers[2].Hash()), newVoterHash(headers[1].Hash())},
+		},
+		"block_is_base": {
+			base:        headers[1].Hash(),
+			block:       headers[1].Hash(),
+			errSentinel: errNotDescendant,
+		},
+		"block_is_ancestor_of_base": {
+			base:        headers[3].Hash(),
+			block:       headers[1].Hash(),
+			errSentinel: errNotDescendant,
+		},
+		"unknown_block": {
+			base:        headers[0].Hash(),
+			block:       common.Hash{9},
+			errSentinel: errTest,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			env := &environment{
+				service: &Service{blockState: newBlockState(ctrl)},
+			}
+
+			ancestors, err := env.Ancestry(newVoterHash(testCase.base), newVoterHash(testCase.block))
+			assert.ErrorIs(t, err, testCase.errSentinel)
+			assert.Equal(t, testCase.ancestors, ancestors)
+		})
+	}
+}
+
+func Test_environment_handleCommitMessage(t *testing.T) {
+	t.Parallel()
+
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+	keypairs := []*ed25519.Keypair{kr.Alice().(*ed25519.Keypair), kr.Bob().(*ed25519.Keypair)}
+	voters := make([]Voter, len(keypairs))
+	for i, keypair := range keypairs {
+		voters[i] = Voter{Key: *keypair.Public().(*ed25519.PublicKey), ID: 1}
+	}
+
+	header := types.NewHeader(common.Hash{1}, common.Hash{1}, common.Hash{}, 1, types.NewDigest())
+	const round, setID uint64 = 2, 1
+	commitMessage := &CommitMessage{
+		Round: round,
+		SetID: setID,
+		Vote:  *NewVoteFromHeader(header),
+	}
+	for _, keypair := range keypairs {
+		signedVote := signTestPrecommit(t, keypair, header, round, setID)
+		commitMessage.Precommits = append(commitMessage.Precommits, signedVote.Vote)
+		commitMessage.AuthData = append(commitMessage.AuthData, AuthData{
+			Signature:   signedVote.Signature,
+			AuthorityID: signedVote.AuthorityID,
+		})
+	}
+
+	expectedCommit := finality.CompactCommit[voterHash, uint32, voterSignature, voterID]{
+		TargetHash:   newVoterHash(header.Hash()),
+		TargetNumber: 1,
+		AuthData:     make(finality.MultiAuthData[voterSignature, voterID], len(keypairs)),
+	}
+	for i := range keypairs {
+		expectedCommit.Precommits = append(expectedCommit.Precommits, finality.Precommit[voterHash, uint32]{
+			TargetHash:   newVoterHash(header.Hash()),
+			TargetNumber: 1,
+		})
+		expectedCommit.AuthData[i].Signature = commitMessage.AuthData[i].Signature
+		expectedCommit.AuthData[i].ID = newVoterID(commitMessage.AuthData[i].AuthorityID)
+	}
+
+	testCases := map[string]struct {
+		commitMessage  func() *CommitMessage
+		hasHeaderCalls int
+		hasHeader      bool
+		expected       *finality.GlobalInItem
+		errSentinel    error
+		errMessage     string
+	}{
+		"set_id_mismatch": {
+			commitMessage: func() *CommitMessage {
+				message := *commitMessage
+				message.SetID = 2
+				return &message
+			},
+			errSentinel: ErrSetIDMismatch,
+			errMessage:  "set IDs do not match: received 2 and expected 1",
+		},
+		"missing_signature": {
+			commitMessage: func() *CommitMessage {
+				message := *commitMessage
+				message.AuthData = message.AuthData[:1]
+				return &message
+			},
+			errSentinel: ErrPrecommitSignatureMismatch,
+			errMessage: "number of precommits does not match number of signatures: " +
+				"2 precommits and 1 signatures",
+		},
+		"invalid_signature": {
+			commitMessage: func() *CommitMessage {
+				message := *commitMessage
+				message.AuthData = []AuthData{message.AuthData[1], message.AuthData[1]}
+				message.AuthData[0].AuthorityID = commitMessage.AuthData[0].AuthorityID
+				return &message
+			},
+			errSentinel: ErrInvalidSignature,
+		},
+		"unknown_block": {
+			commitMessage:  func() *CommitMessage { return commitMessage },
+			hasHeaderCalls: 1,
+			errSentinel:    ErrBlockDoesNotExist,
+		},
+		"valid_commit": {
+			commitMessage:  func() *CommitMessage { return commitMessage },
+			hasHeaderCalls: 2,
+			hasHeader:      true,
+			expected: &finality.GlobalInItem{
+				CommunicationIn: finality.NewCommunicationIn[voterHash, uint32, voterSignature, voterID](
+					finality.CommunicationInCommit[voterHash, uint32, voterSignature, voterID]{
+						Number:        round,
+						CompactCommit: expectedCommit,
+					}),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockState := NewMockBlockState(ctrl)
+			if testCase.hasHeaderCalls > 0 {
+				blockState.EXPECT().HasHeader(header.Hash()).
+					Return(testCase.hasHeader, nil).Times(testCase.hasHeaderCalls)
+			}
+
+			env := &environment{
+				ctx:      context.Background(),
+				service:  &Service{blockState: blockState},
+				setID:    setID,
+				voters:   voters,
+				globalIn: make(chan finality.GlobalInItem, 1),
+			}
+
+			err := env.handleCommitMessage(testCase.commitMessage())
+			assert.ErrorIs(t, err, testCase.errSentinel)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+
+			if testCase.expected == nil {
+				assert.Empty(t, env.globalIn)
+				return
+			}
+			require.Len(t, env.globalIn, 1)
+			assert.Equal(t, *testCase.expected, <-env.globalIn)
+		})
+	}
+}
+
+var errTest = errors.New("test error")
@@ -92,4 +92,16 @@
 	errRoundOutOfBounds         = errors.New("round out of bounds")
 	errRoundsMismatch           = errors.New("rounds mismatch")
 	errInvalidEquivocationStage = errors.New("invalid stage for equivocating")
+
+	errWarpSyncBeginNotFinalised = errors.New("warp sync begin block is not finalised")
+	errEmptyWarpSyncProof        = errors.New("warp sync proof is empty")
+	errMissingAuthoritySetChange = errors.New("fragment header has no authority set change")
+	errDuplicatePrecommit        = errors.New("duplicate precommit from authority")
+
+	errFinalityProofBlockNotFinalised = errors.New("finality proof block is not finalised")
+
+	errInvalidVoterSet      = errors.New("invalid voter set")
+	errNotDescendant        = errors.New("block is not descendant of base")
+	errAuthoritySetChanged  = errors.New("authority set changed")
+	errFinalityVoterStopped = errors.New("finality voter stopped")
 )
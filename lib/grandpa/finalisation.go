@@ -79,6 +79,8 @@ func (fh *finalisationHandler) run(errorCh chan<- error, ready chan<- struct{})
 
 		err := fh.initiateRound()
 		if err != nil {
+			// unblock Start if the first round cannot be initiated
+			fh.setReady(ready)
 			errorCh <- fmt.Errorf("initiating round: %w", err)
 			return
 		}
@@ -127,6 +129,14 @@ func (fh *finalisationHandler) Stop() (err error) {
 	return fh.stop()
 }
 
+// setReady closes the ready channel on the first run of the handler.
+func (fh *finalisationHandler) setReady(ready chan<- struct{}) {
+	if fh.firstRun {
+		fh.firstRun = false
+		close(ready)
+	}
+}
+
 // runEphemeralServices starts the two ephemeral services that handle the
 // votes for the current round, and returns with nil when the two
 // service runs succeed.
@@ -138,10 +148,7 @@ func (fh *finalisationHandler) runEphemeralServices(ready chan<- struct{}) error
 	fh.finalisationEngine, fh.votingRound = fh.newServices()
 	fh.servicesLock.Unlock()
 
-	if fh.firstRun {
-		fh.firstRun = false
-		close(ready)
-	}
+	fh.setReady(ready)
 
 	finalisationEngineErr := make(chan error)
 	go func() {
@@ -0,0 +1,126 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// maxUnknownHeaders is the maximum number of headers included in a finality proof.
+const maxUnknownHeaders = 100_000
+
+// FinalityProof proves the finality of a block with the justification of the given
+// descendant block, and the headers of the blocks after the proven block up to the
+// justified block.
+type FinalityProof struct {
+	Block          common.Hash
+	Justification  []byte
+	UnknownHeaders []types.Header
+}
+
+// ProveFinality returns the SCALE encoded finality proof of the finalised block with the
+// given number. The justification of the proof is the justification of the last block of the
+// authority set of the block, or the latest stored justification if the block belongs to the
+// current authority set. It returns nil if the justification is not stored.
+func (s *Service) ProveFinality(blockNumber uint) (proof []byte, err error) {
+	highestFinalisedHeader, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	if blockNumber > highestFinalisedHeader.Number {
+		return nil, fmt.Errorf("%w: requested block #%d but highest finalised block is #%d",
+			errFinalityProofBlockNotFinalised, blockNumber, highestFinalisedHeader.Number)
+	}
+
+	setID, err := s.grandpaState.GetSetIDByBlockNumber(blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("getting set id of block #%d: %w", blockNumber, err)
+	}
+
+	var justifiedHeader *types.Header
+	var justification []byte
+	lastBlockOfSet, err := s.grandpaState.GetSetIDChange(setID + 1)
+	switch {
+	case errors.Is(err, database.ErrNotFound), err == nil && lastBlockOfSet > highestFinalisedHeader.Number:
+		justifiedHeader, justification, err = s.latestJustification(blockNumber, highestFinalisedHeader)
+		if err != nil {
+			return nil, fmt.Errorf("getting latest justification: %w", err)
+		} else if justifiedHeader == nil {
+			logger.Debugf("no justification stored to prove the finality of block #%d", blockNumber)
+			return nil, nil
+		}
+	case err != nil:
+		return nil, fmt.Errorf("getting last block of set id %d: %w", setID, err)
+	default:
+		justifiedHeader, err = s.blockState.GetHeaderByNumber(lastBlockOfSet)
+		if err != nil {
+			return nil, fmt.Errorf("getting header of block #%d: %w", lastBlockOfSet, err)
+		}
+
+		justification, err = s.blockState.GetJustification(justifiedHeader.Hash())
+		if errors.Is(err, database.ErrNotFound) {
+			logger.Debugf("no justification stored for the last block #%d of set id %d",
+				lastBlockOfSet, setID)
+			return nil, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("getting justification of block #%d: %w", lastBlockOfSet, err)
+		}
+	}
+
+	finalityProof := FinalityProof{
+		Block:          justifiedHeader.Hash(),
+		Justification:  justification,
+		UnknownHeaders: []types.Header{},
+	}
+
+	for number := blockNumber + 1; number <= justifiedHeader.Number; number++ {
+		if len(finalityProof.UnknownHeaders) >= maxUnknownHeaders {
+			break
+		}
+
+		header, err := s.blockState.GetHeaderByNumber(number)
+		if err != nil {
+			return nil, fmt.Errorf("getting header of block #%d: %w", number, err)
+		}
+		finalityProof.UnknownHeaders = append(finalityProof.UnknownHeaders, *header)
+	}
+
+	proof, err = scale.Marshal(finalityProof)
+	if err != nil {
+		return nil, fmt.Errorf("encoding finality proof: %w", err)
+	}
+	return proof, nil
+}
+
+// latestJustification returns the header and the justification of the highest finalised
+// block with a stored justification, which is not lower than the given block number.
+// It returns a nil header if there is no such justification.
+func (s *Service) latestJustification(lowestNumber uint, highestFinalisedHeader *types.Header) (
+	header *types.Header, justification []byte, err error) {
+	header = highestFinalisedHeader
+	for {
+		justification, err = s.blockState.GetJustification(header.Hash())
+		if err == nil {
+			return header, justification, nil
+		} else if !errors.Is(err, database.ErrNotFound) {
+			return nil, nil, fmt.Errorf("getting justification of block #%d: %w", header.Number, err)
+		}
+
+		if header.Number <= lowestNumber {
+			return nil, nil, nil
+		}
+
+		header, err = s.blockState.GetHeader(header.ParentHash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting parent header: %w", err)
+		}
+	}
+}
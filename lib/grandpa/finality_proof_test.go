@@ -0,0 +1,147 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_ProveFinality(t *testing.T) {
+	t.Parallel()
+
+	// the block #3 is the last block of the set 0, and the block #5 is the highest finalised block
+	headers := make([]*types.Header, 6)
+	headers[0] = types.NewHeader(common.Hash{}, common.Hash{1}, common.Hash{}, 0, types.NewDigest())
+	for number := 1; number < len(headers); number++ {
+		headers[number] = types.NewHeader(headers[number-1].Hash(), common.Hash{byte(number)},
+			common.Hash{}, uint(number), types.NewDigest())
+	}
+
+	encodeProof := func(t *testing.T, proof FinalityProof) []byte {
+		t.Helper()
+		encoded, err := scale.Marshal(proof)
+		require.NoError(t, err)
+		return encoded
+	}
+
+	tests := map[string]struct {
+		blockNumber        uint
+		blockStateBuilder  func(ctrl *gomock.Controller) BlockState
+		grandpaStateSetIDs map[uint]uint64
+		expProof           func(t *testing.T) []byte
+		expErr             error
+		expErrMsg          string
+	}{
+		"block_not_finalised": {
+			blockNumber: 6,
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(headers[5], nil)
+				return blockState
+			},
+			expErr:    errFinalityProofBlockNotFinalised,
+			expErrMsg: "finality proof block is not finalised: requested block #6 but highest finalised block is #5",
+		},
+		"block_of_previous_set": {
+			blockNumber: 1,
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(headers[5], nil)
+				blockState.EXPECT().GetHeaderByNumber(uint(3)).Return(headers[3], nil).Times(2)
+				blockState.EXPECT().GetJustification(headers[3].Hash()).Return([]byte{3}, nil)
+				blockState.EXPECT().GetHeaderByNumber(uint(2)).Return(headers[2], nil)
+				return blockState
+			},
+			grandpaStateSetIDs: map[uint]uint64{1: 0},
+			expProof: func(t *testing.T) []byte {
+				return encodeProof(t, FinalityProof{
+					Block:          headers[3].Hash(),
+					Justification:  []byte{3},
+					UnknownHeaders: []types.Header{*headers[2], *headers[3]},
+				})
+			},
+		},
+		"last_block_of_previous_set_justification_not_stored": {
+			blockNumber: 2,
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(headers[5], nil)
+				blockState.EXPECT().GetHeaderByNumber(uint(3)).Return(headers[3], nil)
+				blockState.EXPECT().GetJustification(headers[3].Hash()).Return(nil, database.ErrNotFound)
+				return blockState
+			},
+			grandpaStateSetIDs: map[uint]uint64{2: 0},
+			expProof:           func(*testing.T) []byte { return nil },
+		},
+		"block_of_current_set": {
+			blockNumber: 4,
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(headers[5], nil)
+				blockState.EXPECT().GetJustification(headers[5].Hash()).Return(nil, database.ErrNotFound)
+				blockState.EXPECT().GetHeader(headers[4].Hash()).Return(headers[4], nil)
+				blockState.EXPECT().GetJustification(headers[4].Hash()).Return([]byte{4}, nil)
+				return blockState
+			},
+			grandpaStateSetIDs: map[uint]uint64{4: 1},
+			expProof: func(t *testing.T) []byte {
+				return encodeProof(t, FinalityProof{
+					Block:          headers[4].Hash(),
+					Justification:  []byte{4},
+					UnknownHeaders: []types.Header{},
+				})
+			},
+		},
+		"latest_justification_not_stored": {
+			blockNumber: 5,
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(headers[5], nil)
+				blockState.EXPECT().GetJustification(headers[5].Hash()).Return(nil, database.ErrNotFound)
+				return blockState
+			},
+			grandpaStateSetIDs: map[uint]uint64{5: 1},
+			expProof:           func(*testing.T) []byte { return nil },
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			grandpaState := NewMockGrandpaState(ctrl)
+			for number, setID := range tt.grandpaStateSetIDs {
+				grandpaState.EXPECT().GetSetIDByBlockNumber(number).Return(setID, nil)
+				if setID == 0 {
+					grandpaState.EXPECT().GetSetIDChange(uint64(1)).Return(uint(3), nil)
+				} else {
+					grandpaState.EXPECT().GetSetIDChange(uint64(2)).Return(uint(0), database.ErrNotFound)
+				}
+			}
+
+			service := &Service{
+				blockState:   tt.blockStateBuilder(ctrl),
+				grandpaState: grandpaState,
+			}
+
+			proof, err := service.ProveFinality(tt.blockNumber)
+			assert.ErrorIs(t, err, tt.expErr)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErrMsg)
+				return
+			}
+			assert.Equal(t, tt.expProof(t), proof)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	finality "github.com/ChainSafe/gossamer/pkg/finality-grandpa"
+)
+
+// authoritySetCheckInterval is the interval at which the finality voter
+// checks if the authority set changed, since scheduled and forced changes
+// are applied by the digest handler when blocks are finalised or imported.
+const authoritySetCheckInterval = time.Second
+
+// finalityEnvironment returns the environment of the running finality voter, or nil
+// if the service does not run the finality-grandpa voter.
+func (s *Service) finalityEnvironment() *environment {
+	s.envLock.RLock()
+	defer s.envLock.RUnlock()
+	return s.env
+}
+
+func (s *Service) setFinalityEnvironment(env *environment) {
+	s.envLock.Lock()
+	defer s.envLock.Unlock()
+	s.env = env
+}
+
+// runFinalityVoter runs the finality-grandpa voter for each authority set,
+// until the service is stopped.
+func (s *Service) runFinalityVoter() error {
+	for {
+		err := s.runFinalityVoterForSet()
+		switch {
+		case errors.Is(err, errAuthoritySetChanged):
+			continue
+		case errors.Is(err, errFinalityVoterStopped):
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
+// runFinalityVoterForSet runs the finality-grandpa voter for the current authority set,
+// and returns errAuthoritySetChanged once the authority set changes.
+func (s *Service) runFinalityVoterForSet() error {
+	setID, err := s.grandpaState.GetCurrentSetID()
+	if err != nil {
+		return fmt.Errorf("getting current set id: %w", err)
+	}
+
+	authorities, err := s.grandpaState.GetAuthorities(setID)
+	if err != nil {
+		return fmt.Errorf("getting authorities for set id %d: %w", setID, err)
+	}
+
+	voterSet, err := newVoterSet(authorities)
+	if err != nil {
+		return fmt.Errorf("creating voter set: %w", err)
+	}
+
+	highestFinalised, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	// the rounds of a new authority set start from zero
+	lastRound, lastRoundSetID, err := s.blockState.GetHighestRoundAndSetID()
+	if err != nil {
+		return fmt.Errorf("getting highest round and set id: %w", err)
+	}
+	if lastRoundSetID != setID {
+		lastRound = 0
+	}
+
+	s.roundLock.Lock()
+	s.state = NewState(authorities, setID, lastRound)
+	s.roundLock.Unlock()
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	env := newEnvironment(ctx, s, setID, authorities)
+	lastFinalised := voterHashNumber{
+		Hash:   newVoterHash(highestFinalised.Hash()),
+		Number: uint32(highestFinalised.Number),
+	}
+
+	var lastRoundVotes []voterSignedMessage
+	if lastRound > 0 {
+		lastRoundVotes = env.lastRoundVotes(lastRound)
+	}
+
+	voter, globalOut := finality.NewVoter[voterHash, uint32, voterSignature, voterID](
+		env, *voterSet, env.globalIn, lastRound, lastRoundVotes, lastFinalised, lastFinalised)
+	go env.handleGlobalOutgoing(globalOut)
+
+	s.setFinalityEnvironment(env)
+	defer s.setFinalityEnvironment(nil)
+
+	logger.Infof("starting finality voter for set id %d at round %d with voter set %s",
+		setID, lastRound+1, Voters(authorities))
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- voter.Start()
+	}()
+
+	ticker := time.NewTicker(authoritySetCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			err = voter.Stop()
+			if err != nil {
+				return fmt.Errorf("stopping finality voter: %w", err)
+			}
+			return errFinalityVoterStopped
+		case err := <-errCh:
+			if err == nil {
+				return errFinalityVoterStopped
+			}
+			return fmt.Errorf("running finality voter: %w", err)
+		case <-ticker.C:
+			currentSetID, err := s.grandpaState.GetCurrentSetID()
+			if err != nil {
+				return fmt.Errorf("getting current set id: %w", err)
+			}
+
+			if currentSetID == setID {
+				continue
+			}
+
+			logger.Infof("authority set changed from set id %d to %d, restarting finality voter",
+				setID, currentSetID)
+			err = voter.Stop()
+			if err != nil {
+				return fmt.Errorf("stopping finality voter: %w", err)
+			}
+			return fmt.Errorf("%w: from set id %d to %d", errAuthoritySetChanged, setID, currentSetID)
+		}
+	}
+}
@@ -30,6 +30,8 @@
 
 const (
 	defaultGrandpaInterval = time.Second
+	// voterRestartDelay is the delay before the voter is restarted after it failed.
+	voterRestartDelay = 5 * time.Second
 )
 
 var (
@@ -61,6 +63,11 @@ type Service struct {
 	network        Network
 	interval       time.Duration
 
+	// finalityGrandpa runs the finality-grandpa voter instead of the service voting rounds
+	finalityGrandpa bool
+	envLock         sync.RWMutex
+	env             *environment // environment of the running finality-grandpa voter
+
 	// current state information
 	state *State // current state
 	// map[ed25519.PublicKeyBytes]*SignedVote - pre-votes for the current round
@@ -93,6 +100,8 @@ type Config struct {
 	Authority    bool
 	Interval     time.Duration
 	Telemetry    Telemetry
+	// FinalityGrandpa runs the pkg/finality-grandpa voter as the finality gadget
+	FinalityGrandpa bool
 }
 
 // NewService returns a new GRANDPA Service instance.
@@ -151,6 +160,7 @@ func NewService(cfg *Config) (*Service, error) {
 		finalisedCh:        finalisedCh,
 		interval:           cfg.Interval,
 		telemetry:          cfg.Telemetry,
+		finalityGrandpa:    cfg.FinalityGrandpa,
 	}
 
 	if err := s.registerProtocol(); err != nil {
@@ -171,18 +181,37 @@ func (s *Service) Start() error {
 		return nil
 	}
 
+	if s.finalityGrandpa {
+		go s.runVoterUntilStopped("finality voter", s.runFinalityVoter)
+		return nil
+	}
+
 	s.tracker.start()
 
-	go func() {
-		err := s.initiate()
-		if err != nil {
-			panic(fmt.Sprintf("running grandpa service: %s", err))
-		}
-	}()
+	go s.runVoterUntilStopped("grandpa voter", s.initiate)
 
 	return nil
 }
 
+// runVoterUntilStopped runs the given voter and restarts it after voterRestartDelay
+// if it fails, until the service is stopped.
+func (s *Service) runVoterUntilStopped(name string, runVoter func() error) {
+	for {
+		err := runVoter()
+		if err == nil {
+			return
+		}
+		logger.Criticalf("running %s: %s", name, err)
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(voterRestartDelay):
+		}
+		logger.Infof("restarting %s", name)
+	}
+}
+
 // Stop stops the GRANDPA finality service
 func (s *Service) Stop() error {
 	s.chanLock.Lock()
@@ -191,7 +220,7 @@ func (s *Service) Stop() error {
 	s.cancel()
 	s.blockState.FreeFinalisedNotifierChannel(s.finalisedCh)
 
-	if !s.authority {
+	if !s.authority || s.finalityGrandpa {
 		return nil
 	}
 
@@ -1185,14 +1214,25 @@ func (s *Service) handleCommitMessage(commitMessage *CommitMessage) error {
 		return fmt.Errorf("verifying commit message justification: %w", err)
 	}
 
-	err = s.blockState.SetFinalisedHash(commitMessage.Vote.Hash, commitMessage.Round, s.state.setID)
+	preCommitSigned, err := compactToJustification(commitMessage.Precommits, commitMessage.AuthData)
 	if err != nil {
-		return fmt.Errorf("setting finalised hash: %w", err)
+		return fmt.Errorf("compacting justification: %w", err)
 	}
 
-	preCommitSigned, err := compactToJustification(commitMessage.Precommits, commitMessage.AuthData)
+	justification, err := scale.Marshal(*newJustification(commitMessage.Round, commitMessage.Vote.Hash,
+		commitMessage.Vote.Number, preCommitSigned))
 	if err != nil {
-		return fmt.Errorf("compacting justification: %w", err)
+		return fmt.Errorf("encoding justification: %w", err)
+	}
+
+	err = s.blockState.SetJustification(commitMessage.Vote.Hash, justification)
+	if err != nil {
+		return fmt.Errorf("setting justification: %w", err)
+	}
+
+	err = s.blockState.SetFinalisedHash(commitMessage.Vote.Hash, commitMessage.Round, s.state.setID)
+	if err != nil {
+		return fmt.Errorf("setting finalised hash: %w", err)
 	}
 
 	err = s.grandpaState.SetPrecommits(commitMessage.Round, commitMessage.SetID, preCommitSigned)
@@ -0,0 +1,49 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Service_runVoterUntilStopped(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		voterErr       error
+		serviceStopped bool
+	}{
+		"voter_stopped": {},
+		"voter_failed_and_service_stopped": {
+			voterErr:       errors.New("test error"),
+			serviceStopped: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if testCase.serviceStopped {
+				cancel()
+			}
+			service := &Service{ctx: ctx}
+
+			var runs int
+			service.runVoterUntilStopped("test voter", func() error {
+				runs++
+				return testCase.voterErr
+			})
+
+			assert.Equal(t, 1, runs)
+		})
+	}
+}
@@ -41,11 +41,17 @@ type testJustificationRequest struct {
 	num uint32
 }
 
+// testProtocolID is the legacy protocol id of the chain of the test network
+const testProtocolID = "/test"
+
 type testNetwork struct {
 	t                    *testing.T
 	out                  chan GrandpaMessage
 	finalised            chan GrandpaMessage
 	justificationRequest *testJustificationRequest
+	// protocolNames are the names of the registered notifications protocol,
+	// the first one being its primary name
+	protocolNames []protocol.ID
 }
 
 func newTestNetwork(t *testing.T) *testNetwork {
@@ -82,8 +88,12 @@ func (n *testNetwork) SendJustificationRequest(to peer.ID, num uint32) {
 	}
 }
 
-func (*testNetwork) RegisterNotificationsProtocol(
-	_ protocol.ID,
+func (*testNetwork) ProtocolNames(subprotocol protocol.ID) []protocol.ID {
+	return network.ProtocolNames(common.Hash{}, "", testProtocolID, subprotocol)
+}
+
+func (n *testNetwork) RegisterNotificationsProtocol(
+	name protocol.ID,
 	_ network.MessageType,
 	_ network.HandshakeGetter,
 	_ network.HandshakeDecoder,
@@ -92,7 +102,9 @@ func (*testNetwork) RegisterNotificationsProtocol(
 	_ network.NotificationsMessageHandler,
 	_ network.NotificationsMessageBatchHandler,
 	_ uint64,
+	fallbackNames ...protocol.ID,
 ) error {
+	n.protocolNames = append([]protocol.ID{name}, fallbackNames...)
 	return nil
 }
 
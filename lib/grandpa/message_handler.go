@@ -45,6 +45,13 @@ func NewMessageHandler(grandpa *Service, blockState BlockState, telemetryMailer
 func (h *MessageHandler) handleMessage(from peer.ID, m GrandpaMessage) (network.NotificationsMessage, error) {
 	logger.Tracef("handling grandpa message: %v", m)
 
+	if env := h.grandpa.finalityEnvironment(); env != nil {
+		handled, err := env.handleMessage(from, m)
+		if handled {
+			return nil, err
+		}
+	}
+
 	switch msg := m.(type) {
 	case *VoteMessage:
 		err := h.grandpa.handleVoteMessage(from, msg)
@@ -533,6 +540,13 @@ func (s *Service) VerifyBlockJustification(hash common.Hash, justification []byt
 		}
 	}
 
+	// the justification is stored before finalising the block, for the finalised block
+	// notifications to be followed by the justification
+	err = s.blockState.SetJustification(hash, justification)
+	if err != nil {
+		return fmt.Errorf("setting justification: %w", err)
+	}
+
 	err = s.blockState.SetFinalisedHash(hash, fj.Round, setID)
 	if err != nil {
 		return fmt.Errorf("setting finalised hash: %w", err)
@@ -1517,6 +1517,7 @@ type args struct {
 					mockBlockState.EXPECT().IsDescendantOf(testHash, testHash).
 						Return(true, nil).Times(3)
 					mockBlockState.EXPECT().GetHeader(testHash).Return(testHeader, nil).Times(3)
+					mockBlockState.EXPECT().SetJustification(testHash, justificationBytes).Return(nil)
 					mockBlockState.EXPECT().SetFinalisedHash(testHash, uint64(1),
 						uint64(0)).Return(nil)
 					return mockBlockState
@@ -1547,6 +1548,8 @@ type args struct {
 					mockBlockState.EXPECT().IsDescendantOf(testHash, testHash).
 						Return(true, nil).Times(3)
 					mockBlockState.EXPECT().GetHeader(testHash).Return(testHeader, nil).Times(3)
+					mockBlockState.EXPECT().SetJustification(testHash, append(justificationBytes,
+						[]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}...)).Return(nil)
 					mockBlockState.EXPECT().SetFinalisedHash(testHash, uint64(1),
 						uint64(0)).Return(nil)
 					return mockBlockState
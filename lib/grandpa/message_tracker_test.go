@@ -117,6 +117,11 @@ func TestMessageTracker_handleTick_commitMessage(t *testing.T) {
 					HasFinalisedBlock(commitMessageRound, serviceStateSetID).
 					Return(false, nil)
 
+				justification := scale.MustMarshal(*newJustification(commitMessageRound, testHash, 1, []SignedVote{}))
+				blockStateMock.EXPECT().
+					SetJustification(testHash, justification).
+					Return(nil)
+
 				blockStateMock.EXPECT().
 					SetFinalisedHash(testHash, commitMessageRound, serviceStateSetID).
 					Return(nil)
@@ -5,17 +5,19 @@
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 
 	"github.com/libp2p/go-libp2p/core/peer"
-	"github.com/libp2p/go-libp2p/core/protocol"
 )
 
-const grandpaID1 = "grandpa/1"
+const grandpaID1 = "/grandpa/1"
+
+// legacyGrandpaProtocolID is the legacy name of the grandpa protocol, used with the peers
+// which don't support the genesis hash based name
+const legacyGrandpaProtocolID = "/paritytech/grandpa/1"
 
 // NotificationsMessage is an alias for network.NotificationsMessage
 type NotificationsMessage = network.NotificationsMessage
@@ -54,12 +56,11 @@ func (hs *GrandpaHandshake) IsValid() bool {
 }
 
 func (s *Service) registerProtocol() error {
-	genesisHash := s.blockState.GenesisHash().String()
-	genesisHash = strings.TrimPrefix(genesisHash, "0x")
-	grandpaProtocolID := fmt.Sprintf("/%s/%s", genesisHash, grandpaID1)
+	names := s.network.ProtocolNames(grandpaID1)
+	fallbackNames := append(names[1:], legacyGrandpaProtocolID)
 
 	return s.network.RegisterNotificationsProtocol(
-		protocol.ID(grandpaProtocolID),
+		names[0],
 		network.ConsensusMsgType,
 		s.getHandshake,
 		s.decodeHandshake,
@@ -68,6 +69,7 @@ func (s *Service) registerProtocol() error {
 		s.handleNetworkMessage,
 		nil,
 		network.MaxGrandpaNotificationSize,
+		fallbackNames...,
 	)
 }
 
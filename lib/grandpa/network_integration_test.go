@@ -13,9 +13,25 @@
 	"go.uber.org/mock/gomock"
 
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/stretchr/testify/require"
 )
 
+func TestService_registerProtocol(t *testing.T) {
+	net := newTestNetwork(t)
+	s := &Service{network: net}
+
+	err := s.registerProtocol()
+	require.NoError(t, err)
+
+	expected := []protocol.ID{
+		"/0000000000000000000000000000000000000000000000000000000000000000/grandpa/1",
+		"/test/grandpa/1",
+		"/paritytech/grandpa/1",
+	}
+	require.Equal(t, expected, net.protocolNames)
+}
+
 func TestGrandpaHandshake_Encode(t *testing.T) {
 	hs := &GrandpaHandshake{
 		Role: 4,
@@ -33,6 +33,7 @@ type BlockState interface {
 	GetFinalisedNotifierChannel() chan *types.FinalisationInfo
 	FreeFinalisedNotifierChannel(ch chan *types.FinalisationInfo)
 	SetJustification(hash common.Hash, data []byte) error
+	GetJustification(hash common.Hash) ([]byte, error)
 	BestBlockNumber() (blockNumber uint, err error)
 	GetHighestRoundAndSetID() (uint64, uint64, error)
 	BestBlockHash() common.Hash
@@ -44,6 +45,7 @@ type GrandpaState interface {
 	GetCurrentSetID() (uint64, error)
 	GetAuthorities(setID uint64) ([]types.GrandpaVoter, error)
 	GetSetIDByBlockNumber(num uint) (uint64, error)
+	GetSetIDChange(setID uint64) (blockNumber uint, err error)
 	SetLatestRound(round uint64) error
 	GetLatestRound() (uint64, error)
 	SetPrevotes(round, setID uint64, data []SignedVote) error
@@ -57,6 +59,7 @@ type GrandpaState interface {
 type Network interface {
 	GossipMessage(msg network.NotificationsMessage)
 	SendMessage(to peer.ID, msg NotificationsMessage) error
+	ProtocolNames(subprotocol protocol.ID) []protocol.ID
 	RegisterNotificationsProtocol(sub protocol.ID,
 		messageID network.MessageType,
 		handshakeGetter network.HandshakeGetter,
@@ -66,5 +69,6 @@ type Network interface {
 		messageHandler network.NotificationsMessageHandler,
 		batchHandler network.NotificationsMessageBatchHandler,
 		maxSize uint64,
+		fallbackProtocolIDs ...protocol.ID,
 	) error
 }
@@ -276,6 +276,13 @@ func (s *Service) reportEquivocation(stage Subround, existingVote *SignedVote, c
 		return fmt.Errorf("getting latest round: %w", err)
 	}
 
+	return s.submitEquivocationReport(setID, round, stage, existingVote, currentVote)
+}
+
+// submitEquivocationReport submits to the runtime the report of an equivocation
+// in the given subround of the round and authority set
+func (s *Service) submitEquivocationReport(setID, round uint64, stage Subround,
+	existingVote *SignedVote, currentVote *SignedVote) error {
 	pubKey := existingVote.AuthorityID
 
 	bestBlockHash := s.blockState.BestBlockHash()
@@ -0,0 +1,382 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// AuthoritySetChange is an authority set change proven by a warp sync proof fragment.
+type AuthoritySetChange struct {
+	// Number is the number of the last block of the previous authority set.
+	Number      uint
+	SetID       uint64
+	Authorities []types.GrandpaVoter
+}
+
+// GenerateWarpSyncProof returns the justifications of the last blocks of the authority
+// sets following the authority set of the given finalised block, followed by the
+// justification of the highest finalised block if it is stored.
+func (s *Service) GenerateWarpSyncProof(begin common.Hash) (proof *messages.WarpSyncProof, err error) {
+	beginHeader, err := s.blockState.GetHeader(begin)
+	if err != nil {
+		return nil, fmt.Errorf("getting begin header: %w", err)
+	}
+
+	highestFinalisedHeader, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	if beginHeader.Number > highestFinalisedHeader.Number {
+		return nil, fmt.Errorf("%w: block #%d", errWarpSyncBeginNotFinalised, beginHeader.Number)
+	}
+
+	canonicalHeader, err := s.blockState.GetHeaderByNumber(beginHeader.Number)
+	if err != nil {
+		return nil, fmt.Errorf("getting canonical header: %w", err)
+	}
+
+	if canonicalHeader.Hash() != begin {
+		return nil, fmt.Errorf("%w: block #%d is not canonical", errWarpSyncBeginNotFinalised, beginHeader.Number)
+	}
+
+	setID, err := s.grandpaState.GetSetIDByBlockNumber(beginHeader.Number)
+	if err != nil {
+		return nil, fmt.Errorf("getting set id of begin block: %w", err)
+	}
+
+	currentSetID, err := s.grandpaState.GetCurrentSetID()
+	if err != nil {
+		return nil, fmt.Errorf("getting current set id: %w", err)
+	}
+
+	proof = &messages.WarpSyncProof{}
+	proofSize := 0
+	lastNumber := beginHeader.Number
+	for nextSetID := setID + 1; nextSetID <= currentSetID; nextSetID++ {
+		number, err := s.grandpaState.GetSetIDChange(nextSetID)
+		if err != nil {
+			return nil, fmt.Errorf("getting block number of set id %d change: %w", nextSetID, err)
+		}
+
+		// the begin block can be the last block of its authority set
+		if number <= lastNumber {
+			continue
+		}
+
+		header, err := s.blockState.GetHeaderByNumber(number)
+		if err != nil {
+			return nil, fmt.Errorf("getting header: %w", err)
+		}
+
+		fragment, err := s.newWarpSyncFragment(header)
+		if err != nil {
+			return nil, fmt.Errorf("creating fragment for block #%d: %w", number, err)
+		}
+
+		encodedFragment, err := scale.Marshal(*fragment)
+		if err != nil {
+			return nil, fmt.Errorf("encoding fragment: %w", err)
+		}
+
+		if proofSize+len(encodedFragment) > messages.MaxWarpSyncProofSize {
+			return proof, nil
+		}
+
+		proof.Fragments = append(proof.Fragments, *fragment)
+		proofSize += len(encodedFragment)
+		lastNumber = number
+	}
+
+	if highestFinalisedHeader.Number > lastNumber {
+		fragment, err := s.newWarpSyncFragment(highestFinalisedHeader)
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			logger.Debugf("no justification stored for highest finalised block #%d",
+				highestFinalisedHeader.Number)
+		case err != nil:
+			return nil, fmt.Errorf("creating fragment for highest finalised block: %w", err)
+		default:
+			encodedFragment, err := scale.Marshal(*fragment)
+			if err != nil {
+				return nil, fmt.Errorf("encoding fragment: %w", err)
+			}
+
+			if proofSize+len(encodedFragment) > messages.MaxWarpSyncProofSize {
+				return proof, nil
+			}
+			proof.Fragments = append(proof.Fragments, *fragment)
+		}
+	}
+
+	proof.IsFinished = true
+	return proof, nil
+}
+
+// newWarpSyncFragment creates a warp sync fragment with the stored justification of the
+// given block, and the ancestry of the blocks precommitted in the justification.
+func (s *Service) newWarpSyncFragment(header *types.Header) (*messages.WarpSyncFragment, error) {
+	hash := header.Hash()
+	encodedJustification, err := s.blockState.GetJustification(hash)
+	if err != nil {
+		return nil, fmt.Errorf("getting justification: %w", err)
+	}
+
+	justification := Justification{}
+	err = scale.Unmarshal(encodedJustification, &justification)
+	if err != nil {
+		return nil, fmt.Errorf("decoding justification: %w", err)
+	}
+
+	if justification.Commit.Hash != hash {
+		return nil, fmt.Errorf("%w: justification %s and block hash %s",
+			ErrJustificationMismatch, justification.Commit.Hash.Short(), hash.Short())
+	}
+
+	var ancestries []types.Header
+	seen := make(map[common.Hash]struct{})
+	for _, signedVote := range justification.Commit.Precommits {
+		ancestorHash := signedVote.Vote.Hash
+		for ancestorHash != hash {
+			if _, ok := seen[ancestorHash]; ok {
+				break
+			}
+
+			ancestor, err := s.blockState.GetHeader(ancestorHash)
+			if err != nil {
+				return nil, fmt.Errorf("getting precommitted block header: %w", err)
+			}
+
+			if ancestor.Number <= header.Number {
+				return nil, fmt.Errorf("%w: block %s", ErrPrecommitBlockMismatch, signedVote.Vote.Hash)
+			}
+
+			seen[ancestorHash] = struct{}{}
+			ancestries = append(ancestries, *ancestor)
+			ancestorHash = ancestor.ParentHash
+		}
+	}
+
+	return &messages.WarpSyncFragment{
+		Header: *header,
+		Justification: messages.WarpSyncJustification{
+			Round: justification.Round,
+			Commit: messages.WarpSyncCommit{
+				Hash:       justification.Commit.Hash,
+				Number:     justification.Commit.Number,
+				Precommits: justification.Commit.Precommits,
+			},
+			VotesAncestries: ancestries,
+		},
+	}, nil
+}
+
+// VerifyWarpSyncProof verifies the justifications of the warp sync proof fragments, starting
+// with the authority set with the given set ID and authorities, and returns the authority set
+// changes enacted by the fragments. Each fragment but the last fragment of a finished proof must
+// enact a scheduled authority set change, since the next fragment is signed by the next set.
+func VerifyWarpSyncProof(proof *messages.WarpSyncProof, setID uint64, authorities []types.GrandpaVoter) (
+	changes []AuthoritySetChange, err error) {
+	if len(proof.Fragments) == 0 {
+		return nil, errEmptyWarpSyncProof
+	}
+
+	for i := range proof.Fragments {
+		fragment := proof.Fragments[i]
+		err = verifyWarpSyncJustification(&fragment.Header, fragment.Justification, setID, authorities)
+		if err != nil {
+			return nil, fmt.Errorf("verifying justification of block #%d: %w", fragment.Header.Number, err)
+		}
+
+		scheduledChange, err := findScheduledChange(&fragment.Header)
+		if err != nil {
+			return nil, fmt.Errorf("finding scheduled change: %w", err)
+		}
+
+		if scheduledChange == nil {
+			isLast := i == len(proof.Fragments)-1
+			if isLast && proof.IsFinished {
+				break
+			}
+			return nil, fmt.Errorf("%w: block #%d", errMissingAuthoritySetChange, fragment.Header.Number)
+		}
+
+		authorities, err = types.NewGrandpaVotersFromAuthoritiesRaw(scheduledChange.Auths)
+		if err != nil {
+			return nil, fmt.Errorf("creating grandpa voters: %w", err)
+		}
+		setID++
+
+		changes = append(changes, AuthoritySetChange{
+			Number:      fragment.Header.Number,
+			SetID:       setID,
+			Authorities: authorities,
+		})
+	}
+
+	return changes, nil
+}
+
+// VerifyStateSyncJustification verifies the encoded justification is for the given header
+// and is signed by enough authorities of the given set. The justification is either encoded
+// with its votes ancestries, as sent by Substrate nodes, or without them.
+func VerifyStateSyncJustification(header *types.Header, encodedJustification []byte,
+	setID uint64, authorities []types.GrandpaVoter) error {
+	justification := messages.WarpSyncJustification{}
+	err := scale.Unmarshal(encodedJustification, &justification)
+	if err != nil {
+		withoutAncestries := Justification{}
+		err = scale.Unmarshal(encodedJustification, &withoutAncestries)
+		if err != nil {
+			return fmt.Errorf("decoding justification: %w", err)
+		}
+
+		justification = messages.WarpSyncJustification{
+			Round: withoutAncestries.Round,
+			Commit: messages.WarpSyncCommit{
+				Hash:       withoutAncestries.Commit.Hash,
+				Number:     withoutAncestries.Commit.Number,
+				Precommits: withoutAncestries.Commit.Precommits,
+			},
+		}
+	}
+
+	return verifyWarpSyncJustification(header, justification, setID, authorities)
+}
+
+// verifyWarpSyncJustification verifies the justification is for the given header and
+// is signed by enough authorities of the given set, using the votes ancestries of the
+// justification instead of the block tree to check the precommitted blocks descend
+// from the justified block.
+func verifyWarpSyncJustification(header *types.Header, justification messages.WarpSyncJustification,
+	setID uint64, authorities []types.GrandpaVoter) error {
+	hash := header.Hash()
+	if justification.Commit.Hash != hash || uint(justification.Commit.Number) != header.Number {
+		return fmt.Errorf("%w: justification %s and block hash %s",
+			ErrJustificationMismatch, justification.Commit.Hash.Short(), hash.Short())
+	}
+
+	ancestry := make(map[common.Hash]*types.Header, len(justification.VotesAncestries))
+	for i := range justification.VotesAncestries {
+		ancestor := &justification.VotesAncestries[i]
+		ancestry[ancestor.Hash()] = ancestor
+	}
+
+	authorityKeys := make(map[string]struct{}, len(authorities))
+	for _, authority := range authorities {
+		authorityKeys[string(authority.Key.Encode())] = struct{}{}
+	}
+
+	authData := make([]AuthData, len(justification.Commit.Precommits))
+	for i, signedVote := range justification.Commit.Precommits {
+		authData[i] = AuthData{
+			Signature:   signedVote.Signature,
+			AuthorityID: signedVote.AuthorityID,
+		}
+	}
+	equivocatoryVoters := getEquivocatoryVoters(authData)
+
+	var count int
+	voted := make(map[ed25519.PublicKeyBytes]struct{}, len(justification.Commit.Precommits))
+	for i := range justification.Commit.Precommits {
+		signedVote := &justification.Commit.Precommits[i]
+		err := verifyJustification(signedVote, justification.Round, setID, precommit, authorityKeys)
+		if err != nil {
+			return fmt.Errorf("verifying precommit: %w", err)
+		}
+
+		if !isDescendantInAncestry(ancestry, hash, header.Number, signedVote.Vote) {
+			return fmt.Errorf("%w: block %s", ErrPrecommitBlockMismatch, signedVote.Vote.Hash.Short())
+		}
+
+		// equivocatory voters are counted once below
+		if _, ok := equivocatoryVoters[signedVote.AuthorityID]; ok {
+			continue
+		}
+
+		if _, ok := voted[signedVote.AuthorityID]; ok {
+			return fmt.Errorf("%w: %s", errDuplicatePrecommit, signedVote.AuthorityID)
+		}
+		voted[signedVote.AuthorityID] = struct{}{}
+		count++
+	}
+
+	// strict supermajority of the authorities, which is the
+	// threshold of the voter set of the finality-grandpa crate
+	threshold := len(authorities) - (len(authorities)-1)/3
+	if count+len(equivocatoryVoters) < threshold {
+		return fmt.Errorf("%w: need %d votes but received only %d valid votes",
+			ErrMinVotesNotMet, threshold, count+len(equivocatoryVoters))
+	}
+
+	return nil
+}
+
+// isDescendantInAncestry returns true if the voted block is the base block or
+// descends from it, following the parent hashes of the given ancestry headers.
+func isDescendantInAncestry(ancestry map[common.Hash]*types.Header,
+	baseHash common.Hash, baseNumber uint, vote types.GrandpaVote) bool {
+	hash := vote.Hash
+	number := uint(vote.Number)
+	for {
+		if hash == baseHash {
+			return number == baseNumber
+		}
+
+		if number <= baseNumber {
+			return false
+		}
+
+		header, ok := ancestry[hash]
+		if !ok || header.Number != number {
+			return false
+		}
+
+		hash = header.ParentHash
+		number--
+	}
+}
+
+// findScheduledChange returns the GRANDPA scheduled change found
+// in the header digest, or nil if there is none.
+func findScheduledChange(header *types.Header) (*types.GrandpaScheduledChange, error) {
+	for _, item := range header.Digest {
+		value, err := item.Value()
+		if err != nil {
+			return nil, fmt.Errorf("getting digest item value: %w", err)
+		}
+
+		consensusDigest, ok := value.(types.ConsensusDigest)
+		if !ok || consensusDigest.ConsensusEngineID != types.GrandpaEngineID {
+			continue
+		}
+
+		grandpaDigest := types.NewGrandpaConsensusDigest()
+		err = scale.Unmarshal(consensusDigest.Data, &grandpaDigest)
+		if err != nil {
+			return nil, fmt.Errorf("decoding grandpa consensus digest: %w", err)
+		}
+
+		grandpaDigestValue, err := grandpaDigest.Value()
+		if err != nil {
+			return nil, fmt.Errorf("getting grandpa consensus digest value: %w", err)
+		}
+
+		scheduledChange, ok := grandpaDigestValue.(types.GrandpaScheduledChange)
+		if ok {
+			return &scheduledChange, nil
+		}
+	}
+
+	return nil, nil //nolint:nilnil
+}
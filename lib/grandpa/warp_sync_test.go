@@ -0,0 +1,338 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"io"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type warpSyncTestChain struct {
+	setZeroVoters []Voter
+	setOneVoters  []Voter
+	genesis       *types.Header
+	// setChange is the last block of the set 0, scheduling the set 1
+	setChange *types.Header
+	// setChangeChild is precommitted in the set change justification
+	setChangeChild *types.Header
+	finalised      *types.Header
+
+	setChangeJustification Justification
+	finalisedJustification Justification
+}
+
+func signTestPrecommit(t *testing.T, keypair *ed25519.Keypair, header *types.Header,
+	round, setID uint64) SignedVote {
+	t.Helper()
+
+	vote := *NewVoteFromHeader(header)
+	message, err := scale.Marshal(FullVote{
+		Stage: precommit,
+		Vote:  vote,
+		Round: round,
+		SetID: setID,
+	})
+	require.NoError(t, err)
+
+	signature, err := keypair.Sign(message)
+	require.NoError(t, err)
+
+	signedVote := SignedVote{
+		Vote:        vote,
+		AuthorityID: keypair.Public().(*ed25519.PublicKey).AsBytes(),
+	}
+	copy(signedVote.Signature[:], signature)
+	return signedVote
+}
+
+func newWarpSyncTestChain(t *testing.T) *warpSyncTestChain {
+	t.Helper()
+
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+	keypairs := make([]*ed25519.Keypair, len(kr.Keys))
+	voters := make([]Voter, len(kr.Keys))
+	for i, key := range kr.Keys {
+		keypairs[i] = key
+		voters[i] = Voter{Key: *key.Public().(*ed25519.PublicKey), ID: uint64(i)}
+	}
+
+	chain := &warpSyncTestChain{
+		setZeroVoters: voters[:3],
+		setOneVoters:  voters[3:6],
+		genesis:       types.NewHeader(common.Hash{}, common.Hash{1}, common.Hash{}, 0, types.NewDigest()),
+	}
+
+	authorities := make([]types.GrandpaAuthoritiesRaw, len(chain.setOneVoters))
+	for i, voter := range chain.setOneVoters {
+		authorities[i] = types.GrandpaAuthoritiesRaw{Key: voter.Key.AsBytes(), ID: voter.ID}
+	}
+	grandpaDigest := types.NewGrandpaConsensusDigest()
+	err = grandpaDigest.SetValue(types.GrandpaScheduledChange{Auths: authorities})
+	require.NoError(t, err)
+	encodedGrandpaDigest, err := scale.Marshal(grandpaDigest)
+	require.NoError(t, err)
+	digest := types.NewDigest()
+	err = digest.Add(types.ConsensusDigest{
+		ConsensusEngineID: types.GrandpaEngineID,
+		Data:              encodedGrandpaDigest,
+	})
+	require.NoError(t, err)
+
+	chain.setChange = types.NewHeader(chain.genesis.Hash(), common.Hash{2}, common.Hash{}, 10, digest)
+	chain.setChangeChild = types.NewHeader(chain.setChange.Hash(), common.Hash{3}, common.Hash{}, 11,
+		types.NewDigest())
+	chain.finalised = types.NewHeader(chain.setChangeChild.Hash(), common.Hash{4}, common.Hash{}, 12,
+		types.NewDigest())
+
+	chain.setChangeJustification = *newJustification(1, chain.setChange.Hash(), 10, []SignedVote{
+		signTestPrecommit(t, keypairs[0], chain.setChange, 1, 0),
+		signTestPrecommit(t, keypairs[1], chain.setChangeChild, 1, 0),
+		signTestPrecommit(t, keypairs[2], chain.setChange, 1, 0),
+	})
+	chain.finalisedJustification = *newJustification(2, chain.finalised.Hash(), 12, []SignedVote{
+		signTestPrecommit(t, keypairs[3], chain.finalised, 2, 1),
+		signTestPrecommit(t, keypairs[4], chain.finalised, 2, 1),
+		signTestPrecommit(t, keypairs[5], chain.finalised, 2, 1),
+	})
+
+	return chain
+}
+
+func (c *warpSyncTestChain) proof() *messages.WarpSyncProof {
+	return &messages.WarpSyncProof{
+		Fragments: []messages.WarpSyncFragment{{
+			Header: *c.setChange,
+			Justification: messages.WarpSyncJustification{
+				Round: c.setChangeJustification.Round,
+				Commit: messages.WarpSyncCommit{
+					Hash:       c.setChangeJustification.Commit.Hash,
+					Number:     c.setChangeJustification.Commit.Number,
+					Precommits: c.setChangeJustification.Commit.Precommits,
+				},
+				VotesAncestries: []types.Header{*c.setChangeChild},
+			},
+		}, {
+			Header: *c.finalised,
+			Justification: messages.WarpSyncJustification{
+				Round: c.finalisedJustification.Round,
+				Commit: messages.WarpSyncCommit{
+					Hash:       c.finalisedJustification.Commit.Hash,
+					Number:     c.finalisedJustification.Commit.Number,
+					Precommits: c.finalisedJustification.Commit.Precommits,
+				},
+			},
+		}},
+		IsFinished: true,
+	}
+}
+
+func Test_Service_GenerateWarpSyncProof(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	chain := newWarpSyncTestChain(t)
+	encodedSetChangeJustification, err := scale.Marshal(chain.setChangeJustification)
+	require.NoError(t, err)
+	encodedFinalisedJustification, err := scale.Marshal(chain.finalisedJustification)
+	require.NoError(t, err)
+
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().GetHeader(chain.genesis.Hash()).Return(chain.genesis, nil)
+	blockState.EXPECT().GetHighestFinalisedHeader().Return(chain.finalised, nil)
+	blockState.EXPECT().GetHeaderByNumber(uint(0)).Return(chain.genesis, nil)
+	blockState.EXPECT().GetHeaderByNumber(uint(10)).Return(chain.setChange, nil)
+	blockState.EXPECT().GetJustification(chain.setChange.Hash()).Return(encodedSetChangeJustification, nil)
+	blockState.EXPECT().GetHeader(chain.setChangeChild.Hash()).Return(chain.setChangeChild, nil)
+	blockState.EXPECT().GetJustification(chain.finalised.Hash()).Return(encodedFinalisedJustification, nil)
+
+	grandpaState := NewMockGrandpaState(ctrl)
+	grandpaState.EXPECT().GetSetIDByBlockNumber(uint(0)).Return(uint64(0), nil)
+	grandpaState.EXPECT().GetCurrentSetID().Return(uint64(1), nil)
+	grandpaState.EXPECT().GetSetIDChange(uint64(1)).Return(uint(10), nil)
+
+	service := &Service{
+		blockState:   blockState,
+		grandpaState: grandpaState,
+	}
+
+	proof, err := service.GenerateWarpSyncProof(chain.genesis.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, chain.proof(), proof)
+
+	changes, err := VerifyWarpSyncProof(proof, 0, chain.setZeroVoters)
+	require.NoError(t, err)
+	expectedChanges := []AuthoritySetChange{{
+		Number:      10,
+		SetID:       1,
+		Authorities: chain.setOneVoters,
+	}}
+	assert.Equal(t, expectedChanges, changes)
+}
+
+func Test_VerifyWarpSyncProof(t *testing.T) {
+	t.Parallel()
+
+	chain := newWarpSyncTestChain(t)
+
+	testCases := map[string]struct {
+		proofBuilder func() *messages.WarpSyncProof
+		errWrapped   error
+		errMessage   string
+	}{
+		"empty_proof": {
+			proofBuilder: func() *messages.WarpSyncProof { return &messages.WarpSyncProof{} },
+			errWrapped:   errEmptyWarpSyncProof,
+			errMessage:   "warp sync proof is empty",
+		},
+		"unfinished_proof_without_set_change": {
+			proofBuilder: func() *messages.WarpSyncProof {
+				proof := chain.proof()
+				proof.IsFinished = false
+				return proof
+			},
+			errWrapped: errMissingAuthoritySetChange,
+			errMessage: "fragment header has no authority set change: block #12",
+		},
+		"missing_votes_ancestry": {
+			proofBuilder: func() *messages.WarpSyncProof {
+				proof := chain.proof()
+				proof.Fragments[0].Justification.VotesAncestries = nil
+				return proof
+			},
+			errWrapped: ErrPrecommitBlockMismatch,
+		},
+		"justification_for_other_block": {
+			proofBuilder: func() *messages.WarpSyncProof {
+				proof := chain.proof()
+				proof.Fragments[0].Header = *chain.setChangeChild
+				return proof
+			},
+			errWrapped: ErrJustificationMismatch,
+		},
+		"signed_by_next_set": {
+			proofBuilder: func() *messages.WarpSyncProof {
+				proof := chain.proof()
+				proof.Fragments = proof.Fragments[1:]
+				return proof
+			},
+			errWrapped: ErrInvalidSignature,
+		},
+		"min_votes_not_met": {
+			proofBuilder: func() *messages.WarpSyncProof {
+				proof := chain.proof()
+				commit := &proof.Fragments[1].Justification.Commit
+				commit.Precommits = commit.Precommits[:1]
+				return proof
+			},
+			errWrapped: ErrMinVotesNotMet,
+			errMessage: "verifying justification of block #12: " +
+				"minimum number of votes not met in a Justification: " +
+				"need 3 votes but received only 1 valid votes",
+		},
+		"exactly_two_thirds_votes": {
+			proofBuilder: func() *messages.WarpSyncProof {
+				proof := chain.proof()
+				commit := &proof.Fragments[1].Justification.Commit
+				commit.Precommits = commit.Precommits[:2]
+				return proof
+			},
+			errWrapped: ErrMinVotesNotMet,
+			errMessage: "verifying justification of block #12: " +
+				"minimum number of votes not met in a Justification: " +
+				"need 3 votes but received only 2 valid votes",
+		},
+		"duplicated_precommit": {
+			proofBuilder: func() *messages.WarpSyncProof {
+				proof := chain.proof()
+				commit := &proof.Fragments[1].Justification.Commit
+				commit.Precommits = []SignedVote{commit.Precommits[0], commit.Precommits[0], commit.Precommits[0]}
+				return proof
+			},
+			errWrapped: errDuplicatePrecommit,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			changes, err := VerifyWarpSyncProof(testCase.proofBuilder(), 0, chain.setZeroVoters)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Nil(t, changes)
+		})
+	}
+}
+
+func Test_VerifyStateSyncJustification(t *testing.T) {
+	t.Parallel()
+
+	chain := newWarpSyncTestChain(t)
+
+	testCases := map[string]struct {
+		header               *types.Header
+		justificationEncoder func() []byte
+		setID                uint64
+		authorities          []types.GrandpaVoter
+		errWrapped           error
+	}{
+		"with_votes_ancestries": {
+			header: chain.setChange,
+			justificationEncoder: func() []byte {
+				return scale.MustMarshal(chain.proof().Fragments[0].Justification)
+			},
+			authorities: chain.setZeroVoters,
+		},
+		"without_votes_ancestries": {
+			header: chain.finalised,
+			justificationEncoder: func() []byte {
+				return scale.MustMarshal(chain.finalisedJustification)
+			},
+			setID:       1,
+			authorities: chain.setOneVoters,
+		},
+		"invalid_encoding": {
+			header:               chain.finalised,
+			justificationEncoder: func() []byte { return []byte{1} },
+			setID:                1,
+			authorities:          chain.setOneVoters,
+			errWrapped:           io.EOF,
+		},
+		"signed_by_other_set": {
+			header: chain.finalised,
+			justificationEncoder: func() []byte {
+				return scale.MustMarshal(chain.finalisedJustification)
+			},
+			authorities: chain.setZeroVoters,
+			errWrapped:  ErrInvalidSignature,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := VerifyStateSyncJustification(testCase.header, testCase.justificationEncoder(),
+				testCase.setID, testCase.authorities)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ChainSafe/gossamer/lib/crypto"
+)
+
+// keyTypeLength is the length of the key type prefix of the key file names
+const keyTypeLength = 4
+
+var ErrPublicKeyMismatch = errors.New("public key does not match the secret URI")
+
+// FilesystemKeystore stores keys in a directory using the Substrate keystore layout, which is one
+// file per key named after the hex encoded key type and public key, and containing the JSON encoded
+// secret URI or seed of the key. The directory can be shared with Polkadot SDK nodes.
+type FilesystemKeystore struct {
+	path string
+}
+
+// NewFilesystemKeystore returns a FilesystemKeystore using the given directory, creating it if needed
+func NewFilesystemKeystore(path string) (*FilesystemKeystore, error) {
+	err := os.MkdirAll(path, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("creating keystore directory: %w", err)
+	}
+
+	return &FilesystemKeystore{path: path}, nil
+}
+
+// Path returns the directory of the keystore
+func (fs *FilesystemKeystore) Path() string {
+	return fs.path
+}
+
+// KeyFilename returns the file name of the key with the given key type and public key
+func KeyFilename(name Name, pub crypto.PublicKey) string {
+	return hex.EncodeToString([]byte(name)) + hex.EncodeToString(pub.Encode())
+}
+
+// Write writes the secret URI of the key with the given key type and public key
+func (fs *FilesystemKeystore) Write(name Name, suri string, pub crypto.PublicKey) error {
+	data, err := json.Marshal(suri)
+	if err != nil {
+		return fmt.Errorf("encoding secret URI: %w", err)
+	}
+
+	fp := filepath.Join(fs.path, KeyFilename(name, pub))
+	err = os.WriteFile(fp, data, 0600)
+	if err != nil {
+		return fmt.Errorf("writing key file: %w", err)
+	}
+
+	return nil
+}
+
+// Load reads the keys of the keystore and inserts them in the keystores of the given global keystore.
+// Files which are not named after a key, or whose key type is not used by the node, are ignored.
+func (fs *FilesystemKeystore) Load(ks *GlobalKeystore) error {
+	entries, err := os.ReadDir(fs.path)
+	if err != nil {
+		return fmt.Errorf("reading keystore directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		filename := entry.Name()
+		decoded, err := hex.DecodeString(filename)
+		if err != nil || len(decoded) <= keyTypeLength {
+			continue
+		}
+
+		name, pub := decoded[:keyTypeLength], decoded[keyTypeLength:]
+		keystore, err := ks.GetKeystore(name)
+		if err != nil {
+			continue
+		}
+
+		kp, err := fs.readKeypair(filename, keystore.Type(), pub)
+		if err != nil {
+			return fmt.Errorf("reading key file %s: %w", filename, err)
+		}
+
+		err = keystore.Insert(kp)
+		if err != nil {
+			return fmt.Errorf("inserting key %s: %w", filename, err)
+		}
+	}
+
+	return nil
+}
+
+// readKeypair returns the keypair derived from the secret URI of the given key file. The keypair is
+// derived for every key type if the key type of the keystore is unknown, until its public key
+// matches the given public key.
+func (fs *FilesystemKeystore) readKeypair(filename string, keyType crypto.KeyType, pub []byte) (KeyPair, error) {
+	data, err := os.ReadFile(filepath.Join(fs.path, filename))
+	if err != nil {
+		return nil, err
+	}
+
+	var suri string
+	err = json.Unmarshal(data, &suri)
+	if err != nil {
+		return nil, fmt.Errorf("decoding secret URI: %w", err)
+	}
+
+	keyTypes := []crypto.KeyType{keyType}
+	if keyType == crypto.UnknownType {
+		keyTypes = []crypto.KeyType{crypto.Sr25519Type, crypto.Ed25519Type, crypto.Secp256k1Type}
+	}
+
+	for _, keyType := range keyTypes {
+		kp, err := DecodeKeyPairFromSecretURI(suri, keyType)
+		if err != nil {
+			if len(keyTypes) == 1 {
+				return nil, err
+			}
+			continue
+		}
+
+		if bytes.Equal(kp.Public().Encode(), pub) {
+			return kp, nil
+		}
+	}
+
+	return nil, ErrPublicKeyMismatch
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystemKeystore_Write(t *testing.T) {
+	t.Parallel()
+
+	fs, err := NewFilesystemKeystore(filepath.Join(t.TempDir(), "chains", "dev", "keystore"))
+	require.NoError(t, err)
+
+	kp, err := sr25519.NewKeypairFromSecretURI("//Alice")
+	require.NoError(t, err)
+
+	err = fs.Write(BabeName, "//Alice", kp.Public())
+	require.NoError(t, err)
+
+	fp := filepath.Join(fs.Path(), "62616265d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+	data, err := os.ReadFile(fp)
+	require.NoError(t, err)
+	assert.Equal(t, `"//Alice"`, string(data))
+
+	info, err := os.Stat(fp)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestFilesystemKeystore_Load(t *testing.T) {
+	t.Parallel()
+
+	babeKp, err := sr25519.NewKeypairFromSecretURI("//Alice")
+	require.NoError(t, err)
+	granKp, err := ed25519.NewKeypairFromSecretURI("//Alice")
+	require.NoError(t, err)
+	accoKp, err := ed25519.NewKeypairFromSecretURI("//Bob")
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		files      map[string]string
+		keys       map[Name]KeyPair
+		errWrapped error
+		errMsg     string
+	}{
+		"substrate_keys": {
+			files: map[string]string{
+				KeyFilename(BabeName, babeKp.Public()): `"//Alice"`,
+				KeyFilename(GranName, granKp.Public()): `"//Alice"`,
+				// the generic account keystore accepts the keys of every key type
+				KeyFilename(AccoName, accoKp.Public()): `"//Bob"`,
+			},
+			keys: map[Name]KeyPair{
+				BabeName: babeKp,
+				GranName: granKp,
+				AccoName: accoKp,
+			},
+		},
+		"ignored_files": {
+			files: map[string]string{
+				"not_a_key":                            `"//Alice"`,
+				"6d69786e" + babeKp.Public().Hex()[2:]: `"//Alice"`,
+			},
+			keys: map[Name]KeyPair{},
+		},
+		"invalid_content": {
+			files: map[string]string{
+				KeyFilename(BabeName, babeKp.Public()): `//Alice`,
+			},
+			errMsg: "reading key file " + KeyFilename(BabeName, babeKp.Public()) +
+				": decoding secret URI: invalid character '/' looking for beginning of value",
+		},
+		"public_key_mismatch": {
+			files: map[string]string{
+				KeyFilename(BabeName, babeKp.Public()): `"//Bob"`,
+			},
+			errWrapped: ErrPublicKeyMismatch,
+			errMsg: "reading key file " + KeyFilename(BabeName, babeKp.Public()) +
+				": public key does not match the secret URI",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fs, err := NewFilesystemKeystore(t.TempDir())
+			require.NoError(t, err)
+			for filename, content := range testCase.files {
+				err = os.WriteFile(filepath.Join(fs.Path(), filename), []byte(content), 0600)
+				require.NoError(t, err)
+			}
+
+			ks := NewGlobalKeystore()
+			err = fs.Load(ks)
+			if testCase.errMsg != "" {
+				if testCase.errWrapped != nil {
+					assert.ErrorIs(t, err, testCase.errWrapped)
+				}
+				assert.EqualError(t, err, testCase.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			for _, keystore := range []Keystore{ks.Babe, ks.Gran, ks.Acco} {
+				kp, ok := testCase.keys[keystore.Name()]
+				if !ok {
+					assert.Zero(t, keystore.Size())
+					continue
+				}
+				assert.Equal(t, 1, keystore.Size())
+				assert.NotNil(t, keystore.GetKeypair(kp.Public()))
+			}
+		})
+	}
+}
+
+func TestGlobalKeystore_Insert(t *testing.T) {
+	t.Parallel()
+
+	fs, err := NewFilesystemKeystore(t.TempDir())
+	require.NoError(t, err)
+
+	ks := NewGlobalKeystore()
+	ks.Filesystem = fs
+
+	kp, err := ed25519.NewKeypairFromSecretURI("//Alice")
+	require.NoError(t, err)
+
+	err = ks.Insert([]byte("babe"), kp, "//Alice")
+	require.EqualError(t, err,
+		"given key type is not supported by this keystore, passed key type: ed25519, acceptable key type: sr25519")
+
+	err = ks.Insert([]byte("gran"), kp, "//Alice")
+	require.NoError(t, err)
+	assert.NotNil(t, ks.Gran.GetKeypair(kp.Public()))
+
+	// the keys written by the global keystore are loaded back on restart
+	restarted := NewGlobalKeystore()
+	err = fs.Load(restarted)
+	require.NoError(t, err)
+	assert.NotNil(t, restarted.Gran.GetKeypair(kp.Public()))
+	assert.Zero(t, restarted.Babe.Size())
+}
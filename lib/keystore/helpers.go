@@ -50,6 +50,22 @@ func DecodePrivateKey(in []byte, keytype crypto.KeyType) (priv crypto.PrivateKey
 	return priv, err
 }
 
+// DecodePublicKey turns input bytes into a public key based on the specified key type
+func DecodePublicKey(in []byte, keytype crypto.KeyType) (pub crypto.PublicKey, err error) {
+	switch keytype {
+	case crypto.Sr25519Type:
+		pub, err = sr25519.NewPublicKey(in)
+	case crypto.Ed25519Type:
+		pub, err = ed25519.NewPublicKey(in)
+	case crypto.Secp256k1Type:
+		pub, err = secp256k1.NewPublicKey(in)
+	default:
+		return nil, errors.New("cannot decode key: invalid key type")
+	}
+
+	return pub, err
+}
+
 // DecodeKeyPairFromHex turns an hex-encoded private key into a keypair
 func DecodeKeyPairFromHex(keystr []byte, keytype crypto.KeyType) (kp KeyPair, err error) {
 	switch keytype {
@@ -64,6 +80,23 @@ func DecodeKeyPairFromHex(keystr []byte, keytype crypto.KeyType) (kp KeyPair, er
 	return kp, err
 }
 
+// DecodeKeyPairFromSecretURI derives a keypair of the given key type from a secret URI
+// of the form `phrase//hard/soft///password`
+func DecodeKeyPairFromSecretURI(suri string, keytype crypto.KeyType) (kp KeyPair, err error) {
+	switch keytype {
+	case crypto.Sr25519Type:
+		kp, err = sr25519.NewKeypairFromSecretURI(suri)
+	case crypto.Ed25519Type:
+		kp, err = ed25519.NewKeypairFromSecretURI(suri)
+	case crypto.Secp256k1Type:
+		kp, err = secp256k1.NewKeypairFromSecretURI(suri)
+	default:
+		return nil, errors.New("cannot decode key: invalid key type")
+	}
+
+	return kp, err
+}
+
 // GenerateKeypair create a new keypair with the corresponding type and saves
 // it to basepath/keystore/[public key].key in json format encrypted using the
 // specified password and returns the resulting filepath of the new key
@@ -124,7 +157,8 @@ type KeyRing interface {
 	Ian() KeyPair
 }
 
-// LoadKeystore loads a new keystore and inserts the test key into the keystore
+// LoadKeystore loads a new keystore and inserts the test key into the keystore.
+// The key is either the name of a test key, or a secret URI.
 func LoadKeystore(key string, keyStore TyperInserter, keyRing KeyRing) (err error) {
 	switch strings.ToLower(key) {
 	// Insert can error only if kestore type do not match with key
@@ -149,7 +183,17 @@ func LoadKeystore(key string, keyStore TyperInserter, keyRing KeyRing) (err erro
 	case "ian":
 		return keyStore.Insert(keyRing.Ian())
 	default:
-		return fmt.Errorf("invalid test key provided")
+		// the key is not a test key name, so it must be a secret URI such as `//Alice`
+		keytype := keyStore.Type()
+		if keytype == crypto.UnknownType {
+			keytype = crypto.Sr25519Type
+		}
+
+		kp, err := DecodeKeyPairFromSecretURI(key, keytype)
+		if err != nil {
+			return fmt.Errorf("invalid test key or secret URI provided: %w", err)
+		}
+		return keyStore.Insert(kp)
 	}
 }
 
@@ -215,6 +259,20 @@ func ImportRawPrivateKey(key, keytype, basepath string, password []byte) (string
 	return GenerateKeypair(keytype, kp, basepath, password)
 }
 
+// ImportSecretURI derives a keypair from a secret URI and saves it to the keystore directory
+func ImportSecretURI(suri, keytype, basepath string, password []byte) (string, error) {
+	if keytype == "" {
+		keytype = crypto.Sr25519Type
+	}
+
+	kp, err := DecodeKeyPairFromSecretURI(suri, keytype)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive %s keypair: %w", keytype, err)
+	}
+
+	return GenerateKeypair(keytype, kp.(PublicPrivater), basepath, password)
+}
+
 // UnlockKeys unlocks keys specified by the --unlock flag with the passwords given by --password
 // and places them into the keystore
 func UnlockKeys(ks Inserter, dir, unlock, password string) error {
@@ -288,6 +346,8 @@ func DetermineKeyType(t string) crypto.KeyType {
 	case "acco", "babe", "para", "asgn",
 		"aura", "imon", "audi", "dumy":
 		return crypto.Sr25519Type
+	case "beef":
+		return crypto.Secp256k1Type
 	}
 	return crypto.UnknownType
 }
@@ -307,6 +367,8 @@ func HasKey(pubKeyStr, keyType string, keystore AddressKeypairGetter) (bool, err
 		pubKey, err = sr25519.NewPublicKey(keyBytes)
 	case crypto.Ed25519Type:
 		pubKey, err = ed25519.NewPublicKey(keyBytes)
+	case crypto.Secp256k1Type:
+		pubKey, err = secp256k1.NewPublicKey(keyBytes)
 	default:
 		err = fmt.Errorf("unknown key type: %s", keyType)
 	}
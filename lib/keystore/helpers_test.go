@@ -51,6 +51,7 @@ func TestLoadKeystore(t *testing.T) {
 	{testType: "imon", expectedType: crypto.Sr25519Type},
 	{testType: "audi", expectedType: crypto.Sr25519Type},
 	{testType: "dumy", expectedType: crypto.Sr25519Type},
+	{testType: "beef", expectedType: crypto.Secp256k1Type},
 	{testType: "xxxx", expectedType: crypto.UnknownType},
 }
 
@@ -397,3 +398,25 @@ func TestDecodeKeyPairFromHex(t *testing.T) {
 	_, err = DecodeKeyPairFromHex(nil, "")
 	require.Error(t, err, "cannot decode key: invalid key type")
 }
+
+func TestLoadKeystore_secretURI(t *testing.T) {
+	sr25519KeyRing, err := NewSr25519Keyring()
+	require.NoError(t, err)
+
+	ks := NewBasicKeystore("test", crypto.Sr25519Type)
+	err = LoadKeystore("//Alice", ks, sr25519KeyRing)
+	require.NoError(t, err)
+	require.Equal(t, sr25519KeyRing.Alice().Public().Address(), ks.Keypairs()[0].Public().Address())
+
+	ed25519KeyRing, err := NewEd25519Keyring()
+	require.NoError(t, err)
+
+	ks = NewBasicKeystore("test", crypto.Ed25519Type)
+	err = LoadKeystore("//Bob", ks, ed25519KeyRing)
+	require.NoError(t, err)
+	require.Equal(t, ed25519KeyRing.Bob().Public().Address(), ks.Keypairs()[0].Public().Address())
+
+	ks = NewBasicKeystore("test", crypto.Sr25519Type)
+	err = LoadKeystore("invalid-key", ks, sr25519KeyRing)
+	require.EqualError(t, err, `invalid test key or secret URI provided: invalid secret URI: "invalid-key"`)
+}
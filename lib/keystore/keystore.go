@@ -5,6 +5,7 @@
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
@@ -26,6 +27,7 @@
 	ParaName Name = "para"
 	AsgnName Name = "asgn"
 	AudiName Name = "audi"
+	BeefName Name = "beef"
 	DumyName Name = "dumy"
 )
 
@@ -67,7 +69,11 @@ type GlobalKeystore struct {
 	Asgn Keystore
 	Imon Keystore
 	Audi Keystore
+	Beef Keystore
 	Dumy Keystore
+
+	// Filesystem persists the keys inserted with their secret URI, if set
+	Filesystem *FilesystemKeystore
 }
 
 // NewGlobalKeystore returns a new GlobalKeystore
@@ -81,6 +87,7 @@ func NewGlobalKeystore() *GlobalKeystore {
 		Asgn: NewBasicKeystore(AsgnName, crypto.Sr25519Type),
 		Imon: NewBasicKeystore(ImonName, crypto.Sr25519Type),
 		Audi: NewBasicKeystore(AudiName, crypto.Sr25519Type),
+		Beef: NewBasicKeystore(BeefName, crypto.Secp256k1Type),
 		Dumy: NewGenericKeystore(DumyName),
 	}
 }
@@ -105,9 +112,35 @@ func (k *GlobalKeystore) GetKeystore(name []byte) (Keystore, error) {
 		return k.Asgn, nil
 	case AudiName:
 		return k.Audi, nil
+	case BeefName:
+		return k.Beef, nil
 	case DumyName:
 		return k.Dumy, nil
 	default:
 		return nil, ErrInvalidKeystoreName
 	}
 }
+
+// Insert inserts the keypair derived from the given secret URI into the keystore with the given name,
+// and writes the secret URI to the filesystem keystore if there is one.
+func (k *GlobalKeystore) Insert(name []byte, kp KeyPair, suri string) error {
+	ks, err := k.GetKeystore(name)
+	if err != nil {
+		return err
+	}
+
+	err = ks.Insert(kp)
+	if err != nil {
+		return err
+	}
+
+	if k.Filesystem == nil {
+		return nil
+	}
+
+	err = k.Filesystem.Write(Name(name), suri, kp.Public())
+	if err != nil {
+		return fmt.Errorf("writing key to filesystem keystore: %w", err)
+	}
+	return nil
+}
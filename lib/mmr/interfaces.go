@@ -0,0 +1,25 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package mmr
+
+import (
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+)
+
+// BlockState is the interface required by the MMR offchain service into the block state
+type BlockState interface {
+	GetHeaderByNumber(num uint) (*types.Header, error)
+	GetHighestFinalisedHeader() (*types.Header, error)
+	GetFinalisedNotifierChannel() chan *types.FinalisationInfo
+	FreeFinalisedNotifierChannel(ch chan *types.FinalisationInfo)
+	GetRuntime(blockHash common.Hash) (instance runtime.Instance, err error)
+}
+
+// StorageState is the interface required by the MMR offchain service into the storage state
+type StorageState interface {
+	TrieState(root *common.Hash) (*rtstorage.TrieState, error)
+}
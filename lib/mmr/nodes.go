@@ -0,0 +1,54 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package mmr
+
+import (
+	"math/bits"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// DefaultIndexingPrefix is the prefix of the offchain keys of the MMR nodes written by the MMR pallet
+var DefaultIndexingPrefix = []byte("mmr")
+
+// leafIndexToPos returns the position in the MMR of the leaf with the given index.
+func leafIndexToPos(leafIndex uint64) uint64 {
+	leafCount := leafIndex + 1
+	mmrSize := 2*leafCount - uint64(bits.OnesCount64(leafCount))
+	return mmrSize - uint64(bits.TrailingZeros64(leafCount)) - 1
+}
+
+// rightBranchEndingInLeaf returns the positions of the nodes added to the MMR when
+// appending the leaf with the given index, which are the leaf and its parents which
+// are completed by the leaf.
+func rightBranchEndingInLeaf(leafIndex uint64) []uint64 {
+	pos := leafIndexToPos(leafIndex)
+	parents := uint64(bits.TrailingZeros64(^leafIndex))
+	positions := make([]uint64, 0, parents+1)
+	for i := uint64(0); i <= parents; i++ {
+		positions = append(positions, pos+i)
+	}
+	return positions
+}
+
+// nodeTempKey returns the offchain key where the MMR pallet indexes the node at the given
+// position when it is added by the child block of the given parent block. The parent hash
+// makes the key fork-aware, since the same position is written by the blocks of each fork.
+func nodeTempKey(prefix []byte, pos uint64, parentHash common.Hash) []byte {
+	return scale.MustMarshal(struct {
+		Prefix     []byte
+		Pos        uint64
+		ParentHash common.Hash
+	}{prefix, pos, parentHash})
+}
+
+// nodeCanonKey returns the offchain key of the node at the given position once the block
+// which added the node is finalised.
+func nodeCanonKey(prefix []byte, pos uint64) []byte {
+	return scale.MustMarshal(struct {
+		Prefix []byte
+		Pos    uint64
+	}{prefix, pos})
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package mmr
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_leafIndexToPos(t *testing.T) {
+	t.Parallel()
+
+	// the positions of the leaves of an MMR of 9 leaves
+	//            14
+	//        /        \
+	//      6            13
+	//    /   \        /    \
+	//   2     5      9     12
+	//  / \   / \    / \   /  \
+	// 0   1 3   4  7   8 10  11  15
+	expectedPositions := []uint64{0, 1, 3, 4, 7, 8, 10, 11, 15}
+	for leafIndex, expectedPosition := range expectedPositions {
+		assert.Equal(t, expectedPosition, leafIndexToPos(uint64(leafIndex)))
+	}
+}
+
+func Test_rightBranchEndingInLeaf(t *testing.T) {
+	t.Parallel()
+
+	tests := map[uint64][]uint64{
+		0: {0},
+		1: {1, 2},
+		2: {3},
+		3: {4, 5, 6},
+		7: {11, 12, 13, 14},
+		8: {15},
+	}
+
+	for leafIndex, expectedPositions := range tests {
+		assert.Equal(t, expectedPositions, rightBranchEndingInLeaf(leafIndex))
+	}
+}
+
+func Test_nodeKeys(t *testing.T) {
+	t.Parallel()
+
+	parentHash := common.Hash{1}
+	assert.Equal(t,
+		append([]byte("\x0cmmr\x05\x00\x00\x00\x00\x00\x00\x00"), parentHash[:]...),
+		nodeTempKey(DefaultIndexingPrefix, 5, parentHash))
+	assert.Equal(t,
+		[]byte("\x0cmmr\x05\x00\x00\x00\x00\x00\x00\x00"),
+		nodeCanonKey(DefaultIndexingPrefix, 5))
+}
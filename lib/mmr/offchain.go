@@ -0,0 +1,293 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package mmr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "mmr"))
+
+// canonHeadKey is the offchain key of the number of the highest block whose MMR nodes are canonicalised
+var canonHeadKey = []byte("mmr_gadget_canon_head")
+
+var errMissingNode = errors.New("missing mmr node")
+
+// Config is the configuration of the MMR offchain service
+type Config struct {
+	LogLvl       log.Level
+	BlockState   BlockState
+	StorageState StorageState
+	// OffchainStorage is the persistent offchain storage, where the offchain indexing
+	// of the finalised blocks is written
+	OffchainStorage runtime.BasicStorage
+	// IndexingPrefix is the prefix of the MMR node keys, which defaults to DefaultIndexingPrefix
+	IndexingPrefix []byte
+}
+
+// Service canonicalises the MMR nodes indexed offchain by the MMR pallet when their block is
+// finalised. The pallet indexes the nodes added by a block under fork-aware keys including the
+// parent hash of the block, and the nodes of the finalised blocks are moved to canonical keys
+// which only depend on the position of the node, so that the runtime can generate MMR proofs
+// for any finalised leaf.
+type Service struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	blockState      BlockState
+	storageState    StorageState
+	offchainStorage runtime.BasicStorage
+	indexingPrefix  []byte
+	finalisedCh     chan *types.FinalisationInfo
+
+	// firstMmrBlock is the number of the block of the first MMR leaf, or nil if the MMR
+	// pallet is not active yet
+	firstMmrBlock *uint
+	// canonHead is the number of the highest block whose MMR nodes are canonicalised
+	canonHead *uint
+}
+
+// NewService returns a new MMR offchain service
+func NewService(cfg *Config) *Service {
+	logger.Patch(log.SetLevel(cfg.LogLvl))
+
+	indexingPrefix := cfg.IndexingPrefix
+	if indexingPrefix == nil {
+		indexingPrefix = DefaultIndexingPrefix
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		ctx:             ctx,
+		cancel:          cancel,
+		blockState:      cfg.BlockState,
+		storageState:    cfg.StorageState,
+		offchainStorage: cfg.OffchainStorage,
+		indexingPrefix:  indexingPrefix,
+	}
+}
+
+// Start starts canonicalising the MMR nodes of the finalised blocks
+func (s *Service) Start() error {
+	encodedCanonHead, err := s.offchainStorage.Get(canonHeadKey)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+	case err != nil:
+		return fmt.Errorf("getting canonicalised head: %w", err)
+	default:
+		canonHead := common.BytesToUint(encodedCanonHead)
+		s.canonHead = &canonHead
+	}
+
+	s.finalisedCh = s.blockState.GetFinalisedNotifierChannel()
+	s.wg.Add(1)
+	go s.run()
+	return nil
+}
+
+// Stop stops the MMR offchain service
+func (s *Service) Stop() error {
+	s.cancel()
+	s.wg.Wait()
+	if s.finalisedCh != nil {
+		s.blockState.FreeFinalisedNotifierChannel(s.finalisedCh)
+	}
+	return nil
+}
+
+func (s *Service) run() {
+	defer s.wg.Done()
+
+	// canonicalise the blocks finalised while the node was stopped
+	finalisedHeader, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		logger.Errorf("getting highest finalised header: %s", err)
+	} else {
+		err = s.canonicalise(finalisedHeader)
+		if err != nil {
+			logger.Errorf("canonicalising mmr nodes up to block #%d: %s", finalisedHeader.Number, err)
+		}
+	}
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case info, ok := <-s.finalisedCh:
+			if !ok {
+				return
+			}
+
+			err := s.canonicalise(&info.Header)
+			if err != nil {
+				logger.Errorf("canonicalising mmr nodes up to block #%d: %s", info.Header.Number, err)
+			}
+
+			err = s.pruneStaleForks(&info.Header, info.StaleHeaders)
+			if err != nil {
+				logger.Errorf("pruning mmr nodes of stale forks: %s", err)
+			}
+		}
+	}
+}
+
+// canonicalise canonicalises the MMR nodes of the blocks finalised up to the given header,
+// including the blocks whose finalisation was not notified.
+func (s *Service) canonicalise(finalisedHeader *types.Header) error {
+	if s.firstMmrBlock == nil {
+		firstMmrBlock, err := s.findFirstMmrBlock(finalisedHeader)
+		if err != nil {
+			return fmt.Errorf("finding first mmr block: %w", err)
+		} else if firstMmrBlock == nil {
+			return nil
+		}
+		s.firstMmrBlock = firstMmrBlock
+		logger.Infof("MMR pallet activated at block #%d", *firstMmrBlock)
+	}
+
+	from := *s.firstMmrBlock
+	if s.canonHead != nil && *s.canonHead+1 > from {
+		from = *s.canonHead + 1
+	}
+
+	for number := from; number <= finalisedHeader.Number; number++ {
+		header := finalisedHeader
+		if number != finalisedHeader.Number {
+			var err error
+			header, err = s.blockState.GetHeaderByNumber(number)
+			if err != nil {
+				return fmt.Errorf("getting header of block #%d: %w", number, err)
+			}
+		}
+
+		err := s.canonicaliseBlock(header, uint64(number-*s.firstMmrBlock))
+		if err != nil {
+			return fmt.Errorf("canonicalising block #%d: %w", number, err)
+		}
+
+		canonHead := number
+		s.canonHead = &canonHead
+	}
+
+	if s.canonHead == nil {
+		return nil
+	}
+
+	err := s.offchainStorage.Put(canonHeadKey, common.UintToBytes(*s.canonHead))
+	if err != nil {
+		return fmt.Errorf("setting canonicalised head: %w", err)
+	}
+	return nil
+}
+
+// findFirstMmrBlock returns the number of the block of the first MMR leaf, from the number of
+// leaves of the MMR at the given header, or nil if the MMR pallet is not active at the header.
+func (s *Service) findFirstMmrBlock(header *types.Header) (*uint, error) {
+	trieState, err := s.storageState.TrieState(&header.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	rt, err := s.blockState.GetRuntime(header.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime: %w", err)
+	}
+
+	rt.SetContextStorage(trieState)
+
+	leafCount, err := LeafCount(rt)
+	if errors.Is(err, wazero_runtime.ErrExportFunctionNotFound) || errors.Is(err, ErrPalletNotIncluded) {
+		logger.Debugf("MMR pallet is not active at block #%d", header.Number)
+		return nil, nil //nolint:nilnil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if leafCount == 0 {
+		return nil, nil //nolint:nilnil
+	} else if leafCount > uint64(header.Number)+1 {
+		return nil, fmt.Errorf("%d mmr leaves for block #%d", leafCount, header.Number)
+	}
+
+	firstMmrBlock := header.Number + 1 - uint(leafCount)
+	return &firstMmrBlock, nil
+}
+
+// canonicaliseBlock moves the MMR nodes added by the block of the given header, with the leaf of
+// the given index, from their fork-aware keys to their canonical keys. A node missing from its
+// fork-aware key is an error, unless it is already canonicalised, since the MMR proofs of the
+// runtime cannot be generated without it.
+func (s *Service) canonicaliseBlock(header *types.Header, leafIndex uint64) error {
+	for _, pos := range rightBranchEndingInLeaf(leafIndex) {
+		tempKey := nodeTempKey(s.indexingPrefix, pos, header.ParentHash)
+		node, err := s.offchainStorage.Get(tempKey)
+		if errors.Is(err, database.ErrNotFound) {
+			_, err = s.offchainStorage.Get(nodeCanonKey(s.indexingPrefix, pos))
+			if err == nil {
+				continue
+			} else if !errors.Is(err, database.ErrNotFound) {
+				return fmt.Errorf("getting canonical mmr node at position %d: %w", pos, err)
+			}
+			return fmt.Errorf("%w: at position %d of block #%d", errMissingNode, pos, header.Number)
+		} else if err != nil {
+			return fmt.Errorf("getting mmr node at position %d: %w", pos, err)
+		}
+
+		err = s.offchainStorage.Put(nodeCanonKey(s.indexingPrefix, pos), node)
+		if err != nil {
+			return fmt.Errorf("setting canonical mmr node at position %d: %w", pos, err)
+		}
+
+		err = s.offchainStorage.Del(tempKey)
+		if err != nil {
+			return fmt.Errorf("deleting mmr node at position %d: %w", pos, err)
+		}
+	}
+
+	logger.Tracef("canonicalised mmr nodes of block #%d", header.Number)
+	return nil
+}
+
+// pruneStaleForks deletes the MMR nodes added by the given stale blocks, pruned by the finalisation
+// of the given header, from their fork-aware keys. The nodes of a stale block whose parent is
+// finalised have the same keys as the nodes of the finalised block with the same parent, so they
+// are only deleted once the finalised nodes are canonicalised.
+func (s *Service) pruneStaleForks(finalisedHeader *types.Header, staleHeaders []types.Header) error {
+	if s.firstMmrBlock == nil {
+		return nil
+	}
+
+	for _, header := range staleHeaders {
+		if header.Number < *s.firstMmrBlock {
+			continue
+		}
+
+		canonicalised := s.canonHead != nil && header.Number <= *s.canonHead
+		if !canonicalised && header.Number <= finalisedHeader.Number {
+			continue
+		}
+
+		leafIndex := uint64(header.Number - *s.firstMmrBlock)
+		for _, pos := range rightBranchEndingInLeaf(leafIndex) {
+			err := s.offchainStorage.Del(nodeTempKey(s.indexingPrefix, pos, header.ParentHash))
+			if err != nil {
+				return fmt.Errorf("deleting mmr node at position %d of stale block #%d: %w",
+					pos, header.Number, err)
+			}
+		}
+		logger.Tracef("pruned mmr nodes of stale block #%d", header.Number)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package mmr
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_canonicalise(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	headers := make([]*types.Header, 6)
+	headers[0] = types.NewHeader(common.Hash{}, common.Hash{}, common.Hash{}, 0, types.NewDigest())
+	for number := 1; number < len(headers); number++ {
+		headers[number] = types.NewHeader(headers[number-1].Hash(), common.Hash{byte(number)},
+			common.Hash{}, uint(number), types.NewDigest())
+	}
+
+	offchainStorage := runtime.NewInMemoryDB(t)
+
+	// the MMR pallet is activated at the block #2, so the block #n adds the leaf n-2
+	nodesByBlock := map[uint][]uint64{
+		2: {0},
+		3: {1, 2},
+		4: {3},
+		5: {4, 5, 6},
+	}
+	for number, positions := range nodesByBlock {
+		for _, pos := range positions {
+			err := offchainStorage.Put(nodeTempKey(DefaultIndexingPrefix, pos, headers[number].ParentHash),
+				[]byte{byte(pos)})
+			require.NoError(t, err)
+		}
+	}
+	// the node of a block of another fork is not canonicalised
+	forkTempKey := nodeTempKey(DefaultIndexingPrefix, 3, common.Hash{9})
+	err := offchainStorage.Put(forkTempKey, []byte{9})
+	require.NoError(t, err)
+
+	// the number of leaves is read from the state of the finalised block
+	trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+	storageState := NewMockStorageState(ctrl)
+	storageState.EXPECT().TrieState(&headers[4].StateRoot).Return(trieState, nil)
+	rt := NewMockInstance(ctrl)
+	rt.EXPECT().SetContextStorage(trieState)
+	rt.EXPECT().Exec(runtime.MmrAPIMmrLeafCount, []byte{}).Return([]byte{0, 3, 0, 0, 0, 0, 0, 0, 0}, nil)
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().GetRuntime(headers[4].Hash()).Return(rt, nil)
+	blockState.EXPECT().GetHeaderByNumber(uint(2)).Return(headers[2], nil)
+	blockState.EXPECT().GetHeaderByNumber(uint(3)).Return(headers[3], nil)
+
+	s := NewService(&Config{
+		BlockState:      blockState,
+		StorageState:    storageState,
+		OffchainStorage: offchainStorage,
+	})
+
+	assertCanonicalised := func(t *testing.T, canonHead uint, positions ...uint64) {
+		t.Helper()
+		for _, pos := range positions {
+			node, err := offchainStorage.Get(nodeCanonKey(DefaultIndexingPrefix, pos))
+			require.NoError(t, err)
+			assert.Equal(t, []byte{byte(pos)}, node)
+		}
+
+		encodedCanonHead, err := offchainStorage.Get(canonHeadKey)
+		require.NoError(t, err)
+		assert.Equal(t, canonHead, common.BytesToUint(encodedCanonHead))
+	}
+
+	err = s.canonicalise(headers[4])
+	require.NoError(t, err)
+	assertCanonicalised(t, 4, 0, 1, 2, 3)
+
+	_, err = offchainStorage.Get(nodeTempKey(DefaultIndexingPrefix, 3, headers[4].ParentHash))
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	_, err = offchainStorage.Get(forkTempKey)
+	assert.NoError(t, err)
+
+	// the node of the block of the other fork is deleted once the fork is stale
+	staleHeader := types.NewHeader(common.Hash{9}, common.Hash{}, common.Hash{}, 4, types.NewDigest())
+	err = s.pruneStaleForks(headers[4], []types.Header{*staleHeader})
+	require.NoError(t, err)
+	_, err = offchainStorage.Get(forkTempKey)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	err = s.canonicalise(headers[5])
+	require.NoError(t, err)
+	assertCanonicalised(t, 5, 4, 5, 6)
+
+	// the already canonicalised nodes are not missing
+	err = s.canonicaliseBlock(headers[5], 3)
+	require.NoError(t, err)
+
+	// a missing node is an error, and the canonicalised head does not advance
+	header6 := types.NewHeader(headers[5].Hash(), common.Hash{6}, common.Hash{}, 6, types.NewDigest())
+	err = s.canonicalise(header6)
+	assert.ErrorIs(t, err, errMissingNode)
+	assert.EqualError(t, err, "canonicalising block #6: missing mmr node: at position 7 of block #6")
+	assertCanonicalised(t, 5)
+	assert.Equal(t, uint(5), *s.canonHead)
+}
+
+func TestService_canonicalise_palletNotActive(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	header := types.NewHeader(common.Hash{1}, common.Hash{}, common.Hash{}, 1, types.NewDigest())
+	trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+	storageState := NewMockStorageState(ctrl)
+	storageState.EXPECT().TrieState(&header.StateRoot).Return(trieState, nil)
+	rt := NewMockInstance(ctrl)
+	rt.EXPECT().SetContextStorage(trieState)
+	rt.EXPECT().Exec(runtime.MmrAPIMmrLeafCount, []byte{}).Return([]byte{1, byte(ErrPalletNotIncluded)}, nil)
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().GetRuntime(header.Hash()).Return(rt, nil)
+
+	offchainStorage := runtime.NewInMemoryDB(t)
+	s := NewService(&Config{
+		BlockState:      blockState,
+		StorageState:    storageState,
+		OffchainStorage: offchainStorage,
+	})
+
+	err := s.canonicalise(header)
+	require.NoError(t, err)
+	assert.Nil(t, s.firstMmrBlock)
+
+	_, err = offchainStorage.Get(canonHeadKey)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
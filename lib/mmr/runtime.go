@@ -0,0 +1,191 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package mmr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+var errEmptyResult = errors.New("empty result")
+
+// Error is an error returned by the MMR runtime API
+type Error byte
+
+// Errors returned by the MMR runtime API
+const (
+	ErrInvalidNumericOp Error = iota
+	ErrPush
+	ErrGetRoot
+	ErrCommit
+	ErrGenerateProof
+	ErrVerify
+	ErrLeafNotFound
+	ErrPalletNotIncluded
+	ErrInvalidLeafIndex
+	ErrInvalidBestKnownBlock
+)
+
+func (e Error) Error() string {
+	switch e {
+	case ErrInvalidNumericOp:
+		return "mmr: invalid numeric operation"
+	case ErrPush:
+		return "mmr: error pushing a leaf"
+	case ErrGetRoot:
+		return "mmr: error getting the root"
+	case ErrCommit:
+		return "mmr: error committing the changes"
+	case ErrGenerateProof:
+		return "mmr: error generating the proof"
+	case ErrVerify:
+		return "mmr: proof verification failed"
+	case ErrLeafNotFound:
+		return "mmr: leaf not found"
+	case ErrPalletNotIncluded:
+		return "mmr: pallet not included in the runtime"
+	case ErrInvalidLeafIndex:
+		return "mmr: invalid leaf index"
+	case ErrInvalidBestKnownBlock:
+		return "mmr: invalid best known block"
+	default:
+		return fmt.Sprintf("mmr: unknown error %d", byte(e))
+	}
+}
+
+// LeafProof is the proof of the leaves with the given indices in the MMR with the given number of leaves
+type LeafProof struct {
+	LeafIndices []uint64
+	LeafCount   uint64
+	Items       []common.Hash
+}
+
+// decodeResult decodes a Result<T, Error> returned by the MMR runtime API into the given ok
+// value, or returns the Error of the result. The ok value is nil for an empty Ok tuple.
+func decodeResult(encoded []byte, ok any) error {
+	if len(encoded) == 0 {
+		return errEmptyResult
+	}
+
+	switch encoded[0] {
+	case 0:
+		if ok == nil {
+			return nil
+		}
+		return scale.Unmarshal(encoded[1:], ok)
+	case 1:
+		if len(encoded) < 2 {
+			return fmt.Errorf("%w: missing error", errEmptyResult)
+		}
+		return Error(encoded[1])
+	default:
+		return fmt.Errorf("invalid result variant %d", encoded[0])
+	}
+}
+
+// Root returns the MMR root at the state of the runtime instance
+func Root(rt runtime.Instance) (root common.Hash, err error) {
+	ret, err := rt.Exec(runtime.MmrAPIMmrRoot, []byte{})
+	if err != nil {
+		return root, err
+	}
+
+	err = decodeResult(ret, &root)
+	if err != nil {
+		return root, fmt.Errorf("getting mmr root: %w", err)
+	}
+	return root, nil
+}
+
+// LeafCount returns the number of leaves of the MMR at the state of the runtime instance
+func LeafCount(rt runtime.Instance) (leafCount uint64, err error) {
+	ret, err := rt.Exec(runtime.MmrAPIMmrLeafCount, []byte{})
+	if err != nil {
+		return 0, err
+	}
+
+	err = decodeResult(ret, &leafCount)
+	if err != nil {
+		return 0, fmt.Errorf("getting mmr leaf count: %w", err)
+	}
+	return leafCount, nil
+}
+
+// GenerateProof returns the SCALE encoded leaves of the given blocks and their SCALE encoded
+// LeafProof, for the MMR as it was at the best known block number, or at the state of the runtime
+// instance if the best known block number is nil.
+func GenerateProof(rt runtime.Instance, blockNumbers []uint32, bestKnownBlockNumber *uint32) (
+	leaves, proof []byte, err error) {
+	params, err := scale.Marshal(struct {
+		BlockNumbers         []uint32
+		BestKnownBlockNumber *uint32
+	}{blockNumbers, bestKnownBlockNumber})
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding parameters: %w", err)
+	}
+
+	ret, err := rt.Exec(runtime.MmrAPIGenerateProof, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var leavesProof struct {
+		Leaves [][]byte
+		Proof  LeafProof
+	}
+	err = decodeResult(ret, &leavesProof)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating mmr proof: %w", err)
+	}
+
+	leaves, err = scale.Marshal(leavesProof.Leaves)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding leaves: %w", err)
+	}
+
+	proof, err = scale.Marshal(leavesProof.Proof)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding proof: %w", err)
+	}
+	return leaves, proof, nil
+}
+
+// VerifyProof verifies the SCALE encoded leaves against their SCALE encoded LeafProof and the
+// MMR at the state of the runtime instance. It returns nil if the proof is valid.
+func VerifyProof(rt runtime.Instance, leaves, proof []byte) error {
+	var decodedLeaves [][]byte
+	err := scale.Unmarshal(leaves, &decodedLeaves)
+	if err != nil {
+		return fmt.Errorf("decoding leaves: %w", err)
+	}
+
+	var decodedProof LeafProof
+	err = scale.Unmarshal(proof, &decodedProof)
+	if err != nil {
+		return fmt.Errorf("decoding proof: %w", err)
+	}
+
+	params, err := scale.Marshal(struct {
+		Leaves [][]byte
+		Proof  LeafProof
+	}{decodedLeaves, decodedProof})
+	if err != nil {
+		return fmt.Errorf("encoding parameters: %w", err)
+	}
+
+	ret, err := rt.Exec(runtime.MmrAPIVerifyProof, params)
+	if err != nil {
+		return err
+	}
+
+	err = decodeResult(ret, nil)
+	if err != nil {
+		return fmt.Errorf("verifying mmr proof: %w", err)
+	}
+	return nil
+}
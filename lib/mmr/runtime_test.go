@@ -0,0 +1,130 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package mmr
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRoot(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		ret        []byte
+		root       common.Hash
+		errWrapped error
+		errMessage string
+	}{
+		"ok": {
+			ret:  append([]byte{0}, common.Hash{1}.ToBytes()...),
+			root: common.Hash{1},
+		},
+		"error": {
+			ret:        []byte{1, byte(ErrPalletNotIncluded)},
+			errWrapped: ErrPalletNotIncluded,
+			errMessage: "getting mmr root: mmr: pallet not included in the runtime",
+		},
+		"empty_result": {
+			ret:        []byte{},
+			errWrapped: errEmptyResult,
+			errMessage: "getting mmr root: empty result",
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			rt := NewMockInstance(ctrl)
+			rt.EXPECT().Exec(runtime.MmrAPIMmrRoot, []byte{}).Return(tt.ret, nil)
+
+			root, err := Root(rt)
+			assert.ErrorIs(t, err, tt.errWrapped)
+			if tt.errWrapped != nil {
+				assert.EqualError(t, err, tt.errMessage)
+			}
+			assert.Equal(t, tt.root, root)
+		})
+	}
+}
+
+func TestGenerateProof(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	leaves := [][]byte{{1, 2}}
+	proof := LeafProof{
+		LeafIndices: []uint64{4},
+		LeafCount:   8,
+		Items:       []common.Hash{{3}, {4}},
+	}
+	bestKnownBlockNumber := uint32(9)
+
+	expectedParams := scale.MustMarshal([]uint32{5})
+	expectedParams = append(expectedParams, scale.MustMarshal(&bestKnownBlockNumber)...)
+	ret := append([]byte{0}, scale.MustMarshal(leaves)...)
+	ret = append(ret, scale.MustMarshal(proof)...)
+
+	rt := NewMockInstance(ctrl)
+	rt.EXPECT().Exec(runtime.MmrAPIGenerateProof, expectedParams).Return(ret, nil)
+
+	encodedLeaves, encodedProof, err := GenerateProof(rt, []uint32{5}, &bestKnownBlockNumber)
+	require.NoError(t, err)
+	assert.Equal(t, scale.MustMarshal(leaves), encodedLeaves)
+	assert.Equal(t, scale.MustMarshal(proof), encodedProof)
+}
+
+func TestVerifyProof(t *testing.T) {
+	t.Parallel()
+
+	leaves := scale.MustMarshal([][]byte{{1, 2}})
+	proof := scale.MustMarshal(LeafProof{
+		LeafIndices: []uint64{4},
+		LeafCount:   8,
+		Items:       []common.Hash{{3}},
+	})
+
+	tests := map[string]struct {
+		leaves     []byte
+		proof      []byte
+		ret        []byte
+		errWrapped error
+	}{
+		"valid": {
+			leaves: leaves,
+			proof:  proof,
+			ret:    []byte{0},
+		},
+		"invalid": {
+			leaves:     leaves,
+			proof:      proof,
+			ret:        []byte{1, byte(ErrVerify)},
+			errWrapped: ErrVerify,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			rt := NewMockInstance(ctrl)
+			rt.EXPECT().Exec(runtime.MmrAPIVerifyProof, append(append([]byte{}, tt.leaves...), tt.proof...)).
+				Return(tt.ret, nil)
+
+			err := VerifyProof(rt, tt.leaves, tt.proof)
+			assert.ErrorIs(t, err, tt.errWrapped)
+		})
+	}
+}
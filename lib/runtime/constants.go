@@ -62,10 +62,38 @@
 	BlockBuilderFinalizeBlock = "BlockBuilder_finalize_block"
 	// DecodeSessionKeys is the runtime API call SessionKeys_decode_session_keys
 	DecodeSessionKeys = "SessionKeys_decode_session_keys"
+	// GenerateSessionKeys is the runtime API call SessionKeys_generate_session_keys
+	GenerateSessionKeys = "SessionKeys_generate_session_keys"
 	// TransactionPaymentAPIQueryInfo returns information of a given extrinsic
 	TransactionPaymentAPIQueryInfo = "TransactionPaymentApi_query_info"
 	// TransactionPaymentCallAPIQueryCallInfo returns call query call info
 	TransactionPaymentCallAPIQueryCallInfo = "TransactionPaymentCallApi_query_call_info"
 	// TransactionPaymentCallAPIQueryCallFeeDetails returns call query call fee details
 	TransactionPaymentCallAPIQueryCallFeeDetails = "TransactionPaymentCallApi_query_call_fee_details"
+	// OffchainWorkerAPIOffchainWorker runs the offchain worker for a block header
+	OffchainWorkerAPIOffchainWorker = "OffchainWorkerApi_offchain_worker"
+	// AuthorityDiscoveryAPIAuthorities is the runtime API call AuthorityDiscoveryApi_authorities
+	AuthorityDiscoveryAPIAuthorities = "AuthorityDiscoveryApi_authorities"
+	// BeefyAPIValidatorSet is the runtime API call BeefyApi_validator_set
+	BeefyAPIValidatorSet = "BeefyApi_validator_set"
+	// MmrAPIMmrRoot is the runtime API call MmrApi_mmr_root
+	MmrAPIMmrRoot = "MmrApi_mmr_root"
+	// MmrAPIMmrLeafCount is the runtime API call MmrApi_mmr_leaf_count
+	MmrAPIMmrLeafCount = "MmrApi_mmr_leaf_count"
+	// MmrAPIGenerateProof is the runtime API call MmrApi_generate_proof
+	MmrAPIGenerateProof = "MmrApi_generate_proof"
+	// MmrAPIVerifyProof is the runtime API call MmrApi_verify_proof
+	MmrAPIVerifyProof = "MmrApi_verify_proof"
+	// GenesisBuilderBuildState is the runtime API call GenesisBuilder_build_state
+	GenesisBuilderBuildState = "GenesisBuilder_build_state"
+	// GenesisBuilderGetPreset is the runtime API call GenesisBuilder_get_preset
+	GenesisBuilderGetPreset = "GenesisBuilder_get_preset"
+	// GenesisBuilderPresetNames is the runtime API call GenesisBuilder_preset_names
+	GenesisBuilderPresetNames = "GenesisBuilder_preset_names"
+	// GenesisBuilderCreateDefaultConfig is the runtime API call GenesisBuilder_create_default_config
+	// of the first version of the GenesisBuilder API, replaced by GenesisBuilder_get_preset
+	GenesisBuilderCreateDefaultConfig = "GenesisBuilder_create_default_config"
+	// GenesisBuilderBuildConfig is the runtime API call GenesisBuilder_build_config
+	// of the first version of the GenesisBuilder API, replaced by GenesisBuilder_build_state
+	GenesisBuilderBuildConfig = "GenesisBuilder_build_config"
 )
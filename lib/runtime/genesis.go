@@ -18,7 +18,8 @@
 	ErrGenesisTopNotFound = errors.New("genesis top not found")
 )
 
-// NewTrieFromGenesis creates a new trie from the raw genesis data
+// NewTrieFromGenesis creates a new trie from the raw genesis data. The child tries are
+// loaded from the childrenDefault raw storage maps keyed by their hex encoded child storage key.
 func NewTrieFromGenesis(gen genesis.Genesis) (tr trie.Trie, err error) {
 	tr = in_memory_trie.NewEmptyTrie()
 	genesisFields := gen.GenesisFields()
@@ -28,12 +29,29 @@ func NewTrieFromGenesis(gen genesis.Genesis) (tr trie.Trie, err error) {
 			ErrGenesisTopNotFound, gen.Name)
 	}
 
-	tr, err = in_memory_trie.LoadFromMap(keyValues, trie.V0)
+	top, err := in_memory_trie.LoadFromMap(keyValues, trie.V0)
 	if err != nil {
 		return tr, fmt.Errorf("loading genesis top key values into trie: %w", err)
 	}
 
-	return tr, nil
+	for hexKeyToChild, childKeyValues := range genesisFields.ChildrenDefault {
+		keyToChild, err := common.HexToBytes(hexKeyToChild)
+		if err != nil {
+			return tr, fmt.Errorf("decoding genesis child storage key %s: %w", hexKeyToChild, err)
+		}
+
+		child, err := in_memory_trie.LoadFromMap(childKeyValues, trie.V0)
+		if err != nil {
+			return tr, fmt.Errorf("loading genesis child key values into trie at key %s: %w", hexKeyToChild, err)
+		}
+
+		err = top.SetChild(keyToChild, child)
+		if err != nil {
+			return tr, fmt.Errorf("setting genesis child trie at key %s: %w", hexKeyToChild, err)
+		}
+	}
+
+	return top, nil
 }
 
 func GenesisBlockFromTrie(t trie.Trie) (genesisHeader types.Header, err error) {
@@ -15,11 +15,15 @@
 func Test_NewTrieFromGenesis(t *testing.T) {
 	t.Parallel()
 
+	// child
+	const childStorageKey = "0x6368696c64"
+
 	testCases := map[string]struct {
-		genesis     genesis.Genesis
-		expectedKV  map[string]string
-		errSentinel error
-		errMessage  string
+		genesis         genesis.Genesis
+		expectedKV      map[string]string
+		expectedChildKV map[string]string
+		errSentinel     error
+		errMessage      string
 	}{
 		"genesis_top_not_found": {
 			genesis:     genesis.Genesis{Name: "genesis_name"},
@@ -59,6 +63,64 @@ func Test_NewTrieFromGenesis(t *testing.T) {
 				"0x0103": "0x0b",
 			},
 		},
+		"bad_hex_child_trie_key": {
+			genesis: genesis.Genesis{
+				Name: "genesis_name",
+				Genesis: genesis.Fields{
+					Raw: map[string]map[string]string{
+						"top": {},
+					},
+					ChildrenDefault: map[string]map[string]string{
+						childStorageKey: {
+							"badhexkey": "0xa",
+						},
+					},
+				},
+			},
+			errSentinel: common.ErrNoPrefix,
+			errMessage: "loading genesis child key values into trie at key " + childStorageKey + ": " +
+				"cannot convert key hex to bytes: " +
+				"could not byteify non 0x prefixed string: badhexkey",
+		},
+		"bad_hex_child_storage_key": {
+			genesis: genesis.Genesis{
+				Name: "genesis_name",
+				Genesis: genesis.Fields{
+					Raw: map[string]map[string]string{
+						"top": {},
+					},
+					ChildrenDefault: map[string]map[string]string{
+						"child": {},
+					},
+				},
+			},
+			errSentinel: common.ErrNoPrefix,
+			errMessage: "decoding genesis child storage key child: " +
+				"could not byteify non 0x prefixed string: child",
+		},
+		"success_with_child_trie": {
+			genesis: genesis.Genesis{
+				Name: "genesis_name",
+				Genesis: genesis.Fields{
+					Raw: map[string]map[string]string{
+						"top": {
+							"0x0102": "0x0a",
+						},
+					},
+					ChildrenDefault: map[string]map[string]string{
+						childStorageKey: {
+							"0x0103": "0x0b",
+						},
+					},
+				},
+			},
+			expectedKV: map[string]string{
+				"0x0102": "0x0a",
+			},
+			expectedChildKV: map[string]string{
+				"0x0103": "0x0b",
+			},
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -74,6 +136,16 @@ func Test_NewTrieFromGenesis(t *testing.T) {
 				return
 			}
 
+			if testCase.expectedChildKV != nil {
+				for hexKey, hexValue := range testCase.expectedChildKV {
+					value, err := tr.GetFromChild([]byte("child"), common.MustHexToBytes(hexKey))
+					require.NoError(t, err)
+					assert.Equal(t, hexValue, common.BytesToHex(value))
+				}
+				err = tr.DeleteChild([]byte("child"))
+				require.NoError(t, err)
+			}
+
 			for hexKey, hexValue := range testCase.expectedKV {
 				key := common.MustHexToBytes(hexKey)
 				value := tr.Get(key)
@@ -48,12 +48,19 @@ type Runtime interface {
 	SetVersion(v trie.TrieLayout)
 }
 
+// OffchainIndex storage interface.
+type OffchainIndex interface {
+	SetOffchainIndex(key, value []byte)
+	ClearOffchainIndex(key []byte)
+}
+
 // Storage runtime interface.
 type Storage interface {
 	Trie
 	ChildTrie
 	Transactional
 	Runtime
+	OffchainIndex
 }
 
 // BasicNetwork interface for functions used by runtime network state function
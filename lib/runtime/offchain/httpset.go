@@ -7,9 +7,12 @@
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 )
 
 type contextKey string
@@ -60,10 +63,140 @@ func (b requestIDBuffer) put(i int16) error {
 	}
 }
 
+// HTTPError is the error of an offchain HTTP request operation,
+// encoded as the HttpError enum of the runtime interface
+type HTTPError byte
+
+const (
+	// HTTPErrorDeadlineReached is returned when the deadline was reached before the operation completed
+	HTTPErrorDeadlineReached HTTPError = iota
+	// HTTPErrorIO is returned when an error happened while sending the request or reading the response
+	HTTPErrorIO
+	// HTTPErrorInvalid is returned when the request does not exist or the operation
+	// is not allowed in the current state of the request
+	HTTPErrorInvalid
+)
+
+func (e HTTPError) Error() string {
+	switch e {
+	case HTTPErrorDeadlineReached:
+		return "deadline reached"
+	case HTTPErrorIO:
+		return "io error"
+	case HTTPErrorInvalid:
+		return "invalid request"
+	default:
+		return fmt.Sprintf("unknown http error %d", byte(e))
+	}
+}
+
+// MarshalSCALE encodes the HTTP error as its enum index
+func (e HTTPError) MarshalSCALE() ([]byte, error) {
+	return []byte{byte(e)}, nil
+}
+
+// HTTPRequestStatus is the status of an offchain HTTP request, encoded
+// as the HttpRequestStatus enum of the runtime interface
+type HTTPRequestStatus struct {
+	// Finished is true if the response headers were received
+	Finished bool
+	// StatusCode is the response status code if the request is finished
+	StatusCode uint16
+	// Err is the reason the request is not finished
+	Err HTTPError
+}
+
+// finishedStatusIndex is the index of the Finished variant of the HttpRequestStatus enum
+const finishedStatusIndex = 3
+
+// MarshalSCALE encodes the status as the HttpRequestStatus enum
+func (s HTTPRequestStatus) MarshalSCALE() ([]byte, error) {
+	if !s.Finished {
+		return []byte{byte(s.Err)}, nil
+	}
+	return []byte{finishedStatusIndex, byte(s.StatusCode), byte(s.StatusCode >> 8)}, nil
+}
+
+// HTTPHeader is a header of an offchain HTTP response
+type HTTPHeader struct {
+	Name  []byte
+	Value []byte
+}
+
 // Request holds the request object and update the invalid and waiting status whenever
 // the request starts or is waiting to be read
 type Request struct {
 	Request *http.Request
+
+	// bodyWriter streams the body chunks to the request sent, it is nil until the request is sent
+	bodyWriter *io.PipeWriter
+	// bodyFinished is true once the runtime wrote the last body chunk
+	bodyFinished bool
+	// done is closed once the response headers are received or the request failed
+	done     chan struct{}
+	response *http.Response
+	err      error
+}
+
+func (r *Request) dispatched() bool {
+	return r.bodyWriter != nil
+}
+
+// dispatch sends the request with a body streamed from the chunks written by the runtime.
+// The request headers cannot be changed after the request is sent.
+func (r *Request) dispatch(client *http.Client) {
+	bodyReader, bodyWriter := io.Pipe()
+	r.bodyWriter = bodyWriter
+	r.done = make(chan struct{})
+
+	ctx := context.WithValue(r.Request.Context(), waitingKey, true)
+	ctx = context.WithValue(ctx, invalidKey, true)
+	r.Request = r.Request.WithContext(ctx)
+
+	req := r.Request.Clone(ctx)
+	req.Body = bodyReader
+	go func() {
+		defer close(r.done)
+		r.response, r.err = client.Do(req) //nolint:bodyclose
+	}()
+}
+
+// waitResponse waits until the response headers are received or the request
+// failed, and returns false if the deadline is reached first.
+func (r *Request) waitResponse(expired <-chan time.Time) bool {
+	select {
+	case <-r.done:
+		return true
+	case <-expired:
+		select {
+		case <-r.done:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// finishBody closes the request body, so the request can complete.
+func (r *Request) finishBody() {
+	r.bodyFinished = true
+	_ = r.bodyWriter.Close()
+}
+
+// close releases the request body and the response body of the request.
+func (r *Request) close() {
+	if !r.dispatched() {
+		return
+	}
+
+	_ = r.bodyWriter.CloseWithError(errRequestInvalid)
+	select {
+	case <-r.done:
+		if r.response != nil {
+			_ = r.response.Body.Close()
+		}
+	default:
+	}
 }
 
 // AddHeader adds a new HTTP header into request property, only if request is valid
@@ -87,6 +220,7 @@ type HTTPSet struct {
 	*sync.Mutex
 	reqs   map[int16]*Request
 	idBuff requestIDBuffer
+	client *http.Client
 }
 
 // NewHTTPSet creates a offchain http set that can be used
@@ -96,6 +230,7 @@ func NewHTTPSet() *HTTPSet {
 		new(sync.Mutex),
 		make(map[int16]*Request),
 		newIntBuffer(maxConcurrentRequests),
+		&http.Client{},
 	}
 }
 
@@ -115,6 +250,10 @@ func (p *HTTPSet) StartRequest(method, uri string) (int16, error) {
 	}
 
 	req, err := http.NewRequest(method, uri, nil)
+	if err != nil {
+		_ = p.idBuff.put(id)
+		return 0, err
+	}
 	req.Header = make(http.Header)
 
 	ctx := context.WithValue(req.Context(), waitingKey, false)
@@ -122,10 +261,6 @@ func (p *HTTPSet) StartRequest(method, uri string) (int16, error) {
 
 	req = req.WithContext(ctx)
 
-	if err != nil {
-		return 0, err
-	}
-
 	p.reqs[id] = &Request{
 		Request: req,
 	}
@@ -138,6 +273,9 @@ func (p *HTTPSet) Remove(id int16) error {
 	p.Lock()
 	defer p.Unlock()
 
+	if req, ok := p.reqs[id]; ok {
+		req.close()
+	}
 	delete(p.reqs, id)
 
 	return p.idBuff.put(id)
@@ -150,3 +288,192 @@ func (p *HTTPSet) Get(id int16) *Request {
 
 	return p.reqs[id]
 }
+
+// expiredChannel is a closed channel, used in place of an expired timer channel.
+var expiredChannel = func() <-chan time.Time {
+	expired := make(chan time.Time)
+	close(expired)
+	return expired
+}()
+
+// deadlineTimer returns a channel receiving once the deadline is reached,
+// or a nil channel if there is no deadline, and a function to stop the timer.
+func deadlineTimer(deadline *time.Time) (expired <-chan time.Time, stop func()) {
+	if deadline == nil {
+		return nil, func() {}
+	}
+
+	timer := time.NewTimer(time.Until(*deadline))
+	return timer.C, func() { timer.Stop() }
+}
+
+// WriteBody writes a chunk of the body of the request, sending the request if
+// it was not sent yet. Writing an empty chunk finishes the request body.
+func (p *HTTPSet) WriteBody(id int16, chunk []byte, deadline *time.Time) error {
+	req := p.Get(id)
+	if req == nil || req.bodyFinished {
+		return HTTPErrorInvalid
+	}
+
+	if !req.dispatched() {
+		req.dispatch(p.client)
+	}
+
+	if len(chunk) == 0 {
+		req.finishBody()
+		return nil
+	}
+
+	expired, stop := deadlineTimer(deadline)
+	defer stop()
+
+	written := make(chan error, 1)
+	go func() {
+		_, err := req.bodyWriter.Write(chunk)
+		written <- err
+	}()
+
+	select {
+	case err := <-written:
+		if err != nil {
+			return HTTPErrorIO
+		}
+		return nil
+	case <-expired:
+		// the request cannot complete with a partially written body
+		_ = req.bodyWriter.CloseWithError(HTTPErrorDeadlineReached)
+		req.bodyFinished = true
+		return HTTPErrorDeadlineReached
+	}
+}
+
+// Wait sends the requests which were not sent yet with an empty body, and waits until
+// the response headers of the requests are received or the deadline is reached.
+func (p *HTTPSet) Wait(ids []int16, deadline *time.Time) []HTTPRequestStatus {
+	expired, stop := deadlineTimer(deadline)
+	defer stop()
+
+	reqs := make([]*Request, len(ids))
+	for i, id := range ids {
+		reqs[i] = p.Get(id)
+		if reqs[i] != nil && !reqs[i].dispatched() {
+			reqs[i].dispatch(p.client)
+			reqs[i].finishBody()
+		}
+	}
+
+	statuses := make([]HTTPRequestStatus, len(ids))
+	for i, req := range reqs {
+		if req == nil {
+			statuses[i] = HTTPRequestStatus{Err: HTTPErrorInvalid}
+			continue
+		}
+
+		finished := req.waitResponse(expired)
+		if !finished {
+			// once the deadline is reached, only the requests which
+			// already completed are not reported as timed out
+			expired = expiredChannel
+		}
+
+		switch {
+		case !finished:
+			statuses[i] = HTTPRequestStatus{Err: HTTPErrorDeadlineReached}
+		case req.err != nil:
+			statuses[i] = HTTPRequestStatus{Err: HTTPErrorIO}
+		default:
+			statuses[i] = HTTPRequestStatus{Finished: true, StatusCode: uint16(req.response.StatusCode)}
+		}
+	}
+
+	return statuses
+}
+
+// ResponseHeaders returns the response headers of the request sorted by name, or
+// no headers if the request does not exist or its response was not received yet.
+func (p *HTTPSet) ResponseHeaders(id int16) []HTTPHeader {
+	req := p.Get(id)
+	if req == nil || !req.dispatched() {
+		return nil
+	}
+
+	select {
+	case <-req.done:
+	default:
+		return nil
+	}
+
+	if req.err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(req.response.Header))
+	for name := range req.response.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var headers []HTTPHeader
+	for _, name := range names {
+		for _, value := range req.response.Header[name] {
+			headers = append(headers, HTTPHeader{Name: []byte(name), Value: []byte(value)})
+		}
+	}
+	return headers
+}
+
+// ReadBody reads a chunk of the response body of the request into the buffer, waiting for
+// the response if needed, and returns the number of bytes read. Zero bytes are read once the
+// whole body was read, and the request is then removed.
+func (p *HTTPSet) ReadBody(id int16, buffer []byte, deadline *time.Time) (n int, err error) {
+	req := p.Get(id)
+	if req == nil || !req.dispatched() {
+		return 0, HTTPErrorInvalid
+	}
+
+	expired, stop := deadlineTimer(deadline)
+	defer stop()
+
+	if !req.waitResponse(expired) {
+		return 0, HTTPErrorDeadlineReached
+	}
+
+	if req.err != nil {
+		return 0, HTTPErrorIO
+	}
+
+	if len(buffer) == 0 {
+		return 0, nil
+	}
+
+	type readResult struct {
+		n   int
+		err error
+	}
+	read := make(chan readResult, 1)
+	go func() {
+		for {
+			n, err := req.response.Body.Read(buffer)
+			if n > 0 || err != nil {
+				read <- readResult{n: n, err: err}
+				return
+			}
+		}
+	}()
+
+	select {
+	case result := <-read:
+		switch {
+		case result.n > 0:
+			return result.n, nil
+		case errors.Is(result.err, io.EOF):
+			return 0, p.Remove(id)
+		default:
+			return 0, HTTPErrorIO
+		}
+	case <-expired:
+		// the pending read writes into the buffer, so the response cannot be read anymore
+		_ = p.Remove(id)
+		return 0, HTTPErrorDeadlineReached
+	}
+}
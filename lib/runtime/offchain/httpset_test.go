@@ -6,9 +6,13 @@
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/stretchr/testify/require"
 )
 
@@ -64,7 +68,7 @@ func TestOffchainRequest_AddHeader(t *testing.T) {
 		headerK, headerV string
 	}{
 		"should_return_invalid_request": {
-			offReq: Request{invalidReq},
+			offReq: Request{Request: invalidReq},
 			err:    errRequestInvalid,
 		},
 		"should_add_header": {
@@ -101,3 +105,136 @@ func TestOffchainRequest_AddHeader(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPSet_RequestResponse(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		w.Header().Set("X-Request-Body", string(body))
+		w.Header().Set("X-Request-Header", r.Header.Get("X-Test"))
+		w.WriteHeader(http.StatusCreated)
+		_, err = w.Write([]byte("response body"))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	set := NewHTTPSet()
+	id, err := set.StartRequest(http.MethodPost, server.URL)
+	require.NoError(t, err)
+
+	err = set.Get(id).AddHeader("X-Test", "value")
+	require.NoError(t, err)
+
+	deadline := time.Now().Add(time.Minute)
+	err = set.WriteBody(id, []byte("request "), &deadline)
+	require.NoError(t, err)
+	err = set.WriteBody(id, []byte("body"), nil)
+	require.NoError(t, err)
+
+	// the headers cannot be changed once the request is sent
+	err = set.Get(id).AddHeader("X-Other", "value")
+	require.ErrorIs(t, err, errRequestInvalid)
+
+	err = set.WriteBody(id, nil, nil)
+	require.NoError(t, err)
+	err = set.WriteBody(id, []byte("too late"), nil)
+	require.ErrorIs(t, err, HTTPErrorInvalid)
+
+	statuses := set.Wait([]int16{id, id + 1}, &deadline)
+	expectedStatuses := []HTTPRequestStatus{
+		{Finished: true, StatusCode: http.StatusCreated},
+		{Err: HTTPErrorInvalid},
+	}
+	require.Equal(t, expectedStatuses, statuses)
+
+	headers := set.ResponseHeaders(id)
+	require.Contains(t, headers, HTTPHeader{Name: []byte("X-Request-Body"), Value: []byte("request body")})
+	require.Contains(t, headers, HTTPHeader{Name: []byte("X-Request-Header"), Value: []byte("value")})
+
+	var body []byte
+	buffer := make([]byte, 5)
+	for {
+		n, err := set.ReadBody(id, buffer, &deadline)
+		require.NoError(t, err)
+		if n == 0 {
+			break
+		}
+		body = append(body, buffer[:n]...)
+	}
+	require.Equal(t, []byte("response body"), body)
+
+	// the request is removed once its body is read
+	require.Nil(t, set.Get(id))
+	_, err = set.ReadBody(id, buffer, nil)
+	require.ErrorIs(t, err, HTTPErrorInvalid)
+}
+
+func TestHTTPSet_DeadlineReached(t *testing.T) {
+	t.Parallel()
+
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	t.Cleanup(func() {
+		close(unblock)
+		server.Close()
+	})
+
+	fastServer := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(fastServer.Close)
+
+	set := NewHTTPSet()
+	id, err := set.StartRequest(http.MethodGet, server.URL)
+	require.NoError(t, err)
+	fastID, err := set.StartRequest(http.MethodGet, fastServer.URL)
+	require.NoError(t, err)
+
+	deadline := time.Now().Add(50 * time.Millisecond)
+	statuses := set.Wait([]int16{id, fastID}, &deadline)
+	expectedStatuses := []HTTPRequestStatus{
+		{Err: HTTPErrorDeadlineReached},
+		{Finished: true, StatusCode: http.StatusNotFound},
+	}
+	require.Equal(t, expectedStatuses, statuses)
+	require.Empty(t, set.ResponseHeaders(id))
+
+	_, err = set.ReadBody(id, make([]byte, 1), &deadline)
+	require.ErrorIs(t, err, HTTPErrorDeadlineReached)
+
+	err = set.Remove(id)
+	require.NoError(t, err)
+}
+
+func TestHTTPSet_IOError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	set := NewHTTPSet()
+	id, err := set.StartRequest(http.MethodGet, server.URL)
+	require.NoError(t, err)
+
+	statuses := set.Wait([]int16{id}, nil)
+	require.Equal(t, []HTTPRequestStatus{{Err: HTTPErrorIO}}, statuses)
+
+	_, err = set.ReadBody(id, make([]byte, 1), nil)
+	require.ErrorIs(t, err, HTTPErrorIO)
+}
+
+func TestHTTPRequestStatus_MarshalSCALE(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := scale.Marshal([]HTTPRequestStatus{
+		{Err: HTTPErrorDeadlineReached},
+		{Err: HTTPErrorIO},
+		{Err: HTTPErrorInvalid},
+		{Finished: true, StatusCode: http.StatusOK},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []byte{4 << 2, 0, 1, 2, 3, 200, 0}, encoded)
+}
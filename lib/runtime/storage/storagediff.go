@@ -16,7 +16,8 @@
 
 // storageDiff is a structure that stores the differences between consecutive
 // states of a trie, such as those occurring during the execution of a block.
-// It records updates (upserts), deletions, and changes to child tries.
+// It records updates (upserts), deletions, changes to child tries and changes
+// to the offchain index.
 // This mechanism facilitates applying state transitions efficiently.
 // Changes accumulated in storageDiff can be applied to a trie using
 // the `applyToTrie` method
@@ -26,6 +27,9 @@ type storageDiff struct {
 	deletes        map[string]bool
 	sortedKeys     []string
 	childChangeSet map[string]*storageDiff
+	// offchainIndex holds the values set in the offchain index, where a nil value
+	// clears the key
+	offchainIndex map[string][]byte
 }
 
 // newChangeSet initialises and returns a new storageDiff instance
@@ -34,6 +38,7 @@ func newStorageDiff() *storageDiff {
 		upserts:        make(map[string][]byte),
 		deletes:        make(map[string]bool),
 		childChangeSet: make(map[string]*storageDiff),
+		offchainIndex:  make(map[string][]byte),
 	}
 }
 
@@ -220,8 +225,18 @@ func (cs *storageDiff) deleteFromChild(keyToChild, key string) {
 	childChanges.removeSortedKey(key)
 }
 
+// setOffchainIndex records the value of the key in the offchain index, where a nil
+// value clears the key.
+func (cs *storageDiff) setOffchainIndex(key string, value []byte) {
+	if cs == nil {
+		return
+	}
+
+	cs.offchainIndex[key] = value
+}
+
 // snapshot creates a deep copy of the current change set, including all upserts,
-// deletions, and child trie change sets.
+// deletions, child trie change sets and offchain index changes.
 func (cs *storageDiff) snapshot() *storageDiff {
 	if cs == nil {
 		panic("Trying to create snapshot from nil change set")
@@ -237,6 +252,7 @@ func (cs *storageDiff) snapshot() *storageDiff {
 		deletes:        maps.Clone(cs.deletes),
 		childChangeSet: childChangeSetCopy,
 		sortedKeys:     slices.Clone(cs.sortedKeys),
+		offchainIndex:  maps.Clone(cs.offchainIndex),
 	}
 }
 
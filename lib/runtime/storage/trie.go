@@ -26,6 +26,9 @@ type TrieState struct {
 	mtx          sync.RWMutex
 	state        trie.Trie
 	transactions *list.List
+	// offchainIndex holds the committed offchain index changes, where a nil
+	// value clears the key
+	offchainIndex map[string][]byte
 }
 
 // NewTrieState initialises and returns a new TrieState instance
@@ -93,6 +96,9 @@ func (t *TrieState) CommitTransaction() {
 		// This is the last transaction so we apply all the changes to our state
 		tx := t.transactions.Remove(t.transactions.Back()).(*storageDiff)
 		tx.applyToTrie(t.state)
+		for key, value := range tx.offchainIndex {
+			t.setOffchainIndex(key, value)
+		}
 	}
 }
 
@@ -589,6 +595,54 @@ func (t *TrieState) GetKeysWithPrefixFromChild(keyToChild, prefix []byte) ([][]b
 	return child.GetKeysWithPrefix(prefix), nil
 }
 
+// SetOffchainIndex sets the value of the key in the offchain index. The offchain
+// index changes are not written to the offchain storage by the trie state, they
+// are retrieved with OffchainIndexChanges once the block is executed.
+func (t *TrieState) SetOffchainIndex(key, value []byte) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if value == nil {
+		value = []byte{}
+	}
+
+	if currentTx := t.getCurrentTransaction(); currentTx != nil {
+		currentTx.setOffchainIndex(string(key), value)
+		return
+	}
+
+	t.setOffchainIndex(string(key), value)
+}
+
+// ClearOffchainIndex clears the key from the offchain index
+func (t *TrieState) ClearOffchainIndex(key []byte) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if currentTx := t.getCurrentTransaction(); currentTx != nil {
+		currentTx.setOffchainIndex(string(key), nil)
+		return
+	}
+
+	t.setOffchainIndex(string(key), nil)
+}
+
+func (t *TrieState) setOffchainIndex(key string, value []byte) {
+	if t.offchainIndex == nil {
+		t.offchainIndex = make(map[string][]byte)
+	}
+	t.offchainIndex[key] = value
+}
+
+// OffchainIndexChanges returns the committed offchain index changes, as a map of the
+// changed keys to their values, where a nil value means the key is cleared.
+func (t *TrieState) OffchainIndexChanges() map[string][]byte {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+
+	return maps.Clone(t.offchainIndex)
+}
+
 // LoadCode returns the runtime code (located at :code)
 func (t *TrieState) LoadCode() []byte {
 	return t.Get(common.CodeKey)
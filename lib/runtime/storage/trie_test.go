@@ -745,3 +745,35 @@ func BenchmarkNextKey(b *testing.B) {
 		}
 	}
 }
+
+func TestTrieState_OffchainIndex(t *testing.T) {
+	ts := NewTrieState(inmemory_trie.NewEmptyTrie())
+
+	ts.SetOffchainIndex([]byte("key-1"), []byte("value-1"))
+	ts.SetOffchainIndex([]byte("key-2"), nil)
+
+	{
+		ts.StartTransaction()
+		ts.SetOffchainIndex([]byte("key-3"), []byte("value-3"))
+		{
+			ts.StartTransaction()
+			ts.ClearOffchainIndex([]byte("key-1"))
+			ts.CommitTransaction()
+		}
+		ts.CommitTransaction()
+	}
+
+	{
+		ts.StartTransaction()
+		ts.SetOffchainIndex([]byte("key-4"), []byte("value-4"))
+		// the offchain index changes of a rolled back transaction are discarded
+		ts.RollbackTransaction()
+	}
+
+	expected := map[string][]byte{
+		"key-1": nil,
+		"key-2": {},
+		"key-3": []byte("value-3"),
+	}
+	require.Equal(t, expected, ts.OffchainIndexChanges())
+}
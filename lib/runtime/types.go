@@ -22,7 +22,6 @@
 type NodeStorage struct {
 	LocalStorage      BasicStorage
 	PersistentStorage BasicStorage
-	BaseDB            BasicStorage
 }
 
 // SetLocal persists a key and value into LOCAL node storage
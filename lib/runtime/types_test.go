@@ -135,3 +135,35 @@ func TestAllCryptoTypeSignature(t *testing.T) {
 
 	require.True(t, signVerify.Finish())
 }
+
+func TestSignatureBatchRestart(t *testing.T) {
+	signs := generateEd25519Signatures(t, 100)
+	signVerify := crypto.NewSignatureVerifier(log.New(log.SetWriter(io.Discard)))
+
+	invalidSignature := *signs[0]
+	invalidSignature.Msg = []byte("other message")
+
+	signVerify.Start()
+	signVerify.Add(&invalidSignature)
+
+	// restarting discards the batch containing the invalid signature
+	signVerify.Start()
+	for _, sig := range signs {
+		signVerify.Add(sig)
+	}
+	require.True(t, signVerify.Finish())
+	require.False(t, signVerify.IsStarted())
+
+	signVerify.Start()
+	for _, sig := range signs {
+		signVerify.Add(sig)
+	}
+	signVerify.Add(&invalidSignature)
+	require.False(t, signVerify.Finish())
+
+	// the invalid state is reset for the next batch
+	signVerify.Start()
+	require.False(t, signVerify.IsInvalid())
+	signVerify.Add(signs[0])
+	require.True(t, signVerify.Finish())
+}
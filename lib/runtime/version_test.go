@@ -6,6 +6,7 @@
 import (
 	"testing"
 
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -205,3 +206,44 @@ func Test_Version_Scale(t *testing.T) {
 		})
 	}
 }
+
+func Test_Version_OffchainWorkerAPIVersion(t *testing.T) {
+	t.Parallel()
+
+	offchainWorkerAPIName, err := common.Blake2b8([]byte("OffchainWorkerApi"))
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		version    Version
+		apiVersion uint32
+		errWrapped error
+		errMessage string
+	}{
+		"api_found": {
+			version: Version{APIItems: []APIItem{
+				{Name: [8]byte{1}, Ver: 1},
+				{Name: offchainWorkerAPIName, Ver: 2},
+			}},
+			apiVersion: 2,
+		},
+		"api_not_found": {
+			version:    Version{APIItems: []APIItem{{Name: [8]byte{1}, Ver: 1}}},
+			errWrapped: ErrAPINotFound,
+			errMessage: "runtime API not found: OffchainWorkerApi",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			apiVersion, err := testCase.version.OffchainWorkerAPIVersion()
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.apiVersion, apiVersion)
+		})
+	}
+}
@@ -8,6 +8,7 @@
 	"context"
 	"crypto/rand"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -21,6 +22,7 @@
 	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
@@ -113,16 +115,27 @@ func ext_logging_log_version_1(_ context.Context, m api.Module, level int32, tar
 	}
 }
 
-func ext_crypto_ecdsa_generate_version_1(ctx context.Context, m api.Module, _ uint32, _ uint64) uint32 {
-	panic("TODO impl: see https://github.com/ChainSafe/gossamer/issues/3769 ")
+// generateSecretURI returns the secret URI of a key generated by the runtime, which is the given
+// seed if there is one, or a new random mnemonic otherwise.
+func generateSecretURI(seed *[]byte) (string, error) {
+	if seed != nil {
+		return string(*seed), nil
+	}
+	return crypto.NewBIP39Mnemonic()
 }
 
-func ext_crypto_ed25519_generate_version_1(
+func ext_crypto_ecdsa_generate_version_1(
 	ctx context.Context, m api.Module, keyTypeID uint32, seedSpan uint64) uint32 {
+	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
+	if rtCtx == nil {
+		panic("nil runtime context")
+	}
+
 	id, ok := m.Memory().Read(keyTypeID, 4)
 	if !ok {
-		panic("out of range read")
+		panic("read overflow")
 	}
+
 	seedBytes := read(m, seedSpan)
 
 	var seed *[]byte
@@ -132,33 +145,196 @@ func ext_crypto_ed25519_generate_version_1(
 		return 0
 	}
 
-	var kp *ed25519.Keypair
-
-	if seed != nil {
-		kp, err = ed25519.NewKeypairFromMnenomic(string(*seed), "")
-	} else {
-		kp, err = ed25519.GenerateKeypair()
+	suri, err := generateSecretURI(seed)
+	if err != nil {
+		logger.Warnf("cannot generate key: %s", err)
+		return 0
 	}
 
+	kp, err := secp256k1.NewKeypairFromSecretURI(suri)
 	if err != nil {
 		logger.Warnf("cannot generate key: %s", err)
 		return 0
 	}
 
+	err = rtCtx.Keystore.Insert(id, kp, suri)
+	if err != nil {
+		logger.Warnf("failed to insert key for id 0x%x: %s", id, err)
+		return 0
+	}
+
+	ret, err := write(m, rtCtx.Allocator, kp.Public().Encode())
+	if err != nil {
+		logger.Errorf("failed to allocate memory: %s", err)
+		return 0
+	}
+
+	logger.Debug("generated ecdsa keypair with public key: " + kp.Public().Hex())
+
+	ptr, _ := splitPointerSize(ret)
+	return ptr
+}
+
+func ext_crypto_ecdsa_public_keys_version_1(ctx context.Context, m api.Module, keyTypeID uint32) uint64 {
 	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
 	if rtCtx == nil {
 		panic("nil runtime context")
 	}
 
+	id, ok := m.Memory().Read(keyTypeID, 4)
+	if !ok {
+		panic("read overflow")
+	}
+
 	ks, err := rtCtx.Keystore.GetKeystore(id)
 	if err != nil {
 		logger.Warnf("error for id 0x%x: %s", id, err)
+		return mustWrite(m, rtCtx.Allocator, []byte{0})
+	}
+
+	if ks.Type() != crypto.Secp256k1Type && ks.Type() != crypto.UnknownType {
+		logger.Warnf(
+			"keystore type for id 0x%x is %s and not expected secp256k1",
+			id, ks.Type())
+		return mustWrite(m, rtCtx.Allocator, []byte{0})
+	}
+
+	keys := make([][secp256k1.PublicKeyLength]byte, 0, ks.Size())
+	for _, key := range ks.PublicKeys() {
+		if _, ok := key.(*secp256k1.PublicKey); !ok {
+			continue
+		}
+		var encodedKey [secp256k1.PublicKeyLength]byte
+		copy(encodedKey[:], key.Encode())
+		keys = append(keys, encodedKey)
+	}
+
+	encodedKeys, err := scale.Marshal(keys)
+	if err != nil {
+		logger.Errorf("failed to encode public keys: %s", err)
+		return mustWrite(m, rtCtx.Allocator, []byte{0})
+	}
+
+	return mustWrite(m, rtCtx.Allocator, encodedKeys)
+}
+
+// ecdsaSign signs the 32 bytes message hash with the ecdsa key of the given keystore,
+// and returns the scale encoded optional 65 bytes signature.
+func ecdsaSign(rtCtx *runtime.Context, id, pubKeyData, messageHash []byte) (encodedSignature []byte) {
+	pubKey, err := secp256k1.NewPublicKey(pubKeyData)
+	if err != nil {
+		logger.Errorf("failed to get public key: %s", err)
+		return noneEncoded
+	}
+
+	ks, err := rtCtx.Keystore.GetKeystore(id)
+	if err != nil {
+		logger.Warnf("error for id 0x%x: %s", id, err)
+		return noneEncoded
+	}
+
+	signingKey := ks.GetKeypair(pubKey)
+	if signingKey == nil {
+		logger.Error("could not find public key " + pubKey.Hex() + " in keystore")
+		return noneEncoded
+	}
+
+	sig, err := signingKey.Sign(messageHash)
+	if err != nil {
+		logger.Errorf("could not sign message: %s", err)
+		return noneEncoded
+	}
+
+	var fixedSig [secp256k1.SignatureLengthRecovery]byte
+	copy(fixedSig[:], sig)
+	return scale.MustMarshal(&fixedSig)
+}
+
+func ext_crypto_ecdsa_sign_version_1(ctx context.Context, m api.Module, keyTypeID, key uint32, msg uint64) uint64 {
+	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
+	if rtCtx == nil {
+		panic("nil runtime context")
+	}
+
+	id, ok := m.Memory().Read(keyTypeID, 4)
+	if !ok {
+		panic("read overflow")
+	}
+
+	pubKeyData, ok := m.Memory().Read(key, secp256k1.PublicKeyLength)
+	if !ok {
+		panic("read overflow")
+	}
+
+	hash, err := common.Blake2bHash(read(m, msg))
+	if err != nil {
+		logger.Errorf("failed to hash message: %s", err)
+		return mustWrite(m, rtCtx.Allocator, noneEncoded)
+	}
+
+	return mustWrite(m, rtCtx.Allocator, ecdsaSign(rtCtx, id, pubKeyData, hash[:]))
+}
+
+func ext_crypto_ecdsa_sign_prehashed_version_1(
+	ctx context.Context, m api.Module, keyTypeID, key, msg uint32) uint64 {
+	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
+	if rtCtx == nil {
+		panic("nil runtime context")
+	}
+
+	id, ok := m.Memory().Read(keyTypeID, 4)
+	if !ok {
+		panic("read overflow")
+	}
+
+	pubKeyData, ok := m.Memory().Read(key, secp256k1.PublicKeyLength)
+	if !ok {
+		panic("read overflow")
+	}
+
+	messageHash, ok := m.Memory().Read(msg, secp256k1.MessageLength)
+	if !ok {
+		panic("read overflow")
+	}
+
+	return mustWrite(m, rtCtx.Allocator, ecdsaSign(rtCtx, id, pubKeyData, messageHash))
+}
+
+func ext_crypto_ed25519_generate_version_1(
+	ctx context.Context, m api.Module, keyTypeID uint32, seedSpan uint64) uint32 {
+	id, ok := m.Memory().Read(keyTypeID, 4)
+	if !ok {
+		panic("out of range read")
+	}
+	seedBytes := read(m, seedSpan)
+
+	var seed *[]byte
+	err := scale.Unmarshal(seedBytes, &seed)
+	if err != nil {
+		logger.Warnf("cannot generate key: %s", err)
 		return 0
 	}
 
-	err = ks.Insert(kp)
+	suri, err := generateSecretURI(seed)
 	if err != nil {
-		logger.Warnf("failed to insert key: %s", err)
+		logger.Warnf("cannot generate key: %s", err)
+		return 0
+	}
+
+	kp, err := ed25519.NewKeypairFromSecretURI(suri)
+	if err != nil {
+		logger.Warnf("cannot generate key: %s", err)
+		return 0
+	}
+
+	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
+	if rtCtx == nil {
+		panic("nil runtime context")
+	}
+
+	err = rtCtx.Keystore.Insert(id, kp, suri)
+	if err != nil {
+		logger.Warnf("failed to insert key for id 0x%x: %s", id, err)
 		return 0
 	}
 
@@ -518,27 +694,21 @@ func ext_crypto_sr25519_generate_version_1(
 		return 0
 	}
 
-	var kp *sr25519.Keypair
-	if seed != nil {
-		kp, err = sr25519.NewKeypairFromMnenomic(string(*seed), "")
-	} else {
-		kp, err = sr25519.GenerateKeypair()
-	}
-
+	suri, err := generateSecretURI(seed)
 	if err != nil {
 		logger.Tracef("cannot generate key: %s", err)
 		panic(err)
 	}
 
-	ks, err := rtCtx.Keystore.GetKeystore(id)
+	kp, err := sr25519.NewKeypairFromSecretURI(suri)
 	if err != nil {
-		logger.Warnf("error for id "+common.BytesToHex(id)+": %s", err)
-		return 0
+		logger.Tracef("cannot generate key: %s", err)
+		panic(err)
 	}
 
-	err = ks.Insert(kp)
+	err = rtCtx.Keystore.Insert(id, kp, suri)
 	if err != nil {
-		logger.Warnf("failed to insert key: %s", err)
+		logger.Warnf("failed to insert key for id "+common.BytesToHex(id)+": %s", err)
 		return 0
 	}
 
@@ -663,13 +833,6 @@ func ext_crypto_sr25519_sign_version_1(ctx context.Context, m api.Module, keyTyp
 }
 
 func ext_crypto_sr25519_verify_version_1(ctx context.Context, m api.Module, sig uint32, msg uint64, key uint32) uint32 {
-	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
-	if rtCtx == nil {
-		panic("nil runtime context")
-	}
-
-	sigVerifier := rtCtx.SigVerifier
-
 	message := read(m, msg)
 	signature, ok := m.Memory().Read(sig, 64)
 	if !ok {
@@ -690,17 +853,8 @@ func ext_crypto_sr25519_verify_version_1(ctx context.Context, m api.Module, sig
 		"pub=%s message=0x%x signature=0x%x",
 		pub.Hex(), message, signature)
 
-	if sigVerifier.IsStarted() {
-		signature := crypto.SignatureInfo{
-			PubKey:     pub.Encode(),
-			Sign:       signature,
-			Msg:        message,
-			VerifyFunc: sr25519.VerifySignature,
-		}
-		sigVerifier.Add(&signature)
-		return 1
-	}
-
+	// the deprecated verification never fails, so the signature
+	// is not added to the batch signature verification.
 	ok, err = pub.VerifyDeprecated(message, signature)
 	if err != nil || !ok {
 		message := validateSignatureFail
@@ -777,14 +931,37 @@ func ext_crypto_sr25519_verify_version_2(ctx context.Context, m api.Module, sig
 	return 1
 }
 
-func ext_crypto_start_batch_verify_version_1(_ context.Context, _ api.Module) {
-	// TODO: fix and re-enable signature verification (#1405)
-	// beginBatchVerify(context)
+func ext_crypto_start_batch_verify_version_1(ctx context.Context, _ api.Module) {
+	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
+	if rtCtx == nil {
+		panic("nil runtime context")
+	}
+
+	sigVerifier := rtCtx.SigVerifier
+	if sigVerifier.IsStarted() {
+		logger.Error("batch signature verification already started, discarding the previous batch")
+	}
+
+	sigVerifier.Start()
 }
 
-func ext_crypto_finish_batch_verify_version_1(_ context.Context, _ api.Module) uint32 {
-	// TODO: fix and re-enable signature verification (#1405)
-	// return finishBatchVerify(context)
+func ext_crypto_finish_batch_verify_version_1(ctx context.Context, _ api.Module) uint32 {
+	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
+	if rtCtx == nil {
+		panic("nil runtime context")
+	}
+
+	sigVerifier := rtCtx.SigVerifier
+	if !sigVerifier.IsStarted() {
+		logger.Error("finishing batch signature verification which was not started")
+		return 0
+	}
+
+	if !sigVerifier.Finish() {
+		logger.Error("batch signature verification failed")
+		return 0
+	}
+
 	return 1
 }
 
@@ -1632,10 +1809,9 @@ func ext_offchain_index_set_version_1(ctx context.Context, m api.Module, keySpan
 	cp := make([]byte, len(newValue))
 	copy(cp, newValue)
 
-	err := rtCtx.NodeStorage.BaseDB.Put(storageKey, cp)
-	if err != nil {
-		logger.Errorf("failed to set value in raw storage: %s", err)
-	}
+	// the offchain index changes are recorded with the block state changes, and are
+	// written to the offchain storage when the block is finalised
+	rtCtx.Storage.SetOffchainIndex(storageKey, cp)
 }
 
 //export ext_offchain_index_clear_version_1
@@ -1649,10 +1825,7 @@ func ext_offchain_index_clear_version_1(ctx context.Context, m api.Module, keySp
 	}
 
 	storageKey := read(m, keySpan)
-	err := rtCtx.NodeStorage.BaseDB.Del(storageKey)
-	if err != nil {
-		logger.Errorf("failed to set value in raw storage: %s", err)
-	}
+	rtCtx.Storage.ClearOffchainIndex(storageKey)
 }
 
 func ext_offchain_local_storage_clear_version_1(ctx context.Context, m api.Module, kind uint32, key uint64) {
@@ -1943,6 +2116,160 @@ func ext_offchain_http_request_add_header_version_1(
 	return ptr
 }
 
+// readHTTPDeadline reads the optional deadline of an offchain HTTP operation,
+// which is a timestamp in milliseconds.
+func readHTTPDeadline(m api.Module, deadlineSpan uint64) (*time.Time, error) {
+	var deadlineMillis *uint64
+	err := scale.Unmarshal(read(m, deadlineSpan), &deadlineMillis)
+	if err != nil {
+		return nil, fmt.Errorf("decoding deadline: %w", err)
+	}
+
+	if deadlineMillis == nil {
+		return nil, nil
+	}
+
+	deadline := time.UnixMilli(int64(*deadlineMillis))
+	return &deadline, nil
+}
+
+// writeHTTPResult writes the SCALE encoded result of an offchain HTTP operation,
+// where the error is an offchain.HTTPError.
+func writeHTTPResult(m api.Module, allocator runtime.Allocator, okValue any, err error) (pointerSize uint64) {
+	var httpErr offchain.HTTPError
+	result := scale.NewResult(okValue, httpErr)
+	if err != nil {
+		if !errors.As(err, &httpErr) {
+			httpErr = offchain.HTTPErrorIO
+		}
+		err = result.Set(scale.Err, httpErr)
+	} else {
+		err = result.Set(scale.OK, okValue)
+	}
+	if err != nil {
+		logger.Errorf("failed to set the result data: %s", err)
+		return uint64(0)
+	}
+
+	enc, err := scale.Marshal(result)
+	if err != nil {
+		logger.Errorf("failed to scale marshal the result: %s", err)
+		return uint64(0)
+	}
+
+	ptr, err := write(m, allocator, enc)
+	if err != nil {
+		logger.Errorf("failed to allocate result on memory: %s", err)
+		return uint64(0)
+	}
+
+	return ptr
+}
+
+func ext_offchain_http_request_write_body_version_1(
+	ctx context.Context, m api.Module, reqID uint32, chunkSpan, deadlineSpan uint64) (pointerSize uint64) {
+	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
+	if rtCtx == nil {
+		panic("nil runtime context")
+	}
+
+	chunk := read(m, chunkSpan)
+	deadline, err := readHTTPDeadline(m, deadlineSpan)
+	if err != nil {
+		logger.Errorf("failed to read request deadline: %s", err)
+		return writeHTTPResult(m, rtCtx.Allocator, nil, offchain.HTTPErrorInvalid)
+	}
+
+	err = rtCtx.OffchainHTTPSet.WriteBody(int16(reqID), chunk, deadline)
+	if err != nil {
+		logger.Errorf("failed to write request body: %s", err)
+	}
+
+	return writeHTTPResult(m, rtCtx.Allocator, nil, err)
+}
+
+func ext_offchain_http_response_wait_version_1(
+	ctx context.Context, m api.Module, idsSpan, deadlineSpan uint64) (pointerSize uint64) {
+	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
+	if rtCtx == nil {
+		panic("nil runtime context")
+	}
+
+	// the request ids are encoded as u16
+	var reqIDs []uint16
+	err := scale.Unmarshal(read(m, idsSpan), &reqIDs)
+	if err != nil {
+		panic(fmt.Sprintf("decoding request ids: %s", err))
+	}
+
+	deadline, err := readHTTPDeadline(m, deadlineSpan)
+	if err != nil {
+		panic(err)
+	}
+
+	ids := make([]int16, len(reqIDs))
+	for i, reqID := range reqIDs {
+		ids[i] = int16(reqID)
+	}
+
+	statuses := rtCtx.OffchainHTTPSet.Wait(ids, deadline)
+	enc, err := scale.Marshal(statuses)
+	if err != nil {
+		panic(fmt.Sprintf("encoding request statuses: %s", err))
+	}
+
+	return mustWrite(m, rtCtx.Allocator, enc)
+}
+
+func ext_offchain_http_response_headers_version_1(
+	ctx context.Context, m api.Module, reqID uint32) (pointerSize uint64) {
+	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
+	if rtCtx == nil {
+		panic("nil runtime context")
+	}
+
+	headers := rtCtx.OffchainHTTPSet.ResponseHeaders(int16(reqID))
+	if headers == nil {
+		headers = []offchain.HTTPHeader{}
+	}
+
+	enc, err := scale.Marshal(headers)
+	if err != nil {
+		panic(fmt.Sprintf("encoding response headers: %s", err))
+	}
+
+	return mustWrite(m, rtCtx.Allocator, enc)
+}
+
+func ext_offchain_http_response_read_body_version_1(
+	ctx context.Context, m api.Module, reqID uint32, bufferSpan, deadlineSpan uint64) (pointerSize uint64) {
+	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
+	if rtCtx == nil {
+		panic("nil runtime context")
+	}
+
+	bufferPtr, bufferSize := splitPointerSize(bufferSpan)
+	deadline, err := readHTTPDeadline(m, deadlineSpan)
+	if err != nil {
+		logger.Errorf("failed to read response deadline: %s", err)
+		return writeHTTPResult(m, rtCtx.Allocator, uint32(0), offchain.HTTPErrorInvalid)
+	}
+
+	buffer := make([]byte, bufferSize)
+	n, err := rtCtx.OffchainHTTPSet.ReadBody(int16(reqID), buffer, deadline)
+	if err != nil {
+		logger.Errorf("failed to read response body: %s", err)
+		return writeHTTPResult(m, rtCtx.Allocator, uint32(0), err)
+	}
+
+	ok := m.Memory().Write(bufferPtr, buffer[:n])
+	if !ok {
+		panic("write overflow")
+	}
+
+	return writeHTTPResult(m, rtCtx.Allocator, uint32(n), nil)
+}
+
 func storageAppend(storage runtime.Storage, key, valueToAppend []byte) (err error) {
 	// this function assumes the item in storage is a SCALE encoded array of items
 	// the valueToAppend is a new item, so it appends the item and increases the length prefix by 1
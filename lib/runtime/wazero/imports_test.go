@@ -7,7 +7,9 @@
 	"bytes"
 	"context"
 	"encoding/binary"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"sort"
 	"strings"
@@ -24,6 +26,7 @@
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/runtime/allocator"
+	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
 	"github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/ChainSafe/gossamer/pkg/trie"
@@ -47,12 +50,7 @@
 func Test_ext_offchain_index_clear_version_1(t *testing.T) {
 	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
 
-	err := inst.Context.NodeStorage.BaseDB.Put(testKey, testValue)
-	require.NoError(t, err)
-
-	value, err := inst.Context.NodeStorage.BaseDB.Get(testKey)
-	require.NoError(t, err)
-	require.Equal(t, testValue, value)
+	inst.Context.Storage.SetOffchainIndex(testKey, testValue)
 
 	encKey, err := scale.Marshal(testKey)
 	require.NoError(t, err)
@@ -60,8 +58,8 @@ func Test_ext_offchain_index_clear_version_1(t *testing.T) {
 	_, err = inst.Exec("rtm_ext_offchain_index_clear_version_1", encKey)
 	require.NoError(t, err)
 
-	_, err = inst.Context.NodeStorage.BaseDB.Get(testKey)
-	require.ErrorIs(t, err, database.ErrNotFound)
+	changes := inst.Context.Storage.(*storage.TrieState).OffchainIndexChanges()
+	require.Equal(t, map[string][]byte{string(testKey): nil}, changes)
 }
 
 func Test_ext_crypto_ed25519_generate_version_1(t *testing.T) {
@@ -206,6 +204,43 @@ func Test_ext_crypto_ed25519_verify_version_1(t *testing.T) {
 	require.NotNil(t, read)
 }
 
+func Test_ext_crypto_batch_verify_version_1(t *testing.T) {
+	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
+	inst.Context.Allocator = allocator.NewFreeingBumpHeapAllocator(0)
+	ctx := context.WithValue(context.Background(), runtimeContextKey, inst.Context)
+	writeArg := func(data []byte) uint32 {
+		ptr, _ := splitPointerSize(mustWrite(inst.Module, inst.Context.Allocator, data))
+		return ptr
+	}
+
+	kp, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+	msgData := []byte("Hello world!")
+	sign, err := kp.Private().Sign(msgData)
+	require.NoError(t, err)
+
+	keyPtr := writeArg(kp.Public().Encode())
+	signPtr := writeArg(sign)
+	msgArg := mustWrite(inst.Module, inst.Context.Allocator, msgData)
+	otherMsgArg := mustWrite(inst.Module, inst.Context.Allocator, []byte("other message"))
+
+	// finishing a batch which was not started fails
+	require.Equal(t, uint32(0), ext_crypto_finish_batch_verify_version_1(ctx, inst.Module))
+
+	ext_crypto_start_batch_verify_version_1(ctx, inst.Module)
+	require.Equal(t, uint32(1), ext_crypto_ed25519_verify_version_1(ctx, inst.Module, signPtr, msgArg, keyPtr))
+	require.Equal(t, uint32(1), ext_crypto_finish_batch_verify_version_1(ctx, inst.Module))
+
+	// invalid signatures are only reported when finishing the batch
+	ext_crypto_start_batch_verify_version_1(ctx, inst.Module)
+	require.Equal(t, uint32(1), ext_crypto_ed25519_verify_version_1(ctx, inst.Module, signPtr, msgArg, keyPtr))
+	require.Equal(t, uint32(1), ext_crypto_ed25519_verify_version_1(ctx, inst.Module, signPtr, otherMsgArg, keyPtr))
+	require.Equal(t, uint32(0), ext_crypto_finish_batch_verify_version_1(ctx, inst.Module))
+
+	// signatures are verified immediately outside of a batch
+	require.Equal(t, uint32(0), ext_crypto_ed25519_verify_version_1(ctx, inst.Module, signPtr, otherMsgArg, keyPtr))
+}
+
 func Test_ext_crypto_secp256k1_ecdsa_recover_version_1(t *testing.T) {
 	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
 
@@ -382,6 +417,63 @@ func Test_ext_crypto_ecdsa_verify_version_2_Table(t *testing.T) {
 	}
 }
 
+func Test_ext_crypto_ecdsa_generate_sign_and_public_keys(t *testing.T) {
+	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
+	inst.Context.Allocator = allocator.NewFreeingBumpHeapAllocator(0)
+	ctx := context.WithValue(context.Background(), runtimeContextKey, inst.Context)
+	writeArg := func(data []byte) uint64 {
+		return mustWrite(inst.Module, inst.Context.Allocator, data)
+	}
+
+	idPtr, _ := splitPointerSize(writeArg([]byte(keystore.BeefName)))
+
+	mnemonic, err := crypto.NewBIP39Mnemonic()
+	require.NoError(t, err)
+	seed := []byte(mnemonic)
+	pubKeyPtr := ext_crypto_ecdsa_generate_version_1(ctx, inst.Module, idPtr, writeArg(scale.MustMarshal(&seed)))
+	require.NotZero(t, pubKeyPtr)
+
+	kp, err := secp256k1.NewKeypairFromMnenomic(mnemonic, "")
+	require.NoError(t, err)
+	pubKeyData, ok := inst.Module.Memory().Read(pubKeyPtr, secp256k1.PublicKeyLength)
+	require.True(t, ok)
+	require.Equal(t, kp.Public().Encode(), pubKeyData)
+
+	ret := ext_crypto_ecdsa_public_keys_version_1(ctx, inst.Module, idPtr)
+	var pubKeys [][secp256k1.PublicKeyLength]byte
+	err = scale.Unmarshal(read(inst.Module, ret), &pubKeys)
+	require.NoError(t, err)
+	require.Len(t, pubKeys, 1)
+	require.Equal(t, pubKeyData, pubKeys[0][:])
+
+	msgData := []byte("Hello world!")
+	ret = ext_crypto_ecdsa_sign_version_1(ctx, inst.Module, idPtr, pubKeyPtr, writeArg(msgData))
+	var signature *[secp256k1.SignatureLengthRecovery]byte
+	err = scale.Unmarshal(read(inst.Module, ret), &signature)
+	require.NoError(t, err)
+	require.NotNil(t, signature)
+
+	hash, err := common.Blake2bHash(msgData)
+	require.NoError(t, err)
+	ok, err = kp.Public().Verify(hash[:], signature[:secp256k1.SignatureLength])
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	hashPtr, _ := splitPointerSize(writeArg(hash[:]))
+	ret = ext_crypto_ecdsa_sign_prehashed_version_1(ctx, inst.Module, idPtr, pubKeyPtr, hashPtr)
+	var prehashedSignature *[secp256k1.SignatureLengthRecovery]byte
+	err = scale.Unmarshal(read(inst.Module, ret), &prehashedSignature)
+	require.NoError(t, err)
+	require.Equal(t, signature, prehashedSignature)
+
+	// signing with an unknown key returns none
+	other, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+	otherPtr, _ := splitPointerSize(writeArg(other.Public().Encode()))
+	ret = ext_crypto_ecdsa_sign_prehashed_version_1(ctx, inst.Module, idPtr, otherPtr, hashPtr)
+	require.Equal(t, noneEncoded, read(inst.Module, ret))
+}
+
 func Test_ext_crypto_sr25519_generate_version_1(t *testing.T) {
 	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
 
@@ -1875,6 +1967,68 @@ func Test_ext_offchain_http_request_add_header(t *testing.T) {
 	}
 }
 
+func Test_ext_offchain_http_request_write_body_and_read_response(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		w.Header().Set("X-Request-Body", string(body))
+		_, err = w.Write([]byte("pong"))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
+	inst.Context.Allocator = allocator.NewFreeingBumpHeapAllocator(0)
+	ctx := context.WithValue(context.Background(), runtimeContextKey, inst.Context)
+	writeArg := func(data []byte) uint64 {
+		return mustWrite(inst.Module, inst.Context.Allocator, data)
+	}
+
+	reqID, err := inst.Context.OffchainHTTPSet.StartRequest(http.MethodPost, server.URL)
+	require.NoError(t, err)
+
+	deadline := uint64(time.Now().Add(time.Minute).UnixMilli())
+	deadlineArg := writeArg(scale.MustMarshal(&deadline))
+	noDeadlineArg := writeArg(scale.MustMarshal((*uint64)(nil)))
+
+	ret := ext_offchain_http_request_write_body_version_1(
+		ctx, inst.Module, uint32(reqID), writeArg([]byte("ping")), deadlineArg)
+	require.Equal(t, []byte{0}, read(inst.Module, ret))
+
+	// an empty chunk finishes the request body
+	ret = ext_offchain_http_request_write_body_version_1(
+		ctx, inst.Module, uint32(reqID), newPointerSize(0, 0), noDeadlineArg)
+	require.Equal(t, []byte{0}, read(inst.Module, ret))
+
+	idsArg := writeArg(scale.MustMarshal([]uint16{uint16(reqID), uint16(reqID) + 1}))
+	ret = ext_offchain_http_response_wait_version_1(ctx, inst.Module, idsArg, deadlineArg)
+	// finished with status 200 and invalid
+	require.Equal(t, []byte{2 << 2, 3, 200, 0, 2}, read(inst.Module, ret))
+
+	ret = ext_offchain_http_response_headers_version_1(ctx, inst.Module, uint32(reqID))
+	var headers []offchain.HTTPHeader
+	err = scale.Unmarshal(read(inst.Module, ret), &headers)
+	require.NoError(t, err)
+	require.Contains(t, headers, offchain.HTTPHeader{Name: []byte("X-Request-Body"), Value: []byte("ping")})
+
+	bufferArg := writeArg(make([]byte, 16))
+	bufferPtr, _ := splitPointerSize(bufferArg)
+	ret = ext_offchain_http_response_read_body_version_1(ctx, inst.Module, uint32(reqID), bufferArg, deadlineArg)
+	require.Equal(t, []byte{0, 4, 0, 0, 0}, read(inst.Module, ret))
+	body, ok := inst.Module.Memory().Read(bufferPtr, 4)
+	require.True(t, ok)
+	require.Equal(t, []byte("pong"), body)
+
+	// reading zero bytes means the whole body was read
+	ret = ext_offchain_http_response_read_body_version_1(ctx, inst.Module, uint32(reqID), bufferArg, deadlineArg)
+	require.Equal(t, []byte{0, 0, 0, 0, 0}, read(inst.Module, ret))
+
+	// and the request is removed
+	ret = ext_offchain_http_response_read_body_version_1(ctx, inst.Module, uint32(reqID), bufferArg, deadlineArg)
+	require.Equal(t, []byte{1, byte(offchain.HTTPErrorInvalid)}, read(inst.Module, ret))
+}
+
 func Test_ext_storage_clear_version_1(t *testing.T) {
 	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
 
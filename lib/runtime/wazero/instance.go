@@ -545,6 +545,30 @@ func newRuntime(ctx context.Context,
 		).
 		Export("ext_offchain_http_request_add_header_version_1").
 		NewFunctionBuilder().
+		WithGoModuleFunction(
+			tripleArgWithReturnFn(ext_offchain_http_request_write_body_version_1),
+			[]api.ValueType{i32, i64, i64}, []api.ValueType{i64},
+		).
+		Export("ext_offchain_http_request_write_body_version_1").
+		NewFunctionBuilder().
+		WithGoModuleFunction(
+			doubleArgWithReturnFn(ext_offchain_http_response_wait_version_1),
+			[]api.ValueType{i64, i64}, []api.ValueType{i64},
+		).
+		Export("ext_offchain_http_response_wait_version_1").
+		NewFunctionBuilder().
+		WithGoModuleFunction(
+			singleArgWithReturnFn(ext_offchain_http_response_headers_version_1),
+			[]api.ValueType{i32}, []api.ValueType{i64},
+		).
+		Export("ext_offchain_http_response_headers_version_1").
+		NewFunctionBuilder().
+		WithGoModuleFunction(
+			tripleArgWithReturnFn(ext_offchain_http_response_read_body_version_1),
+			[]api.ValueType{i32, i64, i64}, []api.ValueType{i64},
+		).
+		Export("ext_offchain_http_response_read_body_version_1").
+		NewFunctionBuilder().
 		WithGoModuleFunction(
 			doubleArgFn(ext_storage_append_version_1),
 			[]api.ValueType{i64, i64}, []api.ValueType{},
@@ -640,6 +664,24 @@ func newRuntime(ctx context.Context,
 			[]api.ValueType{i32, i64}, []api.ValueType{i32},
 		).
 		Export("ext_crypto_ecdsa_generate_version_1").
+		NewFunctionBuilder().
+		WithGoModuleFunction(
+			singleArgWithReturnFn(ext_crypto_ecdsa_public_keys_version_1),
+			[]api.ValueType{i32}, []api.ValueType{i64},
+		).
+		Export("ext_crypto_ecdsa_public_keys_version_1").
+		NewFunctionBuilder().
+		WithGoModuleFunction(
+			tripleArgWithReturnFn(ext_crypto_ecdsa_sign_version_1),
+			[]api.ValueType{i32, i32, i64}, []api.ValueType{i64},
+		).
+		Export("ext_crypto_ecdsa_sign_version_1").
+		NewFunctionBuilder().
+		WithGoModuleFunction(
+			tripleArgWithReturnFn(ext_crypto_ecdsa_sign_prehashed_version_1),
+			[]api.ValueType{i32, i32, i32}, []api.ValueType{i64},
+		).
+		Export("ext_crypto_ecdsa_sign_prehashed_version_1").
 		Compile(ctx)
 
 	if err != nil {
@@ -769,6 +811,9 @@ func (i *Instance) Exec(function string, data []byte) ([]byte, error) {
 		return nil, fmt.Errorf("%w: %s", ErrExportFunctionNotFound, function)
 	}
 
+	// discard any batch signature verification left unfinished by the call
+	defer i.Context.SigVerifier.Reset()
+
 	ctx := context.WithValue(context.Background(), runtimeContextKey, i.Context)
 	values, err := runtimeFunc.Call(ctx, api.EncodeU32(inputPtr), api.EncodeU32(dataLength))
 	if err != nil {
@@ -1125,11 +1170,147 @@ func (in *Instance) GrandpaSubmitReportEquivocationUnsignedExtrinsic(
 func (*Instance) RandomSeed() {
 	panic("unimplemented")
 }
-func (*Instance) OffchainWorker() {
-	panic("unimplemented")
+
+// OffchainWorker runs the offchain worker of the runtime for the given block header.
+// The first version of the OffchainWorkerApi takes the block number only.
+func (in *Instance) OffchainWorker(header *types.Header) error {
+	version, err := in.Version()
+	if err != nil {
+		return fmt.Errorf("getting runtime version: %w", err)
+	}
+
+	apiVersion, err := version.OffchainWorkerAPIVersion()
+	if err != nil {
+		return fmt.Errorf("getting offchain worker API version: %w", err)
+	}
+
+	var encodedArgs []byte
+	if apiVersion < 2 {
+		encodedArgs, err = scale.Marshal(uint32(header.Number))
+	} else {
+		encodedArgs, err = scale.Marshal(*header)
+	}
+	if err != nil {
+		return fmt.Errorf("encoding offchain worker arguments: %w", err)
+	}
+
+	_, err = in.Exec(runtime.OffchainWorkerAPIOffchainWorker, encodedArgs)
+	return err
 }
-func (*Instance) GenerateSessionKeys() {
-	panic("unimplemented")
+
+// GenerateSessionKeys generates new session keys from a random seed. The private keys are
+// stored in the keystore of the instance, and the SCALE encoded public keys are returned.
+func (in *Instance) GenerateSessionKeys() ([]byte, error) {
+	var seed *[]byte
+	encodedSeed, err := scale.Marshal(seed)
+	if err != nil {
+		return nil, fmt.Errorf("encoding seed: %w", err)
+	}
+
+	ret, err := in.Exec(runtime.GenerateSessionKeys, encodedSeed)
+	if err != nil {
+		return nil, err
+	}
+
+	var publicKeys []byte
+	err = scale.Unmarshal(ret, &publicKeys)
+	if err != nil {
+		return nil, fmt.Errorf("decoding session public keys: %w", err)
+	}
+	return publicKeys, nil
+}
+
+// GenesisBuilderBuildState builds the genesis storage of the runtime from the given JSON
+// genesis config, writing it to the storage of the instance.
+func (in *Instance) GenesisBuilderBuildState(config []byte) error {
+	encodedConfig, err := scale.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("encoding genesis config: %w", err)
+	}
+
+	ret, err := in.Exec(runtime.GenesisBuilderBuildState, encodedConfig)
+	if errors.Is(err, ErrExportFunctionNotFound) {
+		ret, err = in.Exec(runtime.GenesisBuilderBuildConfig, encodedConfig)
+	}
+	if err != nil {
+		return err
+	}
+
+	result := scale.NewResult(nil, "")
+	err = scale.Unmarshal(ret, &result)
+	if err != nil {
+		return fmt.Errorf("decoding build state result: %w", err)
+	}
+
+	_, err = result.Unwrap()
+	if err != nil {
+		return fmt.Errorf("building genesis state: %w", err)
+	}
+	return nil
+}
+
+// GenesisBuilderGetPreset returns the JSON genesis config patch of the preset with the given
+// name, or the full default genesis config of the runtime if name is nil. It returns nil
+// if the runtime has no preset with the given name.
+func (in *Instance) GenesisBuilderGetPreset(name *string) ([]byte, error) {
+	var id *[]byte
+	if name != nil {
+		nameBytes := []byte(*name)
+		id = &nameBytes
+	}
+
+	encodedID, err := scale.Marshal(id)
+	if err != nil {
+		return nil, fmt.Errorf("encoding preset name: %w", err)
+	}
+
+	ret, err := in.Exec(runtime.GenesisBuilderGetPreset, encodedID)
+	if errors.Is(err, ErrExportFunctionNotFound) && name == nil {
+		ret, err = in.Exec(runtime.GenesisBuilderCreateDefaultConfig, []byte{})
+		if err != nil {
+			return nil, err
+		}
+
+		var config []byte
+		err = scale.Unmarshal(ret, &config)
+		if err != nil {
+			return nil, fmt.Errorf("decoding default config: %w", err)
+		}
+		return config, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var preset *[]byte
+	err = scale.Unmarshal(ret, &preset)
+	if err != nil {
+		return nil, fmt.Errorf("decoding preset: %w", err)
+	}
+
+	if preset == nil {
+		return nil, nil
+	}
+	return *preset, nil
+}
+
+// GenesisBuilderPresetNames returns the names of the genesis config presets of the runtime.
+func (in *Instance) GenesisBuilderPresetNames() ([]string, error) {
+	ret, err := in.Exec(runtime.GenesisBuilderPresetNames, []byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids [][]byte
+	err = scale.Unmarshal(ret, &ids)
+	if err != nil {
+		return nil, fmt.Errorf("decoding preset names: %w", err)
+	}
+
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = string(id)
+	}
+	return names, nil
 }
 
 // GetCodeHash returns the code of the instance
@@ -224,10 +224,6 @@ func TestWestendRuntime_ValidateTransaction(t *testing.T) {
 		LogLvl:  log.Critical,
 	}
 
-	nodeStorage := runtime.NodeStorage{}
-	nodeStorage.BaseDB = runtime.NewInMemoryDB(t)
-	cfg.NodeStorage = nodeStorage
-
 	rt, err := NewRuntimeFromGenesis(cfg)
 	require.NoError(t, err)
 
@@ -993,7 +989,6 @@ func TestInstance_ExecuteBlock_PaseoRuntime_PaseoBlock1008649(t *testing.T) {
 		NodeStorage: runtime.NodeStorage{
 			LocalStorage:      db,
 			PersistentStorage: db,
-			BaseDB:            db,
 		},
 	}
 
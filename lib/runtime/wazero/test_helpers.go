@@ -54,7 +54,6 @@ func NewTestInstance(t *testing.T, targetRuntime string, opts ...TestInstanceOpt
 		NodeStorage: runtime.NodeStorage{
 			LocalStorage:      runtime.NewInMemoryDB(t),
 			PersistentStorage: runtime.NewInMemoryDB(t), // we're using a local storage here since this is a test runtime
-			BaseDB:            runtime.NewInMemoryDB(t), // we're using a local storage here since this is a test runtime
 		},
 		Network:     new(runtime.TestRuntimeNetwork),
 		Transaction: mocks.NewMockTransactionState(ctrl),
@@ -4,6 +4,7 @@
 package transaction
 
 import (
+	"bytes"
 	"sync"
 
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -17,16 +18,27 @@
 	Help:      "total number of transactions in ready pool",
 })
 
-// Pool represents the transaction pool
+// poolItem is a transaction of the pool, together with
+// the block number after which it expires and its sender.
+type poolItem struct {
+	data      *ValidTransaction
+	validTill uint
+	sender    string
+}
+
+// Pool represents the transaction pool, holding the future transactions
+// which require tags not yet provided by the ready transactions, and the
+// transactions awaiting revalidation.
 type Pool struct {
-	transactions map[common.Hash]*ValidTransaction
+	transactions map[common.Hash]*poolItem
+	blockNumber  uint
 	mu           sync.RWMutex
 }
 
 // NewPool returns a new empty Pool
 func NewPool() *Pool {
 	return &Pool{
-		transactions: make(map[common.Hash]*ValidTransaction),
+		transactions: make(map[common.Hash]*poolItem),
 	}
 }
 
@@ -35,7 +47,11 @@ func (p *Pool) Get(extHash common.Hash) *ValidTransaction {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	return p.transactions[extHash]
+	item, ok := p.transactions[extHash]
+	if !ok {
+		return nil
+	}
+	return item.data
 }
 
 // Transactions returns all the transactions in the pool
@@ -46,8 +62,8 @@ func (p *Pool) Transactions() []*ValidTransaction {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
-	for _, tx := range p.transactions {
-		txs[i] = tx
+	for _, item := range p.transactions {
+		txs[i] = item.data
 		i++
 	}
 	return txs
@@ -58,7 +74,11 @@ func (p *Pool) Insert(tx *ValidTransaction) common.Hash {
 	hash := tx.Extrinsic.Hash()
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	p.transactions[hash] = tx
+	p.transactions[hash] = &poolItem{
+		data:      tx,
+		validTill: validTill(p.blockNumber, tx.Validity.Longevity),
+		sender:    ExtrinsicSender(tx.Extrinsic),
+	}
 	transactionPoolGauge.Set(float64(len(p.transactions)))
 	return hash
 }
@@ -78,3 +98,51 @@ func (p *Pool) Len() int {
 
 	return len(p.transactions)
 }
+
+// ProvidedBy returns the transactions in the pool providing the given tag.
+func (p *Pool) ProvidedBy(tag []byte) (txs []*ValidTransaction) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, item := range p.transactions {
+		for _, provided := range item.data.Validity.Provides {
+			if bytes.Equal(provided, tag) {
+				txs = append(txs, item.data)
+				break
+			}
+		}
+	}
+	return txs
+}
+
+// SenderCount returns the number of transactions in the pool signed by the given sender.
+func (p *Pool) SenderCount(sender string) (count int) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, item := range p.transactions {
+		if item.sender == sender {
+			count++
+		}
+	}
+	return count
+}
+
+// PruneExpired sets the current block number of the pool and removes
+// the transactions whose longevity expired at this block number.
+func (p *Pool) PruneExpired(blockNumber uint) (expired []*ValidTransaction) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.blockNumber = blockNumber
+	for hash, item := range p.transactions {
+		if item.validTill >= blockNumber {
+			continue
+		}
+		expired = append(expired, item.data)
+		delete(p.transactions, hash)
+	}
+
+	transactionPoolGauge.Set(float64(len(p.transactions)))
+	return expired
+}
@@ -6,6 +6,7 @@
 import (
 	"container/heap"
 	"errors"
+	"sort"
 	"sync"
 	"time"
 
@@ -15,8 +16,15 @@
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
-// ErrTransactionExists is returned when trying to add a transaction to the queue that already exists
-var ErrTransactionExists = errors.New("transaction is already in queue")
+var (
+	// ErrTransactionExists is returned when trying to add a transaction to the queue that already exists
+	ErrTransactionExists = errors.New("transaction is already in queue")
+	// ErrTooLowPriority is returned when a transaction cannot replace the transactions
+	// providing the same tags, because its priority is not higher than theirs.
+	ErrTooLowPriority = errors.New("priority is too low to replace transactions providing the same tags")
+	// ErrSenderLimitReached is returned when a sender has too many transactions in the pool.
+	ErrSenderLimitReached = errors.New("sender transactions limit reached")
+)
 
 var transactionQueueGauge = promauto.NewGauge(prometheus.GaugeOpts{
 	Namespace: "gossamer_state_transaction",
@@ -36,7 +44,17 @@ type Item struct {
 	order uint64
 
 	// The index is needed by update and is maintained by the heap.Interface methods.
-	index int // The index of the item in the heap.
+	index int // The index of the item in the heap, or -1 if the item is not in the heap.
+
+	// validTill is the block number after which the transaction expires.
+	validTill uint
+	// sender is the encoded signer of the transaction, and is empty for unsigned transactions.
+	sender string
+	// blockedBy is the number of tags required by the item which are provided by
+	// other items still in the queue. The item is only in the heap once it is zero.
+	blockedBy int
+	// unlocks are the items requiring tags provided by this item.
+	unlocks []*Item
 }
 
 // A PriorityQueue implements heap.Interface and holds Items.
@@ -76,11 +94,16 @@ func (pq *priorityQueue) Pop() interface{} { //skipcq: GO-W1029
 	return item
 }
 
-// PriorityQueue is a thread safe wrapper over `priorityQueue`
+// PriorityQueue is the thread safe queue of the ready transactions. Transactions are
+// popped by priority, but only once the transactions providing the tags they require
+// have been popped from the queue, so transactions are popped in dependency order.
 type PriorityQueue struct {
 	pq           priorityQueue
 	currOrder    uint64
 	txs          map[common.Hash]*Item
+	providedBy   map[string]*Item
+	senders      map[string]int
+	blockNumber  uint
 	pollInterval time.Duration
 	sync.Mutex
 }
@@ -89,6 +112,8 @@ type PriorityQueue struct {
 func NewPriorityQueue() *PriorityQueue {
 	spq := &PriorityQueue{
 		txs:          make(map[common.Hash]*Item),
+		providedBy:   make(map[string]*Item),
+		senders:      make(map[string]int),
 		pollInterval: 10 * time.Millisecond,
 	}
 
@@ -96,28 +121,89 @@ func NewPriorityQueue() *PriorityQueue {
 	return spq
 }
 
-// RemoveExtrinsic removes an extrinsic from the queue
+// RemoveExtrinsic removes an extrinsic from the queue. The transactions requiring
+// the tags it provides are no longer blocked by it.
 func (spq *PriorityQueue) RemoveExtrinsic(ext types.Extrinsic) {
 	spq.Lock()
 	defer spq.Unlock()
 
-	hash := ext.Hash()
-	item, ok := spq.txs[hash]
+	item, ok := spq.txs[ext.Hash()]
 	if !ok {
 		return
 	}
 
-	heap.Remove(&spq.pq, item.index)
-	delete(spq.txs, hash)
+	if item.index >= 0 {
+		heap.Remove(&spq.pq, item.index)
+	}
+	spq.remove(item)
+	transactionQueueGauge.Set(float64(len(spq.txs)))
+}
+
+// remove removes the item from the queue indexes, and pushes the items it unlocks
+// to the heap. The item must have been removed from the heap already.
+func (spq *PriorityQueue) remove(item *Item) {
+	delete(spq.txs, item.hash)
+	for _, tag := range item.data.Validity.Provides {
+		if spq.providedBy[string(tag)] == item {
+			delete(spq.providedBy, string(tag))
+		}
+	}
+
+	if item.sender != "" {
+		spq.senders[item.sender]--
+		if spq.senders[item.sender] <= 0 {
+			delete(spq.senders, item.sender)
+		}
+	}
+
+	for _, unlocked := range item.unlocks {
+		if spq.txs[unlocked.hash] != unlocked {
+			continue
+		}
+		unlocked.blockedBy--
+		if unlocked.blockedBy == 0 {
+			heap.Push(&spq.pq, unlocked)
+		}
+	}
+	item.unlocks = nil
 }
 
 // Exists returns true if a hash is in the txs map, false otherwise
 func (spq *PriorityQueue) Exists(extHash common.Hash) bool {
+	spq.Lock()
+	defer spq.Unlock()
 	_, ok := spq.txs[extHash]
 	return ok
 }
 
-// Push inserts a valid transaction with priority p into the queue
+// Provides returns true if a transaction in the queue provides the given tag.
+func (spq *PriorityQueue) Provides(tag []byte) bool {
+	spq.Lock()
+	defer spq.Unlock()
+	_, ok := spq.providedBy[string(tag)]
+	return ok
+}
+
+// ProvidedBy returns the transaction in the queue providing the given tag, or nil.
+func (spq *PriorityQueue) ProvidedBy(tag []byte) *ValidTransaction {
+	spq.Lock()
+	defer spq.Unlock()
+	item, ok := spq.providedBy[string(tag)]
+	if !ok {
+		return nil
+	}
+	return item.data
+}
+
+// SenderCount returns the number of transactions in the queue signed by the given sender.
+func (spq *PriorityQueue) SenderCount(sender string) int {
+	spq.Lock()
+	defer spq.Unlock()
+	return spq.senders[sender]
+}
+
+// Push inserts a valid transaction with priority p into the queue. The transaction is
+// only popped once the transactions in the queue providing the tags it requires are popped.
 func (spq *PriorityQueue) Push(txn *ValidTransaction) (common.Hash, error) {
 	spq.Lock()
 	defer spq.Unlock()
@@ -128,19 +214,68 @@ func (spq *PriorityQueue) Push(txn *ValidTransaction) (common.Hash, error) {
 	}
 
 	item := &Item{
-		data:     txn,
-		hash:     hash,
-		order:    spq.currOrder,
-		priority: txn.Validity.Priority,
+		data:      txn,
+		hash:      hash,
+		order:     spq.currOrder,
+		priority:  txn.Validity.Priority,
+		index:     -1,
+		validTill: validTill(spq.blockNumber, txn.Validity.Longevity),
+		sender:    ExtrinsicSender(txn.Extrinsic),
 	}
 	spq.currOrder++
-	heap.Push(&spq.pq, item)
+
+	for _, tag := range txn.Validity.Requires {
+		provider, ok := spq.providedBy[string(tag)]
+		if !ok {
+			continue
+		}
+		item.blockedBy++
+		provider.unlocks = append(provider.unlocks, item)
+	}
+
+	for _, tag := range txn.Validity.Provides {
+		spq.providedBy[string(tag)] = item
+	}
+
+	if item.sender != "" {
+		spq.senders[item.sender]++
+	}
+
 	spq.txs[hash] = item
+	if item.blockedBy == 0 {
+		heap.Push(&spq.pq, item)
+	}
 
-	transactionQueueGauge.Set(float64(spq.pq.Len()))
+	transactionQueueGauge.Set(float64(len(spq.txs)))
 	return hash, nil
 }
 
+// PruneExpired sets the current block number of the queue and removes
+// the transactions whose longevity expired at this block number.
+func (spq *PriorityQueue) PruneExpired(blockNumber uint) (expired []*ValidTransaction) {
+	spq.Lock()
+	defer spq.Unlock()
+
+	spq.blockNumber = blockNumber
+	for _, item := range spq.txs {
+		if item.validTill >= blockNumber {
+			continue
+		}
+		expired = append(expired, item.data)
+	}
+
+	for _, txn := range expired {
+		item := spq.txs[txn.Extrinsic.Hash()]
+		if item.index >= 0 {
+			heap.Remove(&spq.pq, item.index)
+		}
+		spq.remove(item)
+	}
+
+	transactionQueueGauge.Set(float64(len(spq.txs)))
+	return expired
+}
+
 // PopWithTimer returns the next valid transaction from the queue.
 // When the timer expires, it returns `nil`.
 func (spq *PriorityQueue) PopWithTimer(timerCh <-chan time.Time) (transaction *ValidTransaction) {
@@ -185,9 +320,9 @@ func (spq *PriorityQueue) Pop() *ValidTransaction {
 	}
 
 	item := heap.Pop(&spq.pq).(*Item)
-	delete(spq.txs, item.hash)
+	spq.remove(item)
 
-	transactionQueueGauge.Set(float64(spq.pq.Len()))
+	transactionQueueGauge.Set(float64(len(spq.txs)))
 	return item.data
 }
 
@@ -201,7 +336,9 @@ func (spq *PriorityQueue) Peek() *ValidTransaction {
 	return spq.pq[0].data
 }
 
-// Pending returns all the transactions currently in the queue
+// Pending returns all the transactions currently in the queue, starting with
+// the transactions which can be popped, followed by the blocked transactions
+// in insertion order.
 func (spq *PriorityQueue) Pending() []*ValidTransaction {
 	spq.Lock()
 	defer spq.Unlock()
@@ -210,6 +347,19 @@ func (spq *PriorityQueue) Pending() []*ValidTransaction {
 	for idx := 0; idx < spq.pq.Len(); idx++ {
 		txns = append(txns, spq.pq[idx].data)
 	}
+
+	blocked := make([]*Item, 0, len(spq.txs)-spq.pq.Len())
+	for _, item := range spq.txs {
+		if item.index < 0 {
+			blocked = append(blocked, item)
+		}
+	}
+	sort.Slice(blocked, func(i, j int) bool {
+		return blocked[i].order < blocked[j].order
+	})
+	for _, item := range blocked {
+		txns = append(txns, item.data)
+	}
 	return txns
 }
 
@@ -218,5 +368,5 @@ func (spq *PriorityQueue) Len() int {
 	spq.Lock()
 	defer spq.Unlock()
 
-	return spq.pq.Len()
+	return len(spq.txs)
 }
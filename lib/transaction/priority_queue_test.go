@@ -273,6 +273,100 @@ func TestRemoveExtrinsic(t *testing.T) {
 	}
 }
 
+func TestPriorityQueue_DependencyOrder(t *testing.T) {
+	tests := []*ValidTransaction{
+		{
+			Extrinsic: []byte("nonce2"),
+			Validity:  &Validity{Priority: 10, Requires: [][]byte{{1}}, Provides: [][]byte{{2}}},
+		},
+		{
+			Extrinsic: []byte("nonce1"),
+			Validity:  &Validity{Priority: 5, Requires: [][]byte{{0}}, Provides: [][]byte{{1}}},
+		},
+		{
+			Extrinsic: []byte("nonce0"),
+			Validity:  &Validity{Priority: 1, Provides: [][]byte{{0}}},
+		},
+		{
+			Extrinsic: []byte("other"),
+			Validity:  &Validity{Priority: 3},
+		},
+	}
+
+	pq := NewPriorityQueue()
+
+	// transactions are pushed in dependency order
+	for i := len(tests) - 2; i >= 0; i-- {
+		_, err := pq.Push(tests[i])
+		assert.NoError(t, err)
+	}
+	_, err := pq.Push(tests[3])
+	assert.NoError(t, err)
+
+	assert.Equal(t, 4, pq.Len())
+	assert.True(t, pq.Provides([]byte{2}))
+	assert.Equal(t, tests[1], pq.ProvidedBy([]byte{1}))
+
+	expected := []int{3, 2, 1, 0}
+	for i, exp := range expected {
+		n := pq.Pop()
+		if !reflect.DeepEqual(n, tests[exp]) {
+			t.Fatalf("Fail: iteration %d got %v expected %v", i, n, tests[exp])
+		}
+	}
+	assert.False(t, pq.Provides([]byte{2}))
+}
+
+func TestPriorityQueue_RemoveExtrinsicUnlocks(t *testing.T) {
+	parent := &ValidTransaction{
+		Extrinsic: []byte("parent"),
+		Validity:  &Validity{Priority: 1, Provides: [][]byte{{0}}},
+	}
+	child := &ValidTransaction{
+		Extrinsic: []byte("child"),
+		Validity:  &Validity{Priority: 2, Requires: [][]byte{{0}}},
+	}
+
+	pq := NewPriorityQueue()
+	_, err := pq.Push(parent)
+	assert.NoError(t, err)
+	_, err = pq.Push(child)
+	assert.NoError(t, err)
+
+	assert.Equal(t, parent, pq.Peek())
+	assert.Equal(t, []*ValidTransaction{parent, child}, pq.Pending())
+
+	pq.RemoveExtrinsic(parent.Extrinsic)
+	assert.Equal(t, child, pq.Pop())
+	assert.Nil(t, pq.Pop())
+}
+
+func TestPriorityQueue_PruneExpired(t *testing.T) {
+	shortLived := &ValidTransaction{
+		Extrinsic: []byte("short"),
+		Validity:  &Validity{Priority: 1, Longevity: 2},
+	}
+	longLived := &ValidTransaction{
+		Extrinsic: []byte("long"),
+		Validity:  &Validity{Priority: 1, Longevity: 64},
+	}
+
+	pq := NewPriorityQueue()
+	pq.PruneExpired(10)
+	_, err := pq.Push(shortLived)
+	assert.NoError(t, err)
+	_, err = pq.Push(longLived)
+	assert.NoError(t, err)
+
+	expired := pq.PruneExpired(12)
+	assert.Empty(t, expired)
+
+	expired = pq.PruneExpired(13)
+	assert.Equal(t, []*ValidTransaction{shortLived}, expired)
+	assert.Equal(t, 1, pq.Len())
+	assert.Equal(t, longLived, pq.Pop())
+}
+
 func Test_PriorityQueue_PopWithTimer(t *testing.T) {
 	t.Parallel()
 
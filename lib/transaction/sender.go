@@ -0,0 +1,41 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package transaction
+
+import (
+	"math"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// MaxTransactionsPerSender is the maximum number of transactions of a single
+// sender held in the ready and future queues.
+const MaxTransactionsPerSender = 64
+
+// ExtrinsicSender returns the encoded signer of the extrinsic, or an empty
+// string if the extrinsic is unsigned or cannot be decoded.
+func ExtrinsicSender(ext types.Extrinsic) string {
+	var extrinsic ctypes.Extrinsic
+	err := codec.Decode(ext, &extrinsic)
+	if err != nil || !extrinsic.IsSigned() {
+		return ""
+	}
+
+	signer, err := codec.Encode(extrinsic.Signature.Signer)
+	if err != nil {
+		return ""
+	}
+	return string(signer)
+}
+
+// validTill returns the last block number at which a transaction with the
+// given longevity, validated at the given block number, is still valid.
+func validTill(blockNumber uint, longevity uint64) uint {
+	if longevity > uint64(math.MaxUint-blockNumber) {
+		return math.MaxUint
+	}
+	return blockNumber + uint(longevity)
+}
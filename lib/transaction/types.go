@@ -5,6 +5,7 @@
 
 import (
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 )
 
 // Validity struct see
@@ -42,13 +43,20 @@ func NewValidTransaction(extrinsic types.Extrinsic, validity *Validity) *ValidTr
 	}
 }
 
-// // StatusNotification represents information about a transaction status update.
-// type StatusNotification struct {
-// 	Ext                types.Extrinsic
-// 	Status             string
-// 	PeersBroadcastedTo []string
-// 	BlockHash          *common.Hash
-// }
+// StatusNotification represents information about a transaction status update.
+type StatusNotification struct {
+	Status Status
+	// PeersBroadcastedTo are the IDs of the peers the transaction was broadcast to,
+	// and is only set for the Broadcast status.
+	PeersBroadcastedTo []string
+	// BlockHash is the hash of the block the transaction was included in, retracted from
+	// or finalized in, and is only set for the InBlock, Retracted, FinalityTimeout and
+	// Finalized statuses.
+	BlockHash *common.Hash
+	// UsurpedBy is the hash of the transaction replacing the transaction,
+	// and is only set for the Usurped status.
+	UsurpedBy *common.Hash
+}
 
 /*
 Status represents possible transaction statuses.
@@ -92,6 +92,16 @@ func KeystoreDir(basepath string) (keystorepath string, err error) {
 	return keystorepath, nil
 }
 
+// ChainKeystoreDir returns the absolute filepath of the Substrate compatible keystore directory
+// of the given chain, which is [basepath]/chains/[chain id]/keystore
+func ChainKeystoreDir(basepath, chainID string) (string, error) {
+	keystorepath, err := filepath.Abs(filepath.Join(ExpandDir(basepath), "chains", chainID, "keystore"))
+	if err != nil {
+		return "", fmt.Errorf("failed to create absolute filepath: %s", err)
+	}
+	return keystorepath, nil
+}
+
 // KeystoreFiles returns the filenames of all the keys in the basepath's keystore
 func KeystoreFiles(basepath string) ([]string, error) {
 	keystorepath, err := KeystoreDir(basepath)
@@ -15,31 +15,6 @@
 
 type Signature uint32
 
-type timer struct {
-	wakerChan *wakerChan[error]
-	expired   bool
-}
-
-func newTimer(in <-chan time.Time) *timer {
-	inErr := make(chan error)
-	wc := newWakerChan(inErr)
-	t := timer{wakerChan: wc}
-	go func() {
-		<-in
-		inErr <- nil
-		t.expired = true
-	}()
-	return &t
-}
-
-func (t *timer) SetWaker(waker *waker) {
-	t.wakerChan.setWaker(waker)
-}
-
-func (t *timer) Elapsed() (bool, error) {
-	return t.expired, nil
-}
-
 type listenerItem struct {
 	Hash   string
 	Number uint32
@@ -108,8 +83,8 @@ func (e *environment) RoundData(
 
 	rd := RoundData[string, uint32, Signature, ID]{
 		VoterID:        &e.localID,
-		PrevoteTimer:   newTimer(time.NewTimer(500 * time.Millisecond).C),
-		PrecommitTimer: newTimer(time.NewTimer(1000 * time.Millisecond).C),
+		PrevoteTimer:   NewTimer(time.NewTimer(500 * time.Millisecond).C),
+		PrecommitTimer: NewTimer(time.NewTimer(1000 * time.Millisecond).C),
 		Incoming:       incoming,
 	}
 	return rd
@@ -117,7 +92,7 @@ func (e *environment) RoundData(
 
 func (*environment) RoundCommitTimer() Timer {
 	inner := time.NewTimer(time.Duration(rand.Int63n(1000)) * time.Millisecond).C
-	timer := newTimer(inner)
+	timer := NewTimer(inner)
 	return timer
 }
 
@@ -269,19 +244,19 @@ func (rn *RoundNetwork) AddNode(
 }
 
 type GlobalMessageNetwork struct {
-	BroadcastNetwork[globalInItem, CommunicationOut]
+	BroadcastNetwork[GlobalInItem, CommunicationOut]
 }
 
 func NewGlobalMessageNetwork() *GlobalMessageNetwork {
-	bn := NewBroadcastNetwork[globalInItem, CommunicationOut]()
+	bn := NewBroadcastNetwork[GlobalInItem, CommunicationOut]()
 	gmn := GlobalMessageNetwork{bn}
 	return &gmn
 }
 
 func (gmn *GlobalMessageNetwork) AddNode(
-	f func(CommunicationOut) globalInItem,
+	f func(CommunicationOut) GlobalInItem,
 	out chan CommunicationOut,
-) (in chan globalInItem) {
+) (in chan GlobalInItem) {
 	return gmn.BroadcastNetwork.AddNode(f, out)
 }
 
@@ -324,22 +299,22 @@ func (n *Network) MakeRoundComms(
 	)
 }
 
-func (n *Network) MakeGlobalComms(out chan CommunicationOut) chan globalInItem {
+func (n *Network) MakeGlobalComms(out chan CommunicationOut) chan GlobalInItem {
 	n.mtx.Lock()
 	defer n.mtx.Unlock()
 
-	return n.globalMessages.AddNode(func(message CommunicationOut) globalInItem {
+	return n.globalMessages.AddNode(func(message CommunicationOut) GlobalInItem {
 		if message.variant == nil {
 			panic("nil message variant")
 		}
 		switch message := message.variant.(type) {
 		case CommunicationOutCommit[string, uint32, Signature, ID]:
-			ci := newCommunicationIn[string, uint32, Signature, ID](CommunicationInCommit[string, uint32, Signature, ID]{
+			ci := NewCommunicationIn[string, uint32, Signature, ID](CommunicationInCommit[string, uint32, Signature, ID]{
 				Number:        message.Number,
 				CompactCommit: message.Commit.CompactCommit(),
 				Callback:      nil,
 			})
-			return globalInItem{
+			return GlobalInItem{
 				CommunicationIn: ci,
 			}
 		default:
@@ -349,5 +324,5 @@ func (n *Network) MakeGlobalComms(out chan CommunicationOut) chan globalInItem {
 }
 
 func (n *Network) SendMessage(message CommunicationIn) {
-	n.globalMessages.SendMessage(globalInItem{message, nil})
+	n.globalMessages.SendMessage(GlobalInItem{message, nil})
 }
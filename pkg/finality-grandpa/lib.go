@@ -426,6 +426,11 @@ func (hv *HistoricalVotes[Hash, Number, Signature, ID]) PushVote(msg SignedMessa
 	hv.seen = append(hv.seen, msg)
 }
 
+// Seen returns the votes seen in the round, in the order they were seen.
+func (hv HistoricalVotes[Hash, Number, Signature, ID]) Seen() []SignedMessage[Hash, Number, Signature, ID] {
+	return hv.seen
+}
+
 // SetPrevotedIdx sets the number of messages seen before prevoting.
 func (hv *HistoricalVotes[Hash, Number, Signature, ID]) SetPrevotedIdx() {
 	pi := uint64(len(hv.seen))
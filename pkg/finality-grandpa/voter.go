@@ -6,6 +6,7 @@
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/tidwall/btree"
@@ -53,6 +54,31 @@ type Timer interface {
 	Elapsed() (bool, error)
 }
 
+type timer struct {
+	wakerChan *wakerChan[error]
+	expired   atomic.Bool
+}
+
+// NewTimer returns a Timer which elapses when the given channel receives.
+func NewTimer(in <-chan time.Time) Timer {
+	inErr := make(chan error)
+	t := &timer{wakerChan: newWakerChan(inErr)}
+	go func() {
+		<-in
+		t.expired.Store(true)
+		inErr <- nil
+	}()
+	return t
+}
+
+func (t *timer) SetWaker(waker *waker) {
+	t.wakerChan.setWaker(waker)
+}
+
+func (t *timer) Elapsed() (bool, error) {
+	return t.expired.Load(), nil
+}
+
 // Output is the output stream used to communicate with the outside world.
 type Output[Hash comparable, Number constraints.Unsigned] chan Message[Hash, Number]
 
@@ -339,6 +365,11 @@ type CommunicationOut struct {
 	CommunicationOutCommit[Hash, Number, Signature, ID]
 }
 
+// Variant returns the communication variant, such as a CommunicationOutCommit.
+func (co CommunicationOut) Variant() any {
+	return co.variant
+}
+
 func newCommunicationOut[
 	Hash constraints.Ordered,
 	Number constraints.Unsigned,
@@ -453,6 +484,7 @@ type GoodCatchUp struct{
 // BadCatchUp is the result of processing for a bad catch up.
 type BadCatchUp struct{}
 
+// CommunicationIn is communication between nodes that is not round-localised.
 type CommunicationIn struct {
 	variant any
 }
@@ -464,7 +496,8 @@ func setCommunicationIn[
 	ci.variant = variant
 }
 
-func newCommunicationIn[
+// NewCommunicationIn returns a CommunicationIn with the given variant.
+func NewCommunicationIn[
 	Hash constraints.Ordered, Number constraints.Unsigned, Signature comparable, ID constraints.Ordered,
 	T CommunicationInVariants[Hash, Number, Signature, ID],
 ](variant T) CommunicationIn {
@@ -502,7 +535,8 @@ func newCommunicationIn[
 	Callback func(CatchUpProcessingOutcome)
 }
 
-type globalInItem struct {
+// GlobalInItem is the item type of the voter input stream for messages which are not round-localised.
+type GlobalInItem struct {
 	CommunicationIn
 	Error error
 }
@@ -531,7 +565,7 @@ type Voter[Hash constraints.Ordered, Number constraints.Unsigned, Signature comp
 	inner                  *innerVoterState[Hash, Number, Signature, ID, Environment[Hash, Number, Signature, ID]]
 	finalizedNotifications *wakerChan[finalizedNotification[Hash, Number, Signature, ID]]
 	lastFinalizedNumber    Number
-	globalIn               *wakerChan[globalInItem]
+	globalIn               *wakerChan[GlobalInItem]
 	globalOut              *buffered[CommunicationOut]
 	// the commit protocol might finalize further than the current round (if we're
 	// behind), we keep track of last finalized in round so we don't violate any
@@ -557,7 +591,7 @@ type Voter[Hash constraints.Ordered, Number constraints.Unsigned, Signature comp
 func NewVoter[Hash constraints.Ordered, Number constraints.Unsigned, Signature comparable, ID constraints.Ordered](
 	env Environment[Hash, Number, Signature, ID],
 	voters VoterSet[ID],
-	globalIn chan globalInItem,
+	globalIn chan GlobalInItem,
 	lastRoundNumber uint64,
 	lastRoundVotes []SignedMessage[Hash, Number, Signature, ID],
 	lastRoundBase HashNumber[Hash, Number],
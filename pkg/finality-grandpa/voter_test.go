@@ -32,7 +32,7 @@ func TestVoter_TalkingToMyself(t *testing.T) {
 	voter, globalOut := NewVoter[string, uint32, Signature, ID](
 		&env,
 		*voters,
-		make(chan globalInItem),
+		make(chan GlobalInItem),
 		0,
 		nil,
 		lastFinalized,
@@ -82,7 +82,7 @@ func TestVoter_FinalizingAtFaultThreshold(t *testing.T) {
 		voter, globalOut := NewVoter[string, uint32, Signature, ID](
 			&env,
 			*voters,
-			make(chan globalInItem),
+			make(chan GlobalInItem),
 			0,
 			nil,
 			lastFinalized,
@@ -135,7 +135,7 @@ func TestVoter_ExposingVoterState(t *testing.T) {
 		voter, globalOut := NewVoter[string, uint32, Signature, ID](
 			&env,
 			*voterSet,
-			make(chan globalInItem),
+			make(chan GlobalInItem),
 			0,
 			nil,
 			lastFinalized,
@@ -218,7 +218,7 @@ func TestVoter_BroadcastCommit(t *testing.T) {
 	voter, globalOut := NewVoter[string, uint32, Signature, ID](
 		&env,
 		*voterSet,
-		make(chan globalInItem),
+		make(chan GlobalInItem),
 		0,
 		nil,
 		lastFinalized,
@@ -454,7 +454,7 @@ func TestVoter_SkipsToLatestRoundAfterCatchUp(t *testing.T) {
 	}
 
 	// send in a catch-up message for round 5.
-	ci := newCommunicationIn[string, uint32, Signature, ID](CommunicationInCatchUp[string, uint32, Signature, ID]{
+	ci := NewCommunicationIn[string, uint32, Signature, ID](CommunicationInCatchUp[string, uint32, Signature, ID]{
 		CatchUp: CatchUp[string, uint32, Signature, ID]{
 			BaseNumber:  1,
 			BaseHash:    GenesisHash,
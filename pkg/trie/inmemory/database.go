@@ -306,6 +306,12 @@ func (t *InMemoryTrie) WriteDirty(db db.NewBatcher) error {
 	return batch.Flush()
 }
 
+// PutDirty puts all dirty nodes in the given database, typically a batch
+// flushed by the caller, and sets them to clean.
+func (t *InMemoryTrie) PutDirty(db db.DBPutter) error {
+	return t.writeDirtyNode(db, t.root)
+}
+
 func (t *InMemoryTrie) writeDirtyNode(db db.DBPutter, n *node.Node) (err error) {
 	if n == nil || !n.Dirty {
 		return nil
@@ -0,0 +1,78 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package triedb
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	nibbles "github.com/ChainSafe/gossamer/pkg/trie/codec"
+	"github.com/ChainSafe/gossamer/pkg/trie/triedb/codec"
+)
+
+// CheckDB checks every node and value of the trie, and of its child tries,
+// can be loaded and decoded from the database. It returns the number of nodes
+// checked, or an error for the first node or value which cannot be loaded.
+func (t *TrieDB) CheckDB() (nodes uint, err error) {
+	return t.checkNode(t.rootHandle, nil)
+}
+
+func (t *TrieDB) checkNode(handle NodeHandle, prefix []byte) (nodes uint, err error) {
+	node, err := t.loadIteratorNode(handle)
+	if err != nil {
+		if hash, ok := handle.(persisted); ok {
+			return 0, fmt.Errorf("loading node %s at prefix 0x%x: %w", common.Hash(hash), prefix, err)
+		}
+		return 0, fmt.Errorf("loading node at prefix 0x%x: %w", prefix, err)
+	}
+
+	var children [codec.ChildrenCapacity]NodeHandle
+	var value nodeValue
+	switch n := node.(type) {
+	case Empty:
+		return 0, nil
+	case Leaf:
+		prefix = bytes.Join([][]byte{prefix, n.partialKey}, nil)
+		value = n.value
+	case Branch:
+		prefix = bytes.Join([][]byte{prefix, n.partialKey}, nil)
+		children = n.children
+		value = n.value
+	default:
+		panic("unreachable")
+	}
+	nodes = 1
+
+	if value != nil {
+		data, err := inMemoryFetchedValue(value, t.db)
+		if err != nil {
+			return 0, fmt.Errorf("loading value at key 0x%x: %w", nibbles.NibblesToKeyLE(prefix), err)
+		}
+
+		key := nibbles.NibblesToKeyLE(prefix)
+		if bytes.HasPrefix(key, ChildStorageKeyPrefix) {
+			child := t.newChildTrie(common.BytesToHash(data))
+			childNodes, err := child.CheckDB()
+			if err != nil {
+				return 0, fmt.Errorf("checking child trie at key 0x%x: %w", key, err)
+			}
+			nodes += childNodes
+		}
+	}
+
+	for idx, child := range children {
+		if child == nil {
+			continue
+		}
+
+		childNodes, err := t.checkNode(child, bytes.Join([][]byte{prefix, {byte(idx)}}, nil))
+		if err != nil {
+			return 0, err
+		}
+		nodes += childNodes
+	}
+
+	return nodes, nil
+}
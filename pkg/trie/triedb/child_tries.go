@@ -4,18 +4,145 @@
 package triedb
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 )
 
+// ChildStorageKeyPrefix is the prefix of the keys of the child tries root hashes in the main trie
+var ChildStorageKeyPrefix = []byte(":child_storage:default:")
+
+func childTrieKey(keyToChild []byte) []byte {
+	return bytes.Join([][]byte{ChildStorageKeyPrefix, keyToChild}, nil)
+}
+
+// newChildTrie returns the child trie with the given root hash. The child trie
+// shares the db, version and options of the main trie, except for the cache
+// which is keyed by the main trie keys.
+func (t *TrieDB) newChildTrie(rootHash common.Hash) *TrieDB {
+	child := NewTrieDB(rootHash, t.db, WithRecorder(t.recorder))
+	child.version = t.version
+	child.deferDeletions = t.deferDeletions
+	return child
+}
+
+// getChildTrie returns the child trie located in the main trie at key :child_storage:default:[keyToChild]
+func (t *TrieDB) getChildTrie(keyToChild []byte) (*TrieDB, error) {
+	key := childTrieKey(keyToChild)
+	rootHash := t.Get(key)
+	if rootHash == nil {
+		return nil, fmt.Errorf("%w at key 0x%x", trie.ErrChildTrieDoesNotExist, key)
+	}
+
+	child, ok := t.childTries[string(keyToChild)]
+	if ok {
+		return child, nil
+	}
+
+	return t.newChildTrie(common.BytesToHash(rootHash)), nil
+}
+
+// setChildTrie keeps the given child trie to commit it with the main trie, and
+// sets its root hash in the main trie, or deletes the child trie from the main
+// trie if it is empty.
+func (t *TrieDB) setChildTrie(keyToChild []byte, child *TrieDB) error {
+	rootHash, err := child.Hash()
+	if err != nil {
+		return fmt.Errorf("hashing child trie: %w", err)
+	}
+
+	t.childTries[string(keyToChild)] = child
+
+	if rootHash == hashedNullNode {
+		return t.Delete(childTrieKey(keyToChild))
+	}
+
+	return t.Put(childTrieKey(keyToChild), rootHash.ToBytes())
+}
+
+// GetChild returns the child trie located in the main trie at key :child_storage:default:[keyToChild]
 func (t *TrieDB) GetChild(keyToChild []byte) (trie.Trie, error) {
-	panic("not implemented yet")
+	child, err := t.getChildTrie(keyToChild)
+	if err != nil {
+		return nil, err
+	}
+	return child, nil
 }
 
+// GetFromChild retrieves a value from the child trie located
+// in the main trie at key :child_storage:default:[keyToChild]
 func (t *TrieDB) GetFromChild(keyToChild, key []byte) ([]byte, error) {
-	panic("not implemented yet")
+	child, err := t.getChildTrie(keyToChild)
+	if err != nil {
+		return nil, err
+	}
+
+	return child.Get(key), nil
 }
 
+// GetChildTries returns all child tries in this trie
 func (t *TrieDB) GetChildTries() map[common.Hash]trie.Trie {
-	panic("not implemented yet")
+	children := make(map[common.Hash]trie.Trie)
+	iter := NewPrefixedTrieDBIterator(t, ChildStorageKeyPrefix)
+	for entry := iter.NextEntry(); entry != nil; entry = iter.NextEntry() {
+		rootHash := common.BytesToHash(entry.Value)
+		keyToChild := entry.Key[len(ChildStorageKeyPrefix):]
+		child, ok := t.childTries[string(keyToChild)]
+		if !ok {
+			child = t.newChildTrie(rootHash)
+		}
+		children[rootHash] = child
+	}
+	return children
+}
+
+// PutIntoChild puts a key-value pair into the child trie located in the main trie at
+// key :child_storage:default:[keyToChild], creating the child trie if it does not exist
+func (t *TrieDB) PutIntoChild(keyToChild, key, value []byte) error {
+	child, err := t.getChildTrie(keyToChild)
+	if err != nil {
+		if !errors.Is(err, trie.ErrChildTrieDoesNotExist) {
+			return fmt.Errorf("getting child: %w", err)
+		}
+		// an emptied child trie not committed yet still tracks its deleted nodes
+		child = t.childTries[string(keyToChild)]
+		if child == nil {
+			child = t.newChildTrie(hashedNullNode)
+		}
+	}
+
+	err = child.Put(key, value)
+	if err != nil {
+		return fmt.Errorf("putting into child trie located at key 0x%x: %w", keyToChild, err)
+	}
+
+	return t.setChildTrie(keyToChild, child)
+}
+
+// DeleteChild deletes the child storage trie
+func (t *TrieDB) DeleteChild(keyToChild []byte) (err error) {
+	delete(t.childTries, string(keyToChild))
+	err = t.Delete(childTrieKey(keyToChild))
+	if err != nil {
+		return fmt.Errorf("deleting child trie located at key 0x%x: %w", keyToChild, err)
+	}
+	return nil
+}
+
+// ClearFromChild removes the child storage entry
+func (t *TrieDB) ClearFromChild(keyToChild, key []byte) error {
+	child, err := t.getChildTrie(keyToChild)
+	if err != nil {
+		return err
+	}
+
+	err = child.Delete(key)
+	if err != nil {
+		return fmt.Errorf("deleting from child trie located at key 0x%x: %w", keyToChild, err)
+	}
+
+	return t.setChildTrie(keyToChild, child)
 }
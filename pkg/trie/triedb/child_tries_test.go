@@ -0,0 +1,113 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package triedb
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutAndGetFromChild(t *testing.T) {
+	t.Parallel()
+
+	childKey := []byte("default")
+	trieDB := NewEmptyTrieDB(NewMemoryDB(EmptyNode))
+
+	_, err := trieDB.GetFromChild(childKey, []byte("key"))
+	assert.ErrorIs(t, err, trie.ErrChildTrieDoesNotExist)
+
+	err = trieDB.PutIntoChild(childKey, []byte("key"), []byte("value"))
+	require.NoError(t, err)
+
+	value, err := trieDB.GetFromChild(childKey, []byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	child, err := trieDB.GetChild(childKey)
+	require.NoError(t, err)
+	assert.Equal(t, child.MustHash().ToBytes(), trieDB.Get(childTrieKey(childKey)))
+
+	childTries := trieDB.GetChildTries()
+	assert.Len(t, childTries, 1)
+	assert.Contains(t, childTries, child.MustHash())
+}
+
+func TestPutAndClearFromChild(t *testing.T) {
+	t.Parallel()
+
+	childKey := []byte("default")
+	trieDB := NewEmptyTrieDB(NewMemoryDB(EmptyNode))
+
+	err := trieDB.ClearFromChild(childKey, []byte("key"))
+	assert.ErrorIs(t, err, trie.ErrChildTrieDoesNotExist)
+
+	err = trieDB.PutIntoChild(childKey, []byte("key1"), []byte("value1"))
+	require.NoError(t, err)
+	err = trieDB.PutIntoChild(childKey, []byte("key2"), []byte("value2"))
+	require.NoError(t, err)
+
+	err = trieDB.ClearFromChild(childKey, []byte("key1"))
+	require.NoError(t, err)
+
+	value, err := trieDB.GetFromChild(childKey, []byte("key1"))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	// clearing the last key of the child trie removes it from the main trie
+	err = trieDB.ClearFromChild(childKey, []byte("key2"))
+	require.NoError(t, err)
+
+	_, err = trieDB.GetChild(childKey)
+	assert.ErrorIs(t, err, trie.ErrChildTrieDoesNotExist)
+	assert.Equal(t, hashedNullNode, trieDB.MustHash())
+}
+
+func TestPutAndDeleteChild(t *testing.T) {
+	t.Parallel()
+
+	childKey := []byte("default")
+	trieDB := NewEmptyTrieDB(NewMemoryDB(EmptyNode))
+
+	err := trieDB.PutIntoChild(childKey, []byte("key"), []byte("value"))
+	require.NoError(t, err)
+
+	err = trieDB.DeleteChild(childKey)
+	require.NoError(t, err)
+
+	_, err = trieDB.GetChild(childKey)
+	assert.ErrorContains(t, err, "child trie does not exist at key")
+}
+
+func TestChildTries_SameHashAsInMemoryTrie(t *testing.T) {
+	t.Parallel()
+
+	inMemoryTrie := inmemory.NewEmptyTrie()
+	inMemoryTrie.SetVersion(trie.V1)
+	trieDB := NewEmptyTrieDB(NewMemoryDB(EmptyNode))
+	trieDB.SetVersion(trie.V1)
+
+	childEntries := map[string][]byte{
+		"no":           make([]byte, 10),
+		"noot":         make([]byte, 20),
+		"notable":      make([]byte, 40),
+		"notification": make([]byte, 50),
+	}
+
+	for _, childKey := range []string{"child1", "child2"} {
+		for k, v := range childEntries {
+			require.NoError(t, inMemoryTrie.PutIntoChild([]byte(childKey), []byte(k), v))
+			require.NoError(t, trieDB.PutIntoChild([]byte(childKey), []byte(k), v))
+		}
+	}
+
+	require.NoError(t, inMemoryTrie.ClearFromChild([]byte("child1"), []byte("notable")))
+	require.NoError(t, trieDB.ClearFromChild([]byte("child1"), []byte("notable")))
+
+	assert.Equal(t, inMemoryTrie.MustHash(), trieDB.MustHash())
+	assert.Equal(t, inMemoryTrie.Entries(), trieDB.Entries())
+}
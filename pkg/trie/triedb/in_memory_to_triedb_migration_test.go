@@ -120,3 +120,83 @@ func TestReadTrieDB_Migration(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 }
+
+func TestReadTrieDB_ChildTriesMigration(t *testing.T) {
+	db := newTestDB(t)
+	inMemoryTrie := inmemory.NewEmptyTrie()
+	inMemoryTrie.SetVersion(trie.V1)
+
+	entries := map[string][]byte{
+		"no":   make([]byte, 10),
+		"noot": make([]byte, 40),
+		"test": make([]byte, 60),
+	}
+
+	for k, v := range entries {
+		inMemoryTrie.Put([]byte(k), v)
+		err := inMemoryTrie.PutIntoChild([]byte("child"), []byte(k), v)
+		assert.NoError(t, err)
+	}
+
+	err := inMemoryTrie.WriteDirty(db)
+	assert.NoError(t, err)
+
+	root, err := inMemoryTrie.Hash()
+	assert.NoError(t, err)
+	trieDB := NewTrieDB(root, db)
+	trieDB.SetVersion(trie.V1)
+
+	t.Run("read_child_trie_created_using_v1_trie", func(t *testing.T) {
+		for k, v := range entries {
+			value, err := trieDB.GetFromChild([]byte("child"), []byte(k))
+			assert.NoError(t, err)
+			assert.Equal(t, v, value)
+		}
+	})
+
+	t.Run("update_child_trie_created_using_v1_trie", func(t *testing.T) {
+		err := inMemoryTrie.PutIntoChild([]byte("child"), []byte("notable"), make([]byte, 50))
+		assert.NoError(t, err)
+		err = trieDB.PutIntoChild([]byte("child"), []byte("notable"), make([]byte, 50))
+		assert.NoError(t, err)
+
+		assert.Equal(t, inMemoryTrie.MustHash(), trieDB.MustHash())
+	})
+}
+
+func TestCheckDB_Migration(t *testing.T) {
+	db := newTestDB(t)
+	inMemoryTrie := inmemory.NewEmptyTrie()
+	inMemoryTrie.SetVersion(trie.V1)
+
+	entries := map[string][]byte{
+		"no":   make([]byte, 10),
+		"noot": make([]byte, 40),
+		"test": make([]byte, 60),
+	}
+
+	for k, v := range entries {
+		inMemoryTrie.Put([]byte(k), v)
+		err := inMemoryTrie.PutIntoChild([]byte("child"), []byte(k), v)
+		assert.NoError(t, err)
+	}
+
+	err := inMemoryTrie.WriteDirty(db)
+	assert.NoError(t, err)
+
+	root, err := inMemoryTrie.Hash()
+	assert.NoError(t, err)
+	child, err := inMemoryTrie.GetChild([]byte("child"))
+	assert.NoError(t, err)
+	childRoot := child.MustHash()
+
+	nodes, err := NewTrieDB(root, db).CheckDB()
+	assert.NoError(t, err)
+	assert.Equal(t, uint(9), nodes)
+
+	err = db.Del(childRoot[:])
+	assert.NoError(t, err)
+
+	_, err = NewTrieDB(root, db).CheckDB()
+	assert.ErrorIs(t, err, ErrIncompleteDB)
+}
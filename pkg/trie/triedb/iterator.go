@@ -3,6 +3,8 @@
 
 package triedb
 
+import nibbles "github.com/ChainSafe/gossamer/pkg/trie/codec"
+
 // Entries returns all the key-value pairs in the trie as a map of keys to values
 // where the keys are encoded in Little Endian.
 func (t *TrieDB) Entries() (keyValueMap map[string][]byte) {
@@ -20,10 +22,7 @@ func (t *TrieDB) Entries() (keyValueMap map[string][]byte) {
 // It returns nil if no next key is found.
 func (t *TrieDB) NextKey(key []byte) []byte {
 	iter := NewTrieDBIterator(t)
-
-	// TODO: Seek will potentially skip a lot of keys, we need to find a way to
-	// optimise it, maybe creating a lookupFor
-	iter.Seek(key)
+	iter.cursor = nibbles.KeyLEToNibbles(key)
 	return iter.NextKey()
 }
 
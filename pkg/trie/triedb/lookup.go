@@ -32,9 +32,10 @@ func NewTrieLookup(db db.DBGetter, hash common.Hash, cache cache.TrieCache, reco
 	}
 }
 
-func (l *TrieLookup) lookupNode(keyNibbles []byte) (codec.EncodedNode, error) {
+// lookupNode returns the node with the given full key, looking for the remaining
+// partial key from the node at the lookup hash, or nil if there is no such node.
+func (l *TrieLookup) lookupNode(keyNibbles, partialKey []byte) (codec.EncodedNode, error) {
 	// Start from root node and going downwards
-	partialKey := keyNibbles
 	hash := l.hash[:]
 
 	// Iterates through non inlined nodes
@@ -131,14 +132,14 @@ func (l *TrieLookup) lookupNode(keyNibbles []byte) (codec.EncodedNode, error) {
 	}
 }
 
-func (l *TrieLookup) lookupValue(keyNibbles []byte) (value []byte, err error) {
+func (l *TrieLookup) lookupValue(keyNibbles, partialKey []byte) (value []byte, err error) {
 	if l.cache != nil {
 		if value = l.cache.GetValue(keyNibbles); value != nil {
 			return value, nil
 		}
 	}
 
-	node, err := l.lookupNode(keyNibbles)
+	node, err := l.lookupNode(keyNibbles, partialKey)
 	if err != nil {
 		return nil, err
 	}
@@ -15,7 +15,7 @@ func TestTrieDB_Lookup(t *testing.T) {
 		db := newTestDB(t)
 		lookup := NewTrieLookup(db, trie.EmptyHash, nil, nil)
 
-		value, err := lookup.lookupValue([]byte("test"))
+		value, err := lookup.lookupValue([]byte("test"), []byte("test"))
 		assert.Nil(t, value)
 		assert.ErrorIs(t, err, ErrIncompleteDB)
 	})
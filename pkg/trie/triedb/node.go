@@ -24,8 +24,12 @@ type nodeValue interface {
 	// inline is an inlined value representation
 	inline []byte
 
-	// valueRef is a reference to a value stored in the db
-	valueRef common.Hash
+	// valueRef is a reference to a value stored in the db at the key made of
+	// the partial key of the node it was loaded from followed by the value hash
+	valueRef struct {
+		prefix []byte
+		hash   common.Hash
+	}
 
 	// newValueRef is a value that will be stored in the db
 	newValueRef struct {
@@ -40,7 +44,10 @@ func newEncodedValue(value nodeValue, partial []byte, childF onChildStoreFn) (co
 	case inline:
 		return codec.InlineValue(v), nil
 	case valueRef:
-		return codec.HashedValue(v), nil
+		if !bytes.Equal(v.prefix, partial) {
+			panic("moved external values must be loaded before encoding a node")
+		}
+		return codec.HashedValue(v.hash), nil
 	case newValueRef:
 		// Store value in db
 		childRef, err := childF(newNodeToEncode{partialKey: partial, value: v.data}, partial, nil)
@@ -81,16 +88,21 @@ func (n inline) equal(other nodeValue) bool {
 		return false
 	}
 }
-func (vr valueRef) getHash() common.Hash { return common.Hash(vr) }
+func (vr valueRef) getHash() common.Hash { return vr.hash }
 func (vr valueRef) equal(other nodeValue) bool {
 	switch otherValue := other.(type) {
 	case valueRef:
-		return vr == otherValue
+		return vr.hash == otherValue.hash
 	default:
 		return false
 	}
 }
 
+// dbKey returns the database key of the referenced value
+func (vr valueRef) dbKey() []byte {
+	return bytes.Join([][]byte{vr.prefix, vr.hash[:]}, nil)
+}
+
 func (vr newValueRef) getHash() common.Hash {
 	return vr.hash
 }
@@ -104,7 +116,7 @@ func (vr newValueRef) equal(other nodeValue) bool {
 }
 
 func NewValue(data []byte, threshold int) nodeValue {
-	if len(data) >= threshold {
+	if len(data) > threshold {
 		return newValueRef{data: data}
 	}
 
@@ -116,21 +128,20 @@ func NewValueFromEncoded(prefix []byte, encodedValue codec.EncodedValue) nodeVal
 	case codec.InlineValue:
 		return inline(v)
 	case codec.HashedValue:
-		prefixedKey := bytes.Join([][]byte{prefix, v[:]}, nil)
-		return valueRef(common.NewHash(prefixedKey))
+		return valueRef{prefix: prefix, hash: common.Hash(v)}
 	}
 
 	return nil
 }
 
-func inMemoryFetchedValue(value nodeValue, prefix []byte, db db.DBGetter) ([]byte, error) {
+func inMemoryFetchedValue(value nodeValue, db db.DBGetter) ([]byte, error) {
 	switch v := value.(type) {
 	case inline:
 		return v, nil
 	case newValueRef:
 		return v.data, nil
 	case valueRef:
-		prefixedKey := bytes.Join([][]byte{prefix, v[:]}, nil)
+		prefixedKey := v.dbKey()
 		value, err := db.Get(prefixedKey)
 		if err != nil {
 			return nil, err
@@ -167,7 +178,7 @@ func (n Branch) getPartialKey() []byte { return n.partialKey }
 
 // Create a new node from the encoded data, decoding this data into a codec.Node
 // and mapping that with this node type
-func newNodeFromEncoded(nodeHash common.Hash, data []byte, storage nodeStorage) (Node, error) {
+func newNodeFromEncoded(nodeHash common.Hash, data []byte, storage *nodeStorage) (Node, error) {
 	reader := bytes.NewReader(data)
 	encodedNode, err := codec.Decode(reader)
 	if err != nil {
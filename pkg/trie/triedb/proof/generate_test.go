@@ -144,6 +144,8 @@ func Test_NewProof(t *testing.T) {
 				triedb.Put(entry.Key, entry.Value)
 			}
 
+			err := triedb.Commit()
+			require.NoError(t, err)
 			root := triedb.MustHash()
 
 			// Generate proof
@@ -148,6 +148,8 @@ func Test_GenerateAndVerify(t *testing.T) {
 					triedb.Put(entry.Key, entry.Value)
 				}
 
+				err := triedb.Commit()
+				require.NoError(t, err)
 				root := triedb.MustHash()
 
 				// Generate proof
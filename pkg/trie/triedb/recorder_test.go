@@ -6,6 +6,7 @@
 import (
 	"testing"
 
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,6 +24,8 @@ func TestRecorder(t *testing.T) {
 	triedb.Put([]byte("gossamer"), []byte("gossamervalue"))
 
 	// Commit and get root
+	err := triedb.Commit()
+	require.NoError(t, err)
 	root := triedb.MustHash()
 	require.NotNil(t, root)
 
@@ -96,4 +99,20 @@ func TestRecorder(t *testing.T) {
 			require.Equal(t, node, expectedNodes[i])
 		}
 	})
+
+	t.Run("Record_insert_should_record_loaded_nodes_by_hash", func(t *testing.T) {
+		recorder := NewRecorder()
+		trie := NewTrieDB(root, inmemoryDB, WithRecorder(recorder))
+
+		err := trie.Put([]byte("polkadot"), []byte("newvalue"))
+		require.NoError(t, err)
+
+		recordedNodes := recorder.Drain()
+		require.NotEmpty(t, recordedNodes)
+		for _, node := range recordedNodes {
+			hash, err := common.Blake2bHash(node.Data)
+			require.NoError(t, err)
+			require.Equal(t, hash, node.Hash)
+		}
+	})
 }
@@ -12,6 +12,7 @@
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	nibbles "github.com/ChainSafe/gossamer/pkg/trie/codec"
 	"github.com/ChainSafe/gossamer/pkg/trie/db"
+	"github.com/ChainSafe/gossamer/pkg/trie/tracking"
 
 	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
@@ -27,6 +28,17 @@
 
 type TrieDBOpts func(*TrieDB)
 
+// WithDeferredDeletions keeps the nodes and values removed from the trie in the
+// db when committing, since they can be shared with the tries of other blocks.
+// The inserted and removed nodes are returned by GetChangedNodeHashes and the
+// inserted and removed values by GetChangedValueKeys instead, so a pruner can
+// delete the removed nodes and values once they are no longer used.
+var WithDeferredDeletions = func() TrieDBOpts {
+	return func(t *TrieDB) {
+		t.deferDeletions = true
+	}
+}
+
 var WithCache = func(c cache.TrieCache) TrieDBOpts {
 	return func(t *TrieDB) {
 		t.cache = c
@@ -53,6 +65,18 @@ type TrieDB struct {
 	storage nodeStorage
 	// deathRow is a set of nodes that we want to delete from db
 	deathRow map[common.Hash]interface{}
+	// valueDeathRow is a set of db keys of values that we want to delete from db
+	valueDeathRow map[string]interface{}
+	// deferDeletions keeps the nodes and values removed from the trie in the db
+	// on commit, and tracks the inserted and removed nodes instead
+	deferDeletions bool
+	insertedNodes  map[common.Hash]struct{}
+	deletedNodes   map[common.Hash]struct{}
+	insertedValues map[string]struct{}
+	deletedValues  map[string]struct{}
+	// childTries are the child tries modified since the last commit,
+	// by key to child, which are committed with the trie.
+	childTries map[string]*TrieDB
 	// Optional cache to speed up the db lookups
 	cache cache.TrieCache
 	// Optional recorder for recording trie accesses
@@ -61,7 +85,7 @@ type TrieDB struct {
 
 func NewEmptyTrieDB(db db.RWDatabase, opts ...TrieDBOpts) *TrieDB {
 	root := hashedNullNode
-	return NewTrieDB(root, db)
+	return NewTrieDB(root, db, opts...)
 }
 
 // NewTrieDB creates a new TrieDB using the given root and db
@@ -75,6 +99,13 @@ func NewTrieDB(rootHash common.Hash, db db.RWDatabase, opts ...TrieDBOpts) *Trie
 		storage:    newNodeStorage(),
 		rootHandle: rootHandle,
 		deathRow:   make(map[common.Hash]interface{}),
+
+		valueDeathRow:  make(map[string]interface{}),
+		insertedNodes:  make(map[common.Hash]struct{}),
+		deletedNodes:   make(map[common.Hash]struct{}),
+		insertedValues: make(map[string]struct{}),
+		deletedValues:  make(map[string]struct{}),
+		childTries:     make(map[string]*TrieDB),
 	}
 
 	for _, opt := range opts {
@@ -92,15 +123,55 @@ func (t *TrieDB) SetVersion(v trie.TrieLayout) {
 	t.version = v
 }
 
-// Hash returns the hashed root of the trie.
+// Hash returns the hashed root of the trie. The changes of the trie
+// are not written to the db, which is only done by Commit.
 func (t *TrieDB) Hash() (common.Hash, error) {
-	err := t.commit()
-	if err != nil {
-		return common.EmptyHash, err
+	switch h := t.rootHandle.(type) {
+	case persisted:
+		return common.Hash(h), nil
+	case inMemory:
+		switch stored := t.storage.nodes[h].(type) {
+		case CachedStoredNode:
+			return stored.hash, nil
+		case NewStoredNode:
+			encoded, err := t.encodeWithoutCommit(stored.node)
+			if err != nil {
+				return common.EmptyHash, fmt.Errorf("encoding root node: %w", err)
+			}
+			return common.Blake2bHash(encoded)
+		}
 	}
-	// This is trivial since it is a read only trie, but will change when we
-	// support writes
-	return t.rootHash, nil
+	panic("unreachable")
+}
+
+// Commit writes the changes of the trie and of its modified child tries to the db.
+func (t *TrieDB) Commit() error {
+	for keyToChild, child := range t.childTries {
+		err := child.Commit()
+		if err != nil {
+			return fmt.Errorf("committing child trie located at key 0x%x: %w", keyToChild, err)
+		}
+
+		for hash := range child.deletedNodes {
+			delete(t.insertedNodes, hash)
+			t.deletedNodes[hash] = struct{}{}
+		}
+		for hash := range child.insertedNodes {
+			delete(t.deletedNodes, hash)
+			t.insertedNodes[hash] = struct{}{}
+		}
+		for key := range child.deletedValues {
+			delete(t.insertedValues, key)
+			t.deletedValues[key] = struct{}{}
+		}
+		for key := range child.insertedValues {
+			delete(t.deletedValues, key)
+			t.insertedValues[key] = struct{}{}
+		}
+	}
+	t.childTries = make(map[string]*TrieDB)
+
+	return t.commit()
 }
 
 // MustHash returns the hashed root of the trie.
@@ -129,14 +200,16 @@ func (t *TrieDB) Get(key []byte) []byte {
 }
 
 func (t *TrieDB) lookup(fullKey []byte, partialKey []byte, handle NodeHandle) ([]byte, error) {
-	prefix := fullKey
-
 	for {
 		var partialIdx int
 		switch node := handle.(type) {
 		case persisted:
+			if common.Hash(node) == hashedNullNode {
+				return nil, nil
+			}
+
 			lookup := NewTrieLookup(t.db, common.Hash(node), t.cache, t.recorder)
-			val, err := lookup.lookupValue(fullKey)
+			val, err := lookup.lookupValue(fullKey, partialKey)
 			if err != nil {
 				return nil, err
 			}
@@ -147,20 +220,24 @@ func (t *TrieDB) lookup(fullKey []byte, partialKey []byte, handle NodeHandle) ([
 				return nil, nil
 			case Leaf:
 				if bytes.Equal(n.partialKey, partialKey) {
-					return inMemoryFetchedValue(n.value, prefix, t.db)
+					return inMemoryFetchedValue(n.value, t.db)
 				} else {
 					return nil, nil
 				}
 			case Branch:
 				if bytes.Equal(n.partialKey, partialKey) {
-					return inMemoryFetchedValue(n.value, prefix, t.db)
+					if n.value == nil {
+						return nil, nil
+					}
+					return inMemoryFetchedValue(n.value, t.db)
 				} else if bytes.HasPrefix(partialKey, n.partialKey) {
 					idx := partialKey[len(n.partialKey)]
 					child := n.children[idx]
-					if child != nil {
-						partialIdx = 1 + len(n.partialKey)
-						handle = child
+					if child == nil {
+						return nil, nil
 					}
+					partialIdx = 1 + len(n.partialKey)
+					handle = child
 				} else {
 					return nil, nil
 				}
@@ -171,50 +248,6 @@ func (t *TrieDB) lookup(fullKey []byte, partialKey []byte, handle NodeHandle) ([
 }
 
 // Internal methods
-func (t *TrieDB) getRootNode() (codec.EncodedNode, error) {
-	encodedNode, err := t.db.Get(t.rootHash[:])
-	if err != nil {
-		return nil, err
-	}
-
-	t.recordAccess(encodedNodeAccess{hash: t.rootHash, encodedNode: encodedNode})
-
-	reader := bytes.NewReader(encodedNode)
-	return codec.Decode(reader)
-}
-
-// Internal methods
-
-func (t *TrieDB) getNodeAt(key []byte) (codec.EncodedNode, error) {
-	lookup := NewTrieLookup(t.db, t.rootHash, t.cache, t.recorder)
-	node, err := lookup.lookupNode(nibbles.KeyLEToNibbles(key))
-	if err != nil {
-		return nil, err
-	}
-
-	return node, nil
-}
-
-func (t *TrieDB) getNode(
-	merkleValue codec.MerkleValue,
-) (node codec.EncodedNode, err error) {
-	switch n := merkleValue.(type) {
-	case codec.InlineNode:
-		reader := bytes.NewReader(n)
-		return codec.Decode(reader)
-	case codec.HashedNode:
-		encodedNode, err := t.db.Get(n[:])
-		if err != nil {
-			return nil, err
-		}
-		t.recordAccess(encodedNodeAccess{hash: t.rootHash, encodedNode: encodedNode})
-
-		reader := bytes.NewReader(encodedNode)
-		return codec.Decode(reader)
-	default: // should never happen
-		panic("unreachable")
-	}
-}
 
 // Remove removes the given key from the trie
 func (t *TrieDB) remove(keyNibbles []byte) error {
@@ -241,6 +274,88 @@ func (t *TrieDB) Delete(key []byte) error {
 	return t.remove(keyNibbles)
 }
 
+// ClearPrefix deletes all nodes in the trie for which the key contains the
+// prefix given in little Endian format.
+func (t *TrieDB) ClearPrefix(prefix []byte) error {
+	for _, key := range t.GetKeysWithPrefix(prefix) {
+		err := t.Delete(key)
+		if err != nil {
+			return fmt.Errorf("deleting key 0x%x: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// ClearPrefixLimit deletes the keys having the prefix given in little
+// Endian format for up to `limit` keys. It returns the number of deleted
+// keys and a boolean indicating if all keys with the prefix were deleted.
+func (t *TrieDB) ClearPrefixLimit(prefix []byte, limit uint32) (
+	deleted uint32, allDeleted bool, err error) {
+	if limit == 0 {
+		return 0, false, nil
+	}
+
+	// look for one more key than the limit to know if all keys get deleted
+	keys := make([][]byte, 0)
+	iter := NewPrefixedTrieDBIterator(t, prefix)
+	for key := iter.NextKey(); key != nil && len(keys) <= int(limit); key = iter.NextKey() {
+		keys = append(keys, key)
+	}
+
+	allDeleted = len(keys) <= int(limit)
+	if !allDeleted {
+		keys = keys[:limit]
+	}
+
+	for _, key := range keys {
+		err = t.Delete(key)
+		if err != nil {
+			return deleted, false, fmt.Errorf("deleting key 0x%x: %w", key, err)
+		}
+		deleted++
+	}
+
+	return deleted, allDeleted, nil
+}
+
+// GetChangedNodeHashes returns the hashes of the nodes inserted in and deleted
+// from the db by the commits of the trie, if the deletions are deferred.
+// It returns empty sets otherwise.
+func (t *TrieDB) GetChangedNodeHashes() (inserted, deleted map[common.Hash]struct{}, err error) {
+	inserted = make(map[common.Hash]struct{}, len(t.insertedNodes))
+	for hash := range t.insertedNodes {
+		inserted[hash] = struct{}{}
+	}
+
+	deleted = make(map[common.Hash]struct{}, len(t.deletedNodes))
+	for hash := range t.deletedNodes {
+		deleted[hash] = struct{}{}
+	}
+
+	return inserted, deleted, nil
+}
+
+// GetChangedValueKeys returns the db keys of the values inserted in and deleted
+// from the db by the commits of the trie, if the deletions are deferred.
+// It returns empty sets otherwise.
+func (t *TrieDB) GetChangedValueKeys() (inserted, deleted map[string]struct{}) {
+	inserted = make(map[string]struct{}, len(t.insertedValues))
+	for key := range t.insertedValues {
+		inserted[key] = struct{}{}
+	}
+
+	deleted = make(map[string]struct{}, len(t.deletedValues))
+	for key := range t.deletedValues {
+		deleted[key] = struct{}{}
+	}
+
+	return inserted, deleted
+}
+
+// HandleTrackedDeltas does nothing since the trie tracks the
+// changed nodes itself when committing.
+func (*TrieDB) HandleTrackedDeltas(bool, tracking.Getter) {}
+
 // insert inserts the node and update the rootHandle
 func (t *TrieDB) insert(keyNibbles, value []byte) error {
 	var oldValue nodeValue
@@ -463,20 +578,6 @@ func (t *TrieDB) removeInspector(stored Node, keyNibbles []byte, oldValue *nodeV
 		// Wrong partial, so we return the node as is
 		return restoreNode{n}, nil
 	case Branch:
-		if len(partial) == 0 {
-			if n.value == nil {
-				// Nothing to delete since the branch doesn't contains a value
-				return restoreNode{n}, nil
-			}
-			// The branch contains the value so we delete it
-			t.replaceOldValue(oldValue, n.value)
-			newNode, err := t.fix(Branch{n.partialKey, n.children, nil})
-			if err != nil {
-				return nil, err
-			}
-			return replaceNode{newNode}, nil
-		}
-
 		common := nibbles.CommonPrefix(n.partialKey, partial)
 		existingLength := len(n.partialKey)
 
@@ -695,27 +796,51 @@ func (t *TrieDB) replaceOldValue(
 	oldValue *nodeValue,
 	storedValue nodeValue,
 ) {
-	switch oldv := storedValue.(type) {
-	case valueRef, newValueRef:
-		hash := oldv.getHash()
-		if hash != common.EmptyHash {
-			t.deathRow[oldv.getHash()] = nil
-		}
+	// new values are not stored in the db yet
+	if oldv, ok := storedValue.(valueRef); ok {
+		t.valueDeathRow[string(oldv.dbKey())] = nil
 	}
 	*oldValue = storedValue
 }
 
+// trackInsertedNode records the node inserted in the db if the
+// deletions are deferred, to be returned by GetChangedNodeHashes.
+func (t *TrieDB) trackInsertedNode(hash common.Hash) {
+	if !t.deferDeletions {
+		return
+	}
+
+	delete(t.deletedNodes, hash)
+	t.insertedNodes[hash] = struct{}{}
+}
+
+// trackInsertedValue records the value inserted in the db at the given key if
+// the deletions are deferred, to be returned by GetChangedValueKeys.
+func (t *TrieDB) trackInsertedValue(key []byte) {
+	if !t.deferDeletions {
+		return
+	}
+
+	delete(t.deletedValues, string(key))
+	t.insertedValues[string(key)] = struct{}{}
+}
+
 // lookup node in DB and add it in storage, return storage handle
 // TODO: implement cache to improve performance
 func (t *TrieDB) lookupNode(hash common.Hash) (storageHandle, error) {
+	// the empty trie root node is not stored in the db
+	if hash == hashedNullNode {
+		return t.storage.alloc(CachedStoredNode{node: Empty{}, hash: hash}), nil
+	}
+
 	encodedNode, err := t.db.Get(hash[:])
 	if err != nil {
 		return -1, ErrIncompleteDB
 	}
 
-	t.recordAccess(encodedNodeAccess{hash: t.rootHash, encodedNode: encodedNode})
+	t.recordAccess(encodedNodeAccess{hash: hash, encodedNode: encodedNode})
 
-	node, err := newNodeFromEncoded(hash, encodedNode, t.storage)
+	node, err := newNodeFromEncoded(hash, encodedNode, &t.storage)
 	if err != nil {
 		return -1, err
 	}
@@ -739,30 +864,61 @@ func (t *TrieDB) commit() error {
 	}()
 
 	for hash := range t.deathRow {
+		if hash == hashedNullNode {
+			// the empty trie root node is not stored in the db
+			continue
+		}
+
+		if t.deferDeletions {
+			delete(t.insertedNodes, hash)
+			t.deletedNodes[hash] = struct{}{}
+			continue
+		}
+
 		err := dbBatch.Del(hash[:])
 		if err != nil {
 			return err
 		}
 	}
 
+	for key := range t.valueDeathRow {
+		if t.deferDeletions {
+			delete(t.insertedValues, key)
+			t.deletedValues[key] = struct{}{}
+			continue
+		}
+
+		err := dbBatch.Del([]byte(key))
+		if err != nil {
+			return err
+		}
+	}
+
 	// Reset deathRow
 	t.deathRow = make(map[common.Hash]interface{})
+	t.valueDeathRow = make(map[string]interface{})
 
 	var handle storageHandle
 	switch h := t.rootHandle.(type) {
 	case persisted:
-		return nil // nothing to commit since the root is already in db
+		// nothing to commit since the root is already in db
+		return dbBatch.Flush()
 	case inMemory:
 		handle = storageHandle(h)
 	}
 
 	switch stored := t.storage.destroy(handle).(type) {
 	case NewStoredNode:
+		node, err := t.loadMovedValue(stored.node)
+		if err != nil {
+			return err
+		}
+
 		// Reconstructs the full key for root node
 		var k []byte
 
 		encodedNode, err := newEncodedNode(
-			stored.node,
+			node,
 			func(node nodeToEncode, partialKey []byte, childIndex *byte) (ChildReference, error) {
 				k = append(k, partialKey...)
 				mov := len(partialKey)
@@ -779,6 +935,7 @@ func(node nodeToEncode, partialKey []byte, childIndex *byte) (ChildReference, er
 					if err != nil {
 						return nil, err
 					}
+					t.trackInsertedValue(prefixedKey)
 
 					k = k[:mov]
 					return HashChildReference(hash), nil
@@ -805,6 +962,7 @@ func(node nodeToEncode, partialKey []byte, childIndex *byte) (ChildReference, er
 		if err != nil {
 			return err
 		}
+		t.trackInsertedNode(hash)
 
 		t.rootHash = hash
 		t.rootHandle = persisted(t.rootHash)
@@ -816,12 +974,112 @@ func(node nodeToEncode, partialKey []byte, childIndex *byte) (ChildReference, er
 		t.rootHandle = inMemory(
 			t.storage.alloc(CachedStoredNode{stored.node, stored.hash}),
 		)
-		return nil
+		return dbBatch.Flush()
 	default:
 		panic("unreachable")
 	}
 }
 
+// loadMovedValue loads the hashed value of the given node from the db if the
+// node partial key changed since the value was stored, since the value is stored
+// at a key prefixed with the node partial key and must be stored again.
+func (t *TrieDB) loadMovedValue(node Node) (Node, error) {
+	var partialKey []byte
+	var value nodeValue
+	switch n := node.(type) {
+	case Leaf:
+		partialKey, value = n.partialKey, n.value
+	case Branch:
+		partialKey, value = n.partialKey, n.value
+	default:
+		return node, nil
+	}
+
+	ref, ok := value.(valueRef)
+	if !ok || bytes.Equal(ref.prefix, partialKey) {
+		return node, nil
+	}
+
+	data, err := inMemoryFetchedValue(ref, t.db)
+	if err != nil {
+		return nil, fmt.Errorf("loading moved value: %w", err)
+	}
+	value = newValueRef{hash: ref.hash, data: data}
+
+	// the value is no longer stored at its previous key by this trie
+	if t.deferDeletions {
+		delete(t.insertedValues, string(ref.dbKey()))
+		t.deletedValues[string(ref.dbKey())] = struct{}{}
+	}
+
+	switch n := node.(type) {
+	case Leaf:
+		n.value = value
+		return n, nil
+	case Branch:
+		n.value = value
+		return n, nil
+	default:
+		panic("unreachable")
+	}
+}
+
+// hashChild returns the reference of the given child node, hashing
+// the in memory nodes without writing them to the db.
+func (t *TrieDB) hashChild(child NodeHandle) (ChildReference, error) {
+	switch nh := child.(type) {
+	case persisted:
+		return HashChildReference(nh), nil
+	case inMemory:
+		switch stored := t.storage.nodes[nh].(type) {
+		case CachedStoredNode:
+			return HashChildReference(stored.hash), nil
+		case NewStoredNode:
+			encoded, err := t.encodeWithoutCommit(stored.node)
+			if err != nil {
+				return nil, err
+			}
+
+			// Not inlined node
+			if len(encoded) >= common.HashLength {
+				return HashChildReference(common.MustBlake2bHash(encoded)), nil
+			}
+			return InlineChildReference(encoded), nil
+		}
+	}
+	panic("unreachable")
+}
+
+// encodeWithoutCommit encodes the given node, hashing its new value
+// and its in memory children without writing them to the db.
+func (t *TrieDB) encodeWithoutCommit(node Node) ([]byte, error) {
+	// a moved value is still referenced by its hash, so it does
+	// not need to be loaded from the db to encode the node
+	switch n := node.(type) {
+	case Leaf:
+		if ref, ok := n.value.(valueRef); ok {
+			n.value = valueRef{prefix: n.partialKey, hash: ref.hash}
+			node = n
+		}
+	case Branch:
+		if ref, ok := n.value.(valueRef); ok {
+			n.value = valueRef{prefix: n.partialKey, hash: ref.hash}
+			node = n
+		}
+	}
+
+	return newEncodedNode(node, func(node nodeToEncode, _ []byte, _ *byte) (ChildReference, error) {
+		switch n := node.(type) {
+		case newNodeToEncode:
+			return HashChildReference(common.MustBlake2bHash(n.value)), nil
+		case trieNodeToEncode:
+			return t.hashChild(n.child)
+		default:
+			panic("unreachable")
+		}
+	})
+}
+
 // Commit a node by hashing it and writing it to the db.
 func (t *TrieDB) commitChild(
 	dbBatch database.Batch,
@@ -855,6 +1113,7 @@ func (t *TrieDB) commitChild(
 					if err != nil {
 						panic("inserting in db")
 					}
+					t.trackInsertedValue(prefixedKey)
 
 					if t.cache != nil {
 						t.cache.SetValue(n.partialKey, n.value)
@@ -875,7 +1134,12 @@ func (t *TrieDB) commitChild(
 				}
 			}
 
-			encoded, err := newEncodedNode(storedNode.node, commitChildFunc)
+			node, err := t.loadMovedValue(storedNode.node)
+			if err != nil {
+				return nil, err
+			}
+
+			encoded, err := newEncodedNode(node, commitChildFunc)
 			if err != nil {
 				panic("encoding node")
 			}
@@ -887,6 +1151,7 @@ func (t *TrieDB) commitChild(
 				if err != nil {
 					return nil, err
 				}
+				t.trackInsertedNode(hash)
 
 				return HashChildReference(hash), nil
 			} else {
@@ -914,4 +1179,4 @@ func (t *TrieDB) recordAccess(access trieAccess) {
 	}
 }
 
-var _ trie.TrieRead = (*TrieDB)(nil)
+var _ trie.Trie = (*TrieDB)(nil)
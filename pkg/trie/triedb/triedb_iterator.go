@@ -6,113 +6,141 @@
 import (
 	"bytes"
 
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	nibbles "github.com/ChainSafe/gossamer/pkg/trie/codec"
 	"github.com/ChainSafe/gossamer/pkg/trie/triedb/codec"
 )
 
-type iteratorState struct {
-	parentFullKey []byte            // key of the parent node of the actual node
-	node          codec.EncodedNode // actual node
-}
-
-// fullKeyNibbles return the full key of the node contained in this state
-// child is the child where the node is stored in the parent node
-func (s *iteratorState) fullKeyNibbles(child *int) []byte {
-	fullKey := bytes.Join([][]byte{s.parentFullKey, s.node.GetPartialKey()}, nil)
-	if child != nil {
-		return bytes.Join([][]byte{fullKey, {byte(*child)}}, nil)
-	}
+// inlineNode is a handle to a node inlined in its parent node encoding.
+// It is only used by the iterator to visit nodes decoded from the db,
+// since these are not loaded in the trie node storage.
+type inlineNode []byte
 
-	return nibbles.NibblesToKeyLE(fullKey)
-}
+func (inlineNode) isNodeHandle() {}
 
+// TrieDBIterator iterates over the trie entries in lexicographic order.
+// It searches the next entry from the root node at each step, loading the
+// nodes changed since the last commit from the trie node storage and the
+// other nodes from the db, so the trie does not need to be committed.
 type TrieDBIterator struct {
-	db        *TrieDB          // trie to iterate over
-	nodeStack []*iteratorState // Pending nodes to visit
+	db *TrieDB // trie to iterate over
+	// prefix is the key prefix in nibbles of the entries to iterate over
+	prefix []byte
+	// cursor is the key in nibbles of the last entry returned
+	cursor []byte
+	// inclusive is true if an entry with the cursor key can be returned
+	inclusive bool
 }
 
 func NewTrieDBIterator(trie *TrieDB) *TrieDBIterator {
-	rootNode, err := trie.getRootNode()
-	if err != nil {
-		panic("trying to create trie iterator with incomplete trie DB")
-	}
 	return &TrieDBIterator{
 		db: trie,
-		nodeStack: []*iteratorState{
-			{
-				node: rootNode,
-			},
-		},
 	}
 }
 
 func NewPrefixedTrieDBIterator(trie *TrieDB, prefix []byte) *TrieDBIterator {
-	nodeAtPrefix, err := trie.getNodeAt(prefix)
-	if err != nil {
-		panic("trying to create trie iterator with incomplete trie DB")
+	prefixNibbles := nibbles.KeyLEToNibbles(prefix)
+	return &TrieDBIterator{
+		db:        trie,
+		prefix:    prefixNibbles,
+		cursor:    prefixNibbles,
+		inclusive: true,
 	}
+}
 
-	return &TrieDBIterator{
-		db: trie,
-		nodeStack: []*iteratorState{
-			{
-				parentFullKey: prefix[:len(nodeAtPrefix.GetPartialKey())-1],
-				node:          nodeAtPrefix,
-			},
-		},
+// isAfterCursor returns true if the entry with the given full key
+// in nibbles can be returned according to the iterator cursor.
+func (i *TrieDBIterator) isAfterCursor(fullKey []byte) bool {
+	if i.cursor == nil {
+		return true
 	}
+
+	cmp := bytes.Compare(fullKey, i.cursor)
+	return cmp > 0 || (cmp == 0 && i.inclusive)
 }
 
-// nextToVisit sets the next node to visit in the iterator
-func (i *TrieDBIterator) nextToVisit(parentKey []byte, node codec.EncodedNode) {
-	i.nodeStack = append(i.nodeStack, &iteratorState{
-		parentFullKey: parentKey,
-		node:          node,
-	})
+// isBeforeCursor returns true if all the keys starting with
+// the given prefix in nibbles are before the iterator cursor.
+func (i *TrieDBIterator) isBeforeCursor(prefix []byte) bool {
+	return i.cursor != nil && bytes.Compare(prefix, i.cursor) < 0 && !bytes.HasPrefix(i.cursor, prefix)
 }
 
-// nextState pops the next node to visit from the stack
-// warn: this function does not check if the node stack is empty
-// this check should be made by the caller
-func (i *TrieDBIterator) nextState() *iteratorState {
-	currentState := i.nodeStack[len(i.nodeStack)-1]
-	i.nodeStack = i.nodeStack[:len(i.nodeStack)-1]
-	return currentState
+// nextEntry returns the first entry after the iterator cursor in the
+// sub-trie with the given node and key prefix in nibbles, or nil if there is none.
+func (i *TrieDBIterator) nextEntry(handle NodeHandle, prefix []byte) (*trie.Entry, error) {
+	node, err := i.db.loadIteratorNode(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	var children [codec.ChildrenCapacity]NodeHandle
+	var value nodeValue
+	switch n := node.(type) {
+	case Empty:
+		return nil, nil
+	case Leaf:
+		prefix = bytes.Join([][]byte{prefix, n.partialKey}, nil)
+		value = n.value
+	case Branch:
+		prefix = bytes.Join([][]byte{prefix, n.partialKey}, nil)
+		children = n.children
+		value = n.value
+	default:
+		panic("unreachable")
+	}
+
+	if i.isBeforeCursor(prefix) {
+		return nil, nil
+	}
+
+	if value != nil && i.isAfterCursor(prefix) {
+		return i.db.newIteratorEntry(prefix, value)
+	}
+
+	for idx, child := range children {
+		if child == nil {
+			continue
+		}
+
+		childPrefix := bytes.Join([][]byte{prefix, {byte(idx)}}, nil)
+		if i.isBeforeCursor(childPrefix) {
+			continue
+		}
+
+		entry, err := i.nextEntry(child, childPrefix)
+		if err != nil {
+			return nil, err
+		}
+
+		if entry != nil {
+			return entry, nil
+		}
+	}
+
+	return nil, nil
 }
 
+// NextEntry returns the next entry of the trie, with its key in little Endian,
+// or nil if there is no entry left.
 func (i *TrieDBIterator) NextEntry() *trie.Entry {
-	for len(i.nodeStack) > 0 {
-		currentState := i.nextState()
-		currentNode := currentState.node
-
-		switch n := currentNode.(type) {
-		case codec.Leaf:
-			key := currentState.fullKeyNibbles(nil)
-			value := i.db.Get(key)
-			return &trie.Entry{Key: key, Value: value}
-		case codec.Branch:
-			// Reverse iterate over children because we are using a LIFO stack
-			// and we want to visit the leftmost child first
-			for idx := len(n.Children) - 1; idx >= 0; idx-- {
-				child := n.Children[idx]
-				if child != nil {
-					childNode, err := i.db.getNode(child)
-					if err != nil {
-						panic(err)
-					}
-					i.nextToVisit(currentState.fullKeyNibbles(&idx), childNode)
-				}
-			}
-			if n.GetValue() != nil {
-				key := currentState.fullKeyNibbles(nil)
-				value := i.db.Get(key)
-				return &trie.Entry{Key: key, Value: value}
-			}
-		}
+	entry, err := i.nextEntry(i.db.rootHandle, nil)
+	if err != nil {
+		panic(err)
 	}
 
-	return nil
+	if entry == nil {
+		return nil
+	}
+
+	keyNibbles := nibbles.KeyLEToNibbles(entry.Key)
+	if !bytes.HasPrefix(keyNibbles, i.prefix) {
+		return nil
+	}
+
+	i.cursor = keyNibbles
+	i.inclusive = false
+	return entry
 }
 
 // NextKey performs a depth-first search on the trie and returns the next key
@@ -134,9 +162,91 @@ func (i *TrieDBIterator) NextKeyFunc(predicate func(nextKey []byte) bool) (nextK
 	return nil
 }
 
+// Seek moves the iterator after the first key greater or equal to the target key.
 func (i *TrieDBIterator) Seek(targetKey []byte) {
-	for key := i.NextKey(); bytes.Compare(key, targetKey) < 0; key = i.NextKey() {
+	targetKeyNibbles := nibbles.KeyLEToNibbles(targetKey)
+	if bytes.Compare(targetKeyNibbles, i.cursor) < 0 {
+		return
+	}
+
+	i.cursor = targetKeyNibbles
+	i.inclusive = true
+	i.NextEntry()
+}
+
+// loadIteratorNode returns the node with the given handle, from the trie node
+// storage if it is in memory, or decoded from the db without loading it in the
+// node storage.
+func (t *TrieDB) loadIteratorNode(handle NodeHandle) (Node, error) {
+	switch h := handle.(type) {
+	case inMemory:
+		return t.storage.get(storageHandle(h)), nil
+	case persisted:
+		hash := common.Hash(h)
+		if hash == hashedNullNode {
+			return Empty{}, nil
+		}
+
+		encodedNode, err := t.db.Get(hash[:])
+		if err != nil {
+			return nil, ErrIncompleteDB
+		}
+		t.recordAccess(encodedNodeAccess{hash: hash, encodedNode: encodedNode})
+
+		return decodeIteratorNode(encodedNode)
+	case inlineNode:
+		return decodeIteratorNode(h)
+	default:
+		panic("unreachable")
 	}
 }
 
+// decodeIteratorNode decodes the given encoded node, referencing its
+// children nodes with persisted or inline node handles.
+func decodeIteratorNode(encodedNode []byte) (Node, error) {
+	decoded, err := codec.Decode(bytes.NewReader(encodedNode))
+	if err != nil {
+		return nil, err
+	}
+
+	switch n := decoded.(type) {
+	case codec.Empty:
+		return Empty{}, nil
+	case codec.Leaf:
+		return Leaf{partialKey: n.PartialKey, value: NewValueFromEncoded(n.PartialKey, n.Value)}, nil
+	case codec.Branch:
+		children := [codec.ChildrenCapacity]NodeHandle{}
+		for idx, child := range n.Children {
+			switch c := child.(type) {
+			case codec.HashedNode:
+				children[idx] = persisted(c)
+			case codec.InlineNode:
+				children[idx] = inlineNode(c)
+			}
+		}
+
+		return Branch{
+			partialKey: n.PartialKey,
+			children:   children,
+			value:      NewValueFromEncoded(n.PartialKey, n.Value),
+		}, nil
+	default:
+		panic("unreachable")
+	}
+}
+
+// newIteratorEntry returns the entry with the given key in nibbles and value.
+func (t *TrieDB) newIteratorEntry(keyNibbles []byte, value nodeValue) (*trie.Entry, error) {
+	data, err := inMemoryFetchedValue(value, t.db)
+	if err != nil {
+		return nil, err
+	}
+
+	if ref, ok := value.(valueRef); ok {
+		t.recordAccess(valueAccess{hash: ref.hash, fullKey: keyNibbles, value: data})
+	}
+
+	return &trie.Entry{Key: nibbles.NibblesToKeyLE(keyNibbles), Value: data}, nil
+}
+
 var _ trie.TrieIterator = (*TrieDBIterator)(nil)
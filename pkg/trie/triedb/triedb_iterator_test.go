@@ -62,3 +62,59 @@ func TestIterator(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 }
+
+func TestIterator_UncommittedChanges(t *testing.T) {
+	db := newTestDB(t)
+	inMemoryTrie := inmemory.NewEmptyTrie()
+	inMemoryTrie.SetVersion(trie.V1)
+
+	entries := map[string][]byte{
+		"no":           make([]byte, 10),
+		"noot":         make([]byte, 20),
+		"not":          make([]byte, 40),
+		"notification": make([]byte, 50),
+		"test":         make([]byte, 60),
+	}
+
+	for k, v := range entries {
+		inMemoryTrie.Put([]byte(k), v)
+	}
+
+	err := inMemoryTrie.WriteDirty(db)
+	assert.NoError(t, err)
+
+	trieDB := NewTrieDB(inMemoryTrie.MustHash(), db)
+	trieDB.SetVersion(trie.V1)
+
+	for _, tr := range []trie.Trie{inMemoryTrie, trieDB} {
+		assert.NoError(t, tr.Put([]byte("notable"), make([]byte, 30)))
+		assert.NoError(t, tr.Delete([]byte("noot")))
+	}
+
+	t.Run("iterate_over_all_entries", func(t *testing.T) {
+		iter := NewTrieDBIterator(trieDB)
+
+		var keys [][]byte
+		for key := iter.NextKey(); key != nil; key = iter.NextKey() {
+			keys = append(keys, key)
+		}
+
+		assert.Equal(t, inMemoryTrie.GetKeysWithPrefix(nil), keys)
+	})
+
+	t.Run("iterate_with_prefix", func(t *testing.T) {
+		iter := NewPrefixedTrieDBIterator(trieDB, []byte("not"))
+
+		var keys [][]byte
+		for key := iter.NextKey(); key != nil; key = iter.NextKey() {
+			keys = append(keys, key)
+		}
+
+		expected := [][]byte{[]byte("not"), []byte("notable"), []byte("notification")}
+		assert.Equal(t, expected, keys)
+	})
+
+	t.Run("entries_are_the_same", func(t *testing.T) {
+		assert.Equal(t, inMemoryTrie.Entries(), trieDB.Entries())
+	})
+}
@@ -6,6 +6,7 @@
 import (
 	"testing"
 
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	"github.com/ChainSafe/gossamer/pkg/trie/triedb/codec"
 	"github.com/stretchr/testify/assert"
@@ -463,6 +464,56 @@ func TestDeletes(t *testing.T) {
 				},
 			},
 		},
+		"delete_key_prefix_of_branch_partial_key_should_do_nothing": {
+			trieEntries: []trie.Entry{
+				{
+					Key:   []byte{1},
+					Value: []byte("leaf1"),
+				},
+				{
+					Key:   []byte{3, 4},
+					Value: []byte("branch"),
+				},
+				{
+					Key:   []byte{3, 4, 2},
+					Value: []byte("leaf2"),
+				},
+			},
+			key: []byte{3},
+			expected: nodeStorage{
+				nodes: []StoredNode{
+					NewStoredNode{
+						Leaf{
+							partialKey: []byte{},
+							value:      inline([]byte("leaf1")),
+						},
+					},
+					NewStoredNode{
+						Branch{
+							partialKey: []byte{},
+							children: [codec.ChildrenCapacity]NodeHandle{
+								nil, inMemory(0), nil, inMemory(3),
+							},
+						},
+					},
+					NewStoredNode{
+						Leaf{
+							partialKey: []byte{},
+							value:      inline([]byte("leaf2")),
+						},
+					},
+					NewStoredNode{
+						Branch{
+							partialKey: []byte{4},
+							value:      inline([]byte("branch")),
+							children: [codec.ChildrenCapacity]NodeHandle{
+								nil, nil, inMemory(2),
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -784,3 +835,156 @@ func TestDBCommits(t *testing.T) {
 		assert.Nil(t, v)
 	})
 }
+
+func TestDBCommits_DeferredDeletions(t *testing.T) {
+	t.Parallel()
+
+	inmemoryDB := NewMemoryDB(EmptyNode)
+	tr := NewEmptyTrieDB(inmemoryDB, WithDeferredDeletions())
+	tr.SetVersion(trie.V1)
+
+	err := tr.Put([]byte("branchleaf"), make([]byte, 40))
+	assert.NoError(t, err)
+	err = tr.Put([]byte("branch"), []byte("branchvalue"))
+	assert.NoError(t, err)
+
+	err = tr.Commit()
+	assert.NoError(t, err)
+	inserted, deleted, err := tr.GetChangedNodeHashes()
+	assert.NoError(t, err)
+	insertedValues, deletedValues := tr.GetChangedValueKeys()
+
+	// 1 branch and 1 leaf with a hashed value
+	assert.Len(t, inserted, 2)
+	assert.Empty(t, deleted)
+	assert.Len(t, insertedValues, 1)
+	assert.Empty(t, deletedValues)
+	assert.Len(t, inmemoryDB.data, 3)
+
+	firstRoot := tr.MustHash()
+	err = tr.Delete([]byte("branchleaf"))
+	assert.NoError(t, err)
+
+	err = tr.Commit()
+	assert.NoError(t, err)
+	inserted, deleted, err = tr.GetChangedNodeHashes()
+	assert.NoError(t, err)
+	insertedValues, deletedValues = tr.GetChangedValueKeys()
+
+	// the branch transformed in a leaf is inserted, the previous branch
+	// and leaf and the hashed value are deleted but kept in the db
+	assert.Equal(t, map[common.Hash]struct{}{tr.MustHash(): {}}, inserted)
+	assert.Len(t, deleted, 2)
+	assert.Contains(t, deleted, firstRoot)
+	assert.Empty(t, insertedValues)
+	assert.Len(t, deletedValues, 1)
+	assert.Len(t, inmemoryDB.data, 4)
+
+	// the trie at the first root can still be read
+	previous := NewTrieDB(firstRoot, inmemoryDB)
+	assert.Equal(t, make([]byte, 40), previous.Get([]byte("branchleaf")))
+}
+
+func TestHash_DoesNotWriteToDB(t *testing.T) {
+	t.Parallel()
+
+	inmemoryDB := NewMemoryDB(EmptyNode)
+	tr := NewEmptyTrieDB(inmemoryDB)
+	tr.SetVersion(trie.V1)
+
+	err := tr.Put([]byte("branchleaf"), make([]byte, 40))
+	assert.NoError(t, err)
+	err = tr.Put([]byte("branch"), []byte("branchvalue"))
+	assert.NoError(t, err)
+	err = tr.PutIntoChild([]byte("child"), []byte("key"), []byte("value"))
+	assert.NoError(t, err)
+
+	root, err := tr.Hash()
+	assert.NoError(t, err)
+	assert.Empty(t, inmemoryDB.data)
+
+	err = tr.Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, root, tr.MustHash())
+
+	// the trie and child trie nodes and the hashed value are written
+	assert.Len(t, inmemoryDB.data, 6)
+	committed := NewTrieDB(root, inmemoryDB)
+	value, err := committed.GetFromChild([]byte("child"), []byte("key"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestClearPrefix(t *testing.T) {
+	t.Parallel()
+
+	entries := []trie.Entry{
+		{Key: []byte("no"), Value: []byte("noValue")},
+		{Key: []byte("noot"), Value: []byte("nootValue")},
+		{Key: []byte("not"), Value: []byte("notValue")},
+		{Key: []byte("test"), Value: []byte("testValue")},
+	}
+
+	testCases := map[string]struct {
+		prefix       []byte
+		limit        uint32
+		deleted      uint32
+		allDeleted   bool
+		expectedKeys [][]byte
+	}{
+		"zero_limit": {
+			prefix:       []byte("no"),
+			expectedKeys: [][]byte{[]byte("no"), []byte("noot"), []byte("not"), []byte("test")},
+		},
+		"limit_deletes_in_lexicographic_order": {
+			prefix:       []byte("no"),
+			limit:        2,
+			deleted:      2,
+			expectedKeys: [][]byte{[]byte("not"), []byte("test")},
+		},
+		"limit_equal_to_number_of_keys": {
+			prefix:       []byte("no"),
+			limit:        3,
+			deleted:      3,
+			allDeleted:   true,
+			expectedKeys: [][]byte{[]byte("test")},
+		},
+		"no_key_with_prefix": {
+			prefix:       []byte("other"),
+			limit:        1,
+			allDeleted:   true,
+			expectedKeys: [][]byte{[]byte("no"), []byte("noot"), []byte("not"), []byte("test")},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			trieDB := NewEmptyTrieDB(NewMemoryDB(EmptyNode))
+			for _, entry := range entries {
+				assert.NoError(t, trieDB.Put(entry.Key, entry.Value))
+			}
+
+			deleted, allDeleted, err := trieDB.ClearPrefixLimit(testCase.prefix, testCase.limit)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.deleted, deleted)
+			assert.Equal(t, testCase.allDeleted, allDeleted)
+			assert.Equal(t, testCase.expectedKeys, trieDB.GetKeysWithPrefix(nil))
+		})
+	}
+
+	t.Run("without_limit", func(t *testing.T) {
+		t.Parallel()
+
+		trieDB := NewEmptyTrieDB(NewMemoryDB(EmptyNode))
+		for _, entry := range entries {
+			assert.NoError(t, trieDB.Put(entry.Key, entry.Value))
+		}
+
+		err := trieDB.ClearPrefix([]byte("no"))
+		assert.NoError(t, err)
+		assert.Equal(t, [][]byte{[]byte("test")}, trieDB.GetKeysWithPrefix(nil))
+	})
+}